@@ -0,0 +1,342 @@
+package ipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// The Arrow IPC metadata is encoded with flatbuffers.
+// This file holds the minimal flatbuffers support needed to write and read it:
+// tables of scalars, strings, vectors of tables and vectors of structs.
+//
+// Unlike the flatbuffers builders that write their buffer back to front,
+// fbBuilder writes every object before the objects it references,
+// so that the unsigned offsets of the references always point forward.
+
+// fbObject is a flatbuffers object that is referenced by an offset.
+type fbObject interface {
+	// write appends the object to the builder and returns its position.
+	write(b *fbBuilder) int
+}
+
+type fbBuilder struct {
+	buf []byte
+}
+
+// finish returns the buffer of the root table.
+func (b *fbBuilder) finish(root *fbTable) []byte {
+	b.buf = make([]byte, 4)
+	b.patch(0, root.write(b))
+	return b.buf
+}
+
+func (b *fbBuilder) align(n int) {
+	for len(b.buf)%n != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+// patch writes at pos the offset of the object at target.
+func (b *fbBuilder) patch(pos, target int) {
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(target-pos))
+}
+
+type fbField struct {
+	slot   int
+	scalar []byte
+	ref    fbObject
+}
+
+func (f fbField) size() int {
+	if f.ref != nil {
+		return 4
+	}
+	return len(f.scalar)
+}
+
+// fbTable is a flatbuffers table, its fields are identified by their slot in the schema.
+type fbTable struct {
+	fields []fbField
+}
+
+func (t *fbTable) addScalar(slot int, v []byte) {
+	t.fields = append(t.fields, fbField{slot: slot, scalar: v})
+}
+
+func (t *fbTable) addBool(slot int, v bool) {
+	if v {
+		t.addScalar(slot, []byte{1})
+	} else {
+		t.addScalar(slot, []byte{0})
+	}
+}
+
+func (t *fbTable) addUint8(slot int, v uint8) {
+	t.addScalar(slot, []byte{v})
+}
+
+func (t *fbTable) addInt16(slot int, v int16) {
+	buf := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buf, uint16(v))
+	t.addScalar(slot, buf)
+}
+
+func (t *fbTable) addInt32(slot int, v int32) {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, uint32(v))
+	t.addScalar(slot, buf)
+}
+
+func (t *fbTable) addInt64(slot int, v int64) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(v))
+	t.addScalar(slot, buf)
+}
+
+func (t *fbTable) addRef(slot int, o fbObject) {
+	t.fields = append(t.fields, fbField{slot: slot, ref: o})
+}
+
+func (t *fbTable) write(b *fbBuilder) int {
+	// Lay out the fields by decreasing size after the offset of the vtable,
+	// so that every field is aligned to its size.
+	fields := make([]fbField, len(t.fields))
+	copy(fields, t.fields)
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].size() > fields[j].size()
+	})
+	nslots := 0
+	offsets := make([]int, len(fields))
+	size := 4
+	for i, f := range fields {
+		for size%f.size() != 0 {
+			size++
+		}
+		offsets[i] = size
+		size += f.size()
+		if f.slot >= nslots {
+			nslots = f.slot + 1
+		}
+	}
+
+	// The vtable precedes the table.
+	b.align(2)
+	vtable := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4+2*nslots)...)
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*nslots))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(size))
+	for i, f := range fields {
+		binary.LittleEndian.PutUint16(b.buf[vtable+4+2*f.slot:], uint16(offsets[i]))
+	}
+
+	b.align(8)
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(int32(pos-vtable)))
+	for i, f := range fields {
+		if f.ref == nil {
+			copy(b.buf[pos+offsets[i]:], f.scalar)
+		}
+	}
+	for i, f := range fields {
+		if f.ref != nil {
+			b.patch(pos+offsets[i], f.ref.write(b))
+		}
+	}
+	return pos
+}
+
+// fbString is a flatbuffers string.
+type fbString string
+
+func (s fbString) write(b *fbBuilder) int {
+	b.align(4)
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4)...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(s)))
+	b.buf = append(b.buf, s...)
+	b.buf = append(b.buf, 0)
+	return pos
+}
+
+// fbTables is a flatbuffers vector of tables.
+type fbTables []*fbTable
+
+func (v fbTables) write(b *fbBuilder) int {
+	b.align(4)
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4+4*len(v))...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(v)))
+	for i, t := range v {
+		b.patch(pos+4+4*i, t.write(b))
+	}
+	return pos
+}
+
+// fbStructs is a flatbuffers vector of structs made of 64 bit integers.
+type fbStructs struct {
+	n    int
+	data []int64
+}
+
+func (v fbStructs) write(b *fbBuilder) int {
+	// The elements are aligned to 8 bytes, they follow the length of the vector.
+	b.align(4)
+	if len(b.buf)%8 == 0 {
+		b.buf = append(b.buf, 0, 0, 0, 0)
+	}
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4+8*len(v.data))...)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(v.n))
+	for i, x := range v.data {
+		binary.LittleEndian.PutUint64(b.buf[pos+4+8*i:], uint64(x))
+	}
+	return pos
+}
+
+// fbReader reads a table of a flatbuffers buffer.
+// Reading out of the bounds of the buffer panics with an fbError.
+type fbReader struct {
+	buf []byte
+	pos int
+}
+
+type fbError struct {
+	msg string
+}
+
+func (e fbError) Error() string {
+	return e.msg
+}
+
+func fbRoot(buf []byte) fbReader {
+	r := fbReader{buf: buf}
+	return fbReader{buf: buf, pos: r.uint32(0)}
+}
+
+func (r fbReader) check(pos, n int) {
+	if pos < 0 || pos+n > len(r.buf) {
+		panic(fbError{msg: fmt.Sprintf("invalid flatbuffers offset %d", pos)})
+	}
+}
+
+func (r fbReader) uint32(pos int) int {
+	r.check(pos, 4)
+	return int(binary.LittleEndian.Uint32(r.buf[pos:]))
+}
+
+// field returns the position of the field in the slot, or 0 if the field is not set.
+func (r fbReader) field(slot int) int {
+	r.check(r.pos, 4)
+	vtable := r.pos - int(int32(binary.LittleEndian.Uint32(r.buf[r.pos:])))
+	r.check(vtable, 4)
+	vsize := int(binary.LittleEndian.Uint16(r.buf[vtable:]))
+	if 4+2*slot+2 > vsize {
+		return 0
+	}
+	r.check(vtable+4+2*slot, 2)
+	off := int(binary.LittleEndian.Uint16(r.buf[vtable+4+2*slot:]))
+	if off == 0 {
+		return 0
+	}
+	return r.pos + off
+}
+
+func (r fbReader) scalar(slot, n int) []byte {
+	pos := r.field(slot)
+	if pos == 0 {
+		return nil
+	}
+	r.check(pos, n)
+	return r.buf[pos : pos+n]
+}
+
+func (r fbReader) bool(slot int) bool {
+	b := r.scalar(slot, 1)
+	return b != nil && b[0] != 0
+}
+
+func (r fbReader) uint8(slot int) uint8 {
+	if b := r.scalar(slot, 1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r fbReader) int16(slot int) int16 {
+	if b := r.scalar(slot, 2); b != nil {
+		return int16(binary.LittleEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (r fbReader) int32(slot int) int32 {
+	if b := r.scalar(slot, 4); b != nil {
+		return int32(binary.LittleEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (r fbReader) int64(slot int) int64 {
+	if b := r.scalar(slot, 8); b != nil {
+		return int64(binary.LittleEndian.Uint64(b))
+	}
+	return 0
+}
+
+// ref returns the position of the object referenced by the field in the slot, or 0 if the field is not set.
+func (r fbReader) ref(slot int) int {
+	pos := r.field(slot)
+	if pos == 0 {
+		return 0
+	}
+	return pos + r.uint32(pos)
+}
+
+// table returns the table in the slot and whether the field is set.
+func (r fbReader) table(slot int) (fbReader, bool) {
+	pos := r.ref(slot)
+	return fbReader{buf: r.buf, pos: pos}, pos != 0
+}
+
+func (r fbReader) string(slot int) string {
+	pos := r.ref(slot)
+	if pos == 0 {
+		return ""
+	}
+	n := r.uint32(pos)
+	r.check(pos+4, n)
+	return string(r.buf[pos+4 : pos+4+n])
+}
+
+// vector returns the position of the first element and the length of the vector in the slot.
+func (r fbReader) vector(slot int) (int, int) {
+	pos := r.ref(slot)
+	if pos == 0 {
+		return 0, 0
+	}
+	return pos + 4, r.uint32(pos)
+}
+
+// tables returns the tables of the vector in the slot.
+func (r fbReader) tables(slot int) []fbReader {
+	start, n := r.vector(slot)
+	tables := make([]fbReader, n)
+	for i := range tables {
+		pos := start + 4*i
+		tables[i] = fbReader{buf: r.buf, pos: pos + r.uint32(pos)}
+	}
+	return tables
+}
+
+// int64s returns the 64 bit integers of the vector of structs in the slot, the structs have n fields.
+func (r fbReader) int64s(slot, n int) []int64 {
+	start, l := r.vector(slot)
+	r.check(start, 8*n*l)
+	vs := make([]int64, n*l)
+	for i := range vs {
+		vs[i] = int64(binary.LittleEndian.Uint64(r.buf[start+8*i:]))
+	}
+	return vs
+}
@@ -0,0 +1,262 @@
+package ipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/influxdata/flux"
+)
+
+// Constants of the Arrow columnar format, see Schema.fbs and Message.fbs of the Arrow project.
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeBool          = 6
+	typeTimestamp     = 10
+
+	precisionDouble    = 2
+	timeUnitNanosecond = 3
+
+	// continuation precedes the length of every message of a stream.
+	continuation = 0xFFFFFFFF
+)
+
+// keyValue is an entry of the custom metadata of a schema or field.
+type keyValue struct {
+	key, value string
+}
+
+func keyValues(kvs []keyValue) fbTables {
+	tables := make(fbTables, len(kvs))
+	for i, kv := range kvs {
+		t := new(fbTable)
+		t.addRef(0, fbString(kv.key))
+		t.addRef(1, fbString(kv.value))
+		tables[i] = t
+	}
+	return tables
+}
+
+func readKeyValues(tables []fbReader) []keyValue {
+	kvs := make([]keyValue, len(tables))
+	for i, t := range tables {
+		kvs[i] = keyValue{key: t.string(0), value: t.string(1)}
+	}
+	return kvs
+}
+
+func lookup(kvs []keyValue, key string) (string, bool) {
+	for _, kv := range kvs {
+		if kv.key == key {
+			return kv.value, true
+		}
+	}
+	return "", false
+}
+
+// field is a column of a schema.
+type field struct {
+	col      flux.ColMeta
+	metadata []keyValue
+}
+
+// fieldType returns the union type and the table of the Arrow type of a column type.
+func fieldType(typ flux.ColType) (uint8, *fbTable, error) {
+	t := new(fbTable)
+	switch typ {
+	case flux.TBool:
+		return typeBool, t, nil
+	case flux.TInt, flux.TUInt:
+		t.addInt32(0, 64)
+		t.addBool(1, typ == flux.TInt)
+		return typeInt, t, nil
+	case flux.TFloat:
+		t.addInt16(0, precisionDouble)
+		return typeFloatingPoint, t, nil
+	case flux.TString:
+		return typeUtf8, t, nil
+	case flux.TTime:
+		t.addInt16(0, timeUnitNanosecond)
+		t.addRef(1, fbString("UTC"))
+		return typeTimestamp, t, nil
+	default:
+		return 0, nil, fmt.Errorf("unsupported column type %v", typ)
+	}
+}
+
+// readFieldType returns the column type of an Arrow type, only the types written by fieldType are supported.
+func readFieldType(typeType uint8, t fbReader) (flux.ColType, error) {
+	switch typeType {
+	case typeBool:
+		return flux.TBool, nil
+	case typeInt:
+		if t.int32(0) != 64 {
+			return flux.TInvalid, fmt.Errorf("unsupported integer bit width %d", t.int32(0))
+		}
+		if t.bool(1) {
+			return flux.TInt, nil
+		}
+		return flux.TUInt, nil
+	case typeFloatingPoint:
+		if t.int16(0) != precisionDouble {
+			return flux.TInvalid, fmt.Errorf("unsupported floating point precision %d", t.int16(0))
+		}
+		return flux.TFloat, nil
+	case typeUtf8:
+		return flux.TString, nil
+	case typeTimestamp:
+		if t.int16(0) != timeUnitNanosecond {
+			return flux.TInvalid, fmt.Errorf("unsupported timestamp unit %d", t.int16(0))
+		}
+		return flux.TTime, nil
+	default:
+		return flux.TInvalid, fmt.Errorf("unsupported arrow type %d", typeType)
+	}
+}
+
+// schemaMessage returns the Schema header of a stream.
+func schemaMessage(fields []field, metadata []keyValue) (*fbTable, error) {
+	fieldTables := make(fbTables, len(fields))
+	for i, f := range fields {
+		typeType, typ, err := fieldType(f.col.Type)
+		if err != nil {
+			return nil, err
+		}
+		t := new(fbTable)
+		t.addRef(0, fbString(f.col.Label))
+		t.addBool(1, true)
+		t.addUint8(2, typeType)
+		t.addRef(3, typ)
+		t.addRef(5, fbTables{})
+		if len(f.metadata) > 0 {
+			t.addRef(6, keyValues(f.metadata))
+		}
+		fieldTables[i] = t
+	}
+	schema := new(fbTable)
+	schema.addInt16(0, 0) // little endian
+	schema.addRef(1, fieldTables)
+	if len(metadata) > 0 {
+		schema.addRef(2, keyValues(metadata))
+	}
+	return schema, nil
+}
+
+func readSchema(schema fbReader) ([]field, []keyValue, error) {
+	if schema.int16(0) != 0 {
+		return nil, nil, fmt.Errorf("big endian arrow data is not supported")
+	}
+	tables := schema.tables(1)
+	fields := make([]field, len(tables))
+	for i, t := range tables {
+		typ, _ := t.table(3)
+		colType, err := readFieldType(t.uint8(2), typ)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := t.table(4); ok {
+			return nil, nil, fmt.Errorf("dictionary encoded arrow fields are not supported")
+		}
+		fields[i] = field{
+			col:      flux.ColMeta{Label: t.string(0), Type: colType},
+			metadata: readKeyValues(t.tables(6)),
+		}
+	}
+	return fields, readKeyValues(schema.tables(2)), nil
+}
+
+// writeMessage writes an encapsulated message of a stream, the body must be padded to 8 bytes.
+func writeMessage(w io.Writer, headerType uint8, header *fbTable, body []byte) error {
+	msg := new(fbTable)
+	msg.addInt16(0, metadataV5)
+	msg.addUint8(1, headerType)
+	msg.addRef(2, header)
+	msg.addInt64(3, int64(len(body)))
+	meta := new(fbBuilder).finish(msg)
+	for len(meta)%8 != 0 {
+		meta = append(meta, 0)
+	}
+
+	prefix := make([]byte, 8)
+	binary.LittleEndian.PutUint32(prefix, continuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(meta)))
+	for _, b := range [][]byte{prefix, meta, body} {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEndOfStream writes the marker ending a stream.
+func writeEndOfStream(w io.Writer) error {
+	eos := make([]byte, 8)
+	binary.LittleEndian.PutUint32(eos, continuation)
+	_, err := w.Write(eos)
+	return err
+}
+
+// message is an encapsulated message read from a stream.
+type message struct {
+	headerType uint8
+	header     fbReader
+	body       []byte
+}
+
+// readMessage reads the next message of a stream, it returns nil at the end of the stream
+// and io.EOF if there are no more streams.
+// Reading invalid metadata panics with an fbError.
+func readMessage(r io.Reader) (*message, error) {
+	prefix := make([]byte, 4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(prefix)
+	if size == continuation {
+		if _, err := io.ReadFull(r, prefix); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		size = binary.LittleEndian.Uint32(prefix)
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	meta := make([]byte, size)
+	if _, err := io.ReadFull(r, meta); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	m := fbRoot(meta)
+	if v := m.int16(0); v < metadataV5-1 {
+		return nil, fmt.Errorf("unsupported arrow metadata version %d", v)
+	}
+	header, _ := m.table(2)
+	msg := &message{
+		headerType: m.uint8(1),
+		header:     header,
+	}
+	n := m.int64(3)
+	if n < 0 || n > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid arrow message body length %d", n)
+	}
+	msg.body = make([]byte, n)
+	if _, err := io.ReadFull(r, msg.body); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return msg, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
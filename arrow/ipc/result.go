@@ -0,0 +1,542 @@
+// Package ipc contains the Arrow IPC result encoders and decoders.
+//
+// Every table is written as an Arrow IPC stream: a schema followed by a record batch per
+// flux.ColReader of the table and the end of stream marker. The streams of the tables follow each other,
+// so a reader of Arrow streams reads the tables by opening a stream until the end of the input.
+//
+// The custom metadata of the schema holds the name of the result of the table under "flux.result".
+// The fields of the group key columns have "flux.group" set to "true" in their custom metadata
+// and their value under "flux.groupValue", unless it is null.
+// A result without tables is written as a stream without fields,
+// and an error as a stream without fields that holds the error under "flux.error".
+package ipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const (
+	resultKey     = "flux.result"
+	errorKey      = "flux.error"
+	groupKey      = "flux.group"
+	groupValueKey = "flux.groupValue"
+)
+
+// MultiResultEncoder encodes results as Arrow IPC streams.
+type MultiResultEncoder struct{}
+
+func NewMultiResultEncoder() *MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+type arrowEncoderError struct {
+	msg string
+}
+
+func (e *arrowEncoderError) Error() string {
+	return e.msg
+}
+
+func (e *arrowEncoderError) IsEncoderError() bool {
+	return true
+}
+
+func wrapEncodingError(err error) error {
+	return errors.Wrap(&arrowEncoderError{msg: err.Error()}, "arrow encoder error")
+}
+
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	for results.More() {
+		res := results.Next()
+		n := 0
+		err := res.Tables().Do(func(tbl flux.Table) error {
+			n++
+			if err := encodeTable(wc, res.Name(), tbl); err != nil {
+				return wrapEncodingError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			if flux.IsEncoderError(err) {
+				return wc.Count(), err
+			}
+			// The error is from the query execution, encode it instead.
+			if err := encodeError(wc, []keyValue{{key: resultKey, value: res.Name()}, {key: errorKey, value: err.Error()}}); err != nil {
+				return wc.Count(), err
+			}
+		} else if n == 0 {
+			if err := encodeError(wc, []keyValue{{key: resultKey, value: res.Name()}}); err != nil {
+				return wc.Count(), err
+			}
+		}
+	}
+	if err := results.Err(); err != nil {
+		return wc.Count(), encodeError(wc, []keyValue{{key: errorKey, value: err.Error()}})
+	}
+	return wc.Count(), nil
+}
+
+// encodeError writes a stream without fields and with the given metadata.
+func encodeError(w io.Writer, metadata []keyValue) error {
+	schema, err := schemaMessage(nil, metadata)
+	if err != nil {
+		return wrapEncodingError(err)
+	}
+	if err := writeMessage(w, headerSchema, schema, nil); err != nil {
+		return wrapEncodingError(err)
+	}
+	if err := writeEndOfStream(w); err != nil {
+		return wrapEncodingError(err)
+	}
+	return nil
+}
+
+func encodeTable(w io.Writer, name string, tbl flux.Table) error {
+	key := tbl.Key()
+	fields := make([]field, len(tbl.Cols()))
+	for j, c := range tbl.Cols() {
+		fields[j] = field{col: c}
+		if k := execute.ColIdx(c.Label, key.Cols()); k >= 0 {
+			fields[j].metadata = []keyValue{{key: groupKey, value: "true"}}
+			if v := key.Value(k); !v.IsNull() {
+				fields[j].metadata = append(fields[j].metadata, keyValue{key: groupValueKey, value: formatValue(v, c.Type)})
+			}
+		}
+	}
+	schema, err := schemaMessage(fields, []keyValue{{key: resultKey, value: name}})
+	if err != nil {
+		return err
+	}
+	if err := writeMessage(w, headerSchema, schema, nil); err != nil {
+		return err
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		batch, body := recordBatch(cr)
+		return writeMessage(w, headerRecordBatch, batch, body)
+	}); err != nil {
+		return err
+	}
+	return writeEndOfStream(w)
+}
+
+// formatValue returns the text of a group key value in the metadata of a field.
+func formatValue(v values.Value, typ flux.ColType) string {
+	switch typ {
+	case flux.TBool:
+		return strconv.FormatBool(v.Bool())
+	case flux.TInt:
+		return strconv.FormatInt(v.Int(), 10)
+	case flux.TUInt:
+		return strconv.FormatUint(v.UInt(), 10)
+	case flux.TFloat:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case flux.TString:
+		return v.Str()
+	case flux.TTime:
+		return v.Time().Time().Format(time.RFC3339Nano)
+	default:
+		execute.PanicUnknownType(typ)
+		return ""
+	}
+}
+
+func parseValue(s string, typ flux.ColType) (values.Value, error) {
+	switch typ {
+	case flux.TBool:
+		b, err := strconv.ParseBool(s)
+		return values.NewBool(b), err
+	case flux.TInt:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return values.NewInt(i), err
+	case flux.TUInt:
+		u, err := strconv.ParseUint(s, 10, 64)
+		return values.NewUInt(u), err
+	case flux.TFloat:
+		f, err := strconv.ParseFloat(s, 64)
+		return values.NewFloat(f), err
+	case flux.TString:
+		return values.NewString(s), nil
+	case flux.TTime:
+		t, err := time.Parse(time.RFC3339Nano, s)
+		return values.NewTime(values.ConvertTime(t)), err
+	default:
+		execute.PanicUnknownType(typ)
+		return nil, nil
+	}
+}
+
+// bodyBuilder accumulates the buffers of a record batch.
+type bodyBuilder struct {
+	body    []byte
+	buffers []int64
+}
+
+// add appends a buffer padded to 8 bytes.
+func (b *bodyBuilder) add(buf []byte) {
+	b.buffers = append(b.buffers, int64(len(b.body)), int64(len(buf)))
+	b.body = append(b.body, buf...)
+	for len(b.body)%8 != 0 {
+		b.body = append(b.body, 0)
+	}
+}
+
+// recordBatch returns the RecordBatch header and the body of the message of a ColReader.
+func recordBatch(cr flux.ColReader) (*fbTable, []byte) {
+	n := cr.Len()
+	nodes := make([]int64, 0, 2*len(cr.Cols()))
+	b := new(bodyBuilder)
+	for j, c := range cr.Cols() {
+		validity := make([]byte, (n+7)/8)
+		nulls := 0
+		for i := 0; i < n; i++ {
+			if isNull(cr, i, j) {
+				nulls++
+			} else {
+				validity[i/8] |= 1 << uint(i%8)
+			}
+		}
+		nodes = append(nodes, int64(n), int64(nulls))
+		if nulls == 0 {
+			validity = nil
+		}
+		b.add(validity)
+
+		switch c.Type {
+		case flux.TBool:
+			vs := cr.Bools(j)
+			buf := make([]byte, (n+7)/8)
+			for i := 0; i < n; i++ {
+				if vs.IsValid(i) && vs.Value(i) {
+					buf[i/8] |= 1 << uint(i%8)
+				}
+			}
+			b.add(buf)
+		case flux.TInt:
+			b.add(int64s(n, func(i int) int64 { return cr.Ints(j).Value(i) }))
+		case flux.TUInt:
+			b.add(int64s(n, func(i int) int64 { return int64(cr.UInts(j).Value(i)) }))
+		case flux.TFloat:
+			b.add(int64s(n, func(i int) int64 { return int64(math.Float64bits(cr.Floats(j).Value(i))) }))
+		case flux.TTime:
+			b.add(int64s(n, func(i int) int64 { return cr.Times(j).Value(i) }))
+		case flux.TString:
+			vs := cr.Strings(j)
+			offsets := make([]byte, 4*(n+1))
+			var data []byte
+			for i := 0; i < n; i++ {
+				if vs.IsValid(i) {
+					data = append(data, vs.Value(i)...)
+				}
+				binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+			}
+			b.add(offsets)
+			b.add(data)
+		default:
+			execute.PanicUnknownType(c.Type)
+		}
+	}
+
+	batch := new(fbTable)
+	batch.addInt64(0, int64(n))
+	batch.addRef(1, fbStructs{n: len(nodes) / 2, data: nodes})
+	batch.addRef(2, fbStructs{n: len(b.buffers) / 2, data: b.buffers})
+	return batch, b.body
+}
+
+func isNull(cr flux.ColReader, i, j int) bool {
+	switch c := cr.Cols()[j]; c.Type {
+	case flux.TBool:
+		return cr.Bools(j).IsNull(i)
+	case flux.TInt:
+		return cr.Ints(j).IsNull(i)
+	case flux.TUInt:
+		return cr.UInts(j).IsNull(i)
+	case flux.TFloat:
+		return cr.Floats(j).IsNull(i)
+	case flux.TString:
+		return cr.Strings(j).IsNull(i)
+	case flux.TTime:
+		return cr.Times(j).IsNull(i)
+	default:
+		execute.PanicUnknownType(c.Type)
+		return false
+	}
+}
+
+func int64s(n int, value func(i int) int64) []byte {
+	buf := make([]byte, 8*n)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(buf[8*i:], uint64(value(i)))
+	}
+	return buf
+}
+
+// MultiResultDecoder decodes results from Arrow IPC streams written by a MultiResultEncoder.
+// Streams without the metadata of the encoder are decoded as tables of a result named "_result".
+type MultiResultDecoder struct {
+	a *memory.Allocator
+}
+
+func NewMultiResultDecoder(a *memory.Allocator) *MultiResultDecoder {
+	return &MultiResultDecoder{a: a}
+}
+
+// Decode reads all of the streams before returning the results.
+func (d *MultiResultDecoder) Decode(r io.ReadCloser) (_ flux.ResultIterator, err error) {
+	defer r.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(fbError)
+			if !ok {
+				panic(r)
+			}
+			err = errors.Wrap(e, "invalid arrow message")
+		}
+	}()
+
+	ri := new(resultIterator)
+	var last *decodedResult
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			return ri, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if msg == nil || msg.headerType != headerSchema {
+			return nil, errors.New("arrow stream does not start with a schema")
+		}
+		fields, metadata, err := readSchema(msg.header)
+		if err != nil {
+			return nil, err
+		}
+
+		tbl, err := d.decodeTable(r, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		name, hasResult := lookup(metadata, resultKey)
+		if !hasResult {
+			name = "_result"
+		}
+		msgErr, hasErr := lookup(metadata, errorKey)
+		if hasErr && !hasResult {
+			ri.err = errors.New(msgErr)
+			continue
+		}
+		if last == nil || last.name != name {
+			last = &decodedResult{name: name}
+			ri.results = append(ri.results, last)
+		}
+		if hasErr {
+			last.err = errors.New(msgErr)
+		}
+		if len(fields) > 0 {
+			last.tables = append(last.tables, tbl)
+		}
+	}
+}
+
+// decodeTable reads the record batches of a stream into a table.
+func (d *MultiResultDecoder) decodeTable(r io.Reader, fields []field) (flux.Table, error) {
+	var keyCols []flux.ColMeta
+	var keyValues []values.Value
+	for _, f := range fields {
+		if v, _ := lookup(f.metadata, groupKey); v != "true" {
+			continue
+		}
+		keyCols = append(keyCols, f.col)
+		s, ok := lookup(f.metadata, groupValueKey)
+		if !ok {
+			keyValues = append(keyValues, values.NewNull(flux.SemanticType(f.col.Type)))
+			continue
+		}
+		v, err := parseValue(s, f.col.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "group key column %q", f.col.Label)
+		}
+		keyValues = append(keyValues, v)
+	}
+
+	b := execute.NewColListTableBuilder(execute.NewGroupKey(keyCols, keyValues), d.a)
+	for _, f := range fields {
+		if _, err := b.AddCol(f.col); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if msg == nil {
+			return b.Table()
+		}
+		if msg.headerType != headerRecordBatch {
+			return nil, fmt.Errorf("unsupported arrow message type %d", msg.headerType)
+		}
+		if err := appendRecordBatch(b, fields, msg); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// appendRecordBatch appends the rows of a record batch to the table builder.
+func appendRecordBatch(b *execute.ColListTableBuilder, fields []field, msg *message) error {
+	n := int(msg.header.int64(0))
+	nodes := msg.header.int64s(1, 2)
+	buffers := msg.header.int64s(2, 2)
+	if len(nodes) != 2*len(fields) {
+		return fmt.Errorf("record batch has %d fields, expected %d", len(nodes)/2, len(fields))
+	}
+	if _, ok := msg.header.table(3); ok {
+		return errors.New("compressed arrow record batches are not supported")
+	}
+	buffer := func(k int) ([]byte, error) {
+		if 2*k+1 >= len(buffers) {
+			return nil, errors.New("missing arrow buffer")
+		}
+		off, l := buffers[2*k], buffers[2*k+1]
+		if off < 0 || l < 0 || off+l > int64(len(msg.body)) {
+			return nil, fmt.Errorf("invalid arrow buffer at %d of length %d", off, l)
+		}
+		return msg.body[off : off+l], nil
+	}
+	bit := func(buf []byte, i int) bool {
+		return buf[i/8]&(1<<uint(i%8)) != 0
+	}
+
+	k := 0
+	for j, f := range fields {
+		if nodes[2*j] != int64(n) {
+			return fmt.Errorf("field %q has %d rows, expected %d", f.col.Label, nodes[2*j], n)
+		}
+		validity, err := buffer(k)
+		if err != nil {
+			return err
+		}
+		k++
+		if len(validity) > 0 && len(validity) < (n+7)/8 || len(validity) == 0 && nodes[2*j+1] > 0 {
+			return fmt.Errorf("invalid validity buffer of field %q", f.col.Label)
+		}
+		valid := func(i int) bool {
+			return len(validity) == 0 || bit(validity, i)
+		}
+
+		data, err := buffer(k)
+		if err != nil {
+			return err
+		}
+		k++
+		var offsets []byte
+		if f.col.Type == flux.TString {
+			offsets = data
+			if data, err = buffer(k); err != nil {
+				return err
+			}
+			k++
+			if len(offsets) < 4*(n+1) {
+				return fmt.Errorf("invalid offsets buffer of field %q", f.col.Label)
+			}
+		} else if f.col.Type == flux.TBool && len(data) < (n+7)/8 || f.col.Type != flux.TBool && len(data) < 8*n {
+			return fmt.Errorf("invalid values buffer of field %q", f.col.Label)
+		}
+
+		for i := 0; i < n; i++ {
+			if !valid(i) {
+				if err := b.AppendNil(j); err != nil {
+					return err
+				}
+				continue
+			}
+			var err error
+			switch f.col.Type {
+			case flux.TBool:
+				err = b.AppendBool(j, bit(data, i))
+			case flux.TInt:
+				err = b.AppendInt(j, int64(binary.LittleEndian.Uint64(data[8*i:])))
+			case flux.TUInt:
+				err = b.AppendUInt(j, binary.LittleEndian.Uint64(data[8*i:]))
+			case flux.TFloat:
+				err = b.AppendFloat(j, math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:])))
+			case flux.TTime:
+				err = b.AppendTime(j, execute.Time(binary.LittleEndian.Uint64(data[8*i:])))
+			case flux.TString:
+				start := binary.LittleEndian.Uint32(offsets[4*i:])
+				end := binary.LittleEndian.Uint32(offsets[4*(i+1):])
+				if start > end || int(end) > len(data) {
+					return fmt.Errorf("invalid string offsets of field %q", f.col.Label)
+				}
+				err = b.AppendString(j, string(data[start:end]))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type resultIterator struct {
+	results []*decodedResult
+	err     error
+}
+
+func (ri *resultIterator) More() bool {
+	return len(ri.results) > 0
+}
+
+func (ri *resultIterator) Next() flux.Result {
+	r := ri.results[0]
+	ri.results = ri.results[1:]
+	return r
+}
+
+func (ri *resultIterator) Release() {
+	ri.results = nil
+}
+
+func (ri *resultIterator) Err() error {
+	return ri.err
+}
+
+func (ri *resultIterator) Statistics() flux.Statistics { return flux.Statistics{} }
+
+type decodedResult struct {
+	name   string
+	tables []flux.Table
+	err    error
+}
+
+func (r *decodedResult) Name() string {
+	return r.name
+}
+
+func (r *decodedResult) Tables() flux.TableIterator {
+	return r
+}
+
+func (r *decodedResult) Do(f func(flux.Table) error) error {
+	for _, tbl := range r.tables {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return r.err
+}
+
+func (r *decodedResult) Statistics() flux.Statistics { return flux.Statistics{} }
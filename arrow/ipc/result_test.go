@@ -0,0 +1,171 @@
+package ipc_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/arrow/ipc"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+)
+
+func TestMultiResultEncoder_RoundTrip(t *testing.T) {
+	want := []*executetest.Result{
+		{
+			Nm: "a",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "t"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "t", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "b", Type: flux.TBool},
+						{Label: "i", Type: flux.TInt},
+						{Label: "u", Type: flux.TUInt},
+						{Label: "f", Type: flux.TFloat},
+						{Label: "s", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "x", execute.Time(1), true, int64(math.MaxInt64), uint64(math.MaxUint64), 1.25, "quote \" and unicode é"},
+						{execute.Time(0), "x", execute.Time(2), nil, nil, nil, nil, nil},
+						{execute.Time(0), "x", execute.Time(3), false, int64(math.MinInt64), uint64(0), math.Inf(-1), ""},
+					},
+				},
+				{
+					KeyCols:   []string{"_start", "t"},
+					KeyValues: []interface{}{execute.Time(0), "y"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "t", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+					},
+				},
+				{
+					KeyCols:   []string{"_start", "t"},
+					KeyValues: []interface{}{execute.Time(0), nil},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "t", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), nil, execute.Time(4)},
+					},
+				},
+			},
+		},
+		{
+			Nm: "empty",
+		},
+		{
+			Nm: "b",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{2.5},
+				},
+			}},
+		},
+	}
+	results := make([]flux.Result, len(want))
+	for i, r := range want {
+		r.Normalize()
+		results[i] = r
+	}
+
+	var buf bytes.Buffer
+	n, err := ipc.NewMultiResultEncoder().Encode(&buf, flux.NewSliceResultIterator(results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("unexpected byte count: %d, wrote %d bytes", n, buf.Len())
+	}
+
+	ri, err := ipc.NewMultiResultDecoder(executetest.UnlimitedAllocator).Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*executetest.Result
+	for ri.More() {
+		next := ri.Next()
+		res := &executetest.Result{Nm: next.Name()}
+		if err := next.Tables().Do(func(table flux.Table) error {
+			tbl, err := executetest.ConvertTable(table)
+			if err != nil {
+				return err
+			}
+			res.Tbls = append(res.Tbls, tbl)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		res.Normalize()
+		got = append(got, res)
+	}
+	if err := ri.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Fatalf("unexpected results -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMultiResultEncoder_Errors(t *testing.T) {
+	results := flux.NewSliceResultIterator([]flux.Result{
+		&executetest.Result{
+			Nm:  "failed",
+			Err: errors.New("result error"),
+		},
+		&executetest.Result{
+			Nm: "_result",
+		},
+	})
+
+	var buf bytes.Buffer
+	if _, err := ipc.NewMultiResultEncoder().Encode(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+
+	ri, err := ipc.NewMultiResultDecoder(executetest.UnlimitedAllocator).Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for ri.More() {
+		res := ri.Next()
+		names = append(names, res.Name())
+		err := res.Tables().Do(func(flux.Table) error { return nil })
+		if res.Name() == "failed" && (err == nil || err.Error() != "result error") {
+			t.Errorf("unexpected result error: %v", err)
+		} else if res.Name() != "failed" && err != nil {
+			t.Errorf("unexpected error in result %q: %v", res.Name(), err)
+		}
+	}
+	if want := []string{"failed", "_result"}; !cmp.Equal(want, names) {
+		t.Errorf("unexpected results -want/+got:\n%s", cmp.Diff(want, names))
+	}
+	if err := ri.Err(); err != nil {
+		t.Errorf("unexpected query error: %v", err)
+	}
+}
+
+func TestMultiResultDecoder_InvalidInput(t *testing.T) {
+	for _, input := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff, 0x10},
+		{0xff, 0xff, 0xff, 0xff, 0x08, 0, 0, 0, 0xff, 0xff, 0xff, 0x7f, 0, 0, 0, 0},
+	} {
+		if _, err := ipc.NewMultiResultDecoder(executetest.UnlimitedAllocator).Decode(ioutil.NopCloser(bytes.NewReader(input))); err == nil {
+			t.Errorf("expected an error decoding %x", input)
+		}
+	}
+}
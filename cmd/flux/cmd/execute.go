@@ -7,7 +7,10 @@ import (
 
 	_ "github.com/influxdata/flux/builtin"
 	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/embedded"
 	"github.com/spf13/cobra"
 )
 
@@ -17,14 +20,20 @@ var executeCmd = &cobra.Command{
 	Short: "Execute a Flux script",
 	Long:  "Execute a Flux script from string or file (use @ as prefix to the file)",
 	Args:  cobra.ExactArgs(1),
-	RunE:  execute,
+	RunE:  executeScript,
+}
+
+var executeFlags struct {
+	dataDir string
 }
 
 func init() {
+	influxdb.RegisterStorage()
 	rootCmd.AddCommand(executeCmd)
+	executeCmd.Flags().StringVar(&executeFlags.dataDir, "data-dir", "", "Directory of the embedded storage read by from() and written by to()")
 }
 
-func execute(cmd *cobra.Command, args []string) error {
+func executeScript(cmd *cobra.Command, args []string) error {
 	scriptSource := args[0]
 
 	var script string
@@ -42,7 +51,18 @@ func execute(cmd *cobra.Command, args []string) error {
 		Query: script,
 	}
 
-	querier := NewQuerier()
+	var deps execute.Dependencies
+	if executeFlags.dataDir != "" {
+		engine, err := embedded.Open(executeFlags.dataDir)
+		if err != nil {
+			return err
+		}
+		deps = execute.Dependencies{
+			influxdb.StorageDependency: engine,
+		}
+	}
+
+	querier := NewQuerier(deps)
 	result, err := querier.Query(context.Background(), c)
 	if err != nil {
 		return err
@@ -7,6 +7,7 @@ import (
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin"
 	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/repl"
 	"github.com/spf13/cobra"
 )
@@ -17,7 +18,7 @@ var replCmd = &cobra.Command{
 	Short: "Launch a Flux REPL",
 	Long:  "Launch a Flux REPL (Run-Execute-Print-Loop)",
 	Run: func(cmd *cobra.Command, args []string) {
-		q := NewQuerier()
+		q := NewQuerier(nil)
 		r := repl.New(q)
 		r.Run()
 	},
@@ -39,10 +40,12 @@ func (q *Querier) Query(ctx context.Context, c flux.Compiler) (flux.ResultIterat
 	return flux.NewResultIteratorFromQuery(qry), nil
 }
 
-func NewQuerier() *Querier {
+// NewQuerier creates a Querier that provides deps to the queries it executes.
+func NewQuerier(deps execute.Dependencies) *Querier {
 	config := control.Config{
-		ConcurrencyQuota: 1,
-		MemoryBytesQuota: math.MaxInt64,
+		ConcurrencyQuota:     1,
+		MemoryBytesQuota:     math.MaxInt64,
+		ExecutorDependencies: deps,
 	}
 
 	c := control.New(config)
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/influxdata/flux/influxql"
+	"github.com/spf13/cobra"
+)
+
+// transpileCmd represents the transpile command
+var transpileCmd = &cobra.Command{
+	Use:   "transpile",
+	Short: "Transpile an InfluxQL query to Flux",
+	Long:  "Print the Flux equivalent of an InfluxQL query from string or file (use @ as prefix to the file)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  transpile,
+}
+
+var transpileFlags struct {
+	database        string
+	retentionPolicy string
+}
+
+func init() {
+	rootCmd.AddCommand(transpileCmd)
+	transpileCmd.Flags().StringVar(&transpileFlags.database, "db", "", "Database of the statements that don't name one")
+	transpileCmd.Flags().StringVar(&transpileFlags.retentionPolicy, "rp", influxql.DefaultRetentionPolicy, "Retention policy of the statements that don't name one")
+}
+
+func transpile(cmd *cobra.Command, args []string) error {
+	query := args[0]
+	if query[0] == '@' {
+		queryBytes, err := ioutil.ReadFile(query[1:])
+		if err != nil {
+			return err
+		}
+		query = string(queryBytes)
+	}
+
+	t := influxql.NewTranspiler(influxql.Config{
+		DefaultDatabase:        transpileFlags.database,
+		DefaultRetentionPolicy: transpileFlags.retentionPolicy,
+	})
+	script, err := t.TranspileFlux(query)
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
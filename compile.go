@@ -708,7 +708,11 @@ type function struct {
 
 func (f *function) Type() semantic.Type {
 	// TODO(nathanielc): Update values.Value interface to use PolyTypes
-	t, _ := f.t.MonoType()
+	t, ok := f.t.MonoType()
+	if !ok {
+		// Functions with polymorphic parameters have no monotype.
+		return semantic.Invalid
+	}
 	return t
 }
 func (f *function) PolyType() semantic.PolyType {
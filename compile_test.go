@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
 )
 
 func TestCompile(t *testing.T) {
@@ -28,3 +30,21 @@ func TestCompile(t *testing.T) {
 		}
 	}
 }
+
+func TestFunctionValue_PolymorphicType(t *testing.T) {
+	f := flux.FunctionValue("identity", nil, semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{"v": semantic.Tvar(1)},
+		Required:   semantic.LabelSet{"v"},
+		Return:     semantic.Tvar(1),
+	})
+	if got := f.Type(); got != semantic.Invalid {
+		t.Errorf("unexpected type of polymorphic function: got %v want %v", got, semantic.Invalid)
+	}
+
+	// Objects holding polymorphic functions, such as packages, must still have a type.
+	obj := values.NewObject()
+	obj.Set("identity", f)
+	if got := obj.Type().Nature(); got != semantic.Object {
+		t.Errorf("unexpected object type: got %v want %v", got, semantic.Object)
+	}
+}
@@ -22,6 +22,9 @@ type rowFn struct {
 
 	recordCols map[string]int
 	references []string
+
+	// paramTypes are the types of the parameters other than the record.
+	paramTypes map[string]semantic.Type
 }
 
 func newRowFn(fn *semantic.FunctionExpression) (rowFn, error) {
@@ -33,7 +36,7 @@ func newRowFn(fn *semantic.FunctionExpression) (rowFn, error) {
 		compilationCache: compiler.NewCompilationCache(fn, scope),
 		inRecord:         values.NewObject(),
 		recordName:       fn.Block.Parameters.List[0].Key.Name,
-		references:       findColReferences(fn),
+		references:       findColReferences(fn, fn.Block.Parameters.List[0].Key.Name),
 		recordCols:       make(map[string]int),
 	}, nil
 }
@@ -57,11 +60,13 @@ func (f *rowFn) prepare(cols []flux.ColMeta) error {
 	}
 	f.record = NewRecord(semantic.NewObjectType(propertyTypes))
 	// Compile fn for given types
-	fn, err := f.compilationCache.Compile(
-		semantic.NewObjectType(map[string]semantic.Type{
-			f.recordName: f.record.Type(),
-		}),
-	)
+	inTypes := map[string]semantic.Type{
+		f.recordName: f.record.Type(),
+	}
+	for name, typ := range f.paramTypes {
+		inTypes[name] = typ
+	}
+	fn, err := f.compilationCache.Compile(semantic.NewObjectType(inTypes))
 	if err != nil {
 		return err
 	}
@@ -205,9 +210,81 @@ func (f *RowMapFn) Eval(row int, cr flux.ColReader) (values.Object, error) {
 	return v.Object(), nil
 }
 
-func findColReferences(fn *semantic.FunctionExpression) []string {
+// RowReduceFn is a function of a row and an accumulator record that returns the next accumulator record.
+// The parameters of the function are the row r and the accumulator,
+// whose type is the type of the identity record it starts from.
+type RowReduceFn struct {
+	rowFn
+
+	accumulatorType semantic.Type
+}
+
+// The names of the parameters of a RowReduceFn.
+const (
+	ReduceRecordParam      = "r"
+	ReduceAccumulatorParam = "accumulator"
+)
+
+func NewRowReduceFn(fn *semantic.FunctionExpression, identity values.Object) (*RowReduceFn, error) {
+	if fn.Block.Parameters == nil || len(fn.Block.Parameters.List) != 2 {
+		return nil, errors.New("function should have exactly two parameters")
+	}
+	for _, p := range fn.Block.Parameters.List {
+		if name := p.Key.Name; name != ReduceRecordParam && name != ReduceAccumulatorParam {
+			return nil, fmt.Errorf("function has unexpected parameter %q", name)
+		}
+	}
+	scope := flux.BuiltIns()
+	return &RowReduceFn{
+		rowFn: rowFn{
+			compilationCache: compiler.NewCompilationCache(fn, scope),
+			inRecord:         values.NewObject(),
+			recordName:       ReduceRecordParam,
+			references:       findColReferences(fn, ReduceRecordParam),
+			recordCols:       make(map[string]int),
+			paramTypes: map[string]semantic.Type{
+				ReduceAccumulatorParam: identity.Type(),
+			},
+		},
+		accumulatorType: identity.Type(),
+	}, nil
+}
+
+func (f *RowReduceFn) Prepare(cols []flux.ColMeta) error {
+	err := f.rowFn.prepare(cols)
+	if err != nil {
+		return err
+	}
+	// The accumulator that is returned is the accumulator of the next row, so its type must not change.
+	t := f.preparedFn.Type()
+	if t.Nature() != semantic.Object {
+		return errors.New("reduce function does not evaluate to a record")
+	}
+	want := f.accumulatorType.Properties()
+	got := t.Properties()
+	if len(got) != len(want) {
+		return fmt.Errorf("reduce function returns a record with %d properties, the accumulator has %d", len(got), len(want))
+	}
+	for k, typ := range want {
+		if got[k] == nil || got[k].Nature() != typ.Nature() {
+			return fmt.Errorf("reduce function returns property %q of type %v, the accumulator has type %v", k, got[k], typ)
+		}
+	}
+	return nil
+}
+
+func (f *RowReduceFn) Eval(row int, cr flux.ColReader, accumulator values.Object) (values.Object, error) {
+	f.inRecord.Set(ReduceAccumulatorParam, accumulator)
+	v, err := f.rowFn.eval(row, cr)
+	if err != nil {
+		return nil, err
+	}
+	return v.Object(), nil
+}
+
+func findColReferences(fn *semantic.FunctionExpression, recordName string) []string {
 	v := &colReferenceVisitor{
-		recordName: fn.Block.Parameters.List[0].Key.Name,
+		recordName: recordName,
 	}
 	semantic.Walk(v, fn)
 	return v.refs
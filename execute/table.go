@@ -1,6 +1,7 @@
 package execute
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -437,6 +438,59 @@ func ValueForRow(cr flux.ColReader, i, j int) values.Value {
 	}
 }
 
+// GoValue returns the Go value of a value of a column type,
+// a bool, int64, uint64, float64, string or values.Time, or nil for any other type.
+func GoValue(v values.Value) interface{} {
+	switch v.Type() {
+	case semantic.Bool:
+		return v.Bool()
+	case semantic.Int:
+		return v.Int()
+	case semantic.UInt:
+		return v.UInt()
+	case semantic.Float:
+		return v.Float()
+	case semantic.String:
+		return v.Str()
+	case semantic.Time:
+		return v.Time()
+	default:
+		return nil
+	}
+}
+
+// UnmarshalGoValue decodes the JSON of a value of a column of the given type into its Go value, see GoValue.
+func UnmarshalGoValue(data []byte, typ flux.ColType) (interface{}, error) {
+	switch typ {
+	case flux.TBool:
+		var v bool
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case flux.TInt:
+		var v int64
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case flux.TUInt:
+		var v uint64
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case flux.TFloat:
+		var v float64
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case flux.TString:
+		var v string
+		err := json.Unmarshal(data, &v)
+		return v, err
+	case flux.TTime:
+		var v values.Time
+		err := json.Unmarshal(data, &v)
+		return v, err
+	default:
+		return nil, fmt.Errorf("unsupported column type %v", typ)
+	}
+}
+
 // TableBuilder builds tables that can be used multiple times
 type TableBuilder interface {
 	Key() flux.GroupKey
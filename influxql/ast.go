@@ -0,0 +1,168 @@
+package influxql
+
+import (
+	"regexp"
+	"time"
+)
+
+// statement is a single InfluxQL statement.
+type statement interface {
+	stmt()
+}
+
+// selectStatement is a SELECT statement.
+type selectStatement struct {
+	Fields    []*field
+	Sources   []*measurement
+	Condition expr
+
+	// Interval and IntervalOffset are set by a GROUP BY time() dimension.
+	Interval       time.Duration
+	IntervalOffset time.Duration
+	// Tags are the tag dimensions of the GROUP BY clause, GroupAllTags is set by GROUP BY *.
+	Tags         []string
+	GroupAllTags bool
+
+	Fill      fillOption
+	FillValue expr
+
+	Descending bool
+	// Limit and Offset select the points of every series, SLimit and SOffset the series.
+	Limit   int
+	Offset  int
+	SLimit  int
+	SOffset int
+}
+
+// field is a projection of a SELECT statement.
+type field struct {
+	Expr  expr
+	Alias string
+}
+
+// measurement is a source of a statement.
+type measurement struct {
+	Database        string
+	RetentionPolicy string
+	Name            string
+	Regex           *regexp.Regexp
+}
+
+type fillOption int
+
+const (
+	fillNull fillOption = iota
+	fillNone
+	fillPrevious
+	fillNumber
+)
+
+// showDatabasesStatement is a SHOW DATABASES statement.
+type showDatabasesStatement struct{}
+
+// showMeasurementsStatement is a SHOW MEASUREMENTS statement.
+type showMeasurementsStatement struct {
+	Database  string
+	Source    *measurement
+	Condition expr
+}
+
+// showTagKeysStatement is a SHOW TAG KEYS statement.
+type showTagKeysStatement struct {
+	Database  string
+	Sources   []*measurement
+	Condition expr
+}
+
+// showTagValuesStatement is a SHOW TAG VALUES statement.
+type showTagValuesStatement struct {
+	Database  string
+	Sources   []*measurement
+	Keys      []string
+	Condition expr
+}
+
+// showFieldKeysStatement is a SHOW FIELD KEYS statement.
+type showFieldKeysStatement struct {
+	Database string
+	Sources  []*measurement
+}
+
+func (*selectStatement) stmt()           {}
+func (*showDatabasesStatement) stmt()    {}
+func (*showMeasurementsStatement) stmt() {}
+func (*showTagKeysStatement) stmt()      {}
+func (*showTagValuesStatement) stmt()    {}
+func (*showFieldKeysStatement) stmt()    {}
+
+// expr is an InfluxQL expression.
+type expr interface {
+	expr()
+}
+
+// dataType is the explicit type of a variable reference, as in "host"::tag.
+type dataType int
+
+const (
+	unknownType dataType = iota
+	tagType
+	fieldType
+)
+
+type varRef struct {
+	Val  string
+	Type dataType
+}
+
+type call struct {
+	Name string
+	Args []expr
+}
+
+type binaryExpr struct {
+	Op  token
+	LHS expr
+	RHS expr
+}
+
+type parenExpr struct {
+	Expr expr
+}
+
+type wildcard struct{}
+
+type stringLiteral struct {
+	Val string
+}
+
+type integerLiteral struct {
+	Val int64
+}
+
+type numberLiteral struct {
+	Val float64
+}
+
+type booleanLiteral struct {
+	Val bool
+}
+
+type durationLiteral struct {
+	Val time.Duration
+}
+
+type regexLiteral struct {
+	Val *regexp.Regexp
+}
+
+func (*varRef) expr()          {}
+func (*call) expr()            {}
+func (*binaryExpr) expr()      {}
+func (*parenExpr) expr()       {}
+func (*wildcard) expr()        {}
+func (*stringLiteral) expr()   {}
+func (*integerLiteral) expr()  {}
+func (*numberLiteral) expr()   {}
+func (*booleanLiteral) expr()  {}
+func (*durationLiteral) expr() {}
+func (*regexLiteral) expr()    {}
@@ -0,0 +1,352 @@
+package influxql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// minTime and maxTime are the bounds of the time range of statements that don't restrict it.
+	minTime = time.Unix(0, math.MinInt64+2).UTC()
+	maxTime = time.Unix(0, math.MaxInt64).UTC()
+)
+
+// timeValue is a bound of a time range, either relative to now or absolute.
+type timeValue struct {
+	relative bool
+	d        time.Duration
+	t        time.Time
+}
+
+func (v timeValue) add(d time.Duration) timeValue {
+	if v.relative {
+		v.d += d
+	} else {
+		v.t = v.t.Add(d)
+	}
+	return v
+}
+
+func (v timeValue) String() string {
+	if !v.relative {
+		return formatTime(v.t)
+	}
+	if v.d == 0 {
+		return "now()"
+	}
+	return formatDuration(v.d)
+}
+
+// timeRange is the time range of a statement, the stop is omitted from the range call if unset.
+type timeRange struct {
+	start, stop *timeValue
+}
+
+func (tr timeRange) String() string {
+	start := timeValue{t: minTime}
+	if tr.start != nil {
+		start = *tr.start
+	}
+	if tr.stop == nil {
+		return fmt.Sprintf("range(start: %v)", start)
+	}
+	return fmt.Sprintf("range(start: %v, stop: %v)", start, *tr.stop)
+}
+
+// splitConjuncts returns the operands of the top level AND expressions of e.
+func splitConjuncts(e expr) []expr {
+	switch e := e.(type) {
+	case nil:
+		return nil
+	case *parenExpr:
+		if b, ok := e.Expr.(*binaryExpr); ok && b.Op == and {
+			return splitConjuncts(b)
+		}
+	case *binaryExpr:
+		if e.Op == and {
+			return append(splitConjuncts(e.LHS), splitConjuncts(e.RHS)...)
+		}
+	}
+	return []expr{e}
+}
+
+// isTimeRef reports whether e references the time column.
+func isTimeRef(e expr) bool {
+	ref, ok := e.(*varRef)
+	return ok && strings.EqualFold(ref.Val, "time")
+}
+
+// containsTimeRef reports whether e references the time column anywhere.
+func containsTimeRef(e expr) bool {
+	switch e := e.(type) {
+	case *varRef:
+		return isTimeRef(e)
+	case *binaryExpr:
+		return containsTimeRef(e.LHS) || containsTimeRef(e.RHS)
+	case *parenExpr:
+		return containsTimeRef(e.Expr)
+	case *call:
+		for _, arg := range e.Args {
+			if containsTimeRef(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// extractTimeRange removes the conditions on time from the conjuncts of a condition.
+// It returns the time range they select and the remaining conjuncts.
+func extractTimeRange(cond expr) (timeRange, []expr, error) {
+	var (
+		tr   timeRange
+		rest []expr
+	)
+	for _, c := range splitConjuncts(cond) {
+		b, ok := c.(*binaryExpr)
+		if !ok || (!isTimeRef(b.LHS) && !isTimeRef(b.RHS)) {
+			if containsTimeRef(c) {
+				return tr, nil, fmt.Errorf("conditions on time must be combined with AND and compare time to a value")
+			}
+			rest = append(rest, c)
+			continue
+		}
+
+		op, value := b.Op, b.RHS
+		if isTimeRef(b.RHS) {
+			// Flip the comparison so time is on the left.
+			value = b.LHS
+			switch op {
+			case lt:
+				op = gt
+			case lte:
+				op = gte
+			case gt:
+				op = lt
+			case gte:
+				op = lte
+			}
+		}
+		v, err := evalTime(value)
+		if err != nil {
+			return tr, nil, err
+		}
+
+		// Range starts are inclusive and stops exclusive, an absolute bound is shifted by a nanosecond when needed.
+		exclusive := func(v timeValue) timeValue {
+			if v.relative {
+				return v
+			}
+			return v.add(time.Nanosecond)
+		}
+		var start, stop *timeValue
+		switch op {
+		case gt:
+			v := exclusive(v)
+			start = &v
+		case gte:
+			start = &v
+		case lt:
+			stop = &v
+		case lte:
+			v := exclusive(v)
+			stop = &v
+		case eq:
+			next := v.add(time.Nanosecond)
+			start, stop = &v, &next
+		default:
+			return tr, nil, fmt.Errorf("unsupported operator %v on time", op)
+		}
+		if start != nil {
+			if tr.start != nil {
+				return tr, nil, fmt.Errorf("multiple lower bounds on time")
+			}
+			tr.start = start
+		}
+		if stop != nil {
+			if tr.stop != nil {
+				return tr, nil, fmt.Errorf("multiple upper bounds on time")
+			}
+			tr.stop = stop
+		}
+	}
+	return tr, rest, nil
+}
+
+// timeLayouts are the layouts of time strings in conditions on time.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// evalTime evaluates the value a time is compared to.
+func evalTime(e expr) (timeValue, error) {
+	switch e := e.(type) {
+	case *parenExpr:
+		return evalTime(e.Expr)
+	case *call:
+		if e.Name == "now" && len(e.Args) == 0 {
+			return timeValue{relative: true}, nil
+		}
+	case *stringLiteral:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, e.Val); err == nil {
+				return timeValue{t: t}, nil
+			}
+		}
+		return timeValue{}, fmt.Errorf("invalid time %q", e.Val)
+	case *integerLiteral:
+		return timeValue{t: time.Unix(0, e.Val).UTC()}, nil
+	case *durationLiteral:
+		return timeValue{t: time.Unix(0, int64(e.Val)).UTC()}, nil
+	case *binaryExpr:
+		if e.Op != add && e.Op != sub {
+			break
+		}
+		v, err := evalTime(e.LHS)
+		if err != nil {
+			return v, err
+		}
+		var d time.Duration
+		switch rhs := e.RHS.(type) {
+		case *durationLiteral:
+			d = rhs.Val
+		case *integerLiteral:
+			d = time.Duration(rhs.Val)
+		default:
+			return v, fmt.Errorf("only durations can be added to or subtracted from times")
+		}
+		if e.Op == sub {
+			d = -d
+		}
+		return v.add(d), nil
+	}
+	return timeValue{}, fmt.Errorf("unsupported time value %s", describe(e))
+}
+
+// classifyRefs adds the names of the variables referenced by e to tags or fields.
+// A variable compared to a string or a regular expression is a tag unless it is cast to a field,
+// other variables are fields unless they are cast to a tag.
+func classifyRefs(e expr, tags, fields map[string]bool) {
+	add := func(ref *varRef, tag bool) {
+		switch {
+		case ref.Type == tagType, ref.Type == unknownType && tag:
+			tags[ref.Val] = true
+		default:
+			fields[ref.Val] = true
+		}
+	}
+	switch e := e.(type) {
+	case *varRef:
+		add(e, false)
+	case *parenExpr:
+		classifyRefs(e.Expr, tags, fields)
+	case *call:
+		for _, arg := range e.Args {
+			classifyRefs(arg, tags, fields)
+		}
+	case *binaryExpr:
+		tagComparison := e.Op == eqRegex || e.Op == neqRegex || isString(e.LHS) || isString(e.RHS)
+		for _, operand := range []expr{e.LHS, e.RHS} {
+			if ref, ok := operand.(*varRef); ok {
+				add(ref, tagComparison)
+			} else {
+				classifyRefs(operand, tags, fields)
+			}
+		}
+	}
+}
+
+func isString(e expr) bool {
+	_, ok := e.(*stringLiteral)
+	return ok
+}
+
+// conditionRefs returns the tags and fields referenced by a condition.
+func conditionRefs(e expr) (tags, fields map[string]bool) {
+	tags, fields = make(map[string]bool), make(map[string]bool)
+	classifyRefs(e, tags, fields)
+	return tags, fields
+}
+
+var binaryOperators = map[token]string{
+	add: "+", sub: "-", mul: "*", div: "/",
+	and: "and", or: "or",
+	eq: "==", neq: "!=", lt: "<", lte: "<=", gt: ">", gte: ">=",
+	eqRegex: "=~", neqRegex: "!~",
+}
+
+// fluxExpr returns the Flux expression of an InfluxQL condition.
+// The variables of the condition are converted by ref.
+func fluxExpr(e expr, ref func(*varRef) string) (string, error) {
+	switch e := e.(type) {
+	case *varRef:
+		return ref(e), nil
+	case *parenExpr:
+		s, err := fluxExpr(e.Expr, ref)
+		if err != nil {
+			return "", err
+		}
+		return "(" + s + ")", nil
+	case *binaryExpr:
+		op, ok := binaryOperators[e.Op]
+		if !ok {
+			return "", fmt.Errorf("unsupported operator %v", e.Op)
+		}
+		lhs, err := fluxExpr(e.LHS, ref)
+		if err != nil {
+			return "", err
+		}
+		rhs, err := fluxExpr(e.RHS, ref)
+		if err != nil {
+			return "", err
+		}
+		return lhs + " " + op + " " + rhs, nil
+	case *stringLiteral:
+		return quoteString(e.Val), nil
+	case *integerLiteral:
+		return strconv.FormatInt(e.Val, 10), nil
+	case *numberLiteral:
+		return formatFloat(e.Val), nil
+	case *booleanLiteral:
+		return strconv.FormatBool(e.Val), nil
+	case *durationLiteral:
+		return formatDuration(e.Val), nil
+	case *regexLiteral:
+		return formatRegex(e.Val), nil
+	}
+	return "", fmt.Errorf("unsupported expression %s in condition", describe(e))
+}
+
+// describe returns a short description of an expression for error messages.
+func describe(e expr) string {
+	switch e := e.(type) {
+	case *varRef:
+		return strconv.Quote(e.Val)
+	case *call:
+		return e.Name + "()"
+	case *wildcard:
+		return "*"
+	case *parenExpr:
+		return describe(e.Expr)
+	case *binaryExpr:
+		return describe(e.LHS) + " " + e.Op.String() + " " + describe(e.RHS)
+	case *stringLiteral:
+		return "'" + e.Val + "'"
+	case *integerLiteral:
+		return strconv.FormatInt(e.Val, 10)
+	case *numberLiteral:
+		return formatFloat(e.Val)
+	case *booleanLiteral:
+		return strconv.FormatBool(e.Val)
+	case *durationLiteral:
+		return formatDuration(e.Val)
+	case *regexLiteral:
+		return formatRegex(e.Val)
+	}
+	return fmt.Sprintf("%T", e)
+}
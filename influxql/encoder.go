@@ -0,0 +1,284 @@
+package influxql
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/values"
+)
+
+// MultiResultEncoder encodes results as an InfluxQL JSON response.
+//
+// Every result is a statement, its ID is the name of the result when it is an integer,
+// such as the results of transpiled queries, and its position otherwise.
+// The non empty tables are grouped into series: the name of the series is the _measurement value of the group key
+// and the string columns of the group key, except _start, _stop and _field, are its tags.
+// The other columns are the columns of the series, _time is named time.
+// The _value column of a table with _field in its group key is named after the field.
+// Tables of the same series, such as the tables of the fields of a measurement, are merged into one series
+// with the rows of the same time merged into one row.
+type MultiResultEncoder struct{}
+
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	resp := Response{}
+	for i := 0; results.More(); i++ {
+		res := results.Next()
+		id, err := strconv.Atoi(res.Name())
+		if err != nil {
+			id = i
+		}
+		result := Result{StatementID: id}
+		set := newSeriesSet()
+		if err := res.Tables().Do(set.add); err != nil {
+			result.Err = err.Error()
+		} else {
+			result.Series = set.series()
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	if err := results.Err(); err != nil {
+		resp.Err = err.Error()
+	}
+
+	wc := &iocounter.Writer{Writer: w}
+	err := json.NewEncoder(wc).Encode(resp)
+	return wc.Count(), err
+}
+
+// seriesSet merges the tables of a result into series.
+type seriesSet struct {
+	builders []*seriesBuilder
+	lookup   map[string]*seriesBuilder
+}
+
+func newSeriesSet() *seriesSet {
+	return &seriesSet{lookup: make(map[string]*seriesBuilder)}
+}
+
+// seriesBuilder builds the rows of a series.
+type seriesBuilder struct {
+	series  *Series
+	columns map[string]int
+	// times are the times of the rows and timed reports whether a row has a time.
+	// The rows with a time are keyed by it.
+	times  []values.Time
+	timed  []bool
+	rows   map[values.Time]int
+	sorted bool
+}
+
+// add adds the rows of a table to its series.
+func (s *seriesSet) add(tbl flux.Table) error {
+	key := tbl.Key()
+	var name string
+	if j := execute.ColIdx("_measurement", key.Cols()); j >= 0 && key.Cols()[j].Type == flux.TString {
+		name = key.ValueString(j)
+	}
+	var tags map[string]string
+	for j, c := range key.Cols() {
+		switch c.Label {
+		case "_measurement", "_start", "_stop", "_field":
+			continue
+		}
+		if c.Type == flux.TString && !key.IsNull(j) {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[c.Label] = key.ValueString(j)
+		}
+	}
+
+	// Select the columns of the series, the time column first.
+	var indexes []int
+	timeIdx := -1
+	for j, c := range tbl.Cols() {
+		switch {
+		case c.Label == execute.DefaultTimeColLabel:
+			indexes = append([]int{j}, indexes...)
+			timeIdx = j
+			continue
+		case c.Label == "_start" || c.Label == "_stop":
+			continue
+		case key.HasCol(c.Label) && c.Type == flux.TString:
+			// The measurement, field and tags are already part of the series.
+			continue
+		}
+		indexes = append(indexes, j)
+	}
+
+	var b *seriesBuilder
+	return tbl.Do(func(cr flux.ColReader) error {
+		if cr.Len() == 0 {
+			return nil
+		}
+		if b == nil {
+			b = s.builder(name, tags)
+		}
+		columns := make([]int, len(indexes))
+		for k, j := range indexes {
+			columns[k] = b.column(columnName(tbl, j))
+		}
+		for i := 0; i < cr.Len(); i++ {
+			var row []interface{}
+			if timeIdx >= 0 && cr.Times(timeIdx).IsValid(i) {
+				row = b.row(values.Time(cr.Times(timeIdx).Value(i)), true)
+			} else {
+				row = b.row(0, false)
+			}
+			for k, j := range indexes {
+				row[columns[k]] = rowValue(cr, i, j)
+			}
+		}
+		return nil
+	})
+}
+
+// builder returns the builder of the series with a name and tags, creating it if needed.
+func (s *seriesSet) builder(name string, tags map[string]string) *seriesBuilder {
+	id := name
+	for _, k := range sortedTagKeys(tags) {
+		id += "\x00" + k + "\x00" + tags[k]
+	}
+	if b, ok := s.lookup[id]; ok {
+		return b
+	}
+	b := &seriesBuilder{
+		series:  &Series{Name: name, Tags: tags},
+		columns: make(map[string]int),
+		rows:    make(map[values.Time]int),
+		sorted:  true,
+	}
+	s.builders = append(s.builders, b)
+	s.lookup[id] = b
+	return b
+}
+
+// series returns the series in the order of their first table.
+func (s *seriesSet) series() []*Series {
+	var series []*Series
+	for _, b := range s.builders {
+		series = append(series, b.finish())
+	}
+	return series
+}
+
+// column returns the index of a column of the series, adding it if needed.
+func (b *seriesBuilder) column(name string) int {
+	if j, ok := b.columns[name]; ok {
+		return j
+	}
+	j := len(b.series.Columns)
+	b.series.Columns = append(b.series.Columns, name)
+	b.columns[name] = j
+	return j
+}
+
+// row returns the row of a time, a new row is added if there is no time or the time has no row yet.
+// The row has room for the current columns of the series.
+func (b *seriesBuilder) row(t values.Time, timed bool) []interface{} {
+	i, ok := b.rows[t]
+	if !timed || !ok {
+		i = len(b.series.Values)
+		b.series.Values = append(b.series.Values, nil)
+		b.times = append(b.times, t)
+		b.timed = append(b.timed, timed)
+		if timed {
+			b.rows[t] = i
+		}
+		if i > 0 && (!timed || !b.timed[i-1] || t < b.times[i-1]) {
+			b.sorted = false
+		}
+	}
+	if n := len(b.series.Columns); len(b.series.Values[i]) < n {
+		row := make([]interface{}, n)
+		copy(row, b.series.Values[i])
+		b.series.Values[i] = row
+	}
+	return b.series.Values[i]
+}
+
+// finish pads the rows to the columns of the series and sorts them by time.
+func (b *seriesBuilder) finish() *Series {
+	n := len(b.series.Columns)
+	for i, row := range b.series.Values {
+		if len(row) < n {
+			b.series.Values[i] = append(row, make([]interface{}, n-len(row))...)
+		}
+	}
+	if !b.sorted {
+		sort.Stable(byTime{values: b.series.Values, times: b.times, timed: b.timed})
+	}
+	return b.series
+}
+
+// byTime sorts rows by time, rows without a time keep their position after the rows with a time.
+type byTime struct {
+	values [][]interface{}
+	times  []values.Time
+	timed  []bool
+}
+
+func (s byTime) Len() int { return len(s.values) }
+func (s byTime) Less(i, j int) bool {
+	return s.timed[i] && (!s.timed[j] || s.times[i] < s.times[j])
+}
+func (s byTime) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.times[i], s.times[j] = s.times[j], s.times[i]
+	s.timed[i], s.timed[j] = s.timed[j], s.timed[i]
+}
+
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// columnName returns the name of a column in the series of a table.
+func columnName(tbl flux.Table, j int) string {
+	label := tbl.Cols()[j].Label
+	switch label {
+	case execute.DefaultTimeColLabel:
+		return "time"
+	case execute.DefaultValueColLabel:
+		key := tbl.Key()
+		if f := execute.ColIdx("_field", key.Cols()); f >= 0 && key.Cols()[f].Type == flux.TString {
+			return key.ValueString(f)
+		}
+	}
+	return label
+}
+
+// rowValue returns the JSON value of a cell, times are formatted as RFC3339 strings.
+func rowValue(cr flux.ColReader, i, j int) interface{} {
+	v := execute.ValueForRow(cr, i, j)
+	if v.IsNull() {
+		return nil
+	}
+	switch c := cr.Cols()[j]; c.Type {
+	case flux.TString:
+		return v.Str()
+	case flux.TInt:
+		return v.Int()
+	case flux.TUInt:
+		return v.UInt()
+	case flux.TFloat:
+		return v.Float()
+	case flux.TBool:
+		return v.Bool()
+	case flux.TTime:
+		return v.Time().Time().Format(time.RFC3339Nano)
+	default:
+		execute.PanicUnknownType(c.Type)
+		return nil
+	}
+}
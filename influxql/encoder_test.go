@@ -0,0 +1,231 @@
+package influxql_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/influxql"
+)
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	results := flux.NewSliceResultIterator([]flux.Result{
+		&executetest.Result{
+			Nm: "0",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "_stop", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "idle", Type: flux.TInt},
+						{Label: "usage", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), execute.Time(100), execute.Time(1), "cpu", "a", int64(90), 10.5},
+						{execute.Time(0), execute.Time(100), execute.Time(2), "cpu", "a", nil, 12.0},
+					},
+				},
+				{
+					KeyCols:   []string{"_measurement", "host"},
+					KeyValues: []interface{}{"cpu", "b"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "usage", Type: flux.TFloat},
+					},
+				},
+			},
+		},
+		&executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_value", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+				},
+				Data: [][]interface{}{
+					{"mem", "status", "ok", execute.Time(1)},
+				},
+			}},
+		},
+		&executetest.Result{
+			Nm:  "2",
+			Err: errors.New("expected error"),
+		},
+	})
+
+	var buf bytes.Buffer
+	n, err := new(influxql.MultiResultEncoder).Encode(&buf, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("unexpected byte count: %d, wrote %d bytes", n, buf.Len())
+	}
+
+	want := `{"results":[` +
+		`{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"a"},"columns":["time","idle","usage"],"values":[["1970-01-01T00:00:00.000000001Z",90,10.5],["1970-01-01T00:00:00.000000002Z",null,12]]}]},` +
+		`{"statement_id":1,"series":[{"name":"mem","columns":["time","status"],"values":[["1970-01-01T00:00:00.000000001Z","ok"]]}]},` +
+		`{"statement_id":2,"error":"expected error"}` +
+		"]}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected response -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMultiResultEncoder_MergeSeries(t *testing.T) {
+	fieldTable := func(data [][]interface{}) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"_measurement", "host", "_field"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "host", Type: flux.TString},
+				{Label: "_field", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: data,
+		}
+	}
+	results := flux.NewSliceResultIterator([]flux.Result{
+		&executetest.Result{
+			Nm: "0",
+			Tbls: []*executetest.Table{
+				fieldTable([][]interface{}{
+					{execute.Time(1), "cpu", "a", "usage", 1.5},
+					{execute.Time(2), "cpu", "a", "usage", 2.5},
+				}),
+				fieldTable([][]interface{}{
+					{execute.Time(2), "cpu", "b", "idle", 70.0},
+				}),
+				fieldTable([][]interface{}{
+					{execute.Time(0), "cpu", "a", "idle", 95.0},
+					{execute.Time(2), "cpu", "a", "idle", 90.0},
+					{execute.Time(3), "cpu", "a", "idle", 80.0},
+				}),
+			},
+		},
+	})
+
+	var buf bytes.Buffer
+	if _, err := new(influxql.MultiResultEncoder).Encode(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"results":[{"statement_id":0,"series":[` +
+		`{"name":"cpu","tags":{"host":"a"},"columns":["time","usage","idle"],"values":[` +
+		`["1970-01-01T00:00:00Z",null,95],` +
+		`["1970-01-01T00:00:00.000000001Z",1.5,null],` +
+		`["1970-01-01T00:00:00.000000002Z",2.5,90],` +
+		`["1970-01-01T00:00:00.000000003Z",null,80]]},` +
+		`{"name":"cpu","tags":{"host":"b"},"columns":["time","idle"],"values":[["1970-01-01T00:00:00.000000002Z",70]]}` +
+		"]}]}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected response -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMultiResultEncoder_RoundTrip(t *testing.T) {
+	want := []*executetest.Result{
+		{
+			Nm: "0",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement", "host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "cpu", "server01", "usage_user", 1.5},
+						{execute.Time(10e9), "cpu", "server01", "usage_user", 2.5},
+					},
+				},
+				{
+					KeyCols: []string{"_measurement", "host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "cpu", "server02", "status", "ok"},
+					},
+				},
+			},
+		},
+		{
+			Nm: "1",
+			Tbls: []*executetest.Table{{
+				KeyCols: []string{"_measurement", "_field"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_value", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(5), "mem", "swapped", true},
+				},
+			}},
+		},
+	}
+	results := make([]flux.Result, len(want))
+	for i, r := range want {
+		r.Normalize()
+		results[i] = r
+	}
+
+	var buf bytes.Buffer
+	if _, err := new(influxql.MultiResultEncoder).Encode(&buf, flux.NewSliceResultIterator(results)); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := influxql.NewResultDecoder(executetest.UnlimitedAllocator)
+	ri, err := dec.Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*executetest.Result
+	for ri.More() {
+		next := ri.Next()
+		res := &executetest.Result{Nm: next.Name()}
+		if err := next.Tables().Do(func(table flux.Table) error {
+			tbl, err := executetest.ConvertTable(table)
+			if err != nil {
+				return err
+			}
+			res.Tbls = append(res.Tbls, tbl)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		res.Normalize()
+		got = append(got, res)
+	}
+	if err := ri.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Fatalf("unexpected result -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
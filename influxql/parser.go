@@ -0,0 +1,762 @@
+package influxql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parser is a recursive descent parser of InfluxQL queries.
+// Keywords are identifiers that aren't quoted, they are matched regardless of their case.
+type parser struct {
+	s scanner
+
+	// tok, pos and lit describe the current token.
+	tok token
+	pos int
+	lit string
+}
+
+// parseQuery parses the semicolon separated statements of a query.
+func parseQuery(q string) ([]statement, error) {
+	p := &parser{s: scanner{src: q}}
+	p.next()
+
+	var stmts []statement
+	for {
+		for p.tok == semicolon {
+			p.next()
+		}
+		if p.tok == eof {
+			break
+		}
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+		if p.tok != semicolon && p.tok != eof {
+			return nil, p.unexpected("end of statement")
+		}
+	}
+	if len(stmts) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	return stmts, nil
+}
+
+func (p *parser) next() {
+	p.tok, p.pos, p.lit = p.s.scan()
+}
+
+func (p *parser) unexpected(expected string) error {
+	found := p.lit
+	switch p.tok {
+	case eof:
+		found = "EOF"
+	case illegal:
+		return fmt.Errorf("%s at %d", p.lit, p.pos)
+	}
+	return fmt.Errorf("found %s, expected %s at %d", found, expected, p.pos)
+}
+
+// isKeyword reports whether the current token is the keyword kw.
+func (p *parser) isKeyword(kw string) bool {
+	return p.tok == ident && strings.EqualFold(p.lit, kw)
+}
+
+// acceptKeyword consumes the current token if it is the keyword kw.
+func (p *parser) acceptKeyword(kw string) bool {
+	if p.isKeyword(kw) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.acceptKeyword(kw) {
+		return p.unexpected(kw)
+	}
+	return nil
+}
+
+func (p *parser) expect(tok token) error {
+	if p.tok != tok {
+		return p.unexpected(tok.String())
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) parseIdent() (string, error) {
+	if p.tok != ident && p.tok != quotedIdent {
+		return "", p.unexpected("identifier")
+	}
+	lit := p.lit
+	p.next()
+	return lit, nil
+}
+
+func (p *parser) parseInt() (int, error) {
+	if p.tok != integerLit {
+		return 0, p.unexpected("integer")
+	}
+	n, err := strconv.Atoi(p.lit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %s at %d", p.lit, p.pos)
+	}
+	p.next()
+	return n, nil
+}
+
+func (p *parser) parseStatement() (statement, error) {
+	switch {
+	case p.acceptKeyword("SELECT"):
+		return p.parseSelect()
+	case p.acceptKeyword("SHOW"):
+		return p.parseShow()
+	}
+	return nil, p.unexpected("SELECT or SHOW")
+}
+
+func (p *parser) parseSelect() (*selectStatement, error) {
+	stmt := new(selectStatement)
+	for {
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Fields = append(stmt.Fields, f)
+		if p.tok != comma {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	sources, err := p.parseSources()
+	if err != nil {
+		return nil, err
+	}
+	stmt.Sources = sources
+
+	if p.acceptKeyword("WHERE") {
+		if stmt.Condition, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("GROUP") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if err := p.parseDimensions(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("FILL") {
+		if err := p.parseFill(stmt); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if !p.acceptKeyword("TIME") {
+			return nil, p.unexpected("time")
+		}
+		if p.acceptKeyword("DESC") {
+			stmt.Descending = true
+		} else {
+			p.acceptKeyword("ASC")
+		}
+	}
+	for _, opt := range []struct {
+		kw string
+		n  *int
+	}{
+		{"LIMIT", &stmt.Limit},
+		{"OFFSET", &stmt.Offset},
+		{"SLIMIT", &stmt.SLimit},
+		{"SOFFSET", &stmt.SOffset},
+	} {
+		if p.acceptKeyword(opt.kw) {
+			if *opt.n, err = p.parseInt(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseField() (*field, error) {
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	f := &field{Expr: e}
+	if p.acceptKeyword("AS") {
+		if f.Alias, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseSources() ([]*measurement, error) {
+	var sources []*measurement
+	for {
+		m, err := p.parseMeasurement()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, m)
+		if p.tok != comma {
+			return sources, nil
+		}
+		p.next()
+	}
+}
+
+// parseMeasurement parses a measurement name or regular expression,
+// optionally qualified by a database and retention policy as in db.rp.name or db..name.
+func (p *parser) parseMeasurement() (*measurement, error) {
+	m := new(measurement)
+	var segments []string
+	for {
+		if p.tok == div {
+			re, err := p.parseRegex()
+			if err != nil {
+				return nil, err
+			}
+			m.Regex = re
+			segments = append(segments, "")
+			break
+		}
+		var seg string
+		if p.tok == ident || p.tok == quotedIdent {
+			seg = p.lit
+			p.next()
+		}
+		segments = append(segments, seg)
+		if p.tok != dot {
+			break
+		}
+		p.next()
+	}
+
+	switch len(segments) {
+	case 3:
+		m.Database = segments[0]
+		segments = segments[1:]
+		fallthrough
+	case 2:
+		m.RetentionPolicy = segments[0]
+		segments = segments[1:]
+		fallthrough
+	case 1:
+		m.Name = segments[0]
+	default:
+		return nil, fmt.Errorf("invalid measurement %s at %d", strings.Join(segments, "."), p.pos)
+	}
+	if m.Name == "" && m.Regex == nil {
+		return nil, p.unexpected("measurement")
+	}
+	return m, nil
+}
+
+// parseRegex parses a regular expression, the current token must be its opening slash.
+func (p *parser) parseRegex() (*regexp.Regexp, error) {
+	if p.tok != div {
+		return nil, p.unexpected("regular expression")
+	}
+	pos := p.pos
+	src, err := p.s.scanRegex(pos + 1)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression at %d: %v", pos, err)
+	}
+	p.next()
+	return re, nil
+}
+
+func (p *parser) parseDimensions(stmt *selectStatement) error {
+	for {
+		switch {
+		case p.tok == mul:
+			stmt.GroupAllTags = true
+			p.next()
+		case p.isKeyword("TIME"):
+			p.next()
+			if err := p.parseTimeDimension(stmt); err != nil {
+				return err
+			}
+		default:
+			tag, err := p.parseIdent()
+			if err != nil {
+				return err
+			}
+			if p.tok == colon2 {
+				// Dimensions are always tags.
+				p.next()
+				if _, err := p.parseIdent(); err != nil {
+					return err
+				}
+			}
+			stmt.Tags = append(stmt.Tags, tag)
+		}
+		if p.tok != comma {
+			return nil
+		}
+		p.next()
+	}
+}
+
+// parseTimeDimension parses the arguments of time(interval[, offset]).
+func (p *parser) parseTimeDimension(stmt *selectStatement) error {
+	if stmt.Interval != 0 {
+		return fmt.Errorf("multiple time dimensions at %d", p.pos)
+	}
+	if err := p.expect(lparen); err != nil {
+		return err
+	}
+	d, err := p.parseDuration()
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("time dimension must have a positive duration")
+	}
+	stmt.Interval = d
+	if p.tok == comma {
+		p.next()
+		if stmt.IntervalOffset, err = p.parseDuration(); err != nil {
+			return err
+		}
+	}
+	return p.expect(rparen)
+}
+
+func (p *parser) parseDuration() (time.Duration, error) {
+	neg := false
+	if p.tok == sub {
+		neg = true
+		p.next()
+	}
+	if p.tok != durationLit {
+		return 0, p.unexpected("duration")
+	}
+	d, err := parseDurationLit(p.lit)
+	if err != nil {
+		return 0, err
+	}
+	p.next()
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+func (p *parser) parseFill(stmt *selectStatement) error {
+	if err := p.expect(lparen); err != nil {
+		return err
+	}
+	switch {
+	case p.acceptKeyword("NULL"):
+		stmt.Fill = fillNull
+	case p.acceptKeyword("NONE"):
+		stmt.Fill = fillNone
+	case p.acceptKeyword("PREVIOUS"):
+		stmt.Fill = fillPrevious
+	case p.isKeyword("LINEAR"):
+		return fmt.Errorf("fill(linear) is not supported")
+	default:
+		e, err := p.parseUnary()
+		if err != nil {
+			return err
+		}
+		switch e.(type) {
+		case *integerLiteral, *numberLiteral:
+		default:
+			return fmt.Errorf("fill() requires null, none, previous or a number")
+		}
+		stmt.Fill = fillNumber
+		stmt.FillValue = e
+	}
+	return p.expect(rparen)
+}
+
+func (p *parser) parseShow() (statement, error) {
+	switch {
+	case p.acceptKeyword("DATABASES"):
+		return &showDatabasesStatement{}, nil
+	case p.acceptKeyword("MEASUREMENTS"):
+		return p.parseShowMeasurements()
+	case p.acceptKeyword("TAG"):
+		switch {
+		case p.acceptKeyword("KEYS"):
+			return p.parseShowTagKeys()
+		case p.acceptKeyword("VALUES"):
+			return p.parseShowTagValues()
+		}
+		return nil, p.unexpected("KEYS or VALUES")
+	case p.acceptKeyword("FIELD"):
+		if err := p.expectKeyword("KEYS"); err != nil {
+			return nil, err
+		}
+		return p.parseShowFieldKeys()
+	}
+	return nil, p.unexpected("DATABASES, MEASUREMENTS, TAG KEYS, TAG VALUES or FIELD KEYS")
+}
+
+// parseOn parses an optional ON clause and returns its database.
+func (p *parser) parseOn() (string, error) {
+	if !p.acceptKeyword("ON") {
+		return "", nil
+	}
+	return p.parseIdent()
+}
+
+func (p *parser) parseShowMeasurements() (*showMeasurementsStatement, error) {
+	stmt := new(showMeasurementsStatement)
+	var err error
+	if stmt.Database, err = p.parseOn(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("WITH") {
+		if err := p.expectKeyword("MEASUREMENT"); err != nil {
+			return nil, err
+		}
+		switch p.tok {
+		case eq:
+			p.next()
+			name, err := p.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Source = &measurement{Name: name}
+		case eqRegex:
+			p.next()
+			re, err := p.parseRegex()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Source = &measurement{Regex: re}
+		default:
+			return nil, p.unexpected("= or =~")
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if stmt.Condition, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseShowTagKeys() (*showTagKeysStatement, error) {
+	stmt := new(showTagKeysStatement)
+	var err error
+	if stmt.Database, err = p.parseOn(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("FROM") {
+		if stmt.Sources, err = p.parseSources(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if stmt.Condition, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseShowTagValues() (*showTagValuesStatement, error) {
+	stmt := new(showTagValuesStatement)
+	var err error
+	if stmt.Database, err = p.parseOn(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("FROM") {
+		if stmt.Sources, err = p.parseSources(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectKeyword("WITH"); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("KEY"); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.tok == eq:
+		p.next()
+		key, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		stmt.Keys = []string{key}
+	case p.acceptKeyword("IN"):
+		if err := p.expect(lparen); err != nil {
+			return nil, err
+		}
+		for {
+			key, err := p.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			stmt.Keys = append(stmt.Keys, key)
+			if p.tok != comma {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(rparen); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.unexpected("= or IN")
+	}
+	if p.acceptKeyword("WHERE") {
+		if stmt.Condition, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseShowFieldKeys() (*showFieldKeysStatement, error) {
+	stmt := new(showFieldKeysStatement)
+	var err error
+	if stmt.Database, err = p.parseOn(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("FROM") {
+		if stmt.Sources, err = p.parseSources(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// parseExpr parses an expression using precedence climbing.
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseBinary(1)
+}
+
+func (p *parser) parseBinary(minPrec int) (expr, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.tok
+		prec := op.precedence()
+		if prec < minPrec || prec == 0 {
+			return lhs, nil
+		}
+		p.next()
+
+		var rhs expr
+		if op == eqRegex || op == neqRegex {
+			re, err := p.parseRegex()
+			if err != nil {
+				return nil, err
+			}
+			rhs = &regexLiteral{Val: re}
+		} else if rhs, err = p.parseBinary(prec + 1); err != nil {
+			return nil, err
+		}
+		lhs = &binaryExpr{Op: op, LHS: lhs, RHS: rhs}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	switch p.tok {
+	case lparen:
+		p.next()
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(rparen); err != nil {
+			return nil, err
+		}
+		return &parenExpr{Expr: e}, nil
+	case mul:
+		p.next()
+		return &wildcard{}, nil
+	case div:
+		re, err := p.parseRegex()
+		if err != nil {
+			return nil, err
+		}
+		return &regexLiteral{Val: re}, nil
+	case stringLit:
+		lit := p.lit
+		p.next()
+		return &stringLiteral{Val: lit}, nil
+	case integerLit:
+		n, err := strconv.ParseInt(p.lit, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s at %d", p.lit, p.pos)
+		}
+		p.next()
+		return &integerLiteral{Val: n}, nil
+	case numberLit:
+		f, err := strconv.ParseFloat(p.lit, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at %d", p.lit, p.pos)
+		}
+		p.next()
+		return &numberLiteral{Val: f}, nil
+	case durationLit:
+		d, err := parseDurationLit(p.lit)
+		if err != nil {
+			return nil, err
+		}
+		p.next()
+		return &durationLiteral{Val: d}, nil
+	case sub:
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		switch e := e.(type) {
+		case *integerLiteral:
+			e.Val = -e.Val
+			return e, nil
+		case *numberLiteral:
+			e.Val = -e.Val
+			return e, nil
+		case *durationLiteral:
+			e.Val = -e.Val
+			return e, nil
+		}
+		return &binaryExpr{Op: mul, LHS: &integerLiteral{Val: -1}, RHS: e}, nil
+	case quotedIdent:
+		return p.parseVarRef()
+	case ident:
+		switch {
+		case p.isKeyword("TRUE"):
+			p.next()
+			return &booleanLiteral{Val: true}, nil
+		case p.isKeyword("FALSE"):
+			p.next()
+			return &booleanLiteral{Val: false}, nil
+		}
+		name := p.lit
+		p.next()
+		if p.tok == lparen {
+			return p.parseCall(name)
+		}
+		return p.parseTypeCast(&varRef{Val: name})
+	}
+	return nil, p.unexpected("expression")
+}
+
+func (p *parser) parseVarRef() (expr, error) {
+	name := p.lit
+	p.next()
+	return p.parseTypeCast(&varRef{Val: name})
+}
+
+// parseTypeCast parses an optional ::tag or ::field suffix of a variable reference.
+func (p *parser) parseTypeCast(ref *varRef) (expr, error) {
+	if p.tok != colon2 {
+		return ref, nil
+	}
+	p.next()
+	switch {
+	case p.acceptKeyword("TAG"):
+		ref.Type = tagType
+	case p.acceptKeyword("FIELD"):
+		ref.Type = fieldType
+	default:
+		return nil, p.unexpected("tag or field")
+	}
+	return ref, nil
+}
+
+func (p *parser) parseCall(name string) (expr, error) {
+	c := &call{Name: strings.ToLower(name)}
+	p.next()
+	if p.tok == rparen {
+		p.next()
+		return c, nil
+	}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		c.Args = append(c.Args, arg)
+		if p.tok != comma {
+			break
+		}
+		p.next()
+	}
+	if err := p.expect(rparen); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseDurationLit parses a duration such as 10m or 1h30m, where d is a day and w is a week.
+func parseDurationLit(lit string) (time.Duration, error) {
+	var d time.Duration
+	s := lit
+	for s != "" {
+		i := 0
+		for i < len(s) && isDigit(rune(s[i])) {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %s", lit)
+		}
+		n, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %s", lit)
+		}
+		s = s[i:]
+		j := 0
+		for j < len(s) && !isDigit(rune(s[j])) {
+			j++
+		}
+		var unit time.Duration
+		switch s[:j] {
+		case "ns":
+			unit = time.Nanosecond
+		case "u", "us", "µ", "µs":
+			unit = time.Microsecond
+		case "ms":
+			unit = time.Millisecond
+		case "s":
+			unit = time.Second
+		case "m":
+			unit = time.Minute
+		case "h":
+			unit = time.Hour
+		case "d":
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		default:
+			return 0, fmt.Errorf("invalid duration %s", lit)
+		}
+		d += time.Duration(n) * unit
+		s = s[j:]
+	}
+	return d, nil
+}
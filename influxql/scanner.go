@@ -0,0 +1,322 @@
+package influxql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is a lexical token of InfluxQL.
+type token int
+
+const (
+	illegal token = iota
+	eof
+
+	ident       // cpu, select
+	quotedIdent // "cpu"
+	stringLit   // 'a'
+	integerLit  // 10
+	numberLit   // 10.5
+	durationLit // 10m
+
+	add // +
+	sub // -
+	mul // *
+	div // /
+	mod // %
+
+	and       // AND
+	or        // OR
+	eq        // =
+	neq       // != or <>
+	lt        // <
+	lte       // <=
+	gt        // >
+	gte       // >=
+	eqRegex   // =~
+	neqRegex  // !~
+	lparen    // (
+	rparen    // )
+	comma     // ,
+	dot       // .
+	colon2    // ::
+	semicolon // ;
+)
+
+var tokenStrings = map[token]string{
+	illegal: "ILLEGAL", eof: "EOF",
+	ident: "identifier", quotedIdent: "identifier", stringLit: "string", integerLit: "integer", numberLit: "number", durationLit: "duration",
+	add: "+", sub: "-", mul: "*", div: "/", mod: "%",
+	and: "AND", or: "OR", eq: "=", neq: "!=", lt: "<", lte: "<=", gt: ">", gte: ">=", eqRegex: "=~", neqRegex: "!~",
+	lparen: "(", rparen: ")", comma: ",", dot: ".", colon2: "::", semicolon: ";",
+}
+
+func (t token) String() string {
+	return tokenStrings[t]
+}
+
+// precedence returns the precedence of a binary operator, or 0 if the token isn't one.
+func (t token) precedence() int {
+	switch t {
+	case or:
+		return 1
+	case and:
+		return 2
+	case eq, neq, lt, lte, gt, gte, eqRegex, neqRegex:
+		return 3
+	case add, sub:
+		return 4
+	case mul, div, mod:
+		return 5
+	}
+	return 0
+}
+
+// scanner splits an InfluxQL query into tokens.
+type scanner struct {
+	src string
+	pos int
+}
+
+// scan returns the next token, its position and its literal value.
+// Keywords are returned as identifiers, except for AND and OR.
+func (s *scanner) scan() (token, int, string) {
+	s.skipWhitespace()
+	pos := s.pos
+	if s.pos >= len(s.src) {
+		return eof, pos, ""
+	}
+
+	ch, w := utf8.DecodeRuneInString(s.src[s.pos:])
+	switch {
+	case isIdentStart(ch):
+		lit := s.scanWhile(isIdentChar)
+		switch strings.ToUpper(lit) {
+		case "AND":
+			return and, pos, lit
+		case "OR":
+			return or, pos, lit
+		}
+		return ident, pos, lit
+	case ch >= '0' && ch <= '9', ch == '.' && s.pos+1 < len(s.src) && isDigit(rune(s.src[s.pos+1])):
+		return s.scanNumber()
+	case ch == '"':
+		lit, err := s.scanQuoted('"')
+		if err != nil {
+			return illegal, pos, err.Error()
+		}
+		return quotedIdent, pos, lit
+	case ch == '\'':
+		lit, err := s.scanQuoted('\'')
+		if err != nil {
+			return illegal, pos, err.Error()
+		}
+		return stringLit, pos, lit
+	}
+
+	s.pos += w
+	switch ch {
+	case '+':
+		return add, pos, "+"
+	case '-':
+		if s.peek() == '-' {
+			// A comment runs to the end of the line.
+			for s.pos < len(s.src) && s.src[s.pos] != '\n' {
+				s.pos++
+			}
+			return s.scan()
+		}
+		return sub, pos, "-"
+	case '*':
+		return mul, pos, "*"
+	case '/':
+		return div, pos, "/"
+	case '%':
+		return mod, pos, "%"
+	case '=':
+		if s.peek() == '~' {
+			s.pos++
+			return eqRegex, pos, "=~"
+		}
+		return eq, pos, "="
+	case '!':
+		switch s.peek() {
+		case '=':
+			s.pos++
+			return neq, pos, "!="
+		case '~':
+			s.pos++
+			return neqRegex, pos, "!~"
+		}
+	case '<':
+		switch s.peek() {
+		case '=':
+			s.pos++
+			return lte, pos, "<="
+		case '>':
+			s.pos++
+			return neq, pos, "<>"
+		}
+		return lt, pos, "<"
+	case '>':
+		if s.peek() == '=' {
+			s.pos++
+			return gte, pos, ">="
+		}
+		return gt, pos, ">"
+	case '(':
+		return lparen, pos, "("
+	case ')':
+		return rparen, pos, ")"
+	case ',':
+		return comma, pos, ","
+	case '.':
+		return dot, pos, "."
+	case ':':
+		if s.peek() == ':' {
+			s.pos++
+			return colon2, pos, "::"
+		}
+	case ';':
+		return semicolon, pos, ";"
+	}
+	return illegal, pos, string(ch)
+}
+
+// scanRegex scans the body of a regular expression starting at pos, just after its opening slash.
+func (s *scanner) scanRegex(pos int) (string, error) {
+	s.pos = pos
+	var b strings.Builder
+	for s.pos < len(s.src) {
+		ch := s.src[s.pos]
+		s.pos++
+		switch ch {
+		case '/':
+			return b.String(), nil
+		case '\\':
+			if s.pos < len(s.src) && s.src[s.pos] == '/' {
+				b.WriteByte('/')
+				s.pos++
+				continue
+			}
+		}
+		b.WriteByte(ch)
+	}
+	return "", fmt.Errorf("unterminated regular expression at %d", pos)
+}
+
+func (s *scanner) peek() byte {
+	if s.pos < len(s.src) {
+		return s.src[s.pos]
+	}
+	return 0
+}
+
+func (s *scanner) skipWhitespace() {
+	for s.pos < len(s.src) {
+		ch, w := utf8.DecodeRuneInString(s.src[s.pos:])
+		if !unicode.IsSpace(ch) {
+			return
+		}
+		s.pos += w
+	}
+}
+
+func (s *scanner) scanWhile(f func(rune) bool) string {
+	start := s.pos
+	for s.pos < len(s.src) {
+		ch, w := utf8.DecodeRuneInString(s.src[s.pos:])
+		if !f(ch) {
+			break
+		}
+		s.pos += w
+	}
+	return s.src[start:s.pos]
+}
+
+// scanNumber scans an integer, a number or a duration such as 10m or 1h30m.
+func (s *scanner) scanNumber() (token, int, string) {
+	pos := s.pos
+	s.scanWhile(isDigit)
+	if s.peek() == '.' {
+		s.pos++
+		s.scanWhile(isDigit)
+		return numberLit, pos, s.src[pos:s.pos]
+	}
+	if s.pos < len(s.src) && isDurationUnit(s.src[s.pos:]) {
+		for s.pos < len(s.src) {
+			n := isDurationUnit(s.src[s.pos:])
+			if !n {
+				break
+			}
+			s.scanWhile(unicode.IsLetter)
+			if s.pos >= len(s.src) || !isDigit(rune(s.src[s.pos])) {
+				break
+			}
+			s.scanWhile(isDigit)
+		}
+		return durationLit, pos, s.src[pos:s.pos]
+	}
+	return integerLit, pos, s.src[pos:s.pos]
+}
+
+// scanQuoted scans a string quoted by q, with backslash escapes.
+func (s *scanner) scanQuoted(q byte) (string, error) {
+	start := s.pos
+	s.pos++
+	var b strings.Builder
+	for s.pos < len(s.src) {
+		ch := s.src[s.pos]
+		s.pos++
+		switch ch {
+		case q:
+			return b.String(), nil
+		case '\\':
+			if s.pos >= len(s.src) {
+				break
+			}
+			switch esc := s.src[s.pos]; esc {
+			case 'n':
+				b.WriteByte('\n')
+			case '\\', '"', '\'':
+				b.WriteByte(esc)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(esc)
+			}
+			s.pos++
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	return "", fmt.Errorf("unterminated quoted string at %d", start)
+}
+
+// isDurationUnit reports whether s starts with a duration unit that isn't followed by other identifier characters.
+func isDurationUnit(s string) bool {
+	for _, u := range []string{"ns", "ms", "us", "µs", "u", "µ", "s", "m", "h", "d", "w"} {
+		if strings.HasPrefix(s, u) {
+			rest := s[len(u):]
+			if rest == "" {
+				return true
+			}
+			ch, _ := utf8.DecodeRuneInString(rest)
+			return !isIdentChar(ch) || isDigit(ch)
+		}
+	}
+	return false
+}
+
+func isIdentStart(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_'
+}
+
+func isIdentChar(ch rune) bool {
+	return isIdentStart(ch) || isDigit(ch)
+}
+
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
@@ -0,0 +1,563 @@
+package influxql
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// aggregate describes how an InfluxQL function is computed in Flux.
+type aggregate struct {
+	// call returns the Flux call computing the function with the given arguments.
+	call func(args []expr) (string, error)
+	// selector is set for functions that select a point, keeping its time.
+	selector bool
+	// points is set for selectors returning several points per interval,
+	// they are not sorted by time and cannot be joined with other aggregates.
+	points bool
+	// integer is set for functions returning integers regardless of the type of the field.
+	// The fill value of the other functions is a float, the type of most fields.
+	integer bool
+}
+
+func simpleAggregate(call string) func([]expr) (string, error) {
+	return func(args []expr) (string, error) {
+		if len(args) != 0 {
+			return "", fmt.Errorf("%s expects a single argument", strings.TrimSuffix(call, "()"))
+		}
+		return call, nil
+	}
+}
+
+// pointsSelector returns the call of a selector of the given number of points.
+func pointsSelector(name string) func([]expr) (string, error) {
+	return func(args []expr) (string, error) {
+		if len(args) != 1 {
+			return "", fmt.Errorf("%s expects two arguments", name)
+		}
+		n, ok := args[0].(*integerLiteral)
+		if !ok || n.Val <= 0 {
+			return "", fmt.Errorf("the second argument of %s must be a positive integer", name)
+		}
+		return fmt.Sprintf("%s(n: %d)", name, n.Val), nil
+	}
+}
+
+var aggregates = map[string]aggregate{
+	"count":  {call: simpleAggregate("count()"), integer: true},
+	"sum":    {call: simpleAggregate("sum()")},
+	"mean":   {call: simpleAggregate("mean()")},
+	"spread": {call: simpleAggregate("spread()")},
+	"stddev": {call: simpleAggregate("stddev()")},
+	"median": {
+		call: func(args []expr) (string, error) {
+			if len(args) != 0 {
+				return "", fmt.Errorf("median expects a single argument")
+			}
+			return `percentile(percentile: 0.5, method: "exact_mean")`, nil
+		},
+	},
+	"first": {call: simpleAggregate("first()"), selector: true},
+	"last":  {call: simpleAggregate("last()"), selector: true},
+	"min":   {call: simpleAggregate("min()"), selector: true},
+	"max":   {call: simpleAggregate("max()"), selector: true},
+	"percentile": {
+		call: func(args []expr) (string, error) {
+			if len(args) != 1 {
+				return "", fmt.Errorf("percentile expects two arguments")
+			}
+			var p float64
+			switch arg := args[0].(type) {
+			case *integerLiteral:
+				p = float64(arg.Val)
+			case *numberLiteral:
+				p = arg.Val
+			default:
+				return "", fmt.Errorf("the second argument of percentile must be a number")
+			}
+			if p < 0 || p > 100 {
+				return "", fmt.Errorf("percentile must be between 0 and 100")
+			}
+			return fmt.Sprintf(`percentile(percentile: %s, method: "exact_selector")`, formatFloat(p/100)), nil
+		},
+		selector: true,
+	},
+	"top":    {call: pointsSelector("top"), selector: true, points: true},
+	"bottom": {call: pointsSelector("bottom"), selector: true, points: true},
+	"sample": {call: pointsSelector("sample"), selector: true, points: true},
+}
+
+// selection is a projection of a SELECT statement.
+type selection struct {
+	// field is the selected field or tag, it is empty for a wildcard.
+	field string
+	tag   bool
+	// fn is the aggregate applied to the field, if any.
+	fn   string
+	args []expr
+	// name is the name of the output column.
+	name string
+}
+
+// selections returns the projections of a statement.
+// A statement either only selects fields and tags or only aggregates.
+func selections(stmt *selectStatement) ([]*selection, error) {
+	var sels []*selection
+	aggregated := 0
+	for _, f := range stmt.Fields {
+		sel := new(selection)
+		e := f.Expr
+		if c, ok := e.(*call); ok {
+			if _, ok := aggregates[c.Name]; !ok {
+				return nil, fmt.Errorf("unsupported function %s()", c.Name)
+			}
+			if len(c.Args) == 0 {
+				return nil, fmt.Errorf("%s() requires a field argument", c.Name)
+			}
+			sel.fn, sel.args = c.Name, c.Args[1:]
+			e = c.Args[0]
+			aggregated++
+		}
+		switch e := e.(type) {
+		case *varRef:
+			sel.field = e.Val
+			sel.tag = e.Type == tagType
+			if sel.tag && sel.fn != "" {
+				return nil, fmt.Errorf("cannot apply %s() to the tag %q", sel.fn, e.Val)
+			}
+		case *wildcard:
+			if sel.fn != "" {
+				return nil, fmt.Errorf("wildcards in function calls are not supported")
+			}
+			if len(stmt.Fields) > 1 {
+				return nil, fmt.Errorf("a wildcard cannot be combined with other fields")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported field expression %s", describe(f.Expr))
+		}
+
+		switch {
+		case f.Alias != "":
+			sel.name = f.Alias
+		case sel.fn != "":
+			sel.name = sel.fn
+		default:
+			sel.name = sel.field
+		}
+		sels = append(sels, sel)
+	}
+	if aggregated > 0 && aggregated < len(sels) {
+		return nil, fmt.Errorf("mixing aggregate and non-aggregate queries is not supported")
+	}
+	if len(sels) > 1 {
+		for _, sel := range sels {
+			if aggregates[sel.fn].points {
+				return nil, fmt.Errorf("selector function %s() cannot be combined with other functions", sel.fn)
+			}
+		}
+	}
+
+	// Duplicate names get a numeric suffix, as in mean, mean_1.
+	used := make(map[string]int)
+	for _, sel := range sels {
+		if n := used[sel.name]; n > 0 {
+			used[sel.name]++
+			sel.name = sel.name + "_" + strconv.Itoa(n)
+		} else {
+			used[sel.name] = 1
+		}
+	}
+	return sels, nil
+}
+
+// selectTranspiler holds the parts of a SELECT statement common to all its projections.
+type selectTranspiler struct {
+	*statementTranspiler
+	stmt *selectStatement
+
+	// fieldConds are the conjuncts of the condition that reference fields.
+	fieldConds []string
+	condFields map[string]bool
+	// valueConds are the field conditions with every field replaced by _value,
+	// they are set if the conditions only reference a single field.
+	valueConds []string
+}
+
+func (st *statementTranspiler) transpileSelect(stmt *selectStatement) error {
+	bucket, err := st.bucket("", stmt.Sources)
+	if err != nil {
+		return err
+	}
+	sels, err := selections(stmt)
+	if err != nil {
+		return err
+	}
+	aggregated := sels[0].fn != ""
+	if !aggregated && stmt.Interval != 0 {
+		return fmt.Errorf("GROUP BY time() requires an aggregate function")
+	}
+	if stmt.Offset > 0 && stmt.Limit == 0 {
+		return fmt.Errorf("OFFSET requires LIMIT")
+	}
+	if stmt.SOffset > 0 && stmt.SLimit == 0 {
+		return fmt.Errorf("SOFFSET requires SLIMIT")
+	}
+
+	tr, conjuncts, err := extractTimeRange(stmt.Condition)
+	if err != nil {
+		return err
+	}
+	if tr.stop == nil && stmt.Interval == 0 {
+		tr.stop = &timeValue{t: maxTime}
+	}
+
+	s := &selectTranspiler{statementTranspiler: st, stmt: stmt, condFields: make(map[string]bool)}
+	tagConds := []string{measurementCondition(stmt.Sources)}
+	for _, c := range conjuncts {
+		_, fields := conditionRefs(c)
+		cond, err := fluxExpr(c, func(ref *varRef) string { return member(ref.Val) })
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			tagConds = append(tagConds, cond)
+			continue
+		}
+		s.fieldConds = append(s.fieldConds, cond)
+		for f := range fields {
+			s.condFields[f] = true
+		}
+	}
+	if len(s.condFields) == 1 {
+		for _, c := range conjuncts {
+			_, fields := conditionRefs(c)
+			if len(fields) == 0 {
+				continue
+			}
+			cond, err := fluxExpr(c, func(ref *varRef) string {
+				if fields[ref.Val] {
+					return "r._value"
+				}
+				return member(ref.Val)
+			})
+			if err != nil {
+				return err
+			}
+			s.valueConds = append(s.valueConds, cond)
+		}
+	}
+
+	base := st.w.pipeline(from(bucket, tr), []string{filter(joinConditions(tagConds, "and"))})
+	if !aggregated {
+		st.yield(st.w.pipeline(base, s.rawCalls(sels)))
+		return nil
+	}
+	return s.transpileAggregates(base, sels)
+}
+
+// groupColumns returns the columns of the group key of the series of the statement.
+func (s *selectTranspiler) groupColumns() []string {
+	return append([]string{"_measurement", "_start", "_stop"}, s.stmt.Tags...)
+}
+
+// seriesColumns returns the columns identifying a series in the output of the statement.
+func (s *selectTranspiler) seriesColumns() []string {
+	return append([]string{"_time", "_measurement"}, s.stmt.Tags...)
+}
+
+// limitSeries returns the call applying SLIMIT and SOFFSET, or "" if the statement has none.
+func (s *selectTranspiler) limitSeries() string {
+	if s.stmt.SLimit == 0 {
+		return ""
+	}
+	s.w.importPackage("influxdata/influxdb/v1")
+	if s.stmt.SOffset > 0 {
+		return fmt.Sprintf("v1.limitSeries(n: %d, offset: %d)", s.stmt.SLimit, s.stmt.SOffset)
+	}
+	return fmt.Sprintf("v1.limitSeries(n: %d)", s.stmt.SLimit)
+}
+
+// orderAndLimit returns the calls applying ORDER BY, LIMIT and OFFSET.
+func (s *selectTranspiler) orderAndLimit(sorted bool) []string {
+	var calls []string
+	if !sorted || s.stmt.Descending {
+		if s.stmt.Descending {
+			calls = append(calls, `sort(columns: ["_time"], desc: true)`)
+		} else {
+			calls = append(calls, `sort(columns: ["_time"])`)
+		}
+	}
+	if s.stmt.Limit > 0 {
+		if s.stmt.Offset > 0 {
+			calls = append(calls, fmt.Sprintf("limit(n: %d, offset: %d)", s.stmt.Limit, s.stmt.Offset))
+		} else {
+			calls = append(calls, fmt.Sprintf("limit(n: %d)", s.stmt.Limit))
+		}
+	}
+	return calls
+}
+
+// fieldFilter returns the call of filter selecting the rows of the fields.
+func fieldFilter(fields []string) string {
+	conds := make([]string, len(fields))
+	for i, f := range fields {
+		conds[i] = "r._field == " + quoteString(f)
+	}
+	return filter(strings.Join(conds, " or "))
+}
+
+// pivotFields is the call turning the fields of the rows into columns.
+const pivotFields = `pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`
+
+// rawCalls returns the calls selecting the fields and tags of a statement without aggregates.
+// The fields are pivoted into columns, one row per point.
+func (s *selectTranspiler) rawCalls(sels []*selection) []string {
+	var calls []string
+	wildcard := sels[0].field == ""
+	if !wildcard {
+		fields := make(map[string]bool)
+		for _, sel := range sels {
+			if !sel.tag {
+				fields[sel.field] = true
+			}
+		}
+		for f := range s.condFields {
+			fields[f] = true
+		}
+		calls = append(calls, fieldFilter(sortedKeys(fields)))
+	}
+	calls = append(calls, pivotFields)
+	if len(s.fieldConds) > 0 {
+		calls = append(calls, filter(joinConditions(s.fieldConds, "and")))
+	}
+	// The pivoted tables are already one per series.
+	if !s.stmt.GroupAllTags {
+		calls = append(calls, "group(columns: "+stringArray(s.groupColumns())+")")
+	}
+	if c := s.limitSeries(); c != "" {
+		calls = append(calls, c)
+	}
+	calls = append(calls, s.orderAndLimit(s.stmt.GroupAllTags)...)
+
+	if wildcard || s.stmt.GroupAllTags {
+		drop := []string{"_start", "_stop"}
+		for f := range s.condFields {
+			if !selected(sels, f) {
+				drop = append(drop, f)
+			}
+		}
+		sort.Strings(drop[2:])
+		calls = append(calls, "drop(columns: "+stringArray(drop)+")")
+	} else {
+		keep := s.seriesColumns()
+		for _, sel := range sels {
+			keep = append(keep, sel.field)
+		}
+		calls = append(calls, keepColumns(dedupe(keep)))
+	}
+
+	renames := make(map[string]string)
+	for _, sel := range sels {
+		if sel.field != "" && sel.name != sel.field {
+			renames[sel.field] = sel.name
+		}
+	}
+	if len(renames) > 0 {
+		calls = append(calls, renameColumns(renames))
+	}
+	return calls
+}
+
+func selected(sels []*selection, field string) bool {
+	for _, sel := range sels {
+		if sel.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// transpileAggregates writes the pipelines computing the aggregates of a statement.
+// Every aggregate is computed separately and the results are joined on time and series.
+func (s *selectTranspiler) transpileAggregates(base string, sels []*selection) error {
+	if len(sels) > 1 && s.stmt.GroupAllTags {
+		return fmt.Errorf("GROUP BY * is not supported with multiple aggregates")
+	}
+
+	if len(sels) == 1 {
+		calls, err := s.aggregateCalls(sels[0], false)
+		if err != nil {
+			return err
+		}
+		// The points of a selector of several points are not in time order.
+		calls = append(calls, s.orderAndLimit(!aggregates[sels[0].fn].points)...)
+		s.yield(s.w.pipeline(base, calls))
+		return nil
+	}
+
+	data := s.varName("data")
+	s.assign(data, base)
+	result := ""
+	for i, sel := range sels {
+		calls, err := s.aggregateCalls(sel, true)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s_%d", s.varName("agg"), i)
+		s.assign(name, s.w.pipeline(data, calls))
+		if i == 0 {
+			result = name
+			continue
+		}
+		result = fmt.Sprintf("join(tables: {t0: %s, t1: %s}, on: %s)", result, name, stringArray(s.seriesColumns()))
+	}
+	calls := s.orderAndLimit(false)
+	s.yield(s.w.pipeline(result, calls))
+	return nil
+}
+
+// aggregateCalls returns the calls computing an aggregate of a field.
+// The output has a row per series and interval, the time of the row is the start of its interval.
+// Without GROUP BY time() the interval is the time range of the statement, starting at 0 if it has no lower bound,
+// but a selector that is not joined with other aggregates keeps the time of the point it selects.
+func (s *selectTranspiler) aggregateCalls(sel *selection, joined bool) ([]string, error) {
+	agg := aggregates[sel.fn]
+	aggCall, err := agg.call(sel.args)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := s.fieldCalls(sel.field)
+	if !s.stmt.GroupAllTags {
+		calls = append(calls, "group(columns: "+stringArray(s.groupColumns())+")")
+	}
+	if c := s.limitSeries(); c != "" {
+		calls = append(calls, c)
+	}
+
+	interval := s.stmt.Interval
+	if interval > 0 {
+		args := []string{"every: " + formatDuration(interval)}
+		if offset := s.stmt.IntervalOffset % interval; offset != 0 {
+			if offset < 0 {
+				offset += interval
+			}
+			args = append(args, "start: "+formatTime(time.Unix(0, int64(offset))))
+		}
+		// Empty intervals are only created within an explicit time range.
+		if s.stmt.Fill != fillNone && s.timeBounded() {
+			args = append(args, "createEmpty: true")
+		}
+		calls = append(calls, "window("+strings.Join(args, ", ")+")")
+	}
+	calls = append(calls, aggCall)
+	if !agg.selector || interval > 0 || joined {
+		if agg.selector {
+			calls = append(calls, `drop(columns: ["_time"])`)
+		}
+		if interval > 0 || s.timeBounded() {
+			calls = append(calls, `duplicate(column: "_start", as: "_time")`)
+		} else {
+			// The start of an unbounded range is the minimum time, InfluxQL reports 0 instead.
+			calls = append(calls, `map(fn: (r) => ({_time: `+formatTime(time.Unix(0, 0))+`, _value: r._value}))`)
+		}
+	}
+	if interval > 0 {
+		calls = append(calls, "window(every: inf)")
+		switch s.stmt.Fill {
+		case fillPrevious:
+			calls = append(calls, `fill(column: "_value", usePrevious: true)`)
+		case fillNumber:
+			calls = append(calls, `fill(column: "_value", value: `+fillValue(s.stmt.FillValue, agg.integer)+`)`)
+		}
+	}
+
+	calls = append(calls, renameColumns(map[string]string{"_value": sel.name}))
+	if s.stmt.GroupAllTags {
+		calls = append(calls, `drop(columns: ["_start", "_stop", "_field"])`)
+	} else {
+		calls = append(calls, keepColumns(append(s.seriesColumns(), sel.name)))
+	}
+	return calls, nil
+}
+
+// timeBounded reports whether the statement has a lower bound on time.
+func (s *selectTranspiler) timeBounded() bool {
+	for _, c := range splitConjuncts(s.stmt.Condition) {
+		if b, ok := c.(*binaryExpr); ok {
+			switch {
+			case isTimeRef(b.LHS) && (b.Op == gt || b.Op == gte || b.Op == eq),
+				isTimeRef(b.RHS) && (b.Op == lt || b.Op == lte || b.Op == eq):
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fillValue returns the Flux literal of the number of a fill() option.
+func fillValue(e expr, integer bool) string {
+	var f float64
+	switch e := e.(type) {
+	case *integerLiteral:
+		f = float64(e.Val)
+	case *numberLiteral:
+		f = e.Val
+	}
+	if integer {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return formatFloat(f)
+}
+
+// fieldCalls returns the calls selecting the rows of a field that satisfy the field conditions of the statement,
+// with the values of the field in the _value column.
+func (s *selectTranspiler) fieldCalls(field string) []string {
+	switch {
+	case len(s.fieldConds) == 0:
+		return []string{fieldFilter([]string{field})}
+	case len(s.condFields) == 1 && s.condFields[field]:
+		conds := append([]string{"r._field == " + quoteString(field)}, s.valueConds...)
+		return []string{filter(joinConditions(conds, "and"))}
+	}
+
+	// The condition references other fields, the points are pivoted to evaluate it.
+	fields := map[string]bool{field: true}
+	for f := range s.condFields {
+		fields[f] = true
+	}
+	calls := []string{
+		fieldFilter(sortedKeys(fields)),
+		pivotFields,
+		filter(joinConditions(s.fieldConds, "and")),
+		renameColumns(map[string]string{field: "_value"}),
+	}
+	delete(fields, field)
+	if len(fields) > 0 {
+		calls = append(calls, "drop(columns: "+stringArray(sortedKeys(fields))+")")
+	}
+	return calls
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func dedupe(ss []string) []string {
+	seen := make(map[string]bool, len(ss))
+	out := ss[:0]
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
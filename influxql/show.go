@@ -0,0 +1,146 @@
+package influxql
+
+import (
+	"fmt"
+)
+
+func (st *statementTranspiler) transpileShowDatabases(stmt *showDatabasesStatement) error {
+	st.w.importPackage("influxdata/influxdb/v1")
+	st.yield(st.w.pipeline("v1.databases()", []string{
+		renameColumns(map[string]string{"databaseName": "name"}),
+		`keep(columns: ["name"])`,
+	}))
+	return nil
+}
+
+// metaSource returns the expression reading the series of a meta query,
+// filtered by the sources and condition of the statement.
+func (st *statementTranspiler) metaSource(database string, sources []*measurement, cond expr) (string, error) {
+	bucket, err := st.bucket(database, sources)
+	if err != nil {
+		return "", err
+	}
+	tr, conjuncts, err := extractTimeRange(cond)
+	if err != nil {
+		return "", err
+	}
+	if tr.stop == nil {
+		tr.stop = &timeValue{t: maxTime}
+	}
+
+	conds := []string{measurementCondition(sources)}
+	for _, c := range conjuncts {
+		cond, err := fluxExpr(c, func(ref *varRef) string { return member(ref.Val) })
+		if err != nil {
+			return "", err
+		}
+		conds = append(conds, cond)
+	}
+	src := from(bucket, tr)
+	if cond := joinConditions(conds, "and"); cond != "" {
+		src = st.w.pipeline(src, []string{filter(cond)})
+	}
+	return src, nil
+}
+
+func (st *statementTranspiler) transpileShowMeasurements(stmt *showMeasurementsStatement) error {
+	var sources []*measurement
+	if stmt.Source != nil {
+		sources = []*measurement{stmt.Source}
+	}
+	src, err := st.metaSource(stmt.Database, sources, stmt.Condition)
+	if err != nil {
+		return err
+	}
+	st.yield(st.w.pipeline(src, []string{
+		`keep(columns: ["_measurement"])`,
+		"group()",
+		`distinct(column: "_measurement")`,
+		`sort(columns: ["_value"])`,
+		renameColumns(map[string]string{"_value": "name"}),
+	}))
+	return nil
+}
+
+func (st *statementTranspiler) transpileShowTagKeys(stmt *showTagKeysStatement) error {
+	src, err := st.metaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return err
+	}
+	st.yield(st.w.pipeline(src, []string{
+		"keys()",
+		`keep(columns: ["_measurement", "_value"])`,
+		`filter(fn: (r) => r._value !~ /^_/)`,
+		`group(columns: ["_measurement"])`,
+		"distinct()",
+		`sort(columns: ["_value"])`,
+		renameColumns(map[string]string{"_value": "tagKey"}),
+	}))
+	return nil
+}
+
+func (st *statementTranspiler) transpileShowTagValues(stmt *showTagValuesStatement) error {
+	src, err := st.metaSource(stmt.Database, stmt.Sources, stmt.Condition)
+	if err != nil {
+		return err
+	}
+	if len(stmt.Keys) == 1 {
+		st.yield(st.w.pipeline(src, tagValuesCalls(stmt.Keys[0])))
+		return nil
+	}
+
+	data := st.varName("data")
+	st.assign(data, src)
+	tables := make([]string, len(stmt.Keys))
+	for i, key := range stmt.Keys {
+		tables[i] = fmt.Sprintf("%s_%d", st.varName("values"), i)
+		st.assign(tables[i], st.w.pipeline(data, tagValuesCalls(key)))
+	}
+	st.yield(st.w.pipeline("union(tables: "+identArray(tables)+")", []string{
+		`group(columns: ["_measurement"])`,
+		`sort(columns: ["key", "value"])`,
+	}))
+	return nil
+}
+
+// tagValuesCalls returns the calls listing the values of a tag per measurement.
+// The values are filtered after distinct since filtering on the tag fails for series without it.
+func tagValuesCalls(key string) []string {
+	return []string{
+		"group(columns: " + stringArray([]string{"_measurement", key}) + ")",
+		"distinct(column: " + quoteString(key) + ")",
+		`filter(fn: (r) => r._value != "")`,
+		`keep(columns: ["_measurement", "_value"])`,
+		`group(columns: ["_measurement"])`,
+		`sort(columns: ["_value"])`,
+		`set(key: "key", value: ` + quoteString(key) + `)`,
+		renameColumns(map[string]string{"_value": "value"}),
+	}
+}
+
+func (st *statementTranspiler) transpileShowFieldKeys(stmt *showFieldKeysStatement) error {
+	src, err := st.metaSource(stmt.Database, stmt.Sources, nil)
+	if err != nil {
+		return err
+	}
+	st.yield(st.w.pipeline(src, []string{
+		`keep(columns: ["_measurement", "_field"])`,
+		`group(columns: ["_measurement"])`,
+		`distinct(column: "_field")`,
+		`sort(columns: ["_value"])`,
+		renameColumns(map[string]string{"_value": "fieldKey"}),
+	}))
+	return nil
+}
+
+// identArray returns the identifiers as a Flux array literal.
+func identArray(idents []string) string {
+	s := "["
+	for i, id := range idents {
+		if i > 0 {
+			s += ", "
+		}
+		s += id
+	}
+	return s + "]"
+}
@@ -0,0 +1,362 @@
+package influxql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+)
+
+// DefaultRetentionPolicy is the retention policy of measurements that don't name one.
+const DefaultRetentionPolicy = "autogen"
+
+// Config configures the transpilation of InfluxQL queries.
+type Config struct {
+	// DefaultDatabase is the database of the statements that don't name one.
+	DefaultDatabase string
+	// DefaultRetentionPolicy is the retention policy of the statements that don't name one.
+	// It defaults to autogen.
+	DefaultRetentionPolicy string
+	// Now is the time the spec is compiled at, it defaults to the current time.
+	Now time.Time
+}
+
+// Transpiler converts InfluxQL queries into Flux.
+//
+// A measurement of a database and retention policy is read from the bucket named "database/retentionPolicy".
+// The result of every statement is yielded with the index of the statement as its name.
+type Transpiler struct {
+	config Config
+}
+
+// NewTranspiler returns a transpiler using the given configuration.
+func NewTranspiler(config Config) *Transpiler {
+	if config.DefaultRetentionPolicy == "" {
+		config.DefaultRetentionPolicy = DefaultRetentionPolicy
+	}
+	return &Transpiler{config: config}
+}
+
+// Transpile converts an InfluxQL query into a query spec.
+// The Flux builtins must be registered, for example by importing the builtin package.
+func (t *Transpiler) Transpile(ctx context.Context, txt string) (*flux.Spec, error) {
+	src, err := t.TranspileFlux(txt)
+	if err != nil {
+		return nil, err
+	}
+	now := t.config.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return flux.Compile(ctx, src, now)
+}
+
+// TranspileFlux converts an InfluxQL query into a Flux script.
+func (t *Transpiler) TranspileFlux(txt string) (string, error) {
+	stmts, err := parseQuery(txt)
+	if err != nil {
+		return "", err
+	}
+
+	w := &writer{imports: make(map[string]bool)}
+	for i, stmt := range stmts {
+		if i > 0 {
+			w.body.WriteString("\n")
+		}
+		st := &statementTranspiler{config: t.config, w: w, index: i}
+		if err := st.transpile(stmt); err != nil {
+			return "", err
+		}
+	}
+	return w.String(), nil
+}
+
+// writer accumulates the generated Flux script.
+type writer struct {
+	imports map[string]bool
+	body    strings.Builder
+}
+
+func (w *writer) importPackage(path string) {
+	w.imports[path] = true
+}
+
+// pipeline writes an expression followed by a pipe forward into each of the calls, one per line.
+func (w *writer) pipeline(head string, calls []string) string {
+	var b strings.Builder
+	b.WriteString(head)
+	for _, c := range calls {
+		b.WriteString("\n    |> ")
+		b.WriteString(c)
+	}
+	return b.String()
+}
+
+func (w *writer) String() string {
+	var b strings.Builder
+	if len(w.imports) > 0 {
+		paths := make([]string, 0, len(w.imports))
+		for p := range w.imports {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			b.WriteString("import ")
+			b.WriteString(quoteString(p))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(w.body.String())
+	return b.String()
+}
+
+// statementTranspiler converts a single statement.
+type statementTranspiler struct {
+	config Config
+	w      *writer
+	index  int
+}
+
+func (st *statementTranspiler) transpile(stmt statement) error {
+	switch stmt := stmt.(type) {
+	case *selectStatement:
+		return st.transpileSelect(stmt)
+	case *showDatabasesStatement:
+		return st.transpileShowDatabases(stmt)
+	case *showMeasurementsStatement:
+		return st.transpileShowMeasurements(stmt)
+	case *showTagKeysStatement:
+		return st.transpileShowTagKeys(stmt)
+	case *showTagValuesStatement:
+		return st.transpileShowTagValues(stmt)
+	case *showFieldKeysStatement:
+		return st.transpileShowFieldKeys(stmt)
+	}
+	return fmt.Errorf("unsupported statement %T", stmt)
+}
+
+// varName returns the name of a variable of the statement.
+func (st *statementTranspiler) varName(name string) string {
+	return fmt.Sprintf("%s%d", name, st.index)
+}
+
+// assign writes the assignment of an expression to a variable.
+func (st *statementTranspiler) assign(name, expr string) {
+	st.w.body.WriteString(name)
+	st.w.body.WriteString(" = ")
+	st.w.body.WriteString(expr)
+	st.w.body.WriteString("\n")
+}
+
+// yield writes an expression yielding the result of the statement.
+func (st *statementTranspiler) yield(expr string) {
+	st.w.body.WriteString(expr)
+	st.w.body.WriteString("\n    |> yield(name: ")
+	st.w.body.WriteString(quoteString(strconv.Itoa(st.index)))
+	st.w.body.WriteString(")\n")
+}
+
+// bucket returns the bucket of the sources of a statement, they must all be of the same database and retention policy.
+func (st *statementTranspiler) bucket(database string, sources []*measurement) (string, error) {
+	db, rp := database, ""
+	for _, m := range sources {
+		mdb, mrp := m.Database, m.RetentionPolicy
+		if mdb == "" {
+			mdb = database
+		}
+		if (mdb != "" && db != "" && mdb != db) || (rp != "" && mrp != "" && mrp != rp) {
+			return "", fmt.Errorf("measurements of different databases or retention policies cannot be queried together")
+		}
+		if mdb != "" {
+			db = mdb
+		}
+		if mrp != "" {
+			rp = mrp
+		}
+	}
+	if db == "" {
+		db = st.config.DefaultDatabase
+	}
+	if db == "" {
+		return "", fmt.Errorf("database name required")
+	}
+	if rp == "" {
+		rp = st.config.DefaultRetentionPolicy
+	}
+	return db + "/" + rp, nil
+}
+
+// from returns the expression reading a bucket within a time range.
+func from(bucket string, tr timeRange) string {
+	return fmt.Sprintf("from(bucket: %s)\n    |> %s", quoteString(bucket), tr.String())
+}
+
+// measurementCondition returns the Flux condition matching rows of the sources, or "" if it matches all rows.
+func measurementCondition(sources []*measurement) string {
+	var conds []string
+	for _, m := range sources {
+		if m.Regex != nil {
+			conds = append(conds, "r._measurement =~ "+formatRegex(m.Regex))
+		} else {
+			conds = append(conds, "r._measurement == "+quoteString(m.Name))
+		}
+	}
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	return joinConditions(conds, "or")
+}
+
+// joinConditions combines conditions with a logical operator, conditions that are "" are skipped.
+func joinConditions(conds []string, op string) string {
+	var nonEmpty []string
+	for _, c := range conds {
+		if c != "" {
+			nonEmpty = append(nonEmpty, c)
+		}
+	}
+	if len(nonEmpty) == 1 {
+		return nonEmpty[0]
+	}
+	for i, c := range nonEmpty {
+		nonEmpty[i] = "(" + c + ")"
+	}
+	return strings.Join(nonEmpty, " "+op+" ")
+}
+
+// filter returns a call of filter with the condition.
+func filter(cond string) string {
+	return "filter(fn: (r) => " + cond + ")"
+}
+
+// quoteString returns s as a Flux string literal.
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, ch := range s {
+		switch ch {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(ch)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(ch)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// stringArray returns the strings as a Flux array literal.
+func stringArray(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = quoteString(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// formatRegex returns a Flux regular expression literal.
+func formatRegex(re *regexp.Regexp) string {
+	return "/" + strings.Replace(re.String(), "/", `\/`, -1) + "/"
+}
+
+var identRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// fluxKeywords may not be used as property names in member expressions.
+var fluxKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "empty": true, "in": true, "import": true, "package": true,
+	"return": true, "option": true, "builtin": true, "test": true, "if": true, "then": true, "else": true,
+}
+
+// member returns the Flux expression accessing the column of the record r.
+func member(column string) string {
+	if identRegex.MatchString(column) && !fluxKeywords[column] {
+		return "r." + column
+	}
+	return "r[" + quoteString(column) + "]"
+}
+
+// objectKey returns the Flux object key of a column name.
+func objectKey(column string) string {
+	if identRegex.MatchString(column) && !fluxKeywords[column] {
+		return column
+	}
+	return quoteString(column)
+}
+
+// renameColumns returns a call of rename with the mapping of old to new column names.
+func renameColumns(names map[string]string) string {
+	old := make([]string, 0, len(names))
+	for o := range names {
+		old = append(old, o)
+	}
+	sort.Strings(old)
+	pairs := make([]string, len(old))
+	for i, o := range old {
+		pairs[i] = objectKey(o) + ": " + quoteString(names[o])
+	}
+	return "rename(columns: {" + strings.Join(pairs, ", ") + "})"
+}
+
+// keepColumns returns the call keeping the columns of the tables.
+// Unlike keep(columns:), the predicate does not fail on tables missing a column,
+// such as series without one of the tags.
+func keepColumns(columns []string) string {
+	conds := make([]string, len(columns))
+	for i, c := range columns {
+		conds[i] = "column == " + quoteString(c)
+	}
+	return "keep(fn: (column) => " + strings.Join(conds, " or ") + ")"
+}
+
+// formatDuration returns d as a Flux duration literal.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	for _, u := range []struct {
+		unit string
+		d    time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+		{"ns", time.Nanosecond},
+	} {
+		if n := d / u.d; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10))
+			b.WriteString(u.unit)
+			d -= n * u.d
+		}
+	}
+	return b.String()
+}
+
+// formatTime returns t as a Flux time literal.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// formatFloat returns f as a Flux float literal.
+func formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
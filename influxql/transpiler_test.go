@@ -0,0 +1,389 @@
+package influxql_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the transpiled queries to compile.
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/influxql"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/embedded"
+	"github.com/influxdata/flux/values"
+)
+
+func init() {
+	// The transpiled queries read the embedded storage of TestTranspiler_Execute.
+	influxdb.RegisterStorage()
+}
+
+func TestTranspiler_TranspileFlux(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "raw fields with conditions",
+			query: `SELECT usage AS u, host::tag FROM db.rp.cpu WHERE usage > 2 AND time >= '2018-01-01T00:00:00Z' AND time < '2018-01-02T00:00:00Z'`,
+			want: `from(bucket: "db/rp")
+    |> range(start: 2018-01-01T00:00:00Z, stop: 2018-01-02T00:00:00Z)
+    |> filter(fn: (r) => r._measurement == "cpu")
+    |> filter(fn: (r) => r._field == "usage")
+    |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+    |> filter(fn: (r) => r.usage > 2)
+    |> group(columns: ["_measurement", "_start", "_stop"])
+    |> sort(columns: ["_time"])
+    |> keep(fn: (column) => column == "_time" or column == "_measurement" or column == "usage" or column == "host")
+    |> rename(columns: {usage: "u"})
+    |> yield(name: "0")
+`,
+		},
+		{
+			name:  "count with fill and offset",
+			query: `SELECT count(usage) FROM cpu WHERE time >= '2018-01-01T00:00:00Z' AND time < '2018-01-01T01:00:00Z' GROUP BY time(10m, 5m), host fill(0)`,
+			want: `from(bucket: "db/autogen")
+    |> range(start: 2018-01-01T00:00:00Z, stop: 2018-01-01T01:00:00Z)
+    |> filter(fn: (r) => r._measurement == "cpu")
+    |> filter(fn: (r) => r._field == "usage")
+    |> group(columns: ["_measurement", "_start", "_stop", "host"])
+    |> window(every: 10m, start: 1970-01-01T00:05:00Z, createEmpty: true)
+    |> count()
+    |> duplicate(column: "_start", as: "_time")
+    |> window(every: inf)
+    |> fill(column: "_value", value: 0)
+    |> rename(columns: {_value: "count"})
+    |> keep(fn: (column) => column == "_time" or column == "_measurement" or column == "host" or column == "count")
+    |> yield(name: "0")
+`,
+		},
+		{
+			name:  "multiple aggregates",
+			query: `SELECT mean(usage), max(usage) FROM cpu WHERE region =~ /us-.*/ GROUP BY time(1h) fill(previous)`,
+			want: `data0 = from(bucket: "db/autogen")
+    |> range(start: 1677-09-21T00:12:43.145224194Z)
+    |> filter(fn: (r) => (r._measurement == "cpu") and (r.region =~ /us-.*/))
+agg0_0 = data0
+    |> filter(fn: (r) => r._field == "usage")
+    |> group(columns: ["_measurement", "_start", "_stop"])
+    |> window(every: 1h)
+    |> mean()
+    |> duplicate(column: "_start", as: "_time")
+    |> window(every: inf)
+    |> fill(column: "_value", usePrevious: true)
+    |> rename(columns: {_value: "mean"})
+    |> keep(fn: (column) => column == "_time" or column == "_measurement" or column == "mean")
+agg0_1 = data0
+    |> filter(fn: (r) => r._field == "usage")
+    |> group(columns: ["_measurement", "_start", "_stop"])
+    |> window(every: 1h)
+    |> max()
+    |> drop(columns: ["_time"])
+    |> duplicate(column: "_start", as: "_time")
+    |> window(every: inf)
+    |> fill(column: "_value", usePrevious: true)
+    |> rename(columns: {_value: "max"})
+    |> keep(fn: (column) => column == "_time" or column == "_measurement" or column == "max")
+join(tables: {t0: agg0_0, t1: agg0_1}, on: ["_time", "_measurement"])
+    |> sort(columns: ["_time"])
+    |> yield(name: "0")
+`,
+		},
+		{
+			name:  "selector with series limit",
+			query: `SELECT percentile(usage, 90) FROM cpu GROUP BY * ORDER BY time DESC LIMIT 1 SLIMIT 2 SOFFSET 1`,
+			want: `import "influxdata/influxdb/v1"
+
+from(bucket: "db/autogen")
+    |> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775807Z)
+    |> filter(fn: (r) => r._measurement == "cpu")
+    |> filter(fn: (r) => r._field == "usage")
+    |> v1.limitSeries(n: 2, offset: 1)
+    |> percentile(percentile: 0.9, method: "exact_selector")
+    |> rename(columns: {_value: "percentile"})
+    |> drop(columns: ["_start", "_stop", "_field"])
+    |> sort(columns: ["_time"], desc: true)
+    |> limit(n: 1)
+    |> yield(name: "0")
+`,
+		},
+		{
+			name:  "selector of several points",
+			query: `SELECT top(usage, 3) FROM cpu WHERE host = 'a' GROUP BY host`,
+			want: `from(bucket: "db/autogen")
+    |> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775807Z)
+    |> filter(fn: (r) => (r._measurement == "cpu") and (r.host == "a"))
+    |> filter(fn: (r) => r._field == "usage")
+    |> group(columns: ["_measurement", "_start", "_stop", "host"])
+    |> top(n: 3)
+    |> rename(columns: {_value: "top"})
+    |> keep(fn: (column) => column == "_time" or column == "_measurement" or column == "host" or column == "top")
+    |> sort(columns: ["_time"])
+    |> yield(name: "0")
+`,
+		},
+		{
+			name:  "show tag values",
+			query: `SHOW TAG VALUES FROM cpu WITH KEY = host WHERE region = 'us'`,
+			want: `from(bucket: "db/autogen")
+    |> range(start: 1677-09-21T00:12:43.145224194Z, stop: 2262-04-11T23:47:16.854775807Z)
+    |> filter(fn: (r) => (r._measurement == "cpu") and (r.region == "us"))
+    |> group(columns: ["_measurement", "host"])
+    |> distinct(column: "host")
+    |> filter(fn: (r) => r._value != "")
+    |> keep(columns: ["_measurement", "_value"])
+    |> group(columns: ["_measurement"])
+    |> sort(columns: ["_value"])
+    |> set(key: "key", value: "host")
+    |> rename(columns: {_value: "value"})
+    |> yield(name: "0")
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := influxql.NewTranspiler(influxql.Config{DefaultDatabase: "db"})
+			got, err := tr.TranspileFlux(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected script -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestTranspiler_Transpile(t *testing.T) {
+	queries := []string{
+		`SELECT * FROM cpu`,
+		`SELECT usage FROM cpu, mem GROUP BY host ORDER BY time DESC LIMIT 10 OFFSET 2`,
+		`SELECT usage, idle FROM cpu WHERE usage > idle AND host != 'a'`,
+		`SELECT sum(usage), mean(usage), median(usage) FROM cpu WHERE time > now() - 1h GROUP BY time(5m), host fill(none)`,
+		`SELECT first(usage) AS f, last(usage) AS l FROM /c.*/ WHERE time > now() - 1h GROUP BY time(5m)`,
+		`SELECT spread(usage), stddev(usage) FROM cpu WHERE usage::field > 0 GROUP BY host`,
+		`SELECT bottom(usage, 2) FROM cpu WHERE time > now() - 1h GROUP BY time(5m), host`,
+		`SELECT sample(usage, 10) AS s FROM cpu ORDER BY time DESC LIMIT 5`,
+		`SHOW MEASUREMENTS WITH MEASUREMENT =~ /c.*/`,
+		`SHOW TAG KEYS ON db FROM cpu`,
+		`SHOW TAG VALUES WITH KEY IN (host, region)`,
+		`SHOW FIELD KEYS FROM cpu; SELECT min(usage) FROM cpu`,
+	}
+	for _, q := range queries {
+		t.Run(q, func(t *testing.T) {
+			tr := influxql.NewTranspiler(influxql.Config{
+				DefaultDatabase: "db",
+				Now:             time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			})
+			if _, err := tr.Transpile(context.Background(), q); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestTranspiler_Execute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "influxql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	e, err := embedded.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := func(host string, sec int64, v float64) influxdb.Point {
+		return influxdb.Point{
+			Measurement: "cpu",
+			Tags:        []influxdb.Tag{{Key: "host", Value: host}},
+			Field:       "usage",
+			Time:        values.Time(sec * 1e9),
+			Value:       values.NewFloat(v),
+		}
+	}
+	if err := e.Write(context.Background(), influxdb.BucketRef{Name: "db/autogen"}, []influxdb.Point{
+		point("a", 1514764810, 1),
+		point("a", 1514764820, 3),
+		point("b", 1514764810, 5),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	querier := controltest.New(control.New(control.Config{
+		ConcurrencyQuota:     1,
+		MemoryBytesQuota:     math.MaxInt64,
+		ExecutorDependencies: execute.Dependencies{influxdb.StorageDependency: e},
+	}))
+
+	testCases := []struct {
+		name  string
+		query string
+		want  []*executetest.Table
+	}{
+		{
+			name:  "multiple selectors",
+			query: `SELECT max(usage), min(usage) FROM cpu`,
+			want: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "max", Type: flux.TFloat},
+					{Label: "min", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"cpu", execute.Time(0), 5.0, 1.0},
+				},
+			}},
+		},
+		{
+			name:  "multiple selectors in time range",
+			query: `SELECT max(usage), min(usage) FROM cpu WHERE time >= '2018-01-01T00:00:00Z' AND time < '2018-01-02T00:00:00Z'`,
+			want: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "max", Type: flux.TFloat},
+					{Label: "min", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"cpu", execute.Time(1514764800e9), 5.0, 1.0},
+				},
+			}},
+		},
+		{
+			name:  "selector",
+			query: `SELECT max(usage) FROM cpu`,
+			want: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "max", Type: flux.TFloat},
+					{Label: "_measurement", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1514764810e9), 5.0, "cpu"},
+				},
+			}},
+		},
+		{
+			name:  "selector of several points",
+			query: `SELECT top(usage, 2) FROM cpu`,
+			want: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "top", Type: flux.TFloat},
+					{Label: "_measurement", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1514764810e9), 5.0, "cpu"},
+					{execute.Time(1514764820e9), 3.0, "cpu"},
+				},
+			}},
+		},
+		{
+			name:  "group by tag",
+			query: `SELECT mean(usage) FROM cpu GROUP BY host`,
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "mean", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"cpu", "a", execute.Time(0), 2.0},
+					},
+				},
+				{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "mean", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"cpu", "b", execute.Time(0), 5.0},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := influxql.NewTranspiler(influxql.Config{DefaultDatabase: "db"})
+			script, err := tr.TranspileFlux(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q, err := querier.Query(context.Background(), lang.FluxCompiler{Query: script})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Done()
+			var got []*executetest.Table
+			for _, res := range <-q.Ready() {
+				if err := res.Tables().Do(func(tbl flux.Table) error {
+					cpy, err := executetest.ConvertTable(tbl)
+					if err != nil {
+						return err
+					}
+					got = append(got, cpy)
+					return nil
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := q.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestTranspiler_Errors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		db    string
+	}{
+		{name: "no database", query: `SELECT usage FROM cpu`},
+		{name: "syntax error", query: `SELECT FROM cpu`, db: "db"},
+		{name: "unknown function", query: `SELECT foo(usage) FROM cpu`, db: "db"},
+		{name: "aggregate and raw field", query: `SELECT mean(usage), idle FROM cpu`, db: "db"},
+		{name: "multiple time bounds", query: `SELECT usage FROM cpu WHERE time > now() - 1h AND time > now() - 2h`, db: "db"},
+		{name: "time in disjunction", query: `SELECT usage FROM cpu WHERE time > now() - 1h OR host = 'a'`, db: "db"},
+		{name: "top without count", query: `SELECT top(usage) FROM cpu`, db: "db"},
+		{name: "top with other functions", query: `SELECT top(usage, 2), mean(usage) FROM cpu`, db: "db"},
+		{name: "fill linear", query: `SELECT mean(usage) FROM cpu GROUP BY time(1m) fill(linear)`, db: "db"},
+		{name: "different databases", query: `SELECT usage FROM a..cpu, b..mem`, db: "db"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := influxql.NewTranspiler(influxql.Config{DefaultDatabase: tc.db})
+			if _, err := tr.TranspileFlux(tc.query); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package lineprotocol
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/values"
+	protocol "github.com/influxdata/line-protocol"
+)
+
+// RowMetricConfig selects the columns of a table that make up the metric of each row.
+type RowMetricConfig struct {
+	// Name is the measurement name of every metric. If empty, NameColumn holds the name.
+	Name       string
+	NameColumn string
+	TimeColumn string
+	// FieldColumn, if set, holds the field key of the values of each row.
+	// Otherwise the field keys are the labels of the value columns.
+	FieldColumn string
+	// TagColumns and ValueColumns must be sorted.
+	TagColumns   []string
+	ValueColumns []string
+}
+
+// RowMetric is the metric of a single table row.
+// It implements protocol.Metric and is reused for every row of a table, see Set.
+type RowMetric struct {
+	tags   []*protocol.Tag
+	fields []*protocol.Field
+	name   string
+	t      time.Time
+
+	timeCol, nameCol, fieldCol int
+	isTag, isValue             []bool
+}
+
+// NewRowMetric creates a RowMetric for tables with the given columns.
+// It fails if the time column is missing or is not a time.
+func NewRowMetric(cols []flux.ColMeta, c RowMetricConfig) (*RowMetric, error) {
+	m := &RowMetric{
+		name:     c.Name,
+		timeCol:  -1,
+		nameCol:  -1,
+		fieldCol: -1,
+		isTag:    make([]bool, len(cols)),
+		isValue:  make([]bool, len(cols)),
+	}
+	for j, col := range cols {
+		switch {
+		case col.Label == c.TimeColumn:
+			if col.Type != flux.TTime {
+				return nil, fmt.Errorf("column %s is not of type %s", col.Label, flux.TTime)
+			}
+			m.timeCol = j
+		case c.Name == "" && col.Label == c.NameColumn:
+			if col.Type != flux.TString {
+				return nil, errors.New("invalid type for measurement column")
+			}
+			m.nameCol = j
+		case c.FieldColumn != "" && col.Label == c.FieldColumn:
+			if col.Type != flux.TString {
+				return nil, errors.New("invalid type for field column")
+			}
+			m.fieldCol = j
+		default:
+			m.isTag[j] = contains(c.TagColumns, col.Label)
+			m.isValue[j] = contains(c.ValueColumns, col.Label)
+			if m.isTag[j] && col.Type != flux.TString {
+				return nil, errors.New("invalid type for tag column")
+			}
+		}
+	}
+	if m.timeCol < 0 {
+		return nil, errors.New("Could not get time column")
+	}
+	return m, nil
+}
+
+// contains reports whether the sorted list contains s.
+func contains(list []string, s string) bool {
+	i := sort.SearchStrings(list, s)
+	return i < len(list) && list[i] == s
+}
+
+// Set sets the metric to row i of the column reader.
+// Null tags and values are left out of the metric.
+// It reports false when the row has no metric, because its time, name or field key is null
+// or because all of its values are null.
+func (m *RowMetric) Set(cr flux.ColReader, i int) (bool, error) {
+	m.fields = m.fields[:0]
+	m.tags = m.tags[:0]
+	times := cr.Times(m.timeCol)
+	if times.IsNull(i) {
+		return false, nil
+	}
+	m.t = values.Time(times.Value(i)).Time()
+	if m.nameCol >= 0 {
+		names := cr.Strings(m.nameCol)
+		if names.IsNull(i) {
+			return false, nil
+		}
+		m.name = names.ValueString(i)
+	}
+	var fieldKey string
+	if m.fieldCol >= 0 {
+		keys := cr.Strings(m.fieldCol)
+		if keys.IsNull(i) {
+			return false, nil
+		}
+		fieldKey = keys.ValueString(i)
+	}
+	for j, col := range cr.Cols() {
+		switch {
+		case m.isTag[j]:
+			if tags := cr.Strings(j); tags.IsValid(i) {
+				m.tags = append(m.tags, &protocol.Tag{Key: col.Label, Value: tags.ValueString(i)})
+			}
+		case m.isValue[j]:
+			key := col.Label
+			if m.fieldCol >= 0 {
+				key = fieldKey
+			}
+			switch col.Type {
+			case flux.TFloat:
+				if vs := cr.Floats(j); vs.IsValid(i) {
+					m.fields = append(m.fields, &protocol.Field{Key: key, Value: vs.Value(i)})
+				}
+			case flux.TInt:
+				if vs := cr.Ints(j); vs.IsValid(i) {
+					m.fields = append(m.fields, &protocol.Field{Key: key, Value: vs.Value(i)})
+				}
+			case flux.TUInt:
+				if vs := cr.UInts(j); vs.IsValid(i) {
+					m.fields = append(m.fields, &protocol.Field{Key: key, Value: vs.Value(i)})
+				}
+			case flux.TString:
+				if vs := cr.Strings(j); vs.IsValid(i) {
+					m.fields = append(m.fields, &protocol.Field{Key: key, Value: vs.ValueString(i)})
+				}
+			case flux.TTime:
+				if vs := cr.Times(j); vs.IsValid(i) {
+					m.fields = append(m.fields, &protocol.Field{Key: key, Value: values.Time(vs.Value(i))})
+				}
+			case flux.TBool:
+				if vs := cr.Bools(j); vs.IsValid(i) {
+					m.fields = append(m.fields, &protocol.Field{Key: key, Value: vs.Value(i)})
+				}
+			default:
+				return false, fmt.Errorf("invalid type for column %s", col.Label)
+			}
+		}
+	}
+	return len(m.fields) > 0, nil
+}
+
+func (m *RowMetric) TagList() []*protocol.Tag {
+	return m.tags
+}
+
+func (m *RowMetric) FieldList() []*protocol.Field {
+	return m.fields
+}
+
+func (m *RowMetric) Name() string {
+	return m.name
+}
+
+func (m *RowMetric) Time() time.Time {
+	return m.t
+}
+
+// NewEncoder returns a line protocol encoder writing to w
+// that fails on invalid fields and sorts the fields of each line.
+func NewEncoder(w io.Writer) *protocol.Encoder {
+	e := protocol.NewEncoder(w)
+	e.FailOnFieldErr(true)
+	e.SetFieldSortOrder(protocol.SortFields)
+	return e
+}
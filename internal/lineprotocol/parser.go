@@ -0,0 +1,222 @@
+// Package lineprotocol parses the InfluxDB line protocol and converts
+// the parsed metrics into Flux tables.
+package lineprotocol
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metric is a single parsed line of line protocol.
+type Metric struct {
+	Name string
+	// Tags are sorted by key.
+	Tags []Tag
+	// Fields are kept in the order in which they appear on the line.
+	Fields []Field
+	Time   time.Time
+}
+
+// Tag is a single tag key/value pair of a metric.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Field is a single field of a metric.
+// The value is one of float64, int64, uint64, string or bool.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Parser parses line protocol.
+// The zero value parses nanosecond timestamps and assigns
+// the current time to lines without a timestamp.
+type Parser struct {
+	// Precision is the unit of the timestamps. It defaults to time.Nanosecond.
+	Precision time.Duration
+	// Now returns the time used for lines without a timestamp.
+	// It defaults to time.Now.
+	Now func() time.Time
+}
+
+// Parse parses every line in data.
+// Empty lines and lines starting with # are skipped.
+func (p *Parser) Parse(data []byte) ([]*Metric, error) {
+	var metrics []*Metric
+	for n, line := range bytes.Split(data, []byte("\n")) {
+		m, err := p.ParseLine(string(line))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n+1, err)
+		}
+		if m != nil {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, nil
+}
+
+// ParseLine parses a single line.
+// It returns a nil metric for empty lines and comments.
+func (p *Parser) ParseLine(line string) (*Metric, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return nil, nil
+	}
+
+	m := new(Metric)
+	name, i := readToken(line, 0, ", ", ", ")
+	if name == "" {
+		return nil, fmt.Errorf("missing measurement name")
+	}
+	m.Name = name
+
+	// tags
+	for i < len(line) && line[i] == ',' {
+		var key, value string
+		key, i = readToken(line, i+1, ",= ", ",= ")
+		if i >= len(line) || line[i] != '=' || key == "" {
+			return nil, fmt.Errorf("invalid tag %q", key)
+		}
+		value, i = readToken(line, i+1, ", ", ",= ")
+		if value == "" {
+			return nil, fmt.Errorf("missing value for tag %q", key)
+		}
+		m.Tags = append(m.Tags, Tag{Key: key, Value: value})
+	}
+	sort.Slice(m.Tags, func(i, j int) bool { return m.Tags[i].Key < m.Tags[j].Key })
+
+	// fields
+	i = skipSpaces(line, i)
+	for {
+		var key string
+		key, i = readToken(line, i, ",= ", ",= ")
+		if i >= len(line) || line[i] != '=' || key == "" {
+			return nil, fmt.Errorf("invalid field %q", key)
+		}
+		var value interface{}
+		var err error
+		value, i, err = readFieldValue(line, i+1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for field %q: %v", key, err)
+		}
+		m.Fields = append(m.Fields, Field{Key: key, Value: value})
+		if i < len(line) && line[i] == ',' {
+			i++
+			continue
+		}
+		break
+	}
+
+	// timestamp
+	ts := strings.TrimSpace(line[i:])
+	if ts == "" {
+		now := time.Now
+		if p.Now != nil {
+			now = p.Now
+		}
+		m.Time = now()
+		return m, nil
+	}
+	n, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q", ts)
+	}
+	precision := p.Precision
+	if precision <= 0 {
+		precision = time.Nanosecond
+	}
+	m.Time = time.Unix(0, n*int64(precision)).UTC()
+	return m, nil
+}
+
+// readToken reads an unquoted token starting at i until one of the unescaped stop characters.
+// A backslash followed by one of the escapable characters produces that character.
+func readToken(line string, i int, stops, escapable string) (string, int) {
+	var b strings.Builder
+	for i < len(line) {
+		c := line[i]
+		if c == '\\' && i+1 < len(line) && (line[i+1] == '\\' || strings.IndexByte(escapable, line[i+1]) >= 0) {
+			b.WriteByte(line[i+1])
+			i += 2
+			continue
+		}
+		if strings.IndexByte(stops, c) >= 0 {
+			break
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String(), i
+}
+
+func skipSpaces(line string, i int) int {
+	for i < len(line) && line[i] == ' ' {
+		i++
+	}
+	return i
+}
+
+func readFieldValue(line string, i int) (interface{}, int, error) {
+	if i >= len(line) {
+		return nil, i, fmt.Errorf("missing value")
+	}
+	if line[i] == '"' {
+		var b strings.Builder
+		for i++; i < len(line); i++ {
+			c := line[i]
+			if c == '\\' && i+1 < len(line) && (line[i+1] == '"' || line[i+1] == '\\') {
+				b.WriteByte(line[i+1])
+				i++
+				continue
+			}
+			if c == '"' {
+				return b.String(), i + 1, nil
+			}
+			b.WriteByte(c)
+		}
+		return nil, i, fmt.Errorf("unterminated string")
+	}
+
+	start := i
+	for i < len(line) && line[i] != ',' && line[i] != ' ' {
+		i++
+	}
+	v, err := parseFieldValue(line[start:i])
+	return v, i, err
+}
+
+func parseFieldValue(s string) (interface{}, error) {
+	switch s {
+	case "":
+		return nil, fmt.Errorf("missing value")
+	case "t", "T", "true", "True", "TRUE":
+		return true, nil
+	case "f", "F", "false", "False", "FALSE":
+		return false, nil
+	}
+	switch s[len(s)-1] {
+	case 'i':
+		v, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	case 'u':
+		v, err := strconv.ParseUint(s[:len(s)-1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
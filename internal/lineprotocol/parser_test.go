@@ -0,0 +1,187 @@
+package lineprotocol_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/internal/lineprotocol"
+)
+
+func TestParser_Parse(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name      string
+		precision time.Duration
+		data      string
+		want      []*lineprotocol.Metric
+		wantErr   bool
+	}{
+		{
+			name: "all field types",
+			data: `cpu,host=a,region=west usage=0.5,count=3i,total=7u,state="ok",up=true 1000`,
+			want: []*lineprotocol.Metric{{
+				Name: "cpu",
+				Tags: []lineprotocol.Tag{{Key: "host", Value: "a"}, {Key: "region", Value: "west"}},
+				Fields: []lineprotocol.Field{
+					{Key: "usage", Value: 0.5},
+					{Key: "count", Value: int64(3)},
+					{Key: "total", Value: uint64(7)},
+					{Key: "state", Value: "ok"},
+					{Key: "up", Value: true},
+				},
+				Time: time.Unix(0, 1000).UTC(),
+			}},
+		},
+		{
+			name: "sorted tags, comments and blank lines",
+			data: "# a comment\n\nmem,z=1,a=2 free=1 5\n",
+			want: []*lineprotocol.Metric{{
+				Name:   "mem",
+				Tags:   []lineprotocol.Tag{{Key: "a", Value: "2"}, {Key: "z", Value: "1"}},
+				Fields: []lineprotocol.Field{{Key: "free", Value: 1.0}},
+				Time:   time.Unix(0, 5).UTC(),
+			}},
+		},
+		{
+			name: "escapes",
+			data: `my\ meas,tag\,key=va\ lue field\=key="say \"hi\", ok" 1`,
+			want: []*lineprotocol.Metric{{
+				Name:   "my meas",
+				Tags:   []lineprotocol.Tag{{Key: "tag,key", Value: "va lue"}},
+				Fields: []lineprotocol.Field{{Key: "field=key", Value: `say "hi", ok`}},
+				Time:   time.Unix(0, 1).UTC(),
+			}},
+		},
+		{
+			name:      "precision and missing timestamp",
+			precision: time.Second,
+			data:      "a v=1 2\nb v=2",
+			want: []*lineprotocol.Metric{
+				{
+					Name:   "a",
+					Fields: []lineprotocol.Field{{Key: "v", Value: 1.0}},
+					Time:   time.Unix(2, 0).UTC(),
+				},
+				{
+					Name:   "b",
+					Fields: []lineprotocol.Field{{Key: "v", Value: 2.0}},
+					Time:   now,
+				},
+			},
+		},
+		{
+			name:    "missing fields",
+			data:    "cpu,host=a 1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid integer",
+			data:    "cpu v=1.5i",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			data:    `cpu v="abc`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid timestamp",
+			data:    "cpu v=1 abc",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			p := &lineprotocol.Parser{
+				Precision: tc.precision,
+				Now:       func() time.Time { return now },
+			}
+			got, err := p.Parse([]byte(tc.data))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected metrics -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestTableBuilder(t *testing.T) {
+	p := new(lineprotocol.Parser)
+	metrics, err := p.Parse([]byte(`cpu,host=b usage=2 20
+cpu,host=a usage=1,idle=5i 10
+cpu,host=a usage=3 5
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := lineprotocol.NewTableBuilder(executetest.UnlimitedAllocator)
+	for _, m := range metrics {
+		if err := b.Add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tables, err := b.Tables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]*executetest.Table, len(tables))
+	for i, tbl := range tables {
+		if got[i], err = executetest.ConvertTable(tbl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cols := func(typ flux.ColType) []flux.ColMeta {
+		return []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: typ},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+		}
+	}
+	keyCols := []string{"_field", "_measurement", "host"}
+	want := []*executetest.Table{
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TInt),
+			Data: [][]interface{}{
+				{execute.Time(10), int64(5), "idle", "cpu", "a"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TFloat),
+			Data: [][]interface{}{
+				{execute.Time(5), 3.0, "usage", "cpu", "a"},
+				{execute.Time(10), 1.0, "usage", "cpu", "a"},
+			},
+		},
+		{
+			KeyCols: keyCols,
+			ColMeta: cols(flux.TFloat),
+			Data: [][]interface{}{
+				{execute.Time(20), 2.0, "usage", "cpu", "b"},
+			},
+		},
+	}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	conflict, err := p.ParseLine("cpu,host=b usage=1i 30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Add(conflict); err == nil {
+		t.Error("expected field type conflict error")
+	}
+}
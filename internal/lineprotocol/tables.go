@@ -0,0 +1,158 @@
+package lineprotocol
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	// MeasurementColLabel is the label of the column holding the measurement name.
+	MeasurementColLabel = "_measurement"
+	// FieldColLabel is the label of the column holding the field key.
+	FieldColLabel = "_field"
+)
+
+// TableBuilder accumulates metrics into tables shaped like the ones read from InfluxDB.
+// There is one table per measurement, tag set and field, grouped by
+// _measurement, the tag keys and _field. Each table has the columns
+// _time, _value, _field, _measurement and one column per tag.
+type TableBuilder struct {
+	alloc  *memory.Allocator
+	tables *execute.GroupLookup
+}
+
+// NewTableBuilder creates a TableBuilder that allocates its tables with alloc.
+func NewTableBuilder(alloc *memory.Allocator) *TableBuilder {
+	return &TableBuilder{
+		alloc:  alloc,
+		tables: execute.NewGroupLookup(),
+	}
+}
+
+// Add appends one row for every field of the metric.
+func (b *TableBuilder) Add(m *Metric) error {
+	for _, f := range m.Fields {
+		typ, v, err := fieldValue(f)
+		if err != nil {
+			return err
+		}
+		key := seriesKey(m, f.Key)
+		builder, err := b.tableBuilder(key, m, typ)
+		if err != nil {
+			return err
+		}
+		if err := builder.AppendTime(0, values.ConvertTime(m.Time)); err != nil {
+			return err
+		}
+		if err := builder.AppendValue(1, v); err != nil {
+			return err
+		}
+		for j, c := range key.Cols() {
+			if err := builder.AppendValue(execute.ColIdx(c.Label, builder.Cols()), key.Value(j)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Tables returns the accumulated tables ordered by group key,
+// with the rows of each table sorted by time.
+func (b *TableBuilder) Tables() ([]flux.Table, error) {
+	var tables []flux.Table
+	var err error
+	b.tables.Range(func(key flux.GroupKey, value interface{}) {
+		if err != nil {
+			return
+		}
+		builder := value.(*execute.ColListTableBuilder)
+		builder.Sort([]string{execute.DefaultTimeColLabel}, false)
+		var tbl flux.Table
+		tbl, err = builder.Table()
+		tables = append(tables, tbl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func (b *TableBuilder) tableBuilder(key flux.GroupKey, m *Metric, typ flux.ColType) (*execute.ColListTableBuilder, error) {
+	if v, ok := b.tables.Lookup(key); ok {
+		builder := v.(*execute.ColListTableBuilder)
+		if want := builder.Cols()[1].Type; want != typ {
+			return nil, fmt.Errorf("field type conflict for %v: %s and %s", key, want, typ)
+		}
+		return builder, nil
+	}
+
+	builder := execute.NewColListTableBuilder(key, b.alloc)
+	cols := []flux.ColMeta{
+		{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		{Label: execute.DefaultValueColLabel, Type: typ},
+		{Label: FieldColLabel, Type: flux.TString},
+		{Label: MeasurementColLabel, Type: flux.TString},
+	}
+	for _, t := range m.Tags {
+		cols = append(cols, flux.ColMeta{Label: t.Key, Type: flux.TString})
+	}
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	b.tables.Set(key, builder)
+	return builder, nil
+}
+
+// seriesKey returns the group key of the table holding the given field of the metric.
+// The key columns are sorted by label.
+func seriesKey(m *Metric, field string) flux.GroupKey {
+	cols := make([]flux.ColMeta, 0, len(m.Tags)+2)
+	vs := make([]values.Value, 0, len(m.Tags)+2)
+	cols = append(cols,
+		flux.ColMeta{Label: FieldColLabel, Type: flux.TString},
+		flux.ColMeta{Label: MeasurementColLabel, Type: flux.TString},
+	)
+	vs = append(vs, values.NewString(field), values.NewString(m.Name))
+	for _, t := range m.Tags {
+		cols = append(cols, flux.ColMeta{Label: t.Key, Type: flux.TString})
+		vs = append(vs, values.NewString(t.Value))
+	}
+	sort.Sort(keySorter{cols: cols, values: vs})
+	return execute.NewGroupKey(cols, vs)
+}
+
+type keySorter struct {
+	cols   []flux.ColMeta
+	values []values.Value
+}
+
+func (s keySorter) Len() int           { return len(s.cols) }
+func (s keySorter) Less(i, j int) bool { return s.cols[i].Label < s.cols[j].Label }
+func (s keySorter) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+func fieldValue(f Field) (flux.ColType, values.Value, error) {
+	switch v := f.Value.(type) {
+	case float64:
+		return flux.TFloat, values.NewFloat(v), nil
+	case int64:
+		return flux.TInt, values.NewInt(v), nil
+	case uint64:
+		return flux.TUInt, values.NewUInt(v), nil
+	case string:
+		return flux.TString, values.NewString(v), nil
+	case bool:
+		return flux.TBool, values.NewBool(v), nil
+	default:
+		return flux.TInvalid, nil, fmt.Errorf("unsupported type %T for field %q", f.Value, f.Key)
+	}
+}
@@ -0,0 +1,202 @@
+// Package neldermead implements the Nelder-Mead simplex method for minimizing a function of several variables.
+package neldermead
+
+import "math"
+
+const (
+	defaultMaxIterations = 1000
+	// reflection coefficient
+	defaultAlpha = 1.0
+	// contraction coefficient
+	defaultBeta = 0.5
+	// expansion coefficient
+	defaultGamma = 2.0
+)
+
+// Optimizer represents the parameters to the Nelder-Mead simplex method.
+type Optimizer struct {
+	// Maximum number of iterations.
+	MaxIterations int
+	// Reflection coefficient.
+	Alpha,
+	// Contraction coefficient.
+	Beta,
+	// Expansion coefficient.
+	Gamma float64
+}
+
+// New returns a new instance of Optimizer with all values set to the defaults.
+func New() *Optimizer {
+	return &Optimizer{
+		MaxIterations: defaultMaxIterations,
+		Alpha:         defaultAlpha,
+		Beta:          defaultBeta,
+		Gamma:         defaultGamma,
+	}
+}
+
+// Optimize minimizes objfunc starting from a regular simplex of size scale around start.
+// It stops once the standard deviation of the values of objfunc at the vertices is less than epsilon,
+// or after the maximum number of iterations.
+// It returns the minimum found and the point at which it was found.
+func (o *Optimizer) Optimize(
+	objfunc func([]float64) float64,
+	start []float64,
+	epsilon,
+	scale float64,
+) (float64, []float64) {
+	n := len(start)
+
+	// vertices of the simplex
+	v := make([][]float64, n+1)
+	for i := range v {
+		v[i] = make([]float64, n)
+	}
+
+	// value of the function at each vertex
+	f := make([]float64, n+1)
+
+	// reflection, expansion, contraction and centroid coordinates
+	vr := make([]float64, n)
+	ve := make([]float64, n)
+	vc := make([]float64, n)
+	vm := make([]float64, n)
+
+	// Create the initial simplex, with start as one of its vertices.
+	pn := scale * (math.Sqrt(float64(n+1)) - 1 + float64(n)) / (float64(n) * math.Sqrt(2))
+	qn := scale * (math.Sqrt(float64(n+1)) - 1) / (float64(n) * math.Sqrt(2))
+
+	copy(v[0], start)
+	for i := 1; i <= n; i++ {
+		for j := 0; j < n; j++ {
+			if i-1 == j {
+				v[i][j] = pn + start[j]
+			} else {
+				v[i][j] = qn + start[j]
+			}
+		}
+	}
+
+	for j := 0; j <= n; j++ {
+		f[j] = objfunc(v[j])
+	}
+
+	for itr := 1; itr <= o.MaxIterations; itr++ {
+		// Find the indexes of the largest and smallest values.
+		vg := 0
+		vs := 0
+		for i := 0; i <= n; i++ {
+			if f[i] > f[vg] {
+				vg = i
+			}
+			if f[i] < f[vs] {
+				vs = i
+			}
+		}
+		// Find the index of the second largest value.
+		vh := vs
+		for i := 0; i <= n; i++ {
+			if f[i] > f[vh] && f[i] < f[vg] {
+				vh = i
+			}
+		}
+
+		// Calculate the centroid of all vertices but the largest.
+		for i := 0; i < n; i++ {
+			cent := 0.0
+			for m := 0; m <= n; m++ {
+				if m != vg {
+					cent += v[m][i]
+				}
+			}
+			vm[i] = cent / float64(n)
+		}
+
+		// Reflect the largest vertex through the centroid.
+		for i := 0; i < n; i++ {
+			vr[i] = vm[i] + o.Alpha*(vm[i]-v[vg][i])
+		}
+		fr := objfunc(vr)
+
+		if fr < f[vh] && fr >= f[vs] {
+			copy(v[vg], vr)
+			f[vg] = fr
+		}
+
+		// Investigate a step further in this direction.
+		if fr < f[vs] {
+			for i := 0; i < n; i++ {
+				ve[i] = vm[i] + o.Gamma*(vr[i]-vm[i])
+			}
+			fe := objfunc(ve)
+
+			if fe < fr {
+				copy(v[vg], ve)
+				f[vg] = fe
+			} else {
+				copy(v[vg], vr)
+				f[vg] = fr
+			}
+		}
+
+		// Check to see if a contraction is necessary.
+		if fr >= f[vh] {
+			if fr < f[vg] {
+				// outside contraction
+				for i := 0; i < n; i++ {
+					vc[i] = vm[i] + o.Beta*(vr[i]-vm[i])
+				}
+			} else {
+				// inside contraction
+				for i := 0; i < n; i++ {
+					vc[i] = vm[i] - o.Beta*(vm[i]-v[vg][i])
+				}
+			}
+			fc := objfunc(vc)
+
+			if fc < f[vg] {
+				copy(v[vg], vc)
+				f[vg] = fc
+			} else {
+				// The contraction is not successful,
+				// so halve the distance from the smallest vertex to all the other vertices.
+				for row := 0; row <= n; row++ {
+					if row != vs {
+						for i := 0; i < n; i++ {
+							v[row][i] = v[vs][i] + (v[row][i]-v[vs][i])/2.0
+						}
+					}
+				}
+				f[vg] = objfunc(v[vg])
+				f[vh] = objfunc(v[vh])
+			}
+		}
+
+		// Test for convergence.
+		fsum := 0.0
+		for i := 0; i <= n; i++ {
+			fsum += f[i]
+		}
+		favg := fsum / float64(n+1)
+		s := 0.0
+		for i := 0; i <= n; i++ {
+			s += math.Pow(f[i]-favg, 2.0) / float64(n)
+		}
+		s = math.Sqrt(s)
+		if s < epsilon {
+			break
+		}
+	}
+
+	// Find the index of the smallest value.
+	vs := 0
+	for i := 0; i <= n; i++ {
+		if f[i] < f[vs] {
+			vs = i
+		}
+	}
+
+	parameters := make([]float64, n)
+	copy(parameters, v[vs])
+	return objfunc(v[vs]), parameters
+}
@@ -0,0 +1,32 @@
+package neldermead_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/influxdata/flux/internal/pkg/neldermead"
+)
+
+func TestOptimizer_Optimize(t *testing.T) {
+	// Rosenbrock's function 100*(b-a^2)^2 + (1-a)^2,
+	// whose global minimum 0 is at (a,b) = (1,1).
+	f := func(x []float64) float64 {
+		a, b := x[0], x[1]
+		return 100*(b-a*a)*(b-a*a) + (1-a)*(1-a)
+	}
+
+	start := []float64{-1.2, 1.0}
+	min, parameters := neldermead.New().Optimize(f, start, 1e-12, 1)
+
+	if min > 1e-6 {
+		t.Errorf("unexpected min: got %g exp 0", min)
+	}
+	for i, exp := range []float64{1, 1} {
+		if got := parameters[i]; math.Abs(got-exp) > 1e-3 {
+			t.Errorf("unexpected parameters[%d]: got %f exp %f", i, got, exp)
+		}
+	}
+	if start[0] != -1.2 || start[1] != 1.0 {
+		t.Errorf("start was modified: %v", start)
+	}
+}
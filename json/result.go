@@ -0,0 +1,408 @@
+// Package json contains the json result encoders and decoders.
+//
+// A response is a JSON object with the list of results and an optional error:
+//
+//	{
+//	  "results": [{
+//	    "name": "_result",
+//	    "tables": [{
+//	      "columns": [
+//	        {"label": "_measurement", "type": "string", "group": true},
+//	        {"label": "_time", "type": "time"},
+//	        {"label": "_value", "type": "float"}
+//	      ],
+//	      "groupKey": {"_measurement": "cpu"},
+//	      "records": [
+//	        {"_measurement": "cpu", "_time": "2018-01-01T00:00:00Z", "_value": 1.5},
+//	        {"_measurement": "cpu", "_time": "2018-01-01T00:01:00Z", "_value": null}
+//	      ]
+//	    }],
+//	    "error": "error of the result"
+//	  }],
+//	  "error": "error of the query"
+//	}
+//
+// Values are typed by their column: times are RFC3339 strings and the float values NaN, +Inf and -Inf are strings.
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+type response struct {
+	Results []result `json:"results"`
+	Err     string   `json:"error,omitempty"`
+}
+
+type result struct {
+	Name   string  `json:"name"`
+	Tables []table `json:"tables"`
+	Err    string  `json:"error,omitempty"`
+}
+
+type table struct {
+	Columns  []column                 `json:"columns"`
+	GroupKey map[string]interface{}   `json:"groupKey"`
+	Records  []map[string]interface{} `json:"records"`
+}
+
+type column struct {
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Group bool   `json:"group,omitempty"`
+}
+
+// MultiResultEncoder encodes results as a JSON response.
+// The tables are written as they are read from the results.
+type MultiResultEncoder struct{}
+
+func NewMultiResultEncoder() *MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+type jsonEncoderError struct {
+	msg string
+}
+
+func (e *jsonEncoderError) Error() string {
+	return e.msg
+}
+
+func (e *jsonEncoderError) IsEncoderError() bool {
+	return true
+}
+
+func wrapEncodingError(err error) error {
+	return errors.Wrap(&jsonEncoderError{msg: err.Error()}, "json encoder error")
+}
+
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	write := func(s string) error {
+		if _, err := io.WriteString(wc, s); err != nil {
+			return wrapEncodingError(err)
+		}
+		return nil
+	}
+
+	if err := write(`{"results":[`); err != nil {
+		return wc.Count(), err
+	}
+	for i := 0; results.More(); i++ {
+		if i > 0 {
+			if err := write(","); err != nil {
+				return wc.Count(), err
+			}
+		}
+		if err := encodeResult(wc, results.Next()); err != nil {
+			return wc.Count(), err
+		}
+	}
+	if err := write("]"); err != nil {
+		return wc.Count(), err
+	}
+	if err := results.Err(); err != nil {
+		if err := write(`,"error":` + quote(err.Error())); err != nil {
+			return wc.Count(), err
+		}
+	}
+	err := write("}\n")
+	return wc.Count(), err
+}
+
+// encodeResult writes a result, an error of the result is written as its error.
+func encodeResult(w io.Writer, res flux.Result) error {
+	if _, err := io.WriteString(w, `{"name":`+quote(res.Name())+`,"tables":[`); err != nil {
+		return wrapEncodingError(err)
+	}
+	n := 0
+	err := res.Tables().Do(func(tbl flux.Table) error {
+		t, err := encodeTable(tbl)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(t)
+		if err != nil {
+			return wrapEncodingError(err)
+		}
+		if n > 0 {
+			b = append([]byte(","), b...)
+		}
+		n++
+		if _, err := w.Write(b); err != nil {
+			return wrapEncodingError(err)
+		}
+		return nil
+	})
+	end := "]}"
+	if err != nil {
+		if flux.IsEncoderError(err) {
+			return err
+		}
+		end = `],"error":` + quote(err.Error()) + "}"
+	}
+	if _, err := io.WriteString(w, end); err != nil {
+		return wrapEncodingError(err)
+	}
+	return nil
+}
+
+func encodeTable(tbl flux.Table) (table, error) {
+	key := tbl.Key()
+	t := table{
+		Columns:  make([]column, len(tbl.Cols())),
+		GroupKey: make(map[string]interface{}, len(key.Cols())),
+		Records:  make([]map[string]interface{}, 0),
+	}
+	for j, c := range tbl.Cols() {
+		t.Columns[j] = column{
+			Label: c.Label,
+			Type:  c.Type.String(),
+			Group: key.HasCol(c.Label),
+		}
+	}
+	for j, c := range key.Cols() {
+		t.GroupKey[c.Label] = encodeValue(key.Value(j), c.Type)
+	}
+	err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			record := make(map[string]interface{}, len(cr.Cols()))
+			for j, c := range cr.Cols() {
+				record[c.Label] = encodeValue(execute.ValueForRow(cr, i, j), c.Type)
+			}
+			t.Records = append(t.Records, record)
+		}
+		return nil
+	})
+	return t, err
+}
+
+// encodeValue returns the JSON representation of a value of a column.
+func encodeValue(v values.Value, typ flux.ColType) interface{} {
+	if v.IsNull() {
+		return nil
+	}
+	switch typ {
+	case flux.TBool:
+		return v.Bool()
+	case flux.TInt:
+		return v.Int()
+	case flux.TUInt:
+		return v.UInt()
+	case flux.TFloat:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return f
+	case flux.TString:
+		return v.Str()
+	case flux.TTime:
+		return v.Time().Time().Format(time.RFC3339Nano)
+	default:
+		execute.PanicUnknownType(typ)
+		return nil
+	}
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// MultiResultDecoder decodes a JSON response into results.
+type MultiResultDecoder struct {
+	a *memory.Allocator
+}
+
+func NewMultiResultDecoder(a *memory.Allocator) *MultiResultDecoder {
+	return &MultiResultDecoder{a: a}
+}
+
+func (d *MultiResultDecoder) Decode(r io.ReadCloser) (flux.ResultIterator, error) {
+	defer r.Close()
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var resp response
+	if err := dec.Decode(&resp); err != nil {
+		return nil, err
+	}
+	return &resultIterator{resp: &resp, a: d.a}, nil
+}
+
+type resultIterator struct {
+	resp *response
+	a    *memory.Allocator
+}
+
+func (ri *resultIterator) More() bool {
+	return len(ri.resp.Results) > 0
+}
+
+func (ri *resultIterator) Next() flux.Result {
+	res := ri.resp.Results[0]
+	ri.resp.Results = ri.resp.Results[1:]
+	return &decodedResult{res: &res, a: ri.a}
+}
+
+func (ri *resultIterator) Release() {
+	ri.resp.Results = nil
+}
+
+func (ri *resultIterator) Err() error {
+	if ri.resp.Err != "" {
+		return errors.New(ri.resp.Err)
+	}
+	return nil
+}
+
+func (ri *resultIterator) Statistics() flux.Statistics { return flux.Statistics{} }
+
+type decodedResult struct {
+	res *result
+	a   *memory.Allocator
+}
+
+func (r *decodedResult) Name() string {
+	return r.res.Name
+}
+
+func (r *decodedResult) Tables() flux.TableIterator {
+	return r
+}
+
+func (r *decodedResult) Do(f func(flux.Table) error) error {
+	for _, t := range r.res.Tables {
+		tbl, err := decodeTable(t, r.a)
+		if err != nil {
+			return err
+		}
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	if r.res.Err != "" {
+		return errors.New(r.res.Err)
+	}
+	return nil
+}
+
+func (r *decodedResult) Statistics() flux.Statistics { return flux.Statistics{} }
+
+func decodeTable(t table, a *memory.Allocator) (flux.Table, error) {
+	cols := make([]flux.ColMeta, len(t.Columns))
+	var keyCols []flux.ColMeta
+	var keyValues []values.Value
+	for j, c := range t.Columns {
+		typ, err := decodeType(c.Type)
+		if err != nil {
+			return nil, err
+		}
+		cols[j] = flux.ColMeta{Label: c.Label, Type: typ}
+		if c.Group {
+			v, err := decodeValue(t.GroupKey[c.Label], typ)
+			if err != nil {
+				return nil, errors.Wrapf(err, "group key column %q", c.Label)
+			}
+			keyCols = append(keyCols, cols[j])
+			keyValues = append(keyValues, v)
+		}
+	}
+
+	b := execute.NewColListTableBuilder(execute.NewGroupKey(keyCols, keyValues), a)
+	for _, c := range cols {
+		if _, err := b.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	for _, record := range t.Records {
+		for j, c := range cols {
+			v, err := decodeValue(record[c.Label], c.Type)
+			if err != nil {
+				return nil, errors.Wrapf(err, "column %q", c.Label)
+			}
+			if err := b.AppendValue(j, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.Table()
+}
+
+func decodeType(typ string) (flux.ColType, error) {
+	for _, t := range []flux.ColType{flux.TBool, flux.TInt, flux.TUInt, flux.TFloat, flux.TString, flux.TTime} {
+		if t.String() == typ {
+			return t, nil
+		}
+	}
+	return flux.TInvalid, fmt.Errorf("unsupported data type %q", typ)
+}
+
+// decodeValue converts a value decoded with json.Decoder.UseNumber into a value of the column type.
+func decodeValue(v interface{}, typ flux.ColType) (values.Value, error) {
+	if v == nil {
+		return values.NewNull(flux.SemanticType(typ)), nil
+	}
+	switch typ {
+	case flux.TBool:
+		if b, ok := v.(bool); ok {
+			return values.NewBool(b), nil
+		}
+	case flux.TInt:
+		if n, ok := v.(json.Number); ok {
+			i, err := strconv.ParseInt(string(n), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return values.NewInt(i), nil
+		}
+	case flux.TUInt:
+		if n, ok := v.(json.Number); ok {
+			u, err := strconv.ParseUint(string(n), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return values.NewUInt(u), nil
+		}
+	case flux.TFloat:
+		var s string
+		switch v := v.(type) {
+		case json.Number:
+			s = string(v)
+		case string:
+			s = v
+		default:
+			return nil, fmt.Errorf("expected a float, got %v", v)
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewFloat(f), nil
+	case flux.TString:
+		if s, ok := v.(string); ok {
+			return values.NewString(s), nil
+		}
+	case flux.TTime:
+		if s, ok := v.(string); ok {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, err
+			}
+			return values.NewTime(values.ConvertTime(t)), nil
+		}
+	}
+	return nil, fmt.Errorf("expected a %s, got %v", typ, v)
+}
@@ -0,0 +1,167 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/json"
+)
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	results := flux.NewSliceResultIterator([]flux.Result{
+		&executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{{
+				KeyCols: []string{"_measurement"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"cpu", execute.Time(0), 1.5},
+					{"cpu", execute.Time(60e9), nil},
+				},
+			}},
+		},
+		&executetest.Result{
+			Nm:  "failed",
+			Err: errors.New("expected error"),
+		},
+	})
+
+	var buf bytes.Buffer
+	n, err := json.NewMultiResultEncoder().Encode(&buf, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("unexpected byte count: %d, wrote %d bytes", n, buf.Len())
+	}
+
+	want := `{"results":[` +
+		`{"name":"_result","tables":[{` +
+		`"columns":[{"label":"_measurement","type":"string","group":true},{"label":"_time","type":"time"},{"label":"_value","type":"float"}],` +
+		`"groupKey":{"_measurement":"cpu"},` +
+		`"records":[{"_measurement":"cpu","_time":"1970-01-01T00:00:00Z","_value":1.5},{"_measurement":"cpu","_time":"1970-01-01T00:01:00Z","_value":null}]` +
+		`}]},` +
+		`{"name":"failed","tables":[],"error":"expected error"}` +
+		"]}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected response -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMultiResultEncoder_RoundTrip(t *testing.T) {
+	want := []*executetest.Result{
+		{
+			Nm: "a",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "t"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "t", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "b", Type: flux.TBool},
+						{Label: "i", Type: flux.TInt},
+						{Label: "u", Type: flux.TUInt},
+						{Label: "f", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), "x", execute.Time(1), true, int64(math.MaxInt64), uint64(math.MaxUint64), 1.25},
+						{execute.Time(0), "x", execute.Time(2), nil, nil, nil, nil},
+						{execute.Time(0), "x", execute.Time(3), false, int64(math.MinInt64), uint64(0), math.Inf(-1)},
+					},
+				},
+				{
+					KeyCols:   []string{"_start", "t"},
+					KeyValues: []interface{}{execute.Time(0), "y"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "t", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+					},
+				},
+			},
+		},
+		{
+			Nm: "b",
+			Tbls: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "s", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{"quote \" and unicode é"},
+				},
+			}},
+		},
+	}
+	results := make([]flux.Result, len(want))
+	for i, r := range want {
+		r.Normalize()
+		results[i] = r
+	}
+
+	var buf bytes.Buffer
+	if _, err := json.NewMultiResultEncoder().Encode(&buf, flux.NewSliceResultIterator(results)); err != nil {
+		t.Fatal(err)
+	}
+
+	ri, err := json.NewMultiResultDecoder(executetest.UnlimitedAllocator).Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*executetest.Result
+	for ri.More() {
+		next := ri.Next()
+		res := &executetest.Result{Nm: next.Name()}
+		if err := next.Tables().Do(func(table flux.Table) error {
+			tbl, err := executetest.ConvertTable(table)
+			if err != nil {
+				return err
+			}
+			res.Tbls = append(res.Tbls, tbl)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		res.Normalize()
+		got = append(got, res)
+	}
+	if err := ri.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Fatalf("unexpected results -want/+got:\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMultiResultDecoder_Errors(t *testing.T) {
+	input := `{"results":[{"name":"_result","tables":[],"error":"result error"}],"error":"query error"}`
+	ri, err := json.NewMultiResultDecoder(executetest.UnlimitedAllocator).Decode(ioutil.NopCloser(bytes.NewBufferString(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ri.More() {
+		t.Fatal("expected a result")
+	}
+	err = ri.Next().Tables().Do(func(flux.Table) error { return nil })
+	if err == nil || err.Error() != "result error" {
+		t.Errorf("unexpected result error: %v", err)
+	}
+	if ri.More() {
+		t.Error("unexpected result")
+	}
+	if err := ri.Err(); err == nil || err.Error() != "query error" {
+		t.Errorf("unexpected query error: %v", err)
+	}
+}
@@ -50,9 +50,11 @@ func TestPlan_LogicalPlanFromSpec(t *testing.T) {
 				"Content-Type": "application/vnd.influx",
 				"User-Agent":   "fluxd/dev",
 			},
-			Timeout:      time.Second,
-			TimeColumn:   "_time",
-			ValueColumns: []string{"_value"},
+			Timeout:       time.Second,
+			TimeColumn:    "_time",
+			ValueColumns:  []string{"_value"},
+			Encoding:      http.LineProtocolEncoding,
+			RetryInterval: http.DefaultToHTTPRetryInterval,
 		}
 		toKafkaOpSpec = kafka.ToKafkaOpSpec{
 			Brokers:      []string{"broker"},
@@ -99,6 +101,7 @@ func TestPlan_LogicalPlanFromSpec(t *testing.T) {
 		joinSpec = &universe.MergeJoinProcedureSpec{
 			TableNames: []string{"a", "b"},
 			On:         []string{"_time"},
+			Method:     "inner",
 		}
 		toHTTPSpec = &http.ToHTTPProcedureSpec{
 			Spec: &toHTTPOpSpec,
@@ -0,0 +1,267 @@
+package array
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const FromArrayKind = "fromArray"
+
+// FromArrayOpSpec holds the rows of the tables to create.
+// The values of the rows are of the Go types bool, int64, uint64, float64, string and values.Time,
+// according to the type of their column.
+type FromArrayOpSpec struct {
+	Columns []flux.ColMeta  `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+	Group   []string        `json:"group,omitempty"`
+}
+
+func init() {
+	fromArraySignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"rows":  semantic.NewArrayPolyType(semantic.Tvar(1)),
+			"group": semantic.NewArrayPolyType(semantic.String),
+		},
+		Required: semantic.LabelSet{"rows"},
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("array", "from", flux.FunctionValue(FromArrayKind, createFromArrayOpSpec, fromArraySignature))
+	flux.RegisterOpSpec(FromArrayKind, newFromArrayOp)
+	plan.RegisterProcedureSpec(FromArrayKind, newFromArrayProcedure, FromArrayKind)
+	execute.RegisterSource(FromArrayKind, createFromArraySource)
+}
+
+// ReadArgs loads a flux.Arguments into FromArrayOpSpec.
+// The columns are the properties of the records in rows, sorted by label,
+// and their types are inferred from the type of the records, which must all be the same.
+func (o *FromArrayOpSpec) ReadArgs(args flux.Arguments) error {
+	rows, err := args.GetRequiredArray("rows", semantic.Object)
+	if err != nil {
+		return err
+	}
+	if rows.Len() == 0 {
+		return errors.New("rows must not be empty")
+	}
+
+	// The type of an array does not describe its elements when their types differ,
+	// so the columns are read from the type of the first record and every record is checked against them.
+	properties := rows.Get(0).Object().Type().Properties()
+	o.Columns = make([]flux.ColMeta, 0, len(properties))
+	for label, typ := range properties {
+		colType := flux.ColumnType(typ)
+		if colType == flux.TInvalid {
+			return fmt.Errorf("unsupported type %v of column %q", typ, label)
+		}
+		o.Columns = append(o.Columns, flux.ColMeta{Label: label, Type: colType})
+	}
+	sort.Slice(o.Columns, func(i, j int) bool {
+		return o.Columns[i].Label < o.Columns[j].Label
+	})
+
+	o.Rows = make([][]interface{}, rows.Len())
+	rows.Range(func(i int, v values.Value) {
+		if err != nil {
+			return
+		}
+		record := v.Object()
+		if record.Len() != len(o.Columns) {
+			err = fmt.Errorf("row %d has %d columns, expected %d", i, record.Len(), len(o.Columns))
+			return
+		}
+		row := make([]interface{}, len(o.Columns))
+		for j, c := range o.Columns {
+			v, ok := record.Get(c.Label)
+			if !ok {
+				err = fmt.Errorf("row %d is missing column %q", i, c.Label)
+				return
+			}
+			if got := flux.ColumnType(v.Type()); got != c.Type {
+				err = fmt.Errorf("column %q of row %d is of type %v, expected %v", c.Label, i, got, c.Type)
+				return
+			}
+			row[j] = execute.GoValue(v)
+		}
+		o.Rows[i] = row
+	})
+	if err != nil {
+		return err
+	}
+
+	group, ok, err := args.GetArray("group", semantic.String)
+	if err != nil {
+		return err
+	} else if ok {
+		o.Group = make([]string, group.Len())
+		group.Range(func(i int, v values.Value) {
+			o.Group[i] = v.Str()
+		})
+		for _, label := range o.Group {
+			if execute.ColIdx(label, o.Columns) < 0 {
+				return fmt.Errorf("group column %q is not a column of the rows", label)
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the values of the rows into the Go types of their columns.
+func (o *FromArrayOpSpec) UnmarshalJSON(data []byte) error {
+	var spec struct {
+		Columns []flux.ColMeta      `json:"columns"`
+		Rows    [][]json.RawMessage `json:"rows"`
+		Group   []string            `json:"group"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+	o.Columns = spec.Columns
+	o.Group = spec.Group
+	o.Rows = make([][]interface{}, len(spec.Rows))
+	for i, raw := range spec.Rows {
+		if len(raw) != len(o.Columns) {
+			return fmt.Errorf("row %d has %d values, expected %d", i, len(raw), len(o.Columns))
+		}
+		row := make([]interface{}, len(raw))
+		for j, c := range o.Columns {
+			v, err := execute.UnmarshalGoValue(raw[j], c.Type)
+			if err != nil {
+				return errors.Wrapf(err, "row %d column %q", i, c.Label)
+			}
+			row[j] = v
+		}
+		o.Rows[i] = row
+	}
+	return nil
+}
+
+func createFromArrayOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(FromArrayOpSpec)
+	if err := spec.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newFromArrayOp() flux.OperationSpec {
+	return new(FromArrayOpSpec)
+}
+
+func (FromArrayOpSpec) Kind() flux.OperationKind {
+	return FromArrayKind
+}
+
+type FromArrayProcedureSpec struct {
+	plan.DefaultCost
+	Spec *FromArrayOpSpec
+}
+
+func newFromArrayProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FromArrayOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &FromArrayProcedureSpec{Spec: spec}, nil
+}
+
+func (s *FromArrayProcedureSpec) Kind() plan.ProcedureKind {
+	return FromArrayKind
+}
+
+func (s *FromArrayProcedureSpec) Copy() plan.ProcedureSpec {
+	spec := *s.Spec
+	return &FromArrayProcedureSpec{Spec: &spec}
+}
+
+func createFromArraySource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*FromArrayProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	tables := execute.NewGroupLookup()
+	for _, row := range spec.Spec.Rows {
+		key := groupKey(spec.Spec, row)
+		var b *execute.ColListTableBuilder
+		if v, ok := tables.Lookup(key); ok {
+			b = v.(*execute.ColListTableBuilder)
+		} else {
+			b = execute.NewColListTableBuilder(key, a.Allocator())
+			for _, c := range spec.Spec.Columns {
+				if _, err := b.AddCol(c); err != nil {
+					return nil, err
+				}
+			}
+			tables.Set(key, b)
+		}
+		for j, v := range row {
+			if err := b.AppendValue(j, values.New(v)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s := &ArraySource{id: dsid}
+	var err error
+	tables.Range(func(key flux.GroupKey, value interface{}) {
+		if err != nil {
+			return
+		}
+		var tbl flux.Table
+		tbl, err = value.(*execute.ColListTableBuilder).Table()
+		s.tables = append(s.tables, tbl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// groupKey returns the group key of the table holding the row.
+func groupKey(spec *FromArrayOpSpec, row []interface{}) flux.GroupKey {
+	cols := make([]flux.ColMeta, len(spec.Group))
+	vs := make([]values.Value, len(spec.Group))
+	for i, label := range spec.Group {
+		j := execute.ColIdx(label, spec.Columns)
+		cols[i] = spec.Columns[j]
+		vs[i] = values.New(row[j])
+	}
+	return execute.NewGroupKey(cols, vs)
+}
+
+// ArraySource produces the tables of the rows, one table per group key.
+type ArraySource struct {
+	id     execute.DatasetID
+	tables []flux.Table
+	ts     []execute.Transformation
+}
+
+func (s *ArraySource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *ArraySource) Run(ctx context.Context) {
+	err := s.run()
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *ArraySource) run() error {
+	for _, tbl := range s.tables {
+		for _, t := range s.ts {
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
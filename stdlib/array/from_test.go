@@ -0,0 +1,202 @@
+package array_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/array"
+)
+
+func TestFromArray_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "no rows",
+			Raw:     `import "array" array.from()`,
+			WantErr: true,
+		},
+		{
+			Name:    "empty rows",
+			Raw:     `import "array" array.from(rows: [])`,
+			WantErr: true,
+		},
+		{
+			Name:    "rows of mixed types",
+			Raw:     `import "array" array.from(rows: [{a: 1}, {a: "x"}])`,
+			WantErr: true,
+		},
+		{
+			Name:    "rows of different columns",
+			Raw:     `import "array" array.from(rows: [{a: 1}, {b: 1}])`,
+			WantErr: true,
+		},
+		{
+			Name:    "unsupported column type",
+			Raw:     `import "array" array.from(rows: [{a: 1s}])`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown group column",
+			Raw:     `import "array" array.from(rows: [{a: 1}], group: ["b"])`,
+			WantErr: true,
+		},
+		{
+			Name: "rows",
+			Raw:  `import "array" array.from(rows: [{_time: 2018-01-01T00:00:00Z, host: "a", _value: 1.5, n: 1, ok: true}], group: ["host"])`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromArray0",
+						Spec: &array.FromArrayOpSpec{
+							Columns: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_value", Type: flux.TFloat},
+								{Label: "host", Type: flux.TString},
+								{Label: "n", Type: flux.TInt},
+								{Label: "ok", Type: flux.TBool},
+							},
+							Rows: [][]interface{}{
+								{execute.Time(1514764800e9), 1.5, "a", int64(1), true},
+							},
+							Group: []string{"host"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestFromArrayOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"fromArray","kind":"fromArray","spec":{"columns":[{"Label":"_time","Type":6},{"Label":"_value","Type":4},{"Label":"n","Type":3}],"rows":[[1000000000,1.5,3]],"group":["n"]}}`)
+	op := &flux.Operation{
+		ID: "fromArray",
+		Spec: &array.FromArrayOpSpec{
+			Columns: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "n", Type: flux.TUInt},
+			},
+			Rows: [][]interface{}{
+				{execute.Time(1e9), 1.5, uint64(3)},
+			},
+			Group: []string{"n"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestFromArray_Run(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  []*executetest.Table
+	}{
+		{
+			name: "single table",
+			query: `import "array"
+array.from(rows: [
+	{_time: 1970-01-01T00:00:01Z, host: "a", _value: 1.0},
+	{_time: 1970-01-01T00:00:02Z, host: "b", _value: 2.0},
+])`,
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1e9), 1.0, "a"},
+					{execute.Time(2e9), 2.0, "b"},
+				},
+			}},
+		},
+		{
+			name: "grouped tables",
+			query: `import "array"
+array.from(group: ["host"], rows: [
+	{_time: 1970-01-01T00:00:01Z, host: "b", _value: 1},
+	{_time: 1970-01-01T00:00:02Z, host: "a", _value: 2},
+	{_time: 1970-01-01T00:00:03Z, host: "b", _value: 3},
+])
+	|> sum()`,
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{"a", int64(2)},
+					},
+				},
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{"b", int64(4)},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			querier := &querytest.Querier{
+				C: controltest.New(control.New(control.Config{
+					ConcurrencyQuota: 1,
+					MemoryBytesQuota: math.MaxInt64,
+				})),
+			}
+			q, err := querier.C.Query(context.Background(), lang.FluxCompiler{Query: tc.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Done()
+
+			var got []*executetest.Table
+			for _, res := range <-q.Ready() {
+				if err := res.Tables().Do(func(tbl flux.Table) error {
+					cpy, err := executetest.ConvertTable(tbl)
+					if err != nil {
+						return err
+					}
+					got = append(got, cpy)
+					return nil
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := q.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
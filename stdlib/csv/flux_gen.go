@@ -21,11 +21,11 @@ var pkgAST = &ast.Package{
 			Errors: nil,
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
-					Column: 13,
-					Line:   3,
+					Column: 11,
+					Line:   4,
 				},
 				File:   "csv.flux",
-				Source: "package csv\n\nbuiltin from",
+				Source: "package csv\n\nbuiltin from\nbuiltin to",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -66,6 +66,40 @@ var pkgAST = &ast.Package{
 				},
 				Name: "from",
 			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 11,
+						Line:   4,
+					},
+					File:   "csv.flux",
+					Source: "builtin to",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 11,
+							Line:   4,
+						},
+						File:   "csv.flux",
+						Source: "to",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "to",
+			},
 		}},
 		Imports: nil,
 		Name:    "csv.flux",
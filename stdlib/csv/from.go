@@ -7,27 +7,54 @@ import (
 
 	"context"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
 	"github.com/pkg/errors"
 )
 
-const FromCSVKind = "fromCSV"
+const (
+	FromCSVKind = "fromCSV"
+
+	// AnnotationsMode reads Flux annotated CSV, it is the default mode.
+	AnnotationsMode = "annotations"
+	// RawMode reads plain CSV without annotations.
+	RawMode = "raw"
+)
 
 type FromCSVOpSpec struct {
 	CSV  string `json:"csv"`
 	File string `json:"file"`
+	Mode string `json:"mode,omitempty"`
+
+	// The remaining options only apply to raw mode.
+	Delimiter    string            `json:"delimiter,omitempty"`
+	Quote        string            `json:"quote,omitempty"`
+	Header       *bool             `json:"header,omitempty"` // nil detects whether the first record is a header
+	Types        map[string]string `json:"types,omitempty"`
+	GroupColumns []string          `json:"groupColumns,omitempty"`
+	TimeColumn   string            `json:"timeColumn,omitempty"`
+	TimeLayout   string            `json:"timeLayout,omitempty"`
 }
 
 func init() {
 	fromCSVSignature := semantic.FunctionPolySignature{
 		Parameters: map[string]semantic.PolyType{
-			"csv":  semantic.String,
-			"file": semantic.String,
+			"csv":          semantic.String,
+			"file":         semantic.String,
+			"mode":         semantic.String,
+			"delimiter":    semantic.String,
+			"quote":        semantic.String,
+			"header":       semantic.Bool,
+			"types":        semantic.Tvar(1),
+			"groupColumns": semantic.NewArrayPolyType(semantic.String),
+			"timeColumn":   semantic.String,
+			"timeLayout":   semantic.String,
 		},
 		Required: nil,
 		Return:   flux.TableObjectType,
@@ -67,9 +94,105 @@ func createFromCSVOpSpec(args flux.Arguments, a *flux.Administration) (flux.Oper
 		}
 	}
 
+	if mode, ok, err := args.GetString("mode"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Mode = mode
+	} else {
+		spec.Mode = AnnotationsMode
+	}
+
+	switch spec.Mode {
+	case AnnotationsMode:
+		for _, name := range []string{"delimiter", "quote", "header", "types", "groupColumns", "timeColumn", "timeLayout"} {
+			if _, ok := args.Get(name); ok {
+				return nil, fmt.Errorf("%s is only supported in %s mode", name, RawMode)
+			}
+		}
+	case RawMode:
+		if err := spec.readRawArgs(args); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown mode %q, expected %q or %q", spec.Mode, AnnotationsMode, RawMode)
+	}
+
 	return spec, nil
 }
 
+func (spec *FromCSVOpSpec) readRawArgs(args flux.Arguments) error {
+	spec.Delimiter, spec.Quote = ",", `"`
+	if delimiter, ok, err := args.GetString("delimiter"); err != nil {
+		return err
+	} else if ok {
+		spec.Delimiter = delimiter
+	}
+	if quote, ok, err := args.GetString("quote"); err != nil {
+		return err
+	} else if ok {
+		spec.Quote = quote
+	}
+	if utf8.RuneCountInString(spec.Delimiter) != 1 || utf8.RuneCountInString(spec.Quote) != 1 {
+		return errors.New("delimiter and quote must be a single character")
+	}
+	if spec.Delimiter == spec.Quote || spec.Delimiter == "\n" {
+		return errors.New("invalid delimiter")
+	}
+
+	if header, ok, err := args.GetBool("header"); err != nil {
+		return err
+	} else if ok {
+		spec.Header = &header
+	}
+
+	if types, ok, err := args.GetObject("types"); err != nil {
+		return err
+	} else if ok {
+		spec.Types = make(map[string]string, types.Len())
+		types.Range(func(name string, v values.Value) {
+			if err != nil {
+				return
+			}
+			if v.Type() != semantic.String {
+				err = fmt.Errorf("type of column %q must be a string", name)
+				return
+			}
+			if _, ok := rawTypes[v.Str()]; !ok {
+				err = fmt.Errorf("unknown type %q for column %q", v.Str(), name)
+				return
+			}
+			spec.Types[name] = v.Str()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if groupColumns, ok, err := args.GetArray("groupColumns", semantic.String); err != nil {
+		return err
+	} else if ok {
+		spec.GroupColumns = make([]string, groupColumns.Len())
+		groupColumns.Range(func(i int, v values.Value) {
+			spec.GroupColumns[i] = v.Str()
+		})
+	}
+
+	if timeColumn, ok, err := args.GetString("timeColumn"); err != nil {
+		return err
+	} else if ok {
+		spec.TimeColumn = timeColumn
+	}
+	if timeLayout, ok, err := args.GetString("timeLayout"); err != nil {
+		return err
+	} else if ok {
+		if spec.TimeColumn == "" {
+			return errors.New("timeLayout requires timeColumn")
+		}
+		spec.TimeLayout = timeLayout
+	}
+	return nil
+}
+
 func newFromCSVOp() flux.OperationSpec {
 	return new(FromCSVOpSpec)
 }
@@ -82,6 +205,8 @@ type FromCSVProcedureSpec struct {
 	plan.DefaultCost
 	CSV  string
 	File string
+	Mode string
+	Raw  RawConfig
 }
 
 func newFromCSVProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -90,10 +215,25 @@ func newFromCSVProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pr
 		return nil, fmt.Errorf("invalid spec type %T", qs)
 	}
 
-	return &FromCSVProcedureSpec{
+	ps := &FromCSVProcedureSpec{
 		CSV:  spec.CSV,
 		File: spec.File,
-	}, nil
+		Mode: spec.Mode,
+	}
+	if spec.Mode == RawMode {
+		delimiter, _ := utf8.DecodeRuneInString(spec.Delimiter)
+		quote, _ := utf8.DecodeRuneInString(spec.Quote)
+		ps.Raw = RawConfig{
+			Delimiter:    delimiter,
+			Quote:        quote,
+			Header:       spec.Header,
+			Types:        spec.Types,
+			GroupColumns: spec.GroupColumns,
+			TimeColumn:   spec.TimeColumn,
+			TimeLayout:   spec.TimeLayout,
+		}
+	}
+	return ps, nil
 }
 
 func (s *FromCSVProcedureSpec) Kind() plan.ProcedureKind {
@@ -104,6 +244,19 @@ func (s *FromCSVProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(FromCSVProcedureSpec)
 	ns.CSV = s.CSV
 	ns.File = s.File
+	ns.Mode = s.Mode
+	ns.Raw = s.Raw
+	if s.Raw.Header != nil {
+		header := *s.Raw.Header
+		ns.Raw.Header = &header
+	}
+	if s.Raw.Types != nil {
+		ns.Raw.Types = make(map[string]string, len(s.Raw.Types))
+		for k, v := range s.Raw.Types {
+			ns.Raw.Types[k] = v
+		}
+	}
+	ns.Raw.GroupColumns = append([]string(nil), s.Raw.GroupColumns...)
 	return ns
 }
 
@@ -123,22 +276,46 @@ func createFromCSVSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a ex
 		csvText = string(csvBytes)
 	}
 
+	if spec.Mode == RawMode {
+		tables, err := decodeRaw(csvText, spec.Raw, a.Allocator())
+		if err != nil {
+			return nil, err
+		}
+		return &CSVSource{id: dsid, data: tableIterator(tables)}, nil
+	}
+
 	decoder := csv.NewResultDecoder(csv.ResultDecoderConfig{})
 	result, err := decoder.Decode(strings.NewReader(csvText))
 	if err != nil {
 		return nil, err
 	}
-	csvSource := CSVSource{id: dsid, data: result}
+	csvSource := CSVSource{id: dsid, data: result.Tables()}
 
 	return &csvSource, nil
 }
 
 type CSVSource struct {
 	id   execute.DatasetID
-	data flux.Result
+	data flux.TableIterator
 	ts   []execute.Transformation
 }
 
+// tableIterator iterates over tables that have already been decoded.
+type tableIterator []flux.Table
+
+func (ti tableIterator) Do(f func(flux.Table) error) error {
+	for _, tbl := range ti {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ti tableIterator) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
+
 func (c *CSVSource) AddTransformation(t execute.Transformation) {
 	c.ts = append(c.ts, t)
 }
@@ -147,7 +324,7 @@ func (c *CSVSource) Run(ctx context.Context) {
 	var err error
 	var max execute.Time
 	maxSet := false
-	err = c.data.Tables().Do(func(tbl flux.Table) error {
+	err = c.data.Do(func(tbl flux.Table) error {
 		for _, t := range c.ts {
 			err := t.Process(c.id, tbl)
 			if err != nil {
@@ -1,12 +1,19 @@
 package csv_test
 
 import (
+	"context"
+	"math"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/flux/querytest"
 	"github.com/influxdata/flux/stdlib/csv"
 	"github.com/influxdata/flux/stdlib/universe"
@@ -42,7 +49,8 @@ func TestFromCSV_NewQuery(t *testing.T) {
 					{
 						ID: "fromCSV0",
 						Spec: &csv.FromCSVOpSpec{
-							CSV: "1,2",
+							CSV:  "1,2",
+							Mode: csv.AnnotationsMode,
 						},
 					},
 					{
@@ -74,6 +82,49 @@ func TestFromCSV_NewQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "raw mode",
+			Raw: `import "csv" csv.from(csv: "a;b", mode: "raw", delimiter: ";", quote: "'", header: false,
+	types: {a: "int", "b c": "string"}, groupColumns: ["a"], timeColumn: "t", timeLayout: "2006-01-02")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromCSV0",
+						Spec: &csv.FromCSVOpSpec{
+							CSV:          "a;b",
+							Mode:         csv.RawMode,
+							Delimiter:    ";",
+							Quote:        "'",
+							Header:       new(bool),
+							Types:        map[string]string{"a": "int", "b c": "string"},
+							GroupColumns: []string{"a"},
+							TimeColumn:   "t",
+							TimeLayout:   "2006-01-02",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:    "raw option in annotations mode",
+			Raw:     `import "csv" csv.from(csv: "a,b", delimiter: ";")`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown mode",
+			Raw:     `import "csv" csv.from(csv: "a,b", mode: "xml")`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown type",
+			Raw:     `import "csv" csv.from(csv: "a,b", mode: "raw", types: {a: "decimal"})`,
+			WantErr: true,
+		},
+		{
+			Name:    "invalid delimiter",
+			Raw:     `import "csv" csv.from(csv: "a,b", mode: "raw", delimiter: ";;")`,
+			WantErr: true,
+		},
 		{
 			Name:    "fromCSV File",
 			Raw:     `import "csv" csv.from(file: "f.txt") |> range(start:-4h, stop:-2h) |> sum()`,
@@ -99,3 +150,170 @@ func TestFromCSVOperation_Marshaling(t *testing.T) {
 	}
 	querytest.OperationMarshalingTestHelper(t, data, op)
 }
+
+func TestFromCSV_Raw(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		want    []*executetest.Table
+		wantErr bool
+	}{
+		{
+			name: "detected header and inferred types",
+			query: `import "csv" csv.from(mode: "raw", csv: "
+time,host,value,count,ok
+2018-01-01T00:00:00Z,a,1.5,1,true
+2018-01-01T00:00:10Z,\"b, c\",2,,false
+")`,
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "value", Type: flux.TFloat},
+					{Label: "count", Type: flux.TInt},
+					{Label: "ok", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1514764800e9), "a", 1.5, int64(1), true},
+					{execute.Time(1514764810e9), "b, c", 2.0, nil, false},
+				},
+			}},
+		},
+		{
+			name: "no header",
+			query: `import "csv" csv.from(mode: "raw", csv: "1,x
+2,y")`,
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "column0", Type: flux.TInt},
+					{Label: "column1", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{int64(1), "x"},
+					{int64(2), "y"},
+				},
+			}},
+		},
+		{
+			name: "options",
+			query: `import "csv" csv.from(mode: "raw", delimiter: ";", quote: "'", types: {id: "uint", code: "string"}, groupColumns: ["host"], timeColumn: "day", timeLayout: "2006-01-02", csv: "
+day;host;id;code
+2018-01-01;a;1;007
+2018-01-02;'b;c';2;008
+2018-01-03;a;3;'it''s'
+")`,
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "day", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "id", Type: flux.TUInt},
+						{Label: "code", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1514764800e9), "a", uint64(1), "007"},
+						{execute.Time(1514937600e9), "a", uint64(3), "it's"},
+					},
+				},
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "day", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "id", Type: flux.TUInt},
+						{Label: "code", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1514851200e9), "b;c", uint64(2), "008"},
+					},
+				},
+			},
+		},
+		{
+			name: "time layout of time column",
+			query: `import "csv" csv.from(mode: "raw", types: {created: "time"}, timeColumn: "day", timeLayout: "2006-01-02", csv: "
+day,created
+2018-01-01,2018-01-02T12:00:00Z
+")`,
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "day", Type: flux.TTime},
+					{Label: "created", Type: flux.TTime},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1514764800e9), execute.Time(1514894400e9)},
+				},
+			}},
+		},
+		{
+			name: "wrong number of fields",
+			query: `import "csv" csv.from(mode: "raw", csv: "a,b
+1,2,3")`,
+			wantErr: true,
+		},
+		{
+			name: "invalid explicit type",
+			query: `import "csv" csv.from(mode: "raw", types: {a: "int"}, csv: "a,b
+x,2")`,
+			wantErr: true,
+		},
+		{
+			name: "unknown group column",
+			query: `import "csv" csv.from(mode: "raw", groupColumns: ["c"], csv: "a,b
+1,2")`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			querier := &querytest.Querier{
+				C: controltest.New(control.New(control.Config{
+					ConcurrencyQuota: 1,
+					MemoryBytesQuota: math.MaxInt64,
+				})),
+			}
+			q, err := querier.C.Query(context.Background(), lang.FluxCompiler{Query: tc.query})
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			defer q.Done()
+
+			var got []*executetest.Table
+			for _, res := range <-q.Ready() {
+				if err := res.Tables().Do(func(tbl flux.Table) error {
+					cpy, err := executetest.ConvertTable(tbl)
+					if err != nil {
+						return err
+					}
+					got = append(got, cpy)
+					return nil
+				}); err != nil {
+					if !tc.wantErr {
+						t.Fatal(err)
+					}
+					return
+				}
+			}
+			if err := q.Err(); err != nil {
+				if !tc.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected error")
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package csv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+// RawConfig configures how csv.from reads CSV without annotations.
+type RawConfig struct {
+	Delimiter rune
+	Quote     rune
+	// Header reports whether the first record holds the column names.
+	// If nil the header is detected, see hasHeader.
+	Header *bool
+	// Types maps column names to one of the type names in rawTypes.
+	// The types of the other columns are inferred from their values.
+	Types map[string]string
+	// GroupColumns are the columns of the group key.
+	GroupColumns []string
+	// TimeColumn is parsed as a time using TimeLayout.
+	// Other time columns are parsed as RFC3339 times.
+	TimeColumn string
+	TimeLayout string
+}
+
+// rawTypes are the type names accepted in RawConfig.Types.
+var rawTypes = map[string]flux.ColType{
+	"bool":   flux.TBool,
+	"int":    flux.TInt,
+	"uint":   flux.TUInt,
+	"float":  flux.TFloat,
+	"string": flux.TString,
+	"time":   flux.TTime,
+}
+
+// decodeRaw reads plain CSV into tables grouped by the group columns.
+// Empty fields are empty strings in string columns and nulls in every other column.
+func decodeRaw(text string, c RawConfig, alloc *memory.Allocator) ([]flux.Table, error) {
+	records, err := readRawRecords(text, c.Delimiter, c.Quote)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no CSV records")
+	}
+
+	header := hasHeader(records)
+	if c.Header != nil {
+		header = *c.Header
+	}
+	var names []string
+	if header {
+		names, records = records[0], records[1:]
+	} else {
+		names = make([]string, len(records[0]))
+		for i := range names {
+			names[i] = fmt.Sprintf("column%d", i)
+		}
+	}
+
+	cols := make([]flux.ColMeta, len(names))
+	for i, name := range names {
+		cols[i] = flux.ColMeta{Label: name}
+		switch {
+		case name == c.TimeColumn:
+			cols[i].Type = flux.TTime
+		case c.Types[name] != "":
+			cols[i].Type = rawTypes[c.Types[name]]
+		default:
+			cols[i].Type = inferType(records, i)
+		}
+	}
+	for name := range c.Types {
+		if execute.ColIdx(name, cols) < 0 {
+			return nil, fmt.Errorf("type given for unknown column %q", name)
+		}
+	}
+	if c.TimeColumn != "" && execute.ColIdx(c.TimeColumn, cols) < 0 {
+		return nil, fmt.Errorf("unknown time column %q", c.TimeColumn)
+	}
+	keyIdx := make([]int, len(c.GroupColumns))
+	keyCols := make([]flux.ColMeta, len(c.GroupColumns))
+	for i, label := range c.GroupColumns {
+		j := execute.ColIdx(label, cols)
+		if j < 0 {
+			return nil, fmt.Errorf("unknown group column %q", label)
+		}
+		keyIdx[i] = j
+		keyCols[i] = cols[j]
+	}
+
+	// The time layout only applies to the time column, other time columns are RFC3339.
+	layouts := make([]string, len(cols))
+	for i, col := range cols {
+		layouts[i] = time.RFC3339Nano
+		if col.Label == c.TimeColumn && c.TimeLayout != "" {
+			layouts[i] = c.TimeLayout
+		}
+	}
+	tables := execute.NewGroupLookup()
+	row := make([]values.Value, len(cols))
+	for n, record := range records {
+		for j, field := range record {
+			v, err := parseRawValue(field, cols[j].Type, layouts[j])
+			if err != nil {
+				return nil, fmt.Errorf("record %d, column %q: %v", n+1, cols[j].Label, err)
+			}
+			row[j] = v
+		}
+
+		keyValues := make([]values.Value, len(keyIdx))
+		for i, j := range keyIdx {
+			if row[j].IsNull() {
+				return nil, fmt.Errorf("record %d: group column %q is empty", n+1, cols[j].Label)
+			}
+			keyValues[i] = row[j]
+		}
+		key := execute.NewGroupKey(keyCols, keyValues)
+		var builder *execute.ColListTableBuilder
+		if b, ok := tables.Lookup(key); ok {
+			builder = b.(*execute.ColListTableBuilder)
+		} else {
+			builder = execute.NewColListTableBuilder(key, alloc)
+			for _, col := range cols {
+				if _, err := builder.AddCol(col); err != nil {
+					return nil, err
+				}
+			}
+			tables.Set(key, builder)
+		}
+		for j, v := range row {
+			if err := builder.AppendValue(j, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(records) == 0 {
+		// Only a header, produce a single empty table.
+		builder := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), alloc)
+		for _, col := range cols {
+			if _, err := builder.AddCol(col); err != nil {
+				return nil, err
+			}
+		}
+		tables.Set(builder.Key(), builder)
+	}
+
+	var result []flux.Table
+	tables.Range(func(key flux.GroupKey, value interface{}) {
+		if err != nil {
+			return
+		}
+		var tbl flux.Table
+		tbl, err = value.(*execute.ColListTableBuilder).Table()
+		result = append(result, tbl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readRawRecords splits text into records of fields.
+// Fields may be enclosed in quote characters, a quote character inside a quoted field is written twice.
+// Blank lines are skipped and every record must have the same number of fields.
+func readRawRecords(text string, delim, quote rune) ([][]string, error) {
+	var (
+		records  [][]string
+		record   []string
+		field    strings.Builder
+		inQuotes bool
+		quoted   bool
+		line     = 1
+	)
+	endRecord := func() error {
+		record = append(record, field.String())
+		field.Reset()
+		quoted = false
+		if len(record) == 1 && record[0] == "" {
+			// blank line
+			record = nil
+			return nil
+		}
+		if len(records) > 0 && len(record) != len(records[0]) {
+			return fmt.Errorf("line %d: expected %d fields, got %d", line, len(records[0]), len(record))
+		}
+		records = append(records, record)
+		record = nil
+		return nil
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if inQuotes {
+			switch {
+			case r == quote && i+1 < len(runes) && runes[i+1] == quote:
+				field.WriteRune(quote)
+				i++
+			case r == quote:
+				inQuotes = false
+			default:
+				if r == '\n' {
+					line++
+				}
+				field.WriteRune(r)
+			}
+			continue
+		}
+		switch {
+		case r == quote && field.Len() == 0 && !quoted:
+			inQuotes, quoted = true, true
+		case r == delim:
+			record = append(record, field.String())
+			field.Reset()
+			quoted = false
+		case r == '\n':
+			if err := endRecord(); err != nil {
+				return nil, err
+			}
+			line++
+		case r == '\r' && i+1 < len(runes) && runes[i+1] == '\n':
+			// the newline ends the record
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted field", line)
+	}
+	if err := endRecord(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// hasHeader reports whether the first record looks like a header.
+// It is a header when its fields are distinct, non-empty and none of them is a boolean, number or time.
+func hasHeader(records [][]string) bool {
+	seen := make(map[string]bool, len(records[0]))
+	for _, f := range records[0] {
+		if f == "" || seen[f] || inferValueType(f) != flux.TString {
+			return false
+		}
+		seen[f] = true
+	}
+	return true
+}
+
+// inferType returns the narrowest type that every non-empty value of column j can be parsed as.
+func inferType(records [][]string, j int) flux.ColType {
+	typ := flux.TInvalid
+	for _, record := range records {
+		if record[j] == "" {
+			continue
+		}
+		t := inferValueType(record[j])
+		switch {
+		case typ == flux.TInvalid || typ == t:
+			typ = t
+		case typ == flux.TInt && t == flux.TFloat, typ == flux.TFloat && t == flux.TInt:
+			typ = flux.TFloat
+		default:
+			return flux.TString
+		}
+	}
+	if typ == flux.TInvalid {
+		return flux.TString
+	}
+	return typ
+}
+
+func inferValueType(s string) flux.ColType {
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return flux.TInt
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return flux.TFloat
+	}
+	if s == "true" || s == "false" {
+		return flux.TBool
+	}
+	if _, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return flux.TTime
+	}
+	return flux.TString
+}
+
+func parseRawValue(s string, typ flux.ColType, layout string) (values.Value, error) {
+	if s == "" {
+		if typ == flux.TString {
+			return values.NewString(""), nil
+		}
+		return values.NewNull(flux.SemanticType(typ)), nil
+	}
+	switch typ {
+	case flux.TBool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewBool(v), nil
+	case flux.TInt:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewInt(v), nil
+	case flux.TUInt:
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewUInt(v), nil
+	case flux.TFloat:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewFloat(v), nil
+	case flux.TTime:
+		v, err := time.Parse(layout, s)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewTime(values.ConvertTime(v)), nil
+	default:
+		return values.NewString(s), nil
+	}
+}
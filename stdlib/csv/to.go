@@ -0,0 +1,357 @@
+package csv
+
+import (
+	gocsv "encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ToCSVKind is the Kind for the ToCSV Flux function
+	ToCSVKind = "toCSV"
+
+	// toCSVResultName is the value of the result column written by csv.to.
+	toCSVResultName = "_result"
+)
+
+type ToCSVOpSpec struct {
+	File        string `json:"file"`
+	Annotations bool   `json:"annotations"` // write annotated CSV, otherwise a single header and the records of every table
+	Delimiter   string `json:"delimiter"`
+	Append      bool   `json:"append,omitempty"` // append to the file instead of truncating it
+}
+
+func init() {
+	toCSVSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"file":        semantic.String,
+			"annotations": semantic.Bool,
+			"delimiter":   semantic.String,
+			"append":      semantic.Bool,
+		},
+		[]string{"file"},
+	)
+	flux.RegisterPackageValue("csv", "to", flux.FunctionValueWithSideEffect(ToCSVKind, createToCSVOpSpec, toCSVSignature))
+	flux.RegisterOpSpec(ToCSVKind, func() flux.OperationSpec { return &ToCSVOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(ToCSVKind, newToCSVProcedure, ToCSVKind)
+	execute.RegisterTransformation(ToCSVKind, createToCSVTransformation)
+}
+
+// ReadArgs loads a flux.Arguments into ToCSVOpSpec.
+// Annotations default to true and the delimiter defaults to a comma.
+func (o *ToCSVOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	if o.File, err = args.GetRequiredString("file"); err != nil {
+		return err
+	}
+	if o.File == "" {
+		return errors.New("invalid file name")
+	}
+
+	annotations, ok, err := args.GetBool("annotations")
+	if err != nil {
+		return err
+	}
+	o.Annotations = !ok || annotations
+
+	delimiter, ok, err := args.GetString("delimiter")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		delimiter = ","
+	}
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return errors.New("delimiter must be a single character")
+	}
+	if delimiter == "\n" || delimiter == "\r" || delimiter == `"` {
+		return errors.New("invalid delimiter")
+	}
+	o.Delimiter = delimiter
+
+	if o.Append, _, err = args.GetBool("append"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func createToCSVOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToCSVOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToCSVOpSpec) Kind() flux.OperationKind {
+	return ToCSVKind
+}
+
+type ToCSVProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToCSVOpSpec
+}
+
+func (o *ToCSVProcedureSpec) Kind() plan.ProcedureKind {
+	return ToCSVKind
+}
+
+func (o *ToCSVProcedureSpec) Copy() plan.ProcedureSpec {
+	s := *o.Spec
+	return &ToCSVProcedureSpec{Spec: &s}
+}
+
+func newToCSVProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToCSVOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ToCSVProcedureSpec{Spec: spec}, nil
+}
+
+func createToCSVTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToCSVProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewToCSVTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// fileLocks serializes the writes of all transformations writing to the same file,
+// so that the tables of concurrent result streams are never interleaved.
+var fileLocks = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{m: make(map[string]*sync.Mutex)}
+
+func fileLock(path string) *sync.Mutex {
+	fileLocks.Lock()
+	defer fileLocks.Unlock()
+	mu, ok := fileLocks.m[path]
+	if !ok {
+		mu = new(sync.Mutex)
+		fileLocks.m[path] = mu
+	}
+	return mu
+}
+
+// ToCSVTransformation writes every table it receives to a CSV file and passes the tables on unchanged.
+// Annotated tables are written as complete blocks separated by an empty line.
+// Without annotations, the file has a single header of the column labels followed by the records of every table,
+// so every table must have the same columns.
+type ToCSVTransformation struct {
+	d       execute.Dataset
+	cache   execute.TableBuilderCache
+	spec    *ToCSVProcedureSpec
+	encoder *csv.ResultEncoder
+	mu      *sync.Mutex
+	f       *os.File
+	// header holds the column labels of the first table written without annotations.
+	header []string
+}
+
+// NewToCSVTransformation creates a ToCSVTransformation.
+// The file is created if it does not exist and truncated unless the spec appends to it.
+func NewToCSVTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ToCSVProcedureSpec) (*ToCSVTransformation, error) {
+	path, err := filepath.Abs(spec.Spec.File)
+	if err != nil {
+		return nil, err
+	}
+
+	config := csv.DefaultEncoderConfig()
+	config.Delimiter, _ = utf8.DecodeRuneInString(spec.Spec.Delimiter)
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if !spec.Spec.Append {
+		flag |= os.O_TRUNC
+	}
+	mu := fileLock(path)
+	mu.Lock()
+	f, err := os.OpenFile(path, flag, 0666)
+	mu.Unlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open csv file")
+	}
+	return &ToCSVTransformation{
+		d:       d,
+		cache:   cache,
+		spec:    spec,
+		encoder: csv.NewResultEncoder(config),
+		mu:      mu,
+		f:       f,
+	}, nil
+}
+
+func (t *ToCSVTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *ToCSVTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if created {
+		if err := execute.AddTableCols(tbl, builder); err != nil {
+			return err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fi, err := t.f.Stat()
+	if err != nil {
+		return err
+	}
+	if !t.spec.Spec.Annotations {
+		if err := t.writeRaw(&teeTable{Table: tbl, builder: builder}, fi.Size() == 0); err != nil {
+			return errors.Wrap(err, "failed to write csv file")
+		}
+		return nil
+	}
+	if fi.Size() > 0 {
+		// Separate the table from what was written before, like the encoder separates the tables of a result.
+		if _, err := t.f.WriteString("\r\n"); err != nil {
+			return errors.Wrap(err, "failed to write csv file")
+		}
+	}
+	result := tableResult{table: &teeTable{Table: tbl, builder: builder}}
+	if _, err := t.encoder.Encode(t.f, result); err != nil {
+		return errors.Wrap(err, "failed to write csv file")
+	}
+	return nil
+}
+
+// writeRaw writes the records of a table without annotations.
+// The header is written with the first table, unless the file already has contents.
+func (t *ToCSVTransformation) writeRaw(tbl flux.Table, empty bool) error {
+	cols := tbl.Cols()
+	labels := make([]string, len(cols))
+	for j, c := range cols {
+		labels[j] = c.Label
+	}
+
+	w := gocsv.NewWriter(t.f)
+	w.Comma, _ = utf8.DecodeRuneInString(t.spec.Spec.Delimiter)
+	w.UseCRLF = true
+	if t.header == nil {
+		t.header = labels
+		if empty {
+			if err := w.Write(labels); err != nil {
+				return err
+			}
+		}
+	} else if !equalLabels(t.header, labels) {
+		return fmt.Errorf("tables written without annotations must have the same columns, got %v after %v", labels, t.header)
+	}
+
+	record := make([]string, len(cols))
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			for j := range cols {
+				record[j] = rawValue(execute.ValueForRow(cr, i, j))
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func equalLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// rawValue formats a value the way the annotated CSV encoder does, nulls are empty fields.
+func rawValue(v values.Value) string {
+	if v.IsNull() {
+		return ""
+	}
+	switch v.Type() {
+	case semantic.Bool:
+		return strconv.FormatBool(v.Bool())
+	case semantic.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	case semantic.UInt:
+		return strconv.FormatUint(v.UInt(), 10)
+	case semantic.Float:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case semantic.Time:
+		return v.Time().Time().Format(time.RFC3339Nano)
+	default:
+		return v.Str()
+	}
+}
+
+func (t *ToCSVTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateWatermark(pt)
+}
+
+func (t *ToCSVTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *ToCSVTransformation) Finish(id execute.DatasetID, err error) {
+	if cerr := t.f.Close(); cerr != nil && err == nil {
+		err = errors.Wrap(cerr, "failed to close csv file")
+	}
+	t.d.Finish(err)
+}
+
+// tableResult is a result holding a single table.
+type tableResult struct {
+	table flux.Table
+}
+
+func (r tableResult) Name() string                { return toCSVResultName }
+func (r tableResult) Tables() flux.TableIterator  { return tableIterator{r.table} }
+func (r tableResult) Statistics() flux.Statistics { return flux.Statistics{} }
+
+// teeTable appends the records of a table to a builder while they are read,
+// so the table is only read once to both encode and pass it on.
+type teeTable struct {
+	flux.Table
+	builder execute.TableBuilder
+}
+
+func (t *teeTable) Do(f func(flux.ColReader) error) error {
+	return t.Table.Do(func(cr flux.ColReader) error {
+		if err := execute.AppendCols(cr, t.builder); err != nil {
+			return err
+		}
+		return f(cr)
+	})
+}
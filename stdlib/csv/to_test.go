@@ -0,0 +1,302 @@
+package csv_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/csv"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+)
+
+func TestToCSV_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `import "csv" from(bucket:"mybucket") |> csv.to(file: "out.csv")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mybucket"},
+					},
+					{
+						ID: "toCSV1",
+						Spec: &csv.ToCSVOpSpec{
+							File:        "out.csv",
+							Annotations: true,
+							Delimiter:   ",",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toCSV1"},
+				},
+			},
+		},
+		{
+			Name: "all options",
+			Raw:  `import "csv" from(bucket:"mybucket") |> csv.to(file: "out.csv", annotations: false, delimiter: ";", append: true)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mybucket"},
+					},
+					{
+						ID: "toCSV1",
+						Spec: &csv.ToCSVOpSpec{
+							File:      "out.csv",
+							Delimiter: ";",
+							Append:    true,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toCSV1"},
+				},
+			},
+		},
+		{
+			Name:    "missing file",
+			Raw:     `import "csv" from(bucket:"mybucket") |> csv.to()`,
+			WantErr: true,
+		},
+		{
+			Name:    "invalid delimiter",
+			Raw:     `import "csv" from(bucket:"mybucket") |> csv.to(file: "out.csv", delimiter: ";;")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestToCSV_Process(t *testing.T) {
+	tables := func() []*executetest.Table {
+		cols := []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		}
+		return []*executetest.Table{
+			{
+				KeyCols: []string{"host"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(0), "a", 2.0},
+					{execute.Time(1e9), "a", nil},
+				},
+			},
+			{
+				KeyCols: []string{"host"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(2e9), "b", 3.5},
+				},
+			},
+		}
+	}
+	testCases := []struct {
+		name     string
+		spec     csv.ToCSVOpSpec
+		existing string
+		want     string
+	}{
+		{
+			name: "annotated",
+			spec: csv.ToCSVOpSpec{Annotations: true, Delimiter: ","},
+			want: "#datatype,string,long,dateTime:RFC3339,string,double\r\n" +
+				"#group,false,false,false,true,false\r\n" +
+				"#default,_result,,,,\r\n" +
+				",result,table,_time,host,_value\r\n" +
+				",,0,1970-01-01T00:00:00Z,a,2\r\n" +
+				",,0,1970-01-01T00:00:01Z,a,\r\n" +
+				"\r\n" +
+				"#datatype,string,long,dateTime:RFC3339,string,double\r\n" +
+				"#group,false,false,false,true,false\r\n" +
+				"#default,_result,,,,\r\n" +
+				",result,table,_time,host,_value\r\n" +
+				",,0,1970-01-01T00:00:02Z,b,3.5\r\n",
+		},
+		{
+			name:     "raw with delimiter truncates",
+			spec:     csv.ToCSVOpSpec{Delimiter: ";"},
+			existing: "old contents\r\n",
+			want: "_time;host;_value\r\n" +
+				"1970-01-01T00:00:00Z;a;2\r\n" +
+				"1970-01-01T00:00:01Z;a;\r\n" +
+				"1970-01-01T00:00:02Z;b;3.5\r\n",
+		},
+		{
+			name:     "append",
+			spec:     csv.ToCSVOpSpec{Delimiter: ",", Append: true},
+			existing: "_time,host,_value\r\n1969-12-31T23:59:59Z,z,1\r\n",
+			want: "_time,host,_value\r\n1969-12-31T23:59:59Z,z,1\r\n" +
+				"1970-01-01T00:00:00Z,a,2\r\n" +
+				"1970-01-01T00:00:01Z,a,\r\n" +
+				"1970-01-01T00:00:02Z,b,3.5\r\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "csvto")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			spec := tc.spec
+			spec.File = filepath.Join(dir, "out.csv")
+			if tc.existing != "" {
+				if err := ioutil.WriteFile(spec.File, []byte(tc.existing), 0666); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			tr, err := csv.NewToCSVTransformation(d, c, &csv.ToCSVProcedureSpec{Spec: &spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			parentID := executetest.RandomDatasetID()
+			for _, tbl := range tables() {
+				if err := tr.Process(parentID, tbl); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tr.Finish(parentID, nil)
+
+			got, err := ioutil.ReadFile(spec.File)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, string(got)) {
+				t.Errorf("unexpected file contents -want/+got\n%s", cmp.Diff(tc.want, string(got)))
+			}
+
+			// The tables are passed on unchanged.
+			gotTables, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantTables := tables()
+			executetest.NormalizeTables(gotTables)
+			executetest.NormalizeTables(wantTables)
+			if !cmp.Equal(wantTables, gotTables) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(wantTables, gotTables))
+			}
+		})
+	}
+}
+
+func TestToCSV_RawDifferentColumns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csvto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	tr, err := csv.NewToCSVTransformation(d, c, &csv.ToCSVProcedureSpec{
+		Spec: &csv.ToCSVOpSpec{File: filepath.Join(dir, "out.csv"), Delimiter: ","},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parentID := executetest.RandomDatasetID()
+	if err := tr.Process(parentID, &executetest.Table{
+		ColMeta: []flux.ColMeta{{Label: "a", Type: flux.TInt}},
+		Data:    [][]interface{}{{int64(1)}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Process(parentID, &executetest.Table{
+		KeyCols: []string{"b"},
+		ColMeta: []flux.ColMeta{{Label: "b", Type: flux.TInt}},
+		Data:    [][]interface{}{{int64(1)}},
+	}); err == nil {
+		t.Error("expected error for a table with different columns")
+	}
+	tr.Finish(parentID, nil)
+}
+
+func TestToCSV_ConcurrentStreams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csvto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "out.csv")
+
+	const streams, tablesPerStream = 4, 25
+	trs := make([]*csv.ToCSVTransformation, streams)
+	for i := range trs {
+		d := executetest.NewDataset(executetest.RandomDatasetID())
+		c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+		c.SetTriggerSpec(execute.DefaultTriggerSpec)
+		tr, err := csv.NewToCSVTransformation(d, c, &csv.ToCSVProcedureSpec{
+			Spec: &csv.ToCSVOpSpec{File: file, Delimiter: ",", Append: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		trs[i] = tr
+	}
+
+	var wg sync.WaitGroup
+	for i, tr := range trs {
+		wg.Add(1)
+		go func(i int, tr *csv.ToCSVTransformation) {
+			defer wg.Done()
+			parentID := executetest.RandomDatasetID()
+			for j := 0; j < tablesPerStream; j++ {
+				tbl := &executetest.Table{
+					ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TInt}},
+					Data:    [][]interface{}{{int64(i)}, {int64(i)}},
+				}
+				if err := tr.Process(parentID, tbl); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+			tr.Finish(parentID, nil)
+		}(i, tr)
+	}
+	wg.Wait()
+
+	got, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(got), "\r\n"), "\r\n")
+	if len(lines) != 1+2*streams*tablesPerStream {
+		t.Fatalf("unexpected number of lines: want %d got %d", 1+2*streams*tablesPerStream, len(lines))
+	}
+	if lines[0] != "_value" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	for i := 1; i < len(lines); i += 2 {
+		if lines[i] != lines[i+1] {
+			t.Errorf("interleaved table at line %d: %q %q", i, lines[i], lines[i+1])
+		}
+	}
+}
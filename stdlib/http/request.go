@@ -0,0 +1,318 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/pkg/errors"
+)
+
+const (
+	HTTPRequestKind           = "httpRequest"
+	DefaultHTTPRequestTimeout = 1 * time.Second
+
+	// ClientDependency is the key in execute.Dependencies under which
+	// an embedder may provide the Client used to issue requests.
+	ClientDependency = "http.client"
+
+	// StatusCodeColLabel and BodyColLabel name the columns holding the
+	// response status code and body. Every response header is added as
+	// an additional string column named after its canonical header key.
+	StatusCodeColLabel = "statusCode"
+	BodyColLabel       = "body"
+)
+
+// Client is the interface used to issue HTTP requests.
+// It is satisfied by *http.Client.
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+func init() {
+	requestSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url":     semantic.String,
+			"headers": semantic.Object,
+			"timeout": semantic.Duration,
+		},
+		Required: semantic.LabelSet{"url"},
+		Return:   flux.TableObjectType,
+	}
+	requestWithDataSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url":     semantic.String,
+			"headers": semantic.Object,
+			"data":    semantic.String,
+			"timeout": semantic.Duration,
+		},
+		Required: semantic.LabelSet{"url"},
+		Return:   flux.TableObjectType,
+	}
+
+	flux.RegisterPackageValue("http", "get", flux.FunctionValue(HTTPRequestKind, newCreateHTTPRequestOpSpec(http.MethodGet), requestSignature))
+	flux.RegisterPackageValue("http", "delete", flux.FunctionValue(HTTPRequestKind, newCreateHTTPRequestOpSpec(http.MethodDelete), requestSignature))
+	flux.RegisterPackageValue("http", "post", flux.FunctionValue(HTTPRequestKind, newCreateHTTPRequestOpSpec(http.MethodPost), requestWithDataSignature))
+	flux.RegisterPackageValue("http", "put", flux.FunctionValue(HTTPRequestKind, newCreateHTTPRequestOpSpec(http.MethodPut), requestWithDataSignature))
+	flux.RegisterOpSpec(HTTPRequestKind, newHTTPRequestOp)
+	plan.RegisterProcedureSpec(HTTPRequestKind, newHTTPRequestProcedure, HTTPRequestKind)
+	execute.RegisterSource(HTTPRequestKind, createHTTPRequestSource)
+}
+
+// HTTPRequestOpSpec describes a single HTTP request whose response is returned as a table.
+type HTTPRequestOpSpec struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Data    string            `json:"data"`
+	Timeout time.Duration     `json:"timeout"`
+}
+
+func newCreateHTTPRequestOpSpec(method string) flux.CreateOperationSpec {
+	return func(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+		spec := &HTTPRequestOpSpec{Method: method}
+		if err := spec.ReadArgs(args); err != nil {
+			return nil, err
+		}
+		return spec, nil
+	}
+}
+
+// ReadArgs loads a flux.Arguments into HTTPRequestOpSpec.
+// If the timeout isn't set, it defaults to DefaultHTTPRequestTimeout.
+// The User-Agent header defaults to DefaultToHTTPUserAgent.
+func (o *HTTPRequestOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	o.URL, err = args.GetRequiredString("url")
+	if err != nil {
+		return err
+	}
+	u, err := url.ParseRequestURI(o.URL)
+	if err != nil {
+		return err
+	}
+	if !(u.Scheme == "https" || u.Scheme == "http") {
+		return fmt.Errorf("scheme must be http or https but was %s", u.Scheme)
+	}
+
+	o.Headers = map[string]string{
+		"User-Agent": DefaultToHTTPUserAgent,
+	}
+	headers, err := readStringObject(args, "headers")
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		o.Headers[k] = v
+	}
+
+	if data, ok, err := args.GetString("data"); err != nil {
+		return err
+	} else if ok {
+		o.Data = data
+	}
+
+	timeout, ok, err := args.GetDuration("timeout")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		o.Timeout = DefaultHTTPRequestTimeout
+	} else {
+		o.Timeout = time.Duration(timeout)
+	}
+	return nil
+}
+
+func newHTTPRequestOp() flux.OperationSpec {
+	return new(HTTPRequestOpSpec)
+}
+
+func (s *HTTPRequestOpSpec) Kind() flux.OperationKind {
+	return HTTPRequestKind
+}
+
+type HTTPRequestProcedureSpec struct {
+	plan.DefaultCost
+	URL     string
+	Method  string
+	Headers map[string]string
+	Data    string
+	Timeout time.Duration
+}
+
+func newHTTPRequestProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HTTPRequestOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &HTTPRequestProcedureSpec{
+		URL:     spec.URL,
+		Method:  spec.Method,
+		Headers: spec.Headers,
+		Data:    spec.Data,
+		Timeout: spec.Timeout,
+	}, nil
+}
+
+func (s *HTTPRequestProcedureSpec) Kind() plan.ProcedureKind {
+	return HTTPRequestKind
+}
+
+func (s *HTTPRequestProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(HTTPRequestProcedureSpec)
+	*ns = *s
+	ns.Headers = make(map[string]string, len(s.Headers))
+	for k, v := range s.Headers {
+		ns.Headers[k] = v
+	}
+	return ns
+}
+
+func createHTTPRequestSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*HTTPRequestProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	client, err := ClientFromDependencies(a.Dependencies())
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = toHTTPKeepAliveClient
+	}
+
+	decoder := &HTTPRequestDecoder{
+		ctx:            a.Context(),
+		client:         client,
+		spec:           spec,
+		administration: a,
+	}
+	return execute.CreateSourceFromDecoder(decoder, dsid, a)
+}
+
+// ClientFromDependencies returns the Client provided under ClientDependency,
+// or nil if none was provided.
+func ClientFromDependencies(deps execute.Dependencies) (Client, error) {
+	dep, ok := deps[ClientDependency]
+	if !ok {
+		return nil, nil
+	}
+	client, ok := dep.(Client)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s dependency type %T", ClientDependency, dep)
+	}
+	return client, nil
+}
+
+// HTTPRequestDecoder issues a single HTTP request and decodes the response into a table.
+type HTTPRequestDecoder struct {
+	ctx            context.Context
+	client         Client
+	spec           *HTTPRequestProcedureSpec
+	administration execute.Administration
+	resp           *http.Response
+	body           []byte
+}
+
+func (d *HTTPRequestDecoder) Connect() error {
+	return nil
+}
+
+func (d *HTTPRequestDecoder) Fetch() (bool, error) {
+	if d.resp != nil {
+		// The request is only ever issued once.
+		return false, nil
+	}
+	var body io.Reader
+	if d.spec.Data != "" {
+		body = strings.NewReader(d.spec.Data)
+	}
+	req, err := http.NewRequest(d.spec.Method, d.spec.URL, body)
+	if err != nil {
+		return false, err
+	}
+	for k, v := range d.spec.Headers {
+		req.Header.Set(k, v)
+	}
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if d.spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.spec.Timeout)
+		defer cancel()
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return false, errors.Wrapf(err, "%s %s", d.spec.Method, d.spec.URL)
+	}
+	defer resp.Body.Close()
+
+	// The body must be read before the request context is canceled.
+	d.body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	d.resp = resp
+	return false, nil
+}
+
+func (d *HTTPRequestDecoder) Decode() (flux.Table, error) {
+	key := execute.NewGroupKey(nil, nil)
+	b := execute.NewColListTableBuilder(key, d.administration.Allocator())
+
+	statusIdx, err := b.AddCol(flux.ColMeta{Label: StatusCodeColLabel, Type: flux.TInt})
+	if err != nil {
+		return nil, err
+	}
+	bodyIdx, err := b.AddCol(flux.ColMeta{Label: BodyColLabel, Type: flux.TString})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(d.resp.Header))
+	for name := range d.resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	headerIdxs := make([]int, len(names))
+	for i, name := range names {
+		if headerIdxs[i], err = b.AddCol(flux.ColMeta{Label: name, Type: flux.TString}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.AppendInt(statusIdx, int64(d.resp.StatusCode)); err != nil {
+		return nil, err
+	}
+	if err := b.AppendString(bodyIdx, string(d.body)); err != nil {
+		return nil, err
+	}
+	for i, name := range names {
+		if err := b.AppendString(headerIdxs[i], strings.Join(d.resp.Header[name], ", ")); err != nil {
+			return nil, err
+		}
+	}
+	return b.Table()
+}
+
+func (d *HTTPRequestDecoder) Close() error {
+	return nil
+}
@@ -0,0 +1,200 @@
+package http_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	fhttp "github.com/influxdata/flux/stdlib/http"
+)
+
+func TestHTTPRequest_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "get no url",
+			Raw:     `import "http" http.get()`,
+			WantErr: true,
+		},
+		{
+			Name:    "get bad scheme",
+			Raw:     `import "http" http.get(url: "ftp://localhost")`,
+			WantErr: true,
+		},
+		{
+			Name:    "get non-string header",
+			Raw:     `import "http" http.get(url: "http://localhost", headers: {"X-Count": 1})`,
+			WantErr: true,
+		},
+		{
+			Name: "get with headers",
+			Raw:  `import "http" http.get(url: "http://localhost:8080/status", headers: {"Accept": "application/json"})`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "httpRequest0",
+						Spec: &fhttp.HTTPRequestOpSpec{
+							URL:    "http://localhost:8080/status",
+							Method: "GET",
+							Headers: map[string]string{
+								"Accept":     "application/json",
+								"User-Agent": fhttp.DefaultToHTTPUserAgent,
+							},
+							Timeout: fhttp.DefaultHTTPRequestTimeout,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "post with data",
+			Raw:  `import "http" http.post(url: "https://localhost/alert", data: "{\"level\":\"crit\"}", timeout: 5s)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "httpRequest0",
+						Spec: &fhttp.HTTPRequestOpSpec{
+							URL:    "https://localhost/alert",
+							Method: "POST",
+							Headers: map[string]string{
+								"User-Agent": fhttp.DefaultToHTTPUserAgent,
+							},
+							Data:    `{"level":"crit"}`,
+							Timeout: 5 * time.Second,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestHTTPRequestOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"httpRequest","kind":"httpRequest","spec":{"url":"http://localhost","method":"POST","headers":{"Content-Type":"text/plain"},"data":"hello","timeout":1000000000}}`)
+	op := &flux.Operation{
+		ID: "httpRequest",
+		Spec: &fhttp.HTTPRequestOpSpec{
+			URL:     "http://localhost",
+			Method:  "POST",
+			Headers: map[string]string{"Content-Type": "text/plain"},
+			Data:    "hello",
+			Timeout: time.Second,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+// countingClient wraps a Client and counts the requests made through it.
+type countingClient struct {
+	client fhttp.Client
+	n      int
+}
+
+func (c *countingClient) Do(req *http.Request) (*http.Response, error) {
+	c.n++
+	return c.client.Do(req)
+}
+
+func TestHTTPRequest_Run(t *testing.T) {
+	var gotMethod, gotBody, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotHeader = r.Header.Get("X-Token")
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		gotBody = string(body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Date", "Mon, 01 Jan 2018 00:00:00 GMT")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("queued"))
+	}))
+	defer server.Close()
+
+	client := &countingClient{client: server.Client()}
+	querier := &querytest.Querier{
+		C: controltest.New(control.New(control.Config{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+			ExecutorDependencies: execute.Dependencies{
+				fhttp.ClientDependency: client,
+			},
+		})),
+	}
+
+	compiler := lang.FluxCompiler{
+		Query: `import "http" http.post(url: "` + server.URL + `", headers: {"X-Token": "secret"}, data: "payload")`,
+	}
+	q, err := querier.C.Query(context.Background(), compiler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+
+	var got []*executetest.Table
+	for _, res := range <-q.Ready() {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			cpy, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, cpy)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "statusCode", Type: flux.TInt},
+			{Label: "body", Type: flux.TString},
+			{Label: "Content-Length", Type: flux.TString},
+			{Label: "Content-Type", Type: flux.TString},
+			{Label: "Date", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{int64(202), "queued", "6", "text/plain", "Mon, 01 Jan 2018 00:00:00 GMT"},
+		},
+	}}
+	executetest.NormalizeTables(got)
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+
+	if client.n != 1 {
+		t.Errorf("expected dependency client to be used once, got %d requests", client.n)
+	}
+	if gotMethod != "POST" {
+		t.Errorf("unexpected method: %s", gotMethod)
+	}
+	if gotBody != "payload" {
+		t.Errorf("unexpected body: %s", gotBody)
+	}
+	if gotHeader != "secret" {
+		t.Errorf("unexpected X-Token header: %s", gotHeader)
+	}
+}
@@ -1,10 +1,12 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -15,7 +17,7 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
-	"github.com/influxdata/flux/internal/pkg/syncutil"
+	"github.com/influxdata/flux/internal/lineprotocol"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
@@ -24,20 +26,33 @@ import (
 )
 
 const (
-	ToHTTPKind           = "toHTTP"
-	DefaultToHTTPTimeout = 1 * time.Second
+	ToHTTPKind                 = "toHTTP"
+	DefaultToHTTPTimeout       = 1 * time.Second
+	DefaultToHTTPRetryInterval = 100 * time.Millisecond
+	DefaultToHTTPMaxRetries    = 0
+
+	// LineProtocolEncoding and JSONEncoding are the supported body encodings of http.to.
+	LineProtocolEncoding = "lineprotocol"
+	JSONEncoding         = "json"
 )
 
 func init() {
 	toHTTPSignature := flux.FunctionSignature(
 		map[string]semantic.PolyType{
-			"url":          semantic.String,
-			"method":       semantic.String,
-			"name":         semantic.String,
-			"timeout":      semantic.Duration,
-			"timeColumn":   semantic.String,
-			"tagColumns":   semantic.NewArrayPolyType(semantic.String),
-			"valueColumns": semantic.NewArrayPolyType(semantic.String),
+			"url":           semantic.String,
+			"method":        semantic.String,
+			"name":          semantic.String,
+			"nameColumn":    semantic.String,
+			"headers":       semantic.Object,
+			"urlParams":     semantic.Object,
+			"timeout":       semantic.Duration,
+			"timeColumn":    semantic.String,
+			"tagColumns":    semantic.NewArrayPolyType(semantic.String),
+			"valueColumns":  semantic.NewArrayPolyType(semantic.String),
+			"encoding":      semantic.String,
+			"batchSize":     semantic.Int,
+			"maxRetries":    semantic.Int,
+			"retryInterval": semantic.Duration,
 		},
 		[]string{"url"},
 	)
@@ -76,23 +91,29 @@ var toHTTPKeepAliveClient = newToHTTPClient()
 type innerToHTTPOpSpec ToHTTPOpSpec
 
 type ToHTTPOpSpec struct {
-	URL          string            `json:"url"`
-	Method       string            `json:"method"` // default behavior should be POST
-	Name         string            `json:"name"`
-	NameColumn   string            `json:"nameColumn"` // either name or name_column must be set, if none is set try to use the "_measurement" column.
-	Headers      map[string]string `json:"headers"`    // TODO: implement Headers after bug with keys and arrays and objects is fixed (new parser implemented, with string literals as keys)
-	URLParams    map[string]string `json:"urlParams"`  // TODO: implement URLParams after bug with keys and arrays and objects is fixed (new parser implemented, with string literals as keys)
-	Timeout      time.Duration     `json:"timeout"`    // default to something reasonable if zero
-	NoKeepAlive  bool              `json:"noKeepAlive"`
-	TimeColumn   string            `json:"timeColumn"`
-	TagColumns   []string          `json:"tagColumns"`
-	ValueColumns []string          `json:"valueColumns"`
+	URL           string            `json:"url"`
+	Method        string            `json:"method"` // default behavior should be POST
+	Name          string            `json:"name"`
+	NameColumn    string            `json:"nameColumn"` // either name or name_column must be set, if none is set try to use the "_measurement" column.
+	Headers       map[string]string `json:"headers"`
+	URLParams     map[string]string `json:"urlParams"`
+	Timeout       time.Duration     `json:"timeout"` // default to something reasonable if zero
+	NoKeepAlive   bool              `json:"noKeepAlive"`
+	TimeColumn    string            `json:"timeColumn"`
+	TagColumns    []string          `json:"tagColumns"`
+	ValueColumns  []string          `json:"valueColumns"`
+	Encoding      string            `json:"encoding,omitempty"`      // either lineprotocol or json, defaults to lineprotocol
+	BatchSize     int64             `json:"batchSize,omitempty"`     // maximum number of rows per request, zero sends each table in a single request
+	MaxRetries    int64             `json:"maxRetries,omitempty"`    // number of retries on connection errors and 5xx responses
+	RetryInterval time.Duration     `json:"retryInterval,omitempty"` // initial backoff between retries, doubled after every attempt
 }
 
 // ReadArgs loads a flux.Arguments into ToHTTPOpSpec.  It sets several default values.
 // If the http method isn't set, it defaults to POST, it also uppercases the http method.
 // If the time_column isn't set, it defaults to execute.TimeColLabel.
 // If the value_column isn't set it defaults to a []string{execute.DefaultValueColLabel}.
+// If the encoding isn't set, it defaults to line protocol.
+// The Content-Type and User-Agent headers have defaults that may be overridden by the headers argument.
 func (o *ToHTTPOpSpec) ReadArgs(args flux.Arguments) error {
 	var err error
 	o.URL, err = args.GetRequiredString("url")
@@ -169,15 +190,90 @@ func (o *ToHTTPOpSpec) ReadArgs(args flux.Arguments) error {
 		sort.Strings(o.ValueColumns)
 	}
 
-	// TODO: get other headers working!
+	o.Encoding, ok, err = args.GetString("encoding")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		o.Encoding = LineProtocolEncoding
+	}
+	var contentType string
+	switch o.Encoding {
+	case LineProtocolEncoding:
+		contentType = "application/vnd.influx"
+	case JSONEncoding:
+		contentType = "application/json"
+	default:
+		return fmt.Errorf("unsupported encoding %q, must be one of %q or %q", o.Encoding, LineProtocolEncoding, JSONEncoding)
+	}
+
+	o.BatchSize, ok, err = args.GetInt("batchSize")
+	if err != nil {
+		return err
+	}
+	if o.BatchSize < 0 {
+		return errors.New("batchSize must not be negative")
+	}
+
+	o.MaxRetries, ok, err = args.GetInt("maxRetries")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		o.MaxRetries = DefaultToHTTPMaxRetries
+	}
+	if o.MaxRetries < 0 {
+		return errors.New("maxRetries must not be negative")
+	}
+
+	retryInterval, ok, err := args.GetDuration("retryInterval")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		o.RetryInterval = DefaultToHTTPRetryInterval
+	} else {
+		o.RetryInterval = time.Duration(retryInterval)
+	}
+
 	o.Headers = map[string]string{
-		"Content-Type": "application/vnd.influx",
+		"Content-Type": contentType,
 		"User-Agent":   DefaultToHTTPUserAgent,
 	}
+	headers, err := readStringObject(args, "headers")
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		o.Headers[k] = v
+	}
 
+	o.URLParams, err = readStringObject(args, "urlParams")
 	return err
 }
 
+// readStringObject reads the named object argument into a map.
+// All of the object's properties must be strings.
+// It returns a nil map if the argument is absent.
+func readStringObject(args flux.Arguments, name string) (map[string]string, error) {
+	obj, ok, err := args.GetObject(name)
+	if err != nil || !ok {
+		return nil, err
+	}
+	m := make(map[string]string, obj.Len())
+	obj.Range(func(k string, v values.Value) {
+		if err != nil {
+			return
+		}
+		if v.Type() != semantic.String {
+			err = fmt.Errorf("%s object contains non-string value of type %s for key %q", name, v.Type(), k)
+			return
+		}
+		m[k] = v.Str()
+	})
+	return m, err
+}
+
 func createToHTTPOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
 	if err := a.AddParentFromArgs(args); err != nil {
 		return nil, err
@@ -221,17 +317,21 @@ func (o *ToHTTPProcedureSpec) Copy() plan.ProcedureSpec {
 	s := o.Spec
 	res := &ToHTTPProcedureSpec{
 		Spec: &ToHTTPOpSpec{
-			URL:          s.URL,
-			Method:       s.Method,
-			Name:         s.Name,
-			NameColumn:   s.NameColumn,
-			Headers:      make(map[string]string, len(s.Headers)),
-			URLParams:    make(map[string]string, len(s.URLParams)),
-			Timeout:      s.Timeout,
-			NoKeepAlive:  s.NoKeepAlive,
-			TimeColumn:   s.TimeColumn,
-			TagColumns:   append([]string(nil), s.TagColumns...),
-			ValueColumns: append([]string(nil), s.ValueColumns...),
+			URL:           s.URL,
+			Method:        s.Method,
+			Name:          s.Name,
+			NameColumn:    s.NameColumn,
+			Headers:       make(map[string]string, len(s.Headers)),
+			URLParams:     make(map[string]string, len(s.URLParams)),
+			Timeout:       s.Timeout,
+			NoKeepAlive:   s.NoKeepAlive,
+			TimeColumn:    s.TimeColumn,
+			TagColumns:    append([]string(nil), s.TagColumns...),
+			ValueColumns:  append([]string(nil), s.ValueColumns...),
+			Encoding:      s.Encoding,
+			BatchSize:     s.BatchSize,
+			MaxRetries:    s.MaxRetries,
+			RetryInterval: s.RetryInterval,
 		},
 	}
 	for k, v := range s.Headers {
@@ -258,98 +358,138 @@ func createToHTTPTransformation(id execute.DatasetID, mode execute.AccumulationM
 	}
 	cache := execute.NewTableBuilderCache(a.Allocator())
 	d := execute.NewDataset(id, mode, cache)
-	t := NewToHTTPTransformation(d, cache, s)
+	t := NewToHTTPTransformation(a.Context(), d, cache, s)
+	client, err := ClientFromDependencies(a.Dependencies())
+	if err != nil {
+		return nil, nil, err
+	}
+	if client != nil {
+		t.client = client
+	}
 	return t, d, nil
 }
 
 type ToHTTPTransformation struct {
-	d     execute.Dataset
-	cache execute.TableBuilderCache
-	spec  *ToHTTPProcedureSpec
+	ctx    context.Context
+	d      execute.Dataset
+	cache  execute.TableBuilderCache
+	spec   *ToHTTPProcedureSpec
+	client Client
 }
 
 func (t *ToHTTPTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
 	return t.d.RetractTable(key)
 }
 
-func NewToHTTPTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ToHTTPProcedureSpec) *ToHTTPTransformation {
+func NewToHTTPTransformation(ctx context.Context, d execute.Dataset, cache execute.TableBuilderCache, spec *ToHTTPProcedureSpec) *ToHTTPTransformation {
+	var client Client = toHTTPKeepAliveClient
+	if spec.Spec.NoKeepAlive {
+		client = newToHTTPClient()
+	}
 	return &ToHTTPTransformation{
-		d:     d,
-		cache: cache,
-		spec:  spec,
+		ctx:    ctx,
+		d:      d,
+		cache:  cache,
+		spec:   spec,
+		client: client,
 	}
 }
 
-type toHttpMetric struct {
-	tags   []*protocol.Tag
-	fields []*protocol.Field
-	name   string
-	t      time.Time
+// toHTTPBodyEncoder accumulates metrics into the body of a single request.
+type toHTTPBodyEncoder interface {
+	Encode(m protocol.Metric) error
+	// Body returns the encoded body of all metrics since the last Reset.
+	Body() ([]byte, error)
+	Reset()
 }
 
-func (m *toHttpMetric) TagList() []*protocol.Tag {
-	return m.tags
-}
-func (m *toHttpMetric) FieldList() []*protocol.Field {
-	return m.fields
+func newToHTTPBodyEncoder(encoding string) (toHTTPBodyEncoder, error) {
+	switch encoding {
+	case LineProtocolEncoding, "":
+		enc := new(lineProtocolBodyEncoder)
+		enc.e = lineprotocol.NewEncoder(&enc.buf)
+		return enc, nil
+	case JSONEncoding:
+		return new(jsonBodyEncoder), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
 }
 
-func (m *toHttpMetric) truncateTagsAndFields() {
-	m.fields = m.fields[:0]
-	m.tags = m.tags[:0]
+type lineProtocolBodyEncoder struct {
+	buf bytes.Buffer
+	e   *protocol.Encoder
 }
 
-func (m *toHttpMetric) Name() string {
-	return m.name
+func (e *lineProtocolBodyEncoder) Encode(m protocol.Metric) error {
+	_, err := e.e.Encode(m)
+	return err
 }
 
-func (m *toHttpMetric) Time() time.Time {
-	return m.t
+func (e *lineProtocolBodyEncoder) Body() ([]byte, error) {
+	return e.buf.Bytes(), nil
 }
 
-type idxType struct {
-	Idx  int
-	Type flux.ColType
+func (e *lineProtocolBodyEncoder) Reset() {
+	e.buf.Reset()
 }
 
-func (t *ToHTTPTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
-	pr, pw := io.Pipe() // TODO: replce the pipe with something faster
-	m := &toHttpMetric{}
-	e := protocol.NewEncoder(pw)
-	e.FailOnFieldErr(true)
-	e.SetFieldSortOrder(protocol.SortFields)
-	cols := tbl.Cols()
-	labels := make(map[string]idxType, len(cols))
-	for i, col := range cols {
-		labels[col.Label] = idxType{Idx: i, Type: col.Type}
-	}
+// jsonMetric is the JSON representation of a single metric.
+// The batch format {"metrics": [...]} matches the one used by Telegraf.
+type jsonMetric struct {
+	Name      string                 `json:"name"`
+	Tags      map[string]string      `json:"tags"`
+	Fields    map[string]interface{} `json:"fields"`
+	Timestamp int64                  `json:"timestamp"`
+}
 
-	// do time
-	timeColLabel := t.spec.Spec.TimeColumn
-	timeColIdx, ok := labels[timeColLabel]
+type jsonBodyEncoder struct {
+	Metrics []jsonMetric `json:"metrics"`
+}
 
-	if !ok {
-		return errors.New("Could not get time column")
+func (e *jsonBodyEncoder) Encode(m protocol.Metric) error {
+	jm := jsonMetric{
+		Name:      m.Name(),
+		Tags:      make(map[string]string, len(m.TagList())),
+		Fields:    make(map[string]interface{}, len(m.FieldList())),
+		Timestamp: m.Time().UnixNano(),
 	}
-	if timeColIdx.Type != flux.TTime {
-		return fmt.Errorf("column %s is not of type %s", timeColLabel, timeColIdx.Type)
+	for _, tag := range m.TagList() {
+		jm.Tags[tag.Key] = tag.Value
 	}
-	var measurementNameCol string
-	if t.spec.Spec.Name == "" {
-		measurementNameCol = t.spec.Spec.NameColumn
+	for _, field := range m.FieldList() {
+		if t, ok := field.Value.(values.Time); ok {
+			jm.Fields[field.Key] = t.Time().Format(time.RFC3339Nano)
+			continue
+		}
+		jm.Fields[field.Key] = field.Value
 	}
+	e.Metrics = append(e.Metrics, jm)
+	return nil
+}
 
-	// check if each col is a tag or value and cache this value for the loop
-	colMetadatas := tbl.Cols()
-	isTag := make([]bool, len(colMetadatas))
-	isValue := make([]bool, len(colMetadatas))
+func (e *jsonBodyEncoder) Body() ([]byte, error) {
+	return json.Marshal(e)
+}
 
-	for i, col := range colMetadatas {
-		valIdx := sort.SearchStrings(t.spec.Spec.ValueColumns, col.Label)
-		isValue[i] = valIdx < len(t.spec.Spec.ValueColumns) && t.spec.Spec.ValueColumns[valIdx] == col.Label
+func (e *jsonBodyEncoder) Reset() {
+	e.Metrics = e.Metrics[:0]
+}
 
-		tagIdx := sort.SearchStrings(t.spec.Spec.TagColumns, col.Label)
-		isTag[i] = tagIdx < len(t.spec.Spec.TagColumns) && t.spec.Spec.TagColumns[tagIdx] == col.Label
+func (t *ToHTTPTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	e, err := newToHTTPBodyEncoder(t.spec.Spec.Encoding)
+	if err != nil {
+		return err
+	}
+	m, err := lineprotocol.NewRowMetric(tbl.Cols(), lineprotocol.RowMetricConfig{
+		Name:         t.spec.Spec.Name,
+		NameColumn:   t.spec.Spec.NameColumn,
+		TimeColumn:   t.spec.Spec.TimeColumn,
+		TagColumns:   t.spec.Spec.TagColumns,
+		ValueColumns: t.spec.Spec.ValueColumns,
+	})
+	if err != nil {
+		return err
 	}
 
 	builder, new := t.cache.TableBuilder(tbl.Key())
@@ -359,91 +499,136 @@ func (t *ToHTTPTransformation) Process(id execute.DatasetID, tbl flux.Table) err
 		}
 	}
 
-	var wg syncutil.WaitGroup
-	wg.Do(func() error {
-		m.name = t.spec.Spec.Name
-		err := tbl.Do(func(er flux.ColReader) error {
-			l := er.Len()
-			for i := 0; i < l; i++ {
-				m.truncateTagsAndFields()
-				for j, col := range er.Cols() {
-					switch {
-					case col.Label == timeColLabel:
-						m.t = values.Time(er.Times(j).Value(i)).Time()
-					case measurementNameCol != "" && measurementNameCol == col.Label:
-						if col.Type != flux.TString {
-							return errors.New("invalid type for measurement column")
-						}
-						m.name = er.Strings(j).ValueString(i)
-					case isTag[j]:
-						if col.Type != flux.TString {
-							return errors.New("invalid type for tag column")
-						}
-						m.tags = append(m.tags, &protocol.Tag{Key: col.Label, Value: er.Strings(j).ValueString(i)})
-
-					case isValue[j]:
-						switch col.Type {
-						case flux.TFloat:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Floats(j).Value(i)})
-						case flux.TInt:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Ints(j).Value(i)})
-						case flux.TUInt:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.UInts(j).Value(i)})
-						case flux.TString:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Strings(j).ValueString(i)})
-						case flux.TTime:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: values.Time(er.Times(j).Value(i))})
-						case flux.TBool:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Bools(j).Value(i)})
-						default:
-							return fmt.Errorf("invalid type for column %s", col.Label)
-						}
-					}
-				}
-				_, err := e.Encode(m)
-				if err != nil {
-					return err
-				}
+	reqURL, err := t.requestURL()
+	if err != nil {
+		return err
+	}
 
-				if err := execute.AppendRecord(i, er, builder); err != nil {
+	// batch counts the requests sent for this table and rows the rows in the pending request.
+	batch, rows := 0, int64(0)
+	flush := func() error {
+		body, err := e.Body()
+		if err != nil {
+			return err
+		}
+		batch++
+		if err := t.send(reqURL, body); err != nil {
+			return errors.Wrapf(err, "request %d for table %v", batch, tbl.Key())
+		}
+		e.Reset()
+		rows = 0
+		return nil
+	}
+
+	err = tbl.Do(func(er flux.ColReader) error {
+		l := er.Len()
+		for i := 0; i < l; i++ {
+			if err := execute.AppendRecord(i, er, builder); err != nil {
+				return err
+			}
+
+			if ok, err := m.Set(er, i); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			if err := e.Encode(m); err != nil {
+				return err
+			}
+			rows++
+
+			if t.spec.Spec.BatchSize > 0 && rows >= t.spec.Spec.BatchSize {
+				if err := flush(); err != nil {
 					return err
 				}
 			}
-			return nil
-		})
-		if e := pw.Close(); e != nil && err == nil {
-			err = e
 		}
-		return err
+		return nil
 	})
-
-	req, err := http.NewRequest(t.spec.Spec.Method, t.spec.Spec.URL, pr)
 	if err != nil {
 		return err
 	}
+	if rows > 0 || batch == 0 {
+		return flush()
+	}
+	return nil
+}
 
-	if t.spec.Spec.Timeout > 0 {
-		ctx, cancel := context.WithTimeout(context.Background(), t.spec.Spec.Timeout)
-		req = req.WithContext(ctx)
-		defer cancel()
+// requestURL returns the destination URL with the URL parameters of the spec applied.
+func (t *ToHTTPTransformation) requestURL() (string, error) {
+	if len(t.spec.Spec.URLParams) == 0 {
+		return t.spec.Spec.URL, nil
 	}
-	var resp *http.Response
-	if t.spec.Spec.NoKeepAlive {
-		resp, err = newToHTTPClient().Do(req)
-	} else {
-		resp, err = toHTTPKeepAliveClient.Do(req)
+	u, err := url.Parse(t.spec.Spec.URL)
+	if err != nil {
+		return "", err
 	}
+	q := u.Query()
+	for k, v := range t.spec.Spec.URLParams {
+		q.Set(k, v)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// send issues a request with the given body.
+// Connection errors and 5xx responses are retried up to MaxRetries times,
+// doubling the wait between attempts starting at RetryInterval.
+// Waiting stops when the query is canceled.
+func (t *ToHTTPTransformation) send(reqURL string, body []byte) error {
+	interval := t.spec.Spec.RetryInterval
+	for attempt := int64(0); ; attempt++ {
+		retry, err := t.do(reqURL, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= t.spec.Spec.MaxRetries {
+			return errors.Wrapf(err, "failed after %d attempt(s)", attempt+1)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-t.ctx.Done():
+			timer.Stop()
+			return errors.Wrapf(t.ctx.Err(), "canceled after %d attempt(s)", attempt+1)
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+// maxErrorBodySize limits how much of a failed response body is included in errors.
+const maxErrorBodySize = 1024
+
+// do issues a single request and reports whether a failure may be retried.
+func (t *ToHTTPTransformation) do(reqURL string, body []byte) (bool, error) {
+	req, err := http.NewRequest(t.spec.Spec.Method, reqURL, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return false, err
 	}
-	if err := wg.Wait(); err != nil {
-		return err
+	for k, v := range t.spec.Spec.Headers {
+		req.Header.Set(k, v)
 	}
-	if err := resp.Body.Close(); err != nil {
-		return err
+
+	ctx := t.ctx
+	if t.spec.Spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.spec.Spec.Timeout)
+		defer cancel()
 	}
+	req = req.WithContext(ctx)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
 
-	return req.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, err := io.Copy(ioutil.Discard, resp.Body)
+		return false, err
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	return resp.StatusCode >= 500, err
 }
 
 func (t *ToHTTPTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/flux"
 	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
 	"github.com/influxdata/flux/execute"
@@ -45,6 +47,8 @@ from(bucket:"mybucket") |> http.to(url: "https://localhost:8081", name:"series1"
 								"Content-Type": "application/vnd.influx",
 								"User-Agent":   "fluxd/dev",
 							},
+							Encoding:      fhttp.LineProtocolEncoding,
+							RetryInterval: fhttp.DefaultToHTTPRetryInterval,
 						},
 					},
 				},
@@ -53,6 +57,71 @@ from(bucket:"mybucket") |> http.to(url: "https://localhost:8081", name:"series1"
 				},
 			},
 		},
+		{
+			Name: "headers, url params, json encoding and retries",
+			Raw: `
+import "http"
+from(bucket:"mybucket") |> http.to(
+	url: "https://localhost:8081/write",
+	headers: {"Authorization": "Token abc", "Content-Type": "application/x-custom"},
+	urlParams: {"db": "telegraf"},
+	encoding: "json",
+	batchSize: 100,
+	maxRetries: 3,
+	retryInterval: 1s,
+)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "toHTTP1",
+						Spec: &fhttp.ToHTTPOpSpec{
+							URL:          "https://localhost:8081/write",
+							NameColumn:   "_measurement",
+							Method:       "POST",
+							Timeout:      fhttp.DefaultToHTTPTimeout,
+							TimeColumn:   execute.DefaultTimeColLabel,
+							ValueColumns: []string{execute.DefaultValueColLabel},
+							Headers: map[string]string{
+								"Authorization": "Token abc",
+								"Content-Type":  "application/x-custom",
+								"User-Agent":    "fluxd/dev",
+							},
+							URLParams: map[string]string{
+								"db": "telegraf",
+							},
+							Encoding:      fhttp.JSONEncoding,
+							BatchSize:     100,
+							MaxRetries:    3,
+							RetryInterval: time.Second,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toHTTP1"},
+				},
+			},
+		},
+		{
+			Name:    "unknown encoding",
+			Raw:     `import "http" from(bucket:"mybucket") |> http.to(url: "https://localhost:8081", encoding: "xml")`,
+			WantErr: true,
+		},
+		{
+			Name:    "non-string header",
+			Raw:     `import "http" from(bucket:"mybucket") |> http.to(url: "https://localhost:8081", headers: {"X-Count": 1})`,
+			WantErr: true,
+		},
+		{
+			Name:    "negative batch size",
+			Raw:     `import "http" from(bucket:"mybucket") |> http.to(url: "https://localhost:8081", batchSize: -1)`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -560,7 +629,7 @@ one_table_w_tag,fred=elevendyone _value=4 41
 				tc.want.Table,
 				nil,
 				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
-					return fhttp.NewToHTTPTransformation(d, c, tc.spec)
+					return fhttp.NewToHTTPTransformation(context.Background(), d, c, tc.spec)
 				},
 			)
 			wg.Wait() // wait till we are done getting the data back
@@ -572,3 +641,210 @@ one_table_w_tag,fred=elevendyone _value=4 41
 		})
 	}
 }
+
+func TestToHTTP_ProcessOptions(t *testing.T) {
+	type request struct {
+		method string
+		query  string
+		header http.Header
+		body   string
+	}
+	table := func() flux.Table {
+		return &executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "host", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(11), "cpu", 2.0, "a"},
+				{execute.Time(21), "cpu", 1.5, "b"},
+				{execute.Time(31), "cpu", 3.0, "a"},
+			},
+		}
+	}
+	testCases := []struct {
+		name string
+		spec fhttp.ToHTTPOpSpec
+		// statuses are returned in order, the last one repeats.
+		statuses []int
+		want     []request
+		wantErr  bool
+	}{
+		{
+			name: "headers and url params",
+			spec: fhttp.ToHTTPOpSpec{
+				Method:       "POST",
+				Headers:      map[string]string{"Authorization": "Token abc"},
+				URLParams:    map[string]string{"db": "telegraf", "precision": "ns"},
+				TagColumns:   []string{"host"},
+				ValueColumns: []string{"_value"},
+			},
+			statuses: []int{http.StatusNoContent},
+			want: []request{{
+				method: "POST",
+				query:  "db=telegraf&precision=ns",
+				header: http.Header{"Authorization": []string{"Token abc"}},
+				body:   "cpu,host=a _value=2 11\ncpu,host=b _value=1.5 21\ncpu,host=a _value=3 31\n",
+			}},
+		},
+		{
+			name: "json encoding in batches",
+			spec: fhttp.ToHTTPOpSpec{
+				Method:       "POST",
+				TagColumns:   []string{"host"},
+				ValueColumns: []string{"_value"},
+				Encoding:     fhttp.JSONEncoding,
+				BatchSize:    2,
+			},
+			statuses: []int{http.StatusOK},
+			want: []request{
+				{
+					method: "POST",
+					body:   `{"metrics":[{"name":"cpu","tags":{"host":"a"},"fields":{"_value":2},"timestamp":11},{"name":"cpu","tags":{"host":"b"},"fields":{"_value":1.5},"timestamp":21}]}`,
+				},
+				{
+					method: "POST",
+					body:   `{"metrics":[{"name":"cpu","tags":{"host":"a"},"fields":{"_value":3},"timestamp":31}]}`,
+				},
+			},
+		},
+		{
+			name: "retry on server error",
+			spec: fhttp.ToHTTPOpSpec{
+				Method:       "POST",
+				ValueColumns: []string{"_value"},
+				BatchSize:    3,
+				MaxRetries:   2,
+			},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+			want: []request{
+				{method: "POST", body: "cpu _value=2 11\ncpu _value=1.5 21\ncpu _value=3 31\n"},
+				{method: "POST", body: "cpu _value=2 11\ncpu _value=1.5 21\ncpu _value=3 31\n"},
+				{method: "POST", body: "cpu _value=2 11\ncpu _value=1.5 21\ncpu _value=3 31\n"},
+			},
+		},
+		{
+			name: "retries exhausted",
+			spec: fhttp.ToHTTPOpSpec{
+				Method:       "POST",
+				ValueColumns: []string{"_value"},
+				MaxRetries:   1,
+			},
+			statuses: []int{http.StatusBadGateway},
+			want: []request{
+				{method: "POST", body: "cpu _value=2 11\ncpu _value=1.5 21\ncpu _value=3 31\n"},
+				{method: "POST", body: "cpu _value=2 11\ncpu _value=1.5 21\ncpu _value=3 31\n"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no retry on client error",
+			spec: fhttp.ToHTTPOpSpec{
+				Method:       "POST",
+				ValueColumns: []string{"_value"},
+				MaxRetries:   3,
+			},
+			statuses: []int{http.StatusBadRequest},
+			want: []request{
+				{method: "POST", body: "cpu _value=2 11\ncpu _value=1.5 21\ncpu _value=3 31\n"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got []request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				req := request{
+					method: r.Method,
+					query:  r.URL.RawQuery,
+					body:   string(body),
+				}
+				if v := r.Header.Get("Authorization"); v != "" {
+					req.header = http.Header{"Authorization": []string{v}}
+				}
+				got = append(got, req)
+				status := tc.statuses[len(tc.statuses)-1]
+				if len(got) <= len(tc.statuses) {
+					status = tc.statuses[len(got)-1]
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			spec := tc.spec
+			spec.URL = server.URL
+			spec.Timeout = 5 * time.Second
+			spec.TimeColumn = execute.DefaultTimeColLabel
+			spec.NameColumn = "_measurement"
+			spec.RetryInterval = time.Millisecond
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			tr := fhttp.NewToHTTPTransformation(context.Background(), d, c, &fhttp.ToHTTPProcedureSpec{Spec: &spec})
+			err := tr.Process(executetest.RandomDatasetID(), table())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(tc.want, got, cmp.AllowUnexported(request{})) {
+				t.Errorf("unexpected requests -want/+got\n%s", cmp.Diff(tc.want, got, cmp.AllowUnexported(request{})))
+			}
+		})
+	}
+}
+
+func TestToHTTP_RetryCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	spec := fhttp.ToHTTPOpSpec{
+		URL:           server.URL,
+		Method:        "POST",
+		TimeColumn:    execute.DefaultTimeColLabel,
+		NameColumn:    "_measurement",
+		ValueColumns:  []string{"_value"},
+		MaxRetries:    3,
+		RetryInterval: time.Hour,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	tr := fhttp.NewToHTTPTransformation(ctx, d, c, &fhttp.ToHTTPProcedureSpec{Spec: &spec})
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- tr.Process(executetest.RandomDatasetID(), &executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(11), "cpu", 2.0},
+			},
+		})
+	}()
+	cancel()
+	select {
+	case err := <-errC:
+		if err == nil {
+			t.Fatal("expected an error after cancellation")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("retry wait did not stop on cancellation")
+	}
+}
@@ -0,0 +1,151 @@
+package embedded
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/values"
+)
+
+// blockHeaderSize is the size of an encoded blockHeader.
+const blockHeaderSize = 4 + 8 + 8 + 4
+
+// blockHeader precedes the payload of every block.
+// The payload holds count little endian times followed by count values:
+// little endian floats, integers or unsigned integers, one byte per boolean,
+// or a uvarint length followed by the bytes of every string.
+type blockHeader struct {
+	count uint32
+	min   int64
+	max   int64
+	size  uint32
+}
+
+func (h blockHeader) encode(buf []byte) []byte {
+	var b [blockHeaderSize]byte
+	binary.LittleEndian.PutUint32(b[0:], h.count)
+	binary.LittleEndian.PutUint64(b[4:], uint64(h.min))
+	binary.LittleEndian.PutUint64(b[12:], uint64(h.max))
+	binary.LittleEndian.PutUint32(b[20:], h.size)
+	return append(buf, b[:]...)
+}
+
+// decode reads the header at the start of data and returns the remaining data.
+func (h *blockHeader) decode(data []byte) ([]byte, error) {
+	if len(data) < blockHeaderSize {
+		return nil, errors.New("short block header")
+	}
+	h.count = binary.LittleEndian.Uint32(data[0:])
+	h.min = int64(binary.LittleEndian.Uint64(data[4:]))
+	h.max = int64(binary.LittleEndian.Uint64(data[12:]))
+	h.size = binary.LittleEndian.Uint32(data[20:])
+	data = data[blockHeaderSize:]
+	if uint64(len(data)) < uint64(h.size) {
+		return nil, errors.New("short block payload")
+	}
+	return data, nil
+}
+
+// encodeBlock encodes the sorted times and the values of type typ as a block.
+func encodeBlock(typ flux.ColType, times []int64, vs []values.Value) ([]byte, error) {
+	payload := make([]byte, 0, 16*len(times))
+	var b [binary.MaxVarintLen64]byte
+	for _, t := range times {
+		binary.LittleEndian.PutUint64(b[:], uint64(t))
+		payload = append(payload, b[:8]...)
+	}
+	for _, v := range vs {
+		switch typ {
+		case flux.TFloat:
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(v.Float()))
+			payload = append(payload, b[:8]...)
+		case flux.TInt:
+			binary.LittleEndian.PutUint64(b[:], uint64(v.Int()))
+			payload = append(payload, b[:8]...)
+		case flux.TUInt:
+			binary.LittleEndian.PutUint64(b[:], v.UInt())
+			payload = append(payload, b[:8]...)
+		case flux.TBool:
+			if v.Bool() {
+				payload = append(payload, 1)
+			} else {
+				payload = append(payload, 0)
+			}
+		case flux.TString:
+			n := binary.PutUvarint(b[:], uint64(len(v.Str())))
+			payload = append(payload, b[:n]...)
+			payload = append(payload, v.Str()...)
+		default:
+			return nil, fmt.Errorf("unsupported block type %v", typ)
+		}
+	}
+	if len(payload) > math.MaxUint32 {
+		return nil, errors.New("block is too large")
+	}
+
+	h := blockHeader{
+		count: uint32(len(times)),
+		size:  uint32(len(payload)),
+	}
+	if len(times) > 0 {
+		h.min, h.max = times[0], times[len(times)-1]
+	}
+	return append(h.encode(make([]byte, 0, blockHeaderSize+len(payload))), payload...), nil
+}
+
+// decodeBlock decodes the times and values of type typ of the payload of a block.
+func decodeBlock(typ flux.ColType, h blockHeader, payload []byte) ([]int64, []values.Value, error) {
+	n := int(h.count)
+	if len(payload) < 8*n {
+		return nil, nil, errors.New("short block times")
+	}
+	times := make([]int64, n)
+	for i := range times {
+		times[i] = int64(binary.LittleEndian.Uint64(payload[8*i:]))
+	}
+	payload = payload[8*n:]
+
+	vs := make([]values.Value, n)
+	switch typ {
+	case flux.TFloat, flux.TInt, flux.TUInt:
+		if len(payload) != 8*n {
+			return nil, nil, errors.New("invalid block values")
+		}
+		for i := range vs {
+			u := binary.LittleEndian.Uint64(payload[8*i:])
+			switch typ {
+			case flux.TFloat:
+				vs[i] = values.NewFloat(math.Float64frombits(u))
+			case flux.TInt:
+				vs[i] = values.NewInt(int64(u))
+			default:
+				vs[i] = values.NewUInt(u)
+			}
+		}
+	case flux.TBool:
+		if len(payload) != n {
+			return nil, nil, errors.New("invalid block values")
+		}
+		for i := range vs {
+			vs[i] = values.NewBool(payload[i] != 0)
+		}
+	case flux.TString:
+		for i := range vs {
+			l, k := binary.Uvarint(payload)
+			if k <= 0 || uint64(len(payload)-k) < l {
+				return nil, nil, errors.New("invalid block values")
+			}
+			vs[i] = values.NewString(string(payload[k : k+int(l)]))
+			payload = payload[k+int(l):]
+		}
+		if len(payload) != 0 {
+			return nil, nil, errors.New("invalid block values")
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported block type %v", typ)
+	}
+	return times, vs, nil
+}
@@ -0,0 +1,308 @@
+package embedded
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const indexFile = "series.idx"
+
+// typeNames are the names of the value types of series in the index.
+var typeNames = map[flux.ColType]string{
+	flux.TFloat:  "float",
+	flux.TInt:    "integer",
+	flux.TUInt:   "unsigned",
+	flux.TString: "string",
+	flux.TBool:   "boolean",
+}
+
+// series is an entry of the index of a bucket.
+type series struct {
+	ID          uint64            `json:"id"`
+	Measurement string            `json:"measurement"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Field       string            `json:"field"`
+	Type        string            `json:"type"`
+
+	key  string
+	tags []influxdb.Tag
+	typ  flux.ColType
+}
+
+// init derives the unexported fields from the serialized ones.
+func (s *series) init() error {
+	s.typ = flux.TInvalid
+	for typ, name := range typeNames {
+		if name == s.Type {
+			s.typ = typ
+		}
+	}
+	if s.typ == flux.TInvalid {
+		return fmt.Errorf("series %d has unknown type %q", s.ID, s.Type)
+	}
+	s.tags = make([]influxdb.Tag, 0, len(s.Tags))
+	for k, v := range s.Tags {
+		s.tags = append(s.tags, influxdb.Tag{Key: k, Value: v})
+	}
+	sort.Slice(s.tags, func(i, j int) bool { return s.tags[i].Key < s.tags[j].Key })
+	s.key = seriesKey(s.Measurement, s.tags, s.Field)
+	return nil
+}
+
+// seriesKey returns the key of the series identified by a measurement, sorted tags and field.
+// Keys sort by measurement first, then by tags and finally by field,
+// a series sorts before the series that have additional tags.
+func seriesKey(measurement string, tags []influxdb.Tag, field string) string {
+	var b strings.Builder
+	b.WriteString(measurement)
+	for _, t := range tags {
+		b.WriteByte(1)
+		b.WriteString(t.Key)
+		b.WriteByte('=')
+		b.WriteString(t.Value)
+	}
+	b.WriteByte(0)
+	b.WriteString(field)
+	return b.String()
+}
+
+func (s *series) tag(key string) (string, bool) {
+	v, ok := s.Tags[key]
+	return v, ok
+}
+
+// bucket holds the index of the series of a bucket.
+type bucket struct {
+	dir    string
+	byKey  map[string]*series
+	sorted []*series
+	nextID uint64
+}
+
+// openBucket loads the index of the bucket in dir.
+func openBucket(dir string) (*bucket, error) {
+	b := &bucket{
+		dir:    dir,
+		byKey:  make(map[string]*series),
+		nextID: 1,
+	}
+	f, err := os.Open(filepath.Join(dir, indexFile))
+	if os.IsNotExist(err) {
+		return b, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		s := new(series)
+		if err := json.Unmarshal(scanner.Bytes(), s); err != nil {
+			return nil, errors.Wrap(err, "corrupt series index")
+		}
+		if err := s.init(); err != nil {
+			return nil, err
+		}
+		b.add(s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *bucket) add(s *series) {
+	b.byKey[s.key] = s
+	i := sort.Search(len(b.sorted), func(i int) bool { return b.sorted[i].key >= s.key })
+	b.sorted = append(b.sorted, nil)
+	copy(b.sorted[i+1:], b.sorted[i:])
+	b.sorted[i] = s
+	if s.ID >= b.nextID {
+		b.nextID = s.ID + 1
+	}
+}
+
+// series returns the series of the point, adding it to the index if it does not exist.
+// A series holds values of a single type.
+func (b *bucket) series(p influxdb.Point) (*series, error) {
+	if p.Measurement == "" {
+		return nil, errors.New("cannot write a point without a measurement")
+	}
+	if p.Field == "" {
+		return nil, errors.New("cannot write a point without a field")
+	}
+	if p.Value == nil || p.Value.IsNull() {
+		return nil, fmt.Errorf("cannot write a null value to field %q", p.Field)
+	}
+	typ := execute.ConvertFromKind(p.Value.Type().Nature())
+	if _, ok := typeNames[typ]; !ok {
+		return nil, fmt.Errorf("cannot write a value of type %v to field %q", p.Value.Type(), p.Field)
+	}
+	tags := append([]influxdb.Tag(nil), p.Tags...)
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	for i, t := range tags {
+		if t.Key == "" || strings.HasPrefix(t.Key, "_") {
+			return nil, fmt.Errorf("invalid tag key %q", t.Key)
+		}
+		if i > 0 && tags[i-1].Key == t.Key {
+			return nil, fmt.Errorf("duplicate tag key %q", t.Key)
+		}
+	}
+
+	key := seriesKey(p.Measurement, tags, p.Field)
+	if s, ok := b.byKey[key]; ok {
+		if s.typ != typ {
+			return nil, fmt.Errorf("field type conflict: field %q of measurement %q has type %s, cannot write %s", p.Field, p.Measurement, s.Type, typeNames[typ])
+		}
+		return s, nil
+	}
+
+	s := &series{
+		ID:          b.nextID,
+		Measurement: p.Measurement,
+		Field:       p.Field,
+		Type:        typeNames[typ],
+	}
+	if len(tags) > 0 {
+		s.Tags = make(map[string]string, len(tags))
+		for _, t := range tags {
+			s.Tags[t.Key] = t.Value
+		}
+	}
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	line, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(b.dir, indexFile), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	_, err = f.Write(append(line, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write series index")
+	}
+	b.add(s)
+	return s, nil
+}
+
+// tagKeys returns the union of the tag keys of the series, sorted.
+func (b *bucket) tagKeys() []string {
+	set := make(map[string]bool)
+	for _, s := range b.sorted {
+		for k := range s.Tags {
+			set[k] = true
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (b *bucket) blockFile(s *series) string {
+	return filepath.Join(b.dir, strconv.FormatUint(s.ID, 10)+".blk")
+}
+
+// appendBlock appends the points to the block file of the series as a single block.
+func (b *bucket) appendBlock(s *series, points []influxdb.Point) error {
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Time < points[j].Time })
+	times := make([]int64, len(points))
+	vs := make([]values.Value, len(points))
+	for i, p := range points {
+		times[i] = int64(p.Time)
+		vs[i] = p.Value
+	}
+	data, err := encodeBlock(s.typ, times, vs)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(b.blockFile(s), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to write block")
+	}
+	return nil
+}
+
+// readPoints returns the times and values of the points of the series within the bounds, sorted by time.
+// Of several points with the same time, the last written one is returned.
+func (b *bucket) readPoints(s *series, bounds execute.Bounds) ([]int64, []values.Value, error) {
+	data, err := ioutil.ReadFile(b.blockFile(s))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		times []int64
+		vs    []values.Value
+	)
+	for len(data) > 0 {
+		var h blockHeader
+		if data, err = h.decode(data); err != nil {
+			return nil, nil, errors.Wrapf(err, "corrupt block file of series %d", s.ID)
+		}
+		payload := data[:h.size]
+		data = data[h.size:]
+		if h.max < int64(bounds.Start) || h.min >= int64(bounds.Stop) {
+			continue
+		}
+		bt, bv, err := decodeBlock(s.typ, h, payload)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "corrupt block file of series %d", s.ID)
+		}
+		for i, t := range bt {
+			if bounds.Contains(values.Time(t)) {
+				times = append(times, t)
+				vs = append(vs, bv[i])
+			}
+		}
+	}
+
+	// The blocks are in write order, so a stable sort keeps the last written point of a time last.
+	idx := make([]int, len(times))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return times[idx[i]] < times[idx[j]] })
+	sortedTimes := make([]int64, 0, len(times))
+	sortedValues := make([]values.Value, 0, len(times))
+	for i, j := range idx {
+		if i+1 < len(idx) && times[idx[i+1]] == times[j] {
+			continue
+		}
+		sortedTimes = append(sortedTimes, times[j])
+		sortedValues = append(sortedValues, vs[j])
+	}
+	return sortedTimes, sortedValues, nil
+}
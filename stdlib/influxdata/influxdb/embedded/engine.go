@@ -0,0 +1,124 @@
+// Package embedded implements an on-disk influxdb.Storage for use within a single process.
+//
+// Every bucket is a directory, named by the escaped bucket name, holding an index of its series and one block file per series.
+// The index is a file of JSON lines, one per series, that assigns the series an ID and a value type.
+// The block file of a series is a sequence of columnar blocks, each holding the times and values
+// of the points of a single write sorted by time.
+// Points are read by merging the blocks that overlap the read bounds,
+// the last written value wins when several points have the same time.
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/pkg/errors"
+)
+
+// Engine is an on-disk influxdb.Storage rooted at a directory.
+// It is safe for concurrent use within a process, but not across processes.
+type Engine struct {
+	dir string
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// Open opens the engine rooted at dir, creating the directory if it does not exist.
+func Open(dir string) (*Engine, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create storage directory")
+	}
+	return &Engine{
+		dir:     dir,
+		buckets: make(map[string]*bucket),
+	}, nil
+}
+
+// Read implements influxdb.Storage.
+func (e *Engine) Read(ctx context.Context, spec influxdb.ReadSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	b, err := e.bucket(spec.Bucket, false)
+	if err != nil {
+		return nil, err
+	}
+	return newReader(b, spec, alloc).read(ctx)
+}
+
+// Write implements influxdb.Storage.
+// The points of every series are appended to its block file as a single block.
+func (e *Engine) Write(ctx context.Context, ref influxdb.BucketRef, points []influxdb.Point) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	b, err := e.bucket(ref, true)
+	if err != nil {
+		return err
+	}
+
+	// Group the points by series, preserving the order of the series and the points.
+	var order []*series
+	batches := make(map[*series][]influxdb.Point)
+	for _, p := range points {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s, err := b.series(p)
+		if err != nil {
+			return err
+		}
+		if _, ok := batches[s]; !ok {
+			order = append(order, s)
+		}
+		batches[s] = append(batches[s], p)
+	}
+	for _, s := range order {
+		if err := b.appendBlock(s, batches[s]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bucket returns the bucket referenced by ref, loading its index if needed.
+// If create is true a bucket that does not exist is created.
+func (e *Engine) bucket(ref influxdb.BucketRef, create bool) (*bucket, error) {
+	if ref.Name == "" {
+		return nil, fmt.Errorf("embedded storage identifies buckets by name, cannot use bucket ID %q", ref.ID)
+	}
+	if ref.Name == "." || ref.Name == ".." || strings.ContainsRune(ref.Name, 0) {
+		return nil, fmt.Errorf("invalid bucket name %q", ref.Name)
+	}
+	if b, ok := e.buckets[ref.Name]; ok {
+		return b, nil
+	}
+
+	// Names such as "db/rp" contain path separators, they are escaped into a single directory name.
+	dir := filepath.Join(e.dir, url.PathEscape(ref.Name))
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if !create {
+			return nil, fmt.Errorf("bucket %q not found", ref.Name)
+		}
+		if err := os.Mkdir(dir, 0755); err != nil {
+			return nil, errors.Wrapf(err, "failed to create bucket %q", ref.Name)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	b, err := openBucket(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open bucket %q", ref.Name)
+	}
+	e.buckets[ref.Name] = b
+	return b, nil
+}
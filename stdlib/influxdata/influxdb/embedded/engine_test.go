@@ -0,0 +1,409 @@
+package embedded_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/embedded"
+	"github.com/influxdata/flux/values"
+)
+
+func init() {
+	// The queries of the tests read and write the engine through from and to.
+	influxdb.RegisterStorage()
+}
+
+func openEngine(t *testing.T) (*embedded.Engine, string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "embedded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := embedded.Open(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return e, dir
+}
+
+func point(measurement, host, field string, t int64, v interface{}) influxdb.Point {
+	p := influxdb.Point{
+		Measurement: measurement,
+		Field:       field,
+		Time:        values.Time(t),
+		Value:       values.New(v),
+	}
+	if host != "" {
+		p.Tags = []influxdb.Tag{{Key: "host", Value: host}}
+	}
+	return p
+}
+
+func readTables(t *testing.T, e *embedded.Engine, spec influxdb.ReadSpec) ([]*executetest.Table, error) {
+	t.Helper()
+	tables, err := e.Read(context.Background(), spec, executetest.UnlimitedAllocator)
+	if err != nil {
+		return nil, err
+	}
+	var got []*executetest.Table
+	if err := tables.Do(func(tbl flux.Table) error {
+		cpy, err := executetest.ConvertTable(tbl)
+		if err != nil {
+			return err
+		}
+		got = append(got, cpy)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	executetest.NormalizeTables(got)
+	return got, nil
+}
+
+// (r) => r.host == "a"
+var hostIsA = &semantic.FunctionExpression{
+	Block: &semantic.FunctionBlock{
+		Parameters: &semantic.FunctionParameters{
+			List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+		},
+		Body: &semantic.BinaryExpression{
+			Operator: ast.EqualOperator,
+			Left: &semantic.MemberExpression{
+				Object:   &semantic.IdentifierExpression{Name: "r"},
+				Property: "host",
+			},
+			Right: &semantic.StringLiteral{Value: "a"},
+		},
+	},
+}
+
+func TestEngine_Read(t *testing.T) {
+	e, dir := openEngine(t)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	bucket := influxdb.BucketRef{Name: "db"}
+	if err := e.Write(ctx, bucket, []influxdb.Point{
+		point("cpu", "a", "usage", 1, 1.0),
+		point("cpu", "a", "usage", 3, 3.0),
+		point("cpu", "b", "usage", 2, 2.0),
+		point("cpu", "", "usage", 2, 20.0),
+		point("mem", "a", "free", 1, int64(10)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// The second write overlaps the first one, its value for time 3 wins.
+	if err := e.Write(ctx, bucket, []influxdb.Point{
+		point("cpu", "a", "usage", 3, 30.0),
+		point("cpu", "a", "usage", 2, 2.0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	floatTable := func(keyCols []string, tags bool, data ...[]interface{}) *executetest.Table {
+		cols := []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+		}
+		if tags {
+			cols = append(cols, flux.ColMeta{Label: "host", Type: flux.TString})
+		}
+		return &executetest.Table{KeyCols: keyCols, ColMeta: cols, Data: data}
+	}
+	seriesKey := []string{"_start", "_stop", "_field", "_measurement", "host"}
+	bounds := execute.Bounds{Start: 0, Stop: 10}
+
+	testCases := []struct {
+		name    string
+		spec    influxdb.ReadSpec
+		want    []*executetest.Table
+		wantErr bool
+	}{
+		{
+			name: "bounds",
+			spec: influxdb.ReadSpec{
+				Bucket: bucket,
+				Bounds: execute.Bounds{Start: 2, Stop: 3},
+			},
+			want: []*executetest.Table{
+				floatTable([]string{"_start", "_stop", "_field", "_measurement"}, false,
+					[]interface{}{execute.Time(2), execute.Time(3), execute.Time(2), 20.0, "usage", "cpu"},
+				),
+				floatTable(seriesKey, true,
+					[]interface{}{execute.Time(2), execute.Time(3), execute.Time(2), 2.0, "usage", "cpu", "a"},
+				),
+				floatTable(seriesKey, true,
+					[]interface{}{execute.Time(2), execute.Time(3), execute.Time(2), 2.0, "usage", "cpu", "b"},
+				),
+			},
+		},
+		{
+			name: "predicate",
+			spec: influxdb.ReadSpec{
+				Bucket:    bucket,
+				Bounds:    bounds,
+				Predicate: hostIsA,
+			},
+			want: []*executetest.Table{
+				floatTable(seriesKey, true,
+					[]interface{}{execute.Time(0), execute.Time(10), execute.Time(1), 1.0, "usage", "cpu", "a"},
+					[]interface{}{execute.Time(0), execute.Time(10), execute.Time(2), 2.0, "usage", "cpu", "a"},
+					[]interface{}{execute.Time(0), execute.Time(10), execute.Time(3), 30.0, "usage", "cpu", "a"},
+				),
+				{
+					KeyCols: seriesKey,
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), execute.Time(10), execute.Time(1), int64(10), "free", "mem", "a"},
+					},
+				},
+			},
+		},
+		{
+			name: "descending points limit and series window",
+			spec: influxdb.ReadSpec{
+				Bucket:       bucket,
+				Bounds:       bounds,
+				Descending:   true,
+				PointsLimit:  2,
+				SeriesLimit:  1,
+				SeriesOffset: 1,
+			},
+			want: []*executetest.Table{
+				floatTable(seriesKey, true,
+					[]interface{}{execute.Time(0), execute.Time(10), execute.Time(3), 30.0, "usage", "cpu", "a"},
+					[]interface{}{execute.Time(0), execute.Time(10), execute.Time(2), 2.0, "usage", "cpu", "a"},
+				),
+			},
+		},
+		{
+			name: "schema collision",
+			spec: influxdb.ReadSpec{
+				Bucket:    bucket,
+				Bounds:    bounds,
+				Predicate: hostIsA,
+				GroupMode: flux.GroupModeBy,
+				GroupKeys: []string{"host"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "group by measurement",
+			spec: influxdb.ReadSpec{
+				Bucket:    bucket,
+				Bounds:    execute.Bounds{Start: 2, Stop: 3},
+				GroupMode: flux.GroupModeBy,
+				GroupKeys: []string{"_measurement"},
+			},
+			want: []*executetest.Table{
+				floatTable([]string{"_measurement"}, true,
+					[]interface{}{execute.Time(2), execute.Time(3), execute.Time(2), 20.0, "usage", "cpu", nil},
+					[]interface{}{execute.Time(2), execute.Time(3), execute.Time(2), 2.0, "usage", "cpu", "a"},
+					[]interface{}{execute.Time(2), execute.Time(3), execute.Time(2), 2.0, "usage", "cpu", "b"},
+				),
+			},
+		},
+		{
+			name: "unknown bucket",
+			spec: influxdb.ReadSpec{
+				Bucket: influxdb.BucketRef{Name: "other"},
+				Bounds: bounds,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bucket ID",
+			spec: influxdb.ReadSpec{
+				Bucket: influxdb.BucketRef{ID: "0001"},
+				Bounds: bounds,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readTables(t, e, tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestEngine_Write(t *testing.T) {
+	e, dir := openEngine(t)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	bucket := influxdb.BucketRef{Name: "db"}
+	if err := e.Write(ctx, bucket, []influxdb.Point{
+		point("m", "", "s", 1, "x"),
+		point("m", "", "b", 1, true),
+		point("m", "", "u", 1, uint64(7)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, points := range map[string][]influxdb.Point{
+		"type conflict":     {point("m", "", "s", 2, 1.0)},
+		"null value":        {{Measurement: "m", Field: "f", Time: 1, Value: values.NewNull(semantic.Float)}},
+		"no measurement":    {point("", "", "f", 1, 1.0)},
+		"reserved tag key":  {{Measurement: "m", Tags: []influxdb.Tag{{Key: "_x", Value: "v"}}, Field: "f", Time: 1, Value: values.NewFloat(1)}},
+		"invalid bucket":    nil,
+		"duplicate tag key": {{Measurement: "m", Tags: []influxdb.Tag{{Key: "t", Value: "a"}, {Key: "t", Value: "b"}}, Field: "f", Time: 1, Value: values.NewFloat(1)}},
+	} {
+		b := bucket
+		if name == "invalid bucket" {
+			b = influxdb.BucketRef{Name: ".."}
+		}
+		if err := e.Write(ctx, b, points); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// A reopened engine reads the same points.
+	reopened, err := embedded.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readTables(t, reopened, influxdb.ReadSpec{
+		Bucket:    bucket,
+		Bounds:    execute.Bounds{Start: 0, Stop: 10},
+		GroupMode: flux.GroupModeBy,
+		GroupKeys: []string{"_field"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := func(field string, typ flux.ColType, v interface{}) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"_field"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_start", Type: flux.TTime},
+				{Label: "_stop", Type: flux.TTime},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: typ},
+				{Label: "_field", Type: flux.TString},
+				{Label: "_measurement", Type: flux.TString},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), execute.Time(10), execute.Time(1), v, field, "m"},
+			},
+		}
+	}
+	want := []*executetest.Table{
+		table("b", flux.TBool, true),
+		table("s", flux.TString, "x"),
+		table("u", flux.TUInt, uint64(7)),
+	}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestEngine_Query(t *testing.T) {
+	e, dir := openEngine(t)
+	defer os.RemoveAll(dir)
+
+	querier := controltest.New(control.New(control.Config{
+		ConcurrencyQuota:     1,
+		MemoryBytesQuota:     math.MaxInt64,
+		ExecutorDependencies: execute.Dependencies{influxdb.StorageDependency: e},
+	}))
+	query := func(q string) []*executetest.Table {
+		t.Helper()
+		qry, err := querier.Query(context.Background(), lang.FluxCompiler{Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer qry.Done()
+		var got []*executetest.Table
+		for _, res := range <-qry.Ready() {
+			if err := res.Tables().Do(func(tbl flux.Table) error {
+				cpy, err := executetest.ConvertTable(tbl)
+				if err != nil {
+					return err
+				}
+				got = append(got, cpy)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := qry.Err(); err != nil {
+			t.Fatal(err)
+		}
+		executetest.NormalizeTables(got)
+		return got
+	}
+
+	query(`import "csv"
+csv.from(csv: "
+#datatype,string,long,dateTime:RFC3339,double,string,string,string
+#group,false,false,false,false,true,true,true
+#default,_result,,,,,,
+,result,table,_time,_value,_field,_measurement,host
+,,0,2018-01-01T00:00:00Z,1.5,usage,cpu,a
+,,0,2018-01-01T00:00:10Z,2.5,usage,cpu,a
+,,1,2018-01-01T00:00:00Z,7.0,usage,cpu,b
+") |> to(bucket: "db")`)
+
+	got := query(`from(bucket: "db")
+	|> range(start: 2018-01-01T00:00:00Z, stop: 2018-01-01T00:01:00Z)
+	|> filter(fn: (r) => r.host == "a")
+	|> sum()`)
+	want := []*executetest.Table{{
+		KeyCols: []string{"_start", "_stop", "_field", "_measurement", "host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1514764800e9), execute.Time(1514764860e9), "usage", "cpu", "a", 4.0},
+		},
+	}}
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,337 @@
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+)
+
+// seriesPoints are the points of a series selected by a read.
+type seriesPoints struct {
+	s     *series
+	times []int64
+	vs    []values.Value
+}
+
+// reader reads the tables selected by a ReadSpec from a bucket.
+type reader struct {
+	b     *bucket
+	spec  influxdb.ReadSpec
+	alloc *memory.Allocator
+}
+
+func newReader(b *bucket, spec influxdb.ReadSpec, alloc *memory.Allocator) *reader {
+	return &reader{
+		b:     b,
+		spec:  spec,
+		alloc: alloc,
+	}
+}
+
+func (r *reader) read(ctx context.Context) (flux.TableIterator, error) {
+	if r.spec.GroupMode != flux.GroupModeNone && r.spec.GroupMode != flux.GroupModeBy {
+		return nil, fmt.Errorf("group mode %v is not supported by embedded storage", r.spec.GroupMode)
+	}
+
+	var pred *execute.RowPredicateFn
+	if r.spec.Predicate != nil {
+		var err error
+		if pred, err = execute.NewRowPredicateFn(r.spec.Predicate); err != nil {
+			return nil, err
+		}
+	}
+	// A PointsLimit of -1 means only the series keys are needed, one point per series is enough.
+	limit := int(r.spec.PointsLimit)
+	if limit < 0 {
+		limit = 1
+	}
+
+	var selected []seriesPoints
+	for _, s := range r.b.sorted {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		times, vs, err := r.b.readPoints(s, r.spec.Bounds)
+		if err != nil {
+			return nil, err
+		}
+		sp := seriesPoints{s: s, times: times, vs: vs}
+		if pred != nil && len(times) > 0 {
+			if sp, err = r.filter(pred, sp); err != nil {
+				return nil, err
+			}
+		}
+		if r.spec.Descending {
+			for i, j := 0, len(sp.times)-1; i < j; i, j = i+1, j-1 {
+				sp.times[i], sp.times[j] = sp.times[j], sp.times[i]
+				sp.vs[i], sp.vs[j] = sp.vs[j], sp.vs[i]
+			}
+		}
+		if limit > 0 && len(sp.times) > limit {
+			sp.times, sp.vs = sp.times[:limit], sp.vs[:limit]
+		}
+		if len(sp.times) > 0 {
+			selected = append(selected, sp)
+		}
+	}
+
+	if r.spec.SeriesLimit > 0 {
+		offset, end := int(r.spec.SeriesOffset), int(r.spec.SeriesOffset+r.spec.SeriesLimit)
+		if offset > len(selected) {
+			offset = len(selected)
+		}
+		if end > len(selected) {
+			end = len(selected)
+		}
+		selected = selected[offset:end]
+	}
+
+	if r.spec.GroupMode == flux.GroupModeBy {
+		return r.groupTables(selected)
+	}
+	var tables tableIterator
+	for _, sp := range selected {
+		tbl, err := r.seriesTable(sp)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, tbl)
+	}
+	return tables, nil
+}
+
+// baseCols are the columns of every table read, the tag columns follow them.
+func baseCols(typ flux.ColType) []flux.ColMeta {
+	return []flux.ColMeta{
+		{Label: execute.DefaultStartColLabel, Type: flux.TTime},
+		{Label: execute.DefaultStopColLabel, Type: flux.TTime},
+		{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		{Label: execute.DefaultValueColLabel, Type: typ},
+		{Label: influxdb.FieldColLabel, Type: flux.TString},
+		{Label: influxdb.MeasurementColLabel, Type: flux.TString},
+	}
+}
+
+// filter returns the points of the series for which the predicate is true.
+// The predicate sees every tag key of the bucket, tags the series doesn't have are empty strings.
+func (r *reader) filter(pred *execute.RowPredicateFn, sp seriesPoints) (seriesPoints, error) {
+	tagKeys := r.b.tagKeys()
+	cols := baseCols(sp.s.typ)
+	for _, k := range tagKeys {
+		cols = append(cols, flux.ColMeta{Label: k, Type: flux.TString})
+	}
+	tags := make([]string, len(tagKeys))
+	for i, k := range tagKeys {
+		tags[i] = sp.s.Tags[k]
+	}
+	tbl, err := r.buildTable(execute.NewGroupKey(nil, nil), cols, []seriesPoints{sp}, func(s *series, j int) (string, bool) {
+		return tags[j], true
+	})
+	if err != nil {
+		return seriesPoints{}, err
+	}
+	if err := pred.Prepare(cols); err != nil {
+		return seriesPoints{}, err
+	}
+
+	filtered := seriesPoints{s: sp.s}
+	err = tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			ok, err := pred.Eval(i, cr)
+			if err != nil {
+				return err
+			}
+			if ok {
+				filtered.times = append(filtered.times, sp.times[i])
+				filtered.vs = append(filtered.vs, sp.vs[i])
+			}
+		}
+		return nil
+	})
+	return filtered, err
+}
+
+// seriesTable returns the table of a single series.
+// Its group key is _start, _stop, _field, _measurement and the tags.
+func (r *reader) seriesTable(sp seriesPoints) (flux.Table, error) {
+	cols := baseCols(sp.s.typ)
+	keyCols := []flux.ColMeta{cols[0], cols[1], cols[4], cols[5]}
+	keyValues := []values.Value{
+		values.NewTime(r.spec.Bounds.Start),
+		values.NewTime(r.spec.Bounds.Stop),
+		values.NewString(sp.s.Field),
+		values.NewString(sp.s.Measurement),
+	}
+	for _, t := range sp.s.tags {
+		c := flux.ColMeta{Label: t.Key, Type: flux.TString}
+		cols = append(cols, c)
+		keyCols = append(keyCols, c)
+		keyValues = append(keyValues, values.NewString(t.Value))
+	}
+	return r.buildTable(execute.NewGroupKey(keyCols, keyValues), cols, []seriesPoints{sp}, func(s *series, j int) (string, bool) {
+		return s.tags[j].Value, true
+	})
+}
+
+// groupTables returns one table per distinct value of the group keys of the series,
+// in the order of the first series of every table.
+// A tag that a series doesn't have is null.
+func (r *reader) groupTables(selected []seriesPoints) (flux.TableIterator, error) {
+	var (
+		order  []string
+		groups = make(map[string][]seriesPoints)
+	)
+	for _, sp := range selected {
+		var b strings.Builder
+		for _, label := range r.spec.GroupKeys {
+			if v, ok := r.label(sp.s, label); ok {
+				b.WriteString(v)
+				b.WriteByte(0)
+			} else {
+				b.WriteByte(1)
+			}
+		}
+		k := b.String()
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], sp)
+	}
+
+	var tables tableIterator
+	for _, k := range order {
+		group := groups[k]
+		typ := group[0].s.typ
+		tagSet := make(map[string]bool)
+		for _, sp := range group {
+			if sp.s.typ != typ {
+				return nil, fmt.Errorf("schema collision: cannot group %s and %s types together", typeNames[typ], typeNames[sp.s.typ])
+			}
+			for k := range sp.s.Tags {
+				tagSet[k] = true
+			}
+		}
+
+		cols := baseCols(typ)
+		var tagKeys []string
+		for _, k := range r.b.tagKeys() {
+			if tagSet[k] {
+				tagKeys = append(tagKeys, k)
+				cols = append(cols, flux.ColMeta{Label: k, Type: flux.TString})
+			}
+		}
+
+		var (
+			keyCols   []flux.ColMeta
+			keyValues []values.Value
+		)
+		for _, label := range r.spec.GroupKeys {
+			j := execute.ColIdx(label, cols)
+			if j < 0 || execute.ColIdx(label, keyCols) >= 0 {
+				continue
+			}
+			keyCols = append(keyCols, cols[j])
+			switch label {
+			case execute.DefaultStartColLabel:
+				keyValues = append(keyValues, values.NewTime(r.spec.Bounds.Start))
+			case execute.DefaultStopColLabel:
+				keyValues = append(keyValues, values.NewTime(r.spec.Bounds.Stop))
+			default:
+				if v, ok := r.label(group[0].s, label); ok {
+					keyValues = append(keyValues, values.NewString(v))
+				} else {
+					keyValues = append(keyValues, values.NewNull(flux.SemanticType(flux.TString)))
+				}
+			}
+		}
+
+		tbl, err := r.buildTable(execute.NewGroupKey(keyCols, keyValues), cols, group, func(s *series, j int) (string, bool) {
+			return s.tag(tagKeys[j])
+		})
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, tbl)
+	}
+	return tables, nil
+}
+
+// label returns the value of the string column label of the series.
+func (r *reader) label(s *series, label string) (string, bool) {
+	switch label {
+	case influxdb.FieldColLabel:
+		return s.Field, true
+	case influxdb.MeasurementColLabel:
+		return s.Measurement, true
+	}
+	return s.tag(label)
+}
+
+// buildTable builds a table with the base columns followed by tag columns.
+// The tag function returns the value of the j-th tag column of a series, or false if it is null.
+func (r *reader) buildTable(key flux.GroupKey, cols []flux.ColMeta, group []seriesPoints, tag func(s *series, j int) (string, bool)) (flux.Table, error) {
+	builder := execute.NewColListTableBuilder(key, r.alloc)
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	nBase := len(baseCols(flux.TInvalid))
+	for _, sp := range group {
+		for i, t := range sp.times {
+			if err := builder.AppendTime(0, r.spec.Bounds.Start); err != nil {
+				return nil, err
+			}
+			if err := builder.AppendTime(1, r.spec.Bounds.Stop); err != nil {
+				return nil, err
+			}
+			if err := builder.AppendTime(2, values.Time(t)); err != nil {
+				return nil, err
+			}
+			if err := builder.AppendValue(3, sp.vs[i]); err != nil {
+				return nil, err
+			}
+			if err := builder.AppendString(4, sp.s.Field); err != nil {
+				return nil, err
+			}
+			if err := builder.AppendString(5, sp.s.Measurement); err != nil {
+				return nil, err
+			}
+			for j := nBase; j < len(cols); j++ {
+				v, ok := tag(sp.s, j-nBase)
+				var err error
+				if ok {
+					err = builder.AppendString(j, v)
+				} else {
+					err = builder.AppendNil(j)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return builder.Table()
+}
+
+// tableIterator iterates over tables that have already been read.
+type tableIterator []flux.Table
+
+func (ti tableIterator) Do(f func(flux.Table) error) error {
+	for _, tbl := range ti {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ti tableIterator) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
@@ -0,0 +1,92 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*FromProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	if spec.WindowSet || spec.AggregateSet {
+		return nil, errors.New("window and aggregate push downs are not supported by from")
+	}
+	if spec.GroupingSet && spec.GroupMode != flux.GroupModeBy {
+		return nil, fmt.Errorf("group mode %v is not supported by from", spec.GroupMode)
+	}
+	s, err := storageFromDependencies(a.Dependencies())
+	if err != nil {
+		return nil, err
+	}
+
+	readSpec := ReadSpec{
+		Bucket: BucketRef{Name: spec.Bucket, ID: spec.BucketID},
+		Bounds: execute.Bounds{
+			Start: values.ConvertTime(spec.Bounds.Start.Time(spec.Bounds.Now)),
+			Stop:  values.ConvertTime(spec.Bounds.Stop.Time(spec.Bounds.Now)),
+		},
+		Descending: spec.Descending,
+	}
+	if spec.FilterSet {
+		readSpec.Predicate = spec.Filter
+	}
+	if spec.LimitSet {
+		readSpec.PointsLimit = spec.PointsLimit
+		readSpec.SeriesLimit = spec.SeriesLimit
+		readSpec.SeriesOffset = spec.SeriesOffset
+	}
+	if spec.GroupingSet {
+		readSpec.GroupMode = spec.GroupMode
+		readSpec.GroupKeys = spec.GroupKeys
+	}
+	return &StorageSource{
+		id:    dsid,
+		s:     s,
+		spec:  readSpec,
+		alloc: a.Allocator(),
+	}, nil
+}
+
+// StorageSource produces the tables read from a Storage.
+type StorageSource struct {
+	id    execute.DatasetID
+	s     Storage
+	spec  ReadSpec
+	alloc *memory.Allocator
+	ts    []execute.Transformation
+}
+
+func (s *StorageSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *StorageSource) Run(ctx context.Context) {
+	err := s.run(ctx)
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *StorageSource) run(ctx context.Context) error {
+	tables, err := s.s.Read(ctx, s.spec, s.alloc)
+	if err != nil {
+		return err
+	}
+	return tables.Do(func(tbl flux.Table) error {
+		for _, t := range s.ts {
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	// StorageDependency is the key in execute.Dependencies under which
+	// an embedder provides the Storage read by from and written by to.
+	StorageDependency = "influxdb.storage"
+
+	// Labels of the columns of the tables read from storage.
+	MeasurementColLabel = "_measurement"
+	FieldColLabel       = "_field"
+)
+
+// Storage reads and writes the series of buckets.
+// A series is identified by its measurement, tag set and field, and holds values of a single type.
+type Storage interface {
+	// Read returns the tables selected by the spec.
+	// Every table has the columns _start, _stop, _time, _value, _field, _measurement and one column per tag key.
+	Read(ctx context.Context, spec ReadSpec, alloc *memory.Allocator) (flux.TableIterator, error)
+	// Write stores the points in a bucket.
+	Write(ctx context.Context, bucket BucketRef, points []Point) error
+}
+
+// BucketRef identifies a bucket by either its name or its ID.
+type BucketRef struct {
+	Name string
+	ID   string
+}
+
+func (b BucketRef) String() string {
+	if b.Name != "" {
+		return b.Name
+	}
+	return b.ID
+}
+
+// ReadSpec describes the data read by from, including the operations the planner pushed into it.
+type ReadSpec struct {
+	Bucket BucketRef
+	// Bounds limits the points to those with _start <= _time < _stop.
+	Bounds execute.Bounds
+	// Predicate, if not nil, is a function of a single row that selects the points to read.
+	Predicate *semantic.FunctionExpression
+	// PointsLimit limits the number of points read per series if positive.
+	// SeriesLimit and SeriesOffset select a window of the series if SeriesLimit is positive.
+	PointsLimit  int64
+	SeriesLimit  int64
+	SeriesOffset int64
+	Descending   bool
+	// GroupKeys, if GroupMode is flux.GroupModeBy, are the columns by which the series are grouped.
+	// Otherwise there is one table per series.
+	GroupMode flux.GroupMode
+	GroupKeys []string
+}
+
+// Tag is a single tag key/value pair.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Point is a single value of a series.
+type Point struct {
+	Measurement string
+	// Tags are sorted by key.
+	Tags  []Tag
+	Field string
+	Time  values.Time
+	// Value is a non-null float, integer, unsigned integer, string or boolean.
+	Value values.Value
+}
+
+// RegisterStorage registers the from source and the to transformation that read and write
+// the Storage provided under StorageDependency.
+// Embedders that execute from and to themselves register their own source and transformation instead.
+func RegisterStorage() {
+	execute.RegisterSource(FromKind, createFromSource)
+	execute.RegisterTransformation(ToKind, createToTransformation)
+}
+
+// storageFromDependencies returns the Storage provided under StorageDependency.
+func storageFromDependencies(deps execute.Dependencies) (Storage, error) {
+	dep, ok := deps[StorageDependency]
+	if !ok {
+		return nil, fmt.Errorf("no storage is configured, provide one under the %s dependency", StorageDependency)
+	}
+	s, ok := dep.(Storage)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s dependency type %T", StorageDependency, dep)
+	}
+	return s, nil
+}
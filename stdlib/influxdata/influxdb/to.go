@@ -1,13 +1,36 @@
 package influxdb
 
 import (
+	"context"
+	"fmt"
+	"sort"
+
 	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
 )
 
 // ToKind is the kind for the `to` flux function
 const ToKind = "to"
 
+// ToOpSpec is the flux.OperationSpec for the `to` flux function.
+type ToOpSpec struct {
+	Bucket            string                       `json:"bucket"`
+	BucketID          string                       `json:"bucketID"`
+	Org               string                       `json:"org"`
+	OrgID             string                       `json:"orgID"`
+	Host              string                       `json:"host"`
+	Token             string                       `json:"token"`
+	TimeColumn        string                       `json:"timeColumn"`
+	MeasurementColumn string                       `json:"measurementColumn"`
+	TagColumns        []string                     `json:"tagColumns"` // if empty, the string columns that hold neither the measurement nor a field are the tags
+	FieldFn           *semantic.FunctionExpression `json:"fieldFn"`    // if nil, the _field and _value columns hold the field
+}
+
 var ToSignature = flux.FunctionSignature(
 	map[string]semantic.PolyType{
 		"bucket":            semantic.String,
@@ -18,7 +41,7 @@ var ToSignature = flux.FunctionSignature(
 		"token":             semantic.String,
 		"timeColumn":        semantic.String,
 		"measurementColumn": semantic.String,
-		"tagColumns":        semantic.Array,
+		"tagColumns":        semantic.NewArrayPolyType(semantic.String),
 		"fieldFn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
 			Parameters: map[string]semantic.PolyType{
 				"r": semantic.Tvar(1),
@@ -31,5 +54,334 @@ var ToSignature = flux.FunctionSignature(
 )
 
 func init() {
-	flux.RegisterPackageValue("influxdata/influxdb", ToKind, flux.FunctionValueWithSideEffect(ToKind, nil, ToSignature))
+	flux.RegisterPackageValue("influxdata/influxdb", ToKind, flux.FunctionValueWithSideEffect(ToKind, createToOpSpec, ToSignature))
+	flux.RegisterOpSpec(ToKind, func() flux.OperationSpec { return &ToOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(ToKind, newToProcedure, ToKind)
+}
+
+// ReadArgs loads a flux.Arguments into ToOpSpec.  It sets several default values.
+// Exactly one of bucket or bucketID must be set.
+// If the time column isn't set, it defaults to execute.DefaultTimeColLabel.
+// If the measurement column isn't set, it defaults to _measurement.
+func (o *ToOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	var ok bool
+	if o.Bucket, _, err = args.GetString("bucket"); err != nil {
+		return err
+	}
+	if o.BucketID, _, err = args.GetString("bucketID"); err != nil {
+		return err
+	}
+	if o.Bucket == "" && o.BucketID == "" {
+		return errors.New("must specify one of bucket or bucketID")
+	}
+	if o.Bucket != "" && o.BucketID != "" {
+		return errors.New("must specify only one of bucket or bucketID")
+	}
+
+	if o.Org, _, err = args.GetString("org"); err != nil {
+		return err
+	}
+	if o.OrgID, _, err = args.GetString("orgID"); err != nil {
+		return err
+	}
+	if o.Host, _, err = args.GetString("host"); err != nil {
+		return err
+	}
+	if o.Token, _, err = args.GetString("token"); err != nil {
+		return err
+	}
+
+	if o.TimeColumn, ok, err = args.GetString("timeColumn"); err != nil {
+		return err
+	}
+	if !ok {
+		o.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if o.MeasurementColumn, ok, err = args.GetString("measurementColumn"); err != nil {
+		return err
+	}
+	if !ok {
+		o.MeasurementColumn = MeasurementColLabel
+	}
+
+	tagColumns, ok, err := args.GetArray("tagColumns", semantic.String)
+	if err != nil {
+		return err
+	}
+	if ok {
+		o.TagColumns = make([]string, tagColumns.Len())
+		tagColumns.Range(func(i int, v values.Value) {
+			o.TagColumns[i] = v.Str()
+		})
+		sort.Strings(o.TagColumns)
+	}
+
+	fieldFn, ok, err := args.GetFunction("fieldFn")
+	if err != nil {
+		return err
+	}
+	if ok {
+		if o.FieldFn, err = interpreter.ResolveFunction(fieldFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createToOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToOpSpec) Kind() flux.OperationKind {
+	return ToKind
+}
+
+type ToProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToOpSpec
+}
+
+func (o *ToProcedureSpec) Kind() plan.ProcedureKind {
+	return ToKind
+}
+
+func (o *ToProcedureSpec) Copy() plan.ProcedureSpec {
+	s := *o.Spec
+	s.TagColumns = append([]string(nil), o.Spec.TagColumns...)
+	if o.Spec.FieldFn != nil {
+		s.FieldFn = o.Spec.FieldFn.Copy().(*semantic.FunctionExpression)
+	}
+	return &ToProcedureSpec{Spec: &s}
+}
+
+func newToProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ToProcedureSpec{Spec: spec}, nil
+}
+
+func createToTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	if s.Spec.Host != "" {
+		return nil, nil, fmt.Errorf("writing to the remote host %q is not supported", s.Spec.Host)
+	}
+	storage, err := storageFromDependencies(a.Dependencies())
+	if err != nil {
+		return nil, nil, err
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewToTransformation(a.Context(), d, cache, s, storage)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// ToTransformation writes the rows of every table it receives to a Storage
+// and passes the tables on unchanged.
+type ToTransformation struct {
+	d       execute.Dataset
+	cache   execute.TableBuilderCache
+	spec    *ToProcedureSpec
+	storage Storage
+	fn      *execute.RowMapFn
+	ctx     context.Context
+}
+
+// NewToTransformation creates a ToTransformation.
+func NewToTransformation(ctx context.Context, d execute.Dataset, cache execute.TableBuilderCache, spec *ToProcedureSpec, storage Storage) (*ToTransformation, error) {
+	t := &ToTransformation{
+		ctx:     ctx,
+		d:       d,
+		cache:   cache,
+		spec:    spec,
+		storage: storage,
+	}
+	if spec.Spec.FieldFn != nil {
+		fn, err := execute.NewRowMapFn(spec.Spec.FieldFn)
+		if err != nil {
+			return nil, err
+		}
+		t.fn = fn
+	}
+	return t, nil
+}
+
+func (t *ToTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *ToTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.spec.Spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no time column %q", t.spec.Spec.TimeColumn)
+	}
+	if cols[timeIdx].Type != flux.TTime {
+		return fmt.Errorf("time column %q has type %v, not time", t.spec.Spec.TimeColumn, cols[timeIdx].Type)
+	}
+	measurementIdx := execute.ColIdx(t.spec.Spec.MeasurementColumn, cols)
+	if measurementIdx < 0 {
+		return fmt.Errorf("no measurement column %q", t.spec.Spec.MeasurementColumn)
+	}
+	if cols[measurementIdx].Type != flux.TString {
+		return fmt.Errorf("measurement column %q has type %v, not string", t.spec.Spec.MeasurementColumn, cols[measurementIdx].Type)
+	}
+	var fieldIdx, valueIdx int
+	if t.fn != nil {
+		if err := t.fn.Prepare(cols); err != nil {
+			return err
+		}
+	} else {
+		if fieldIdx = execute.ColIdx(FieldColLabel, cols); fieldIdx < 0 {
+			return fmt.Errorf("no field column %q, use fieldFn to select the fields", FieldColLabel)
+		}
+		if valueIdx = execute.ColIdx(execute.DefaultValueColLabel, cols); valueIdx < 0 {
+			return fmt.Errorf("no value column %q, use fieldFn to select the fields", execute.DefaultValueColLabel)
+		}
+	}
+	tagIdxs, err := t.tagIdxs(cols)
+	if err != nil {
+		return err
+	}
+
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if created {
+		if err := execute.AddTableCols(tbl, builder); err != nil {
+			return err
+		}
+	}
+
+	// The points of a table are written at once so a table that fails to convert writes nothing.
+	var points []Point
+	err = tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			tm := execute.ValueForRow(cr, i, timeIdx)
+			measurement := execute.ValueForRow(cr, i, measurementIdx)
+			if tm.IsNull() || measurement.IsNull() {
+				return errors.New("cannot write a row with a null time or measurement")
+			}
+			p := Point{
+				Measurement: measurement.Str(),
+				Time:        tm.Time(),
+			}
+			for _, j := range tagIdxs {
+				if v := execute.ValueForRow(cr, i, j); !v.IsNull() && v.Str() != "" {
+					p.Tags = append(p.Tags, Tag{Key: cols[j].Label, Value: v.Str()})
+				}
+			}
+
+			if t.fn == nil {
+				field := execute.ValueForRow(cr, i, fieldIdx)
+				if field.IsNull() {
+					return errors.New("cannot write a row with a null field")
+				}
+				p.Field = field.Str()
+				p.Value = execute.ValueForRow(cr, i, valueIdx)
+				if !p.Value.IsNull() {
+					points = append(points, p)
+				}
+			} else {
+				obj, err := t.fn.Eval(i, cr)
+				if err != nil {
+					return err
+				}
+				var fields []string
+				obj.Range(func(k string, v values.Value) {
+					if !v.IsNull() {
+						fields = append(fields, k)
+					}
+				})
+				sort.Strings(fields)
+				for _, k := range fields {
+					fp := p
+					fp.Field = k
+					fp.Value, _ = obj.Get(k)
+					points = append(points, fp)
+				}
+			}
+
+			if err := execute.AppendRecord(i, cr, builder); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	if err := t.storage.Write(t.ctx, BucketRef{Name: t.spec.Spec.Bucket, ID: t.spec.Spec.BucketID}, points); err != nil {
+		return errors.Wrap(err, "failed to write points")
+	}
+	return nil
+}
+
+// tagIdxs returns the indexes of the tag columns, sorted by label.
+// If the spec has no tag columns, all string columns are tags except for the measurement column,
+// the _field and _value columns, and the columns named like the fields returned by fieldFn.
+func (t *ToTransformation) tagIdxs(cols []flux.ColMeta) ([]int, error) {
+	if len(t.spec.Spec.TagColumns) == 0 {
+		exclude := map[string]bool{
+			t.spec.Spec.MeasurementColumn: true,
+			FieldColLabel:                 true,
+			execute.DefaultValueColLabel:  true,
+		}
+		if t.fn != nil {
+			for k := range t.fn.Type().Properties() {
+				exclude[k] = true
+			}
+		}
+		var idxs []int
+		for j, c := range cols {
+			if c.Type == flux.TString && !exclude[c.Label] {
+				idxs = append(idxs, j)
+			}
+		}
+		sort.Slice(idxs, func(i, j int) bool { return cols[idxs[i]].Label < cols[idxs[j]].Label })
+		return idxs, nil
+	}
+
+	idxs := make([]int, len(t.spec.Spec.TagColumns))
+	for i, tag := range t.spec.Spec.TagColumns {
+		j := execute.ColIdx(tag, cols)
+		if j < 0 {
+			return nil, fmt.Errorf("no tag column %q", tag)
+		}
+		if cols[j].Type != flux.TString {
+			return nil, fmt.Errorf("tag column %q has type %v, not string", tag, cols[j].Type)
+		}
+		idxs[i] = j
+	}
+	return idxs, nil
+}
+
+func (t *ToTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateWatermark(pt)
+}
+
+func (t *ToTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *ToTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
 }
@@ -0,0 +1,266 @@
+package influxdb_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/values"
+)
+
+func init() {
+	// Without RegisterStorage, embedders register their own implementations of from and to.
+	execute.RegisterSource(influxdb.FromKind, func(plan.ProcedureSpec, execute.DatasetID, execute.Administration) (execute.Source, error) {
+		return nil, nil
+	})
+	execute.RegisterTransformation(influxdb.ToKind, func(execute.DatasetID, execute.AccumulationMode, plan.ProcedureSpec, execute.Administration) (execute.Transformation, execute.Dataset, error) {
+		return nil, nil, nil
+	})
+}
+
+func TestTo_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mydb") |> to(bucket:"series1")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mydb"},
+					},
+					{
+						ID: "to1",
+						Spec: &influxdb.ToOpSpec{
+							Bucket:            "series1",
+							TimeColumn:        "_time",
+							MeasurementColumn: "_measurement",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "to1"},
+				},
+			},
+		},
+		{
+			Name: "options",
+			Raw:  `from(bucket:"mydb") |> to(bucketID:"0001", org:"myorg", timeColumn:"t", measurementColumn:"m", tagColumns:["b", "a"])`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mydb"},
+					},
+					{
+						ID: "to1",
+						Spec: &influxdb.ToOpSpec{
+							BucketID:          "0001",
+							Org:               "myorg",
+							TimeColumn:        "t",
+							MeasurementColumn: "m",
+							TagColumns:        []string{"a", "b"},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "to1"},
+				},
+			},
+		},
+		{
+			Name:    "no bucket",
+			Raw:     `from(bucket:"mydb") |> to(org:"myorg")`,
+			WantErr: true,
+		},
+		{
+			Name:    "bucket and bucketID",
+			Raw:     `from(bucket:"mydb") |> to(bucket:"a", bucketID:"0001")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+// mockStorage records the points written to it.
+type mockStorage struct {
+	bucket influxdb.BucketRef
+	points []influxdb.Point
+}
+
+func (s *mockStorage) Read(ctx context.Context, spec influxdb.ReadSpec, alloc *memory.Allocator) (flux.TableIterator, error) {
+	panic("not implemented")
+}
+
+func (s *mockStorage) Write(ctx context.Context, bucket influxdb.BucketRef, points []influxdb.Point) error {
+	s.bucket = bucket
+	s.points = append(s.points, points...)
+	return nil
+}
+
+func TestTo_Process(t *testing.T) {
+	// (r) => ({load: r.load, up: r.up})
+	member := func(property string) *semantic.MemberExpression {
+		return &semantic.MemberExpression{
+			Object:   &semantic.IdentifierExpression{Name: "r"},
+			Property: property,
+		}
+	}
+	fieldFn := &semantic.FunctionExpression{
+		Block: &semantic.FunctionBlock{
+			Parameters: &semantic.FunctionParameters{
+				List: []*semantic.FunctionParameter{{Key: &semantic.Identifier{Name: "r"}}},
+			},
+			Body: &semantic.ObjectExpression{
+				Properties: []*semantic.Property{
+					{Key: &semantic.Identifier{Name: "load"}, Value: member("load")},
+					{Key: &semantic.Identifier{Name: "up"}, Value: member("up")},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		spec    influxdb.ToOpSpec
+		tables  []*executetest.Table
+		want    []influxdb.Point
+		wantErr bool
+	}{
+		{
+			name: "influxdb shaped tables",
+			spec: influxdb.ToOpSpec{
+				Bucket:            "b",
+				TimeColumn:        "_time",
+				MeasurementColumn: "_measurement",
+			},
+			tables: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop", "_field", "_measurement", "host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "_field", Type: flux.TString},
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(10), execute.Time(1), 1.5, "usage", "cpu", "a"},
+					{execute.Time(0), execute.Time(10), execute.Time(2), nil, "usage", "cpu", "a"},
+				},
+			}},
+			want: []influxdb.Point{
+				{Measurement: "cpu", Tags: []influxdb.Tag{{Key: "host", Value: "a"}}, Field: "usage", Time: 1, Value: values.NewFloat(1.5)},
+			},
+		},
+		{
+			name: "field function",
+			spec: influxdb.ToOpSpec{
+				Bucket:            "b",
+				TimeColumn:        "t",
+				MeasurementColumn: "m",
+				FieldFn:           fieldFn,
+			},
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "t", Type: flux.TTime},
+					{Label: "m", Type: flux.TString},
+					{Label: "host", Type: flux.TString},
+					{Label: "load", Type: flux.TInt},
+					{Label: "up", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "sys", "a", int64(3), true},
+					{execute.Time(2), "sys", "", int64(4), false},
+				},
+			}},
+			want: []influxdb.Point{
+				{Measurement: "sys", Tags: []influxdb.Tag{{Key: "host", Value: "a"}}, Field: "load", Time: 1, Value: values.NewInt(3)},
+				{Measurement: "sys", Tags: []influxdb.Tag{{Key: "host", Value: "a"}}, Field: "up", Time: 1, Value: values.NewBool(true)},
+				{Measurement: "sys", Field: "load", Time: 2, Value: values.NewInt(4)},
+				{Measurement: "sys", Field: "up", Time: 2, Value: values.NewBool(false)},
+			},
+		},
+		{
+			name: "missing field column",
+			spec: influxdb.ToOpSpec{
+				Bucket:            "b",
+				TimeColumn:        "_time",
+				MeasurementColumn: "_measurement",
+			},
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "_measurement", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "cpu"},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			storage := new(mockStorage)
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			tr, err := influxdb.NewToTransformation(context.Background(), d, c, &influxdb.ToProcedureSpec{Spec: &tc.spec}, storage)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parentID := executetest.RandomDatasetID()
+			for _, tbl := range tc.tables {
+				if err = tr.Process(parentID, tbl); err != nil {
+					break
+				}
+			}
+			tr.Finish(parentID, err)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if storage.bucket.Name != tc.spec.Bucket {
+				t.Errorf("unexpected bucket %q", storage.bucket.Name)
+			}
+			if !cmp.Equal(tc.want, storage.points) {
+				t.Errorf("unexpected points -want/+got\n%s", cmp.Diff(tc.want, storage.points))
+			}
+
+			// The tables are passed on unchanged.
+			gotTables, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			executetest.NormalizeTables(gotTables)
+			executetest.NormalizeTables(tc.tables)
+			sort.Sort(executetest.SortedTables(gotTables))
+			sort.Sort(executetest.SortedTables(tc.tables))
+			if !cmp.Equal(tc.tables, gotTables) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.tables, gotTables))
+			}
+		})
+	}
+}
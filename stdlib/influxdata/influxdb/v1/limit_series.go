@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const LimitSeriesKind = "limitSeries"
+
+// LimitSeriesOpSpec limits the number of tables returned, the tables are ordered by group key.
+// It is the equivalent of the SLIMIT and SOFFSET clauses of InfluxQL.
+type LimitSeriesOpSpec struct {
+	N      int64 `json:"n"`
+	Offset int64 `json:"offset"`
+}
+
+func init() {
+	limitSeriesSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"n":      semantic.Int,
+			"offset": semantic.Int,
+		},
+		[]string{"n"},
+	)
+
+	flux.RegisterPackageValue("influxdata/influxdb/v1", LimitSeriesKind, flux.FunctionValue(LimitSeriesKind, createLimitSeriesOpSpec, limitSeriesSignature))
+	flux.RegisterOpSpec(LimitSeriesKind, newLimitSeriesOp)
+	plan.RegisterProcedureSpec(LimitSeriesKind, newLimitSeriesProcedure, LimitSeriesKind)
+	execute.RegisterTransformation(LimitSeriesKind, createLimitSeriesTransformation)
+}
+
+func createLimitSeriesOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(LimitSeriesOpSpec)
+
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("n must be non negative, got %d", n)
+	}
+	spec.N = n
+
+	if offset, ok, err := args.GetInt("offset"); err != nil {
+		return nil, err
+	} else if ok {
+		if offset < 0 {
+			return nil, fmt.Errorf("offset must be non negative, got %d", offset)
+		}
+		spec.Offset = offset
+	}
+
+	return spec, nil
+}
+
+func newLimitSeriesOp() flux.OperationSpec {
+	return new(LimitSeriesOpSpec)
+}
+
+func (s *LimitSeriesOpSpec) Kind() flux.OperationKind {
+	return LimitSeriesKind
+}
+
+type LimitSeriesProcedureSpec struct {
+	plan.DefaultCost
+	N      int64 `json:"n"`
+	Offset int64 `json:"offset"`
+}
+
+func newLimitSeriesProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*LimitSeriesOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &LimitSeriesProcedureSpec{
+		N:      spec.N,
+		Offset: spec.Offset,
+	}, nil
+}
+
+func (s *LimitSeriesProcedureSpec) Kind() plan.ProcedureKind {
+	return LimitSeriesKind
+}
+func (s *LimitSeriesProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(LimitSeriesProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createLimitSeriesTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*LimitSeriesProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewLimitSeriesTransformation(d, cache, s, a.Allocator())
+	return t, d, nil
+}
+
+// limitSeriesTransformation buffers all of its tables,
+// the tables within the limit are only known once every table has been processed.
+type limitSeriesTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	alloc *memory.Allocator
+
+	tables *execute.GroupLookup
+
+	n, offset int
+}
+
+func NewLimitSeriesTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *LimitSeriesProcedureSpec, a *memory.Allocator) *limitSeriesTransformation {
+	return &limitSeriesTransformation{
+		d:      d,
+		cache:  cache,
+		alloc:  a,
+		tables: execute.NewGroupLookup(),
+		n:      int(spec.N),
+		offset: int(spec.Offset),
+	}
+}
+
+func (t *limitSeriesTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	t.tables.Delete(key)
+	return t.d.RetractTable(key)
+}
+
+func (t *limitSeriesTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	if _, ok := t.tables.Lookup(tbl.Key()); ok {
+		return fmt.Errorf("limitSeries found duplicate table with key: %v", tbl.Key())
+	}
+	cpy, err := execute.CopyTable(tbl, t.alloc)
+	if err != nil {
+		return err
+	}
+	t.tables.Set(tbl.Key(), cpy)
+	return nil
+}
+
+func (t *limitSeriesTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *limitSeriesTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *limitSeriesTransformation) Finish(id execute.DatasetID, err error) {
+	if err == nil {
+		// The lookup ranges over the tables in group key order.
+		i := 0
+		t.tables.Range(func(key flux.GroupKey, value interface{}) {
+			defer func() { i++ }()
+			if err != nil || i < t.offset || i >= t.offset+t.n {
+				return
+			}
+			tbl := value.(flux.Table)
+			builder, _ := t.cache.TableBuilder(key)
+			if err = execute.AddTableCols(tbl, builder); err != nil {
+				return
+			}
+			err = execute.AppendTable(tbl, builder)
+		})
+	}
+	t.tables = nil
+	t.d.Finish(err)
+}
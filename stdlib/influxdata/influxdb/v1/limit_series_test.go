@@ -0,0 +1,87 @@
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb/v1"
+)
+
+func TestLimitSeriesOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"limitSeries","kind":"limitSeries","spec":{"n":10,"offset":2}}`)
+	op := &flux.Operation{
+		ID: "limitSeries",
+		Spec: &v1.LimitSeriesOpSpec{
+			N:      10,
+			Offset: 2,
+		},
+	}
+
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestLimitSeries_Process(t *testing.T) {
+	series := func(host string, v float64) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "host", Type: flux.TString},
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{host, execute.Time(1), v},
+				{host, execute.Time(2), v + 1},
+			},
+		}
+	}
+	testCases := []struct {
+		name string
+		spec *v1.LimitSeriesProcedureSpec
+		data []flux.Table
+		want []*executetest.Table
+	}{
+		{
+			name: "limit",
+			spec: &v1.LimitSeriesProcedureSpec{
+				N: 2,
+			},
+			data: []flux.Table{series("c", 5), series("a", 1), series("b", 3)},
+			want: []*executetest.Table{series("a", 1), series("b", 3)},
+		},
+		{
+			name: "offset",
+			spec: &v1.LimitSeriesProcedureSpec{
+				N:      1,
+				Offset: 1,
+			},
+			data: []flux.Table{series("c", 5), series("a", 1), series("b", 3)},
+			want: []*executetest.Table{series("b", 3)},
+		},
+		{
+			name: "offset past tables",
+			spec: &v1.LimitSeriesProcedureSpec{
+				N:      2,
+				Offset: 3,
+			},
+			data: []flux.Table{series("c", 5), series("a", 1), series("b", 3)},
+			want: []*executetest.Table(nil),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				nil,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return v1.NewLimitSeriesTransformation(d, c, tc.spec, executetest.UnlimitedAllocator)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package interpolate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const LinearInterpolateKind = "linearInterpolate"
+
+// The ways to handle the null values before the first value and after the last value of a table,
+// which cannot be interpolated.
+const (
+	// EdgesNull leaves them null.
+	EdgesNull = "null"
+	// EdgesNearest replaces them with the first or last value.
+	EdgesNearest = "nearest"
+	// EdgesDrop drops their rows.
+	EdgesDrop = "drop"
+)
+
+type LinearInterpolateOpSpec struct {
+	Every      flux.Duration `json:"every"`
+	Column     string        `json:"column"`
+	TimeColumn string        `json:"timeColumn"`
+	Edges      string        `json:"edges"`
+}
+
+func init() {
+	linearSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"every":      semantic.Duration,
+			"column":     semantic.String,
+			"timeColumn": semantic.String,
+			"edges":      semantic.String,
+		},
+		[]string{"every"},
+	)
+
+	flux.RegisterPackageValue("interpolate", "linear", flux.FunctionValue(LinearInterpolateKind, createLinearInterpolateOpSpec, linearSignature))
+	flux.RegisterOpSpec(LinearInterpolateKind, newLinearInterpolateOp)
+	plan.RegisterProcedureSpec(LinearInterpolateKind, newLinearInterpolateProcedure, LinearInterpolateKind)
+	execute.RegisterTransformation(LinearInterpolateKind, createLinearInterpolateTransformation)
+}
+
+func createLinearInterpolateOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(LinearInterpolateOpSpec)
+
+	every, err := args.GetRequiredDuration("every")
+	if err != nil {
+		return nil, err
+	}
+	if every <= 0 {
+		return nil, errors.New("every must be positive")
+	}
+	spec.Every = every
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if col, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = col
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if edges, ok, err := args.GetString("edges"); err != nil {
+		return nil, err
+	} else if ok {
+		switch edges {
+		case EdgesNull, EdgesNearest, EdgesDrop:
+		default:
+			return nil, fmt.Errorf("edges must be one of %q, %q or %q, got %q", EdgesNull, EdgesNearest, EdgesDrop, edges)
+		}
+		spec.Edges = edges
+	} else {
+		spec.Edges = EdgesNull
+	}
+
+	return spec, nil
+}
+
+func newLinearInterpolateOp() flux.OperationSpec {
+	return new(LinearInterpolateOpSpec)
+}
+
+func (s *LinearInterpolateOpSpec) Kind() flux.OperationKind {
+	return LinearInterpolateKind
+}
+
+type LinearInterpolateProcedureSpec struct {
+	plan.DefaultCost
+	Every      flux.Duration
+	Column     string
+	TimeColumn string
+	Edges      string
+}
+
+func newLinearInterpolateProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*LinearInterpolateOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &LinearInterpolateProcedureSpec{
+		Every:      spec.Every,
+		Column:     spec.Column,
+		TimeColumn: spec.TimeColumn,
+		Edges:      spec.Edges,
+	}, nil
+}
+
+func (s *LinearInterpolateProcedureSpec) Kind() plan.ProcedureKind {
+	return LinearInterpolateKind
+}
+func (s *LinearInterpolateProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(LinearInterpolateProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createLinearInterpolateTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*LinearInterpolateProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewLinearInterpolateTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type linearInterpolateTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	every   values.Duration
+	column  string
+	timeCol string
+	edges   string
+}
+
+func NewLinearInterpolateTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *LinearInterpolateProcedureSpec) *linearInterpolateTransformation {
+	return &linearInterpolateTransformation{
+		d:       d,
+		cache:   cache,
+		every:   values.Duration(spec.Every),
+		column:  spec.Column,
+		timeCol: spec.TimeColumn,
+		edges:   spec.Edges,
+	}
+}
+
+func (t *linearInterpolateTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *linearInterpolateTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("interpolate.linear found duplicate table with key: %v", key)
+	}
+	cols := tbl.Cols()
+	valueIdx := execute.ColIdx(t.column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.column)
+	}
+	switch typ := cols[valueIdx].Type; typ {
+	case flux.TInt, flux.TUInt, flux.TFloat:
+	default:
+		return fmt.Errorf("interpolate.linear does not support %v", typ)
+	}
+	timeIdx := execute.ColIdx(t.timeCol, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.timeCol)
+	}
+	if typ := cols[timeIdx].Type; typ != flux.TTime {
+		return fmt.Errorf("time column %q is of type %v, expected time", t.timeCol, typ)
+	}
+	if key.HasCol(t.column) || key.HasCol(t.timeCol) {
+		return errors.New("interpolate.linear cannot be applied to group key columns")
+	}
+
+	for j, c := range cols {
+		if j == valueIdx {
+			// Interpolated values are always floats.
+			c.Type = flux.TFloat
+		}
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+
+	li := &linearInterpolator{
+		builder:  builder,
+		valueIdx: valueIdx,
+		timeIdx:  timeIdx,
+		edges:    t.edges,
+	}
+	var prev values.Time
+	first := true
+	err := tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			ts := cr.Times(timeIdx)
+			if ts.IsNull(i) {
+				return errors.New("interpolate.linear found null time in time column")
+			}
+			tm := values.Time(ts.Value(i))
+			if !first {
+				if tm < prev {
+					return errors.New("interpolate.linear found out-of-order times in time column")
+				}
+				// Generate the missing rows between the previous row and this one,
+				// at the multiples of every strictly between their times.
+				g := prev.Truncate(t.every)
+				if g <= prev {
+					g = g.Add(t.every)
+				}
+				for ; g < tm; g = g.Add(t.every) {
+					if err := li.add(t.generatedRow(key, cols, g)); err != nil {
+						return err
+					}
+				}
+			}
+			row := make([]values.Value, len(cols))
+			for j := range cols {
+				v := execute.ValueForRow(cr, i, j)
+				if j == valueIdx && !v.IsNull() {
+					v = values.NewFloat(floatValue(v))
+				} else if j == valueIdx {
+					v = values.NewNull(semantic.Float)
+				}
+				row[j] = v
+			}
+			if err := li.add(row); err != nil {
+				return err
+			}
+			prev = tm
+			first = false
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return li.finish()
+}
+
+// generatedRow returns a row at time tm with the values of the group key and null values for the other columns.
+func (t *linearInterpolateTransformation) generatedRow(key flux.GroupKey, cols []flux.ColMeta, tm values.Time) []values.Value {
+	row := make([]values.Value, len(cols))
+	for j, c := range cols {
+		switch {
+		case c.Label == t.timeCol:
+			row[j] = values.NewTime(tm)
+		case c.Label == t.column:
+			row[j] = values.NewNull(semantic.Float)
+		case key.HasCol(c.Label):
+			row[j] = key.LabelValue(c.Label)
+		default:
+			row[j] = values.NewNull(execute.ConvertToKind(c.Type))
+		}
+	}
+	return row
+}
+
+func (t *linearInterpolateTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *linearInterpolateTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *linearInterpolateTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// linearInterpolator appends rows to a builder, replacing null values
+// with the linear interpolation of the values before and after them.
+// Rows are held back from the first null value until the next value is known.
+type linearInterpolator struct {
+	builder  execute.TableBuilder
+	valueIdx int
+	timeIdx  int
+	edges    string
+
+	// last is the last row with a value, nil until there is one.
+	last []values.Value
+	// pending holds the rows with a null value since the last row with a value.
+	pending [][]values.Value
+}
+
+func (li *linearInterpolator) add(row []values.Value) error {
+	if row[li.valueIdx].IsNull() {
+		li.pending = append(li.pending, row)
+		return nil
+	}
+	if li.last == nil {
+		// The pending rows are a leading gap.
+		if err := li.flushEdge(row[li.valueIdx]); err != nil {
+			return err
+		}
+	} else {
+		t0, v0 := li.last[li.timeIdx].Time(), li.last[li.valueIdx].Float()
+		t1, v1 := row[li.timeIdx].Time(), row[li.valueIdx].Float()
+		for _, p := range li.pending {
+			v := v0
+			// Rows at the time of the last value take its value, which also avoids dividing by zero
+			// when the next value has the same time.
+			if tm := p[li.timeIdx].Time(); tm != t0 {
+				v = v0 + (v1-v0)*float64(tm-t0)/float64(t1-t0)
+			}
+			p[li.valueIdx] = values.NewFloat(v)
+			if err := li.appendRow(p); err != nil {
+				return err
+			}
+		}
+		li.pending = li.pending[:0]
+	}
+	li.last = row
+	return li.appendRow(row)
+}
+
+// finish appends the pending rows, which are a trailing gap.
+func (li *linearInterpolator) finish() error {
+	var nearest values.Value
+	if li.last != nil {
+		nearest = li.last[li.valueIdx]
+	}
+	return li.flushEdge(nearest)
+}
+
+// flushEdge appends the pending rows of a leading or trailing gap according to the edges setting,
+// using the nearest value, if there is one.
+func (li *linearInterpolator) flushEdge(nearest values.Value) error {
+	defer func() {
+		li.pending = li.pending[:0]
+	}()
+	if li.edges == EdgesDrop {
+		return nil
+	}
+	for _, p := range li.pending {
+		if li.edges == EdgesNearest && nearest != nil {
+			p[li.valueIdx] = nearest
+		}
+		if err := li.appendRow(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (li *linearInterpolator) appendRow(row []values.Value) error {
+	for j, v := range row {
+		if err := li.builder.AppendValue(j, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// floatValue converts a non-null int, uint or float value to a float64.
+func floatValue(v values.Value) float64 {
+	switch v.Type() {
+	case semantic.Int:
+		return float64(v.Int())
+	case semantic.UInt:
+		return float64(v.UInt())
+	default:
+		return v.Float()
+	}
+}
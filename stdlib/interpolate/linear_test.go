@@ -0,0 +1,311 @@
+package interpolate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/interpolate"
+)
+
+func TestLinearInterpolate_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `import "interpolate" from(bucket:"mybucket") |> interpolate.linear(every: 1m)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "linearInterpolate1",
+						Spec: &interpolate.LinearInterpolateOpSpec{
+							Every:      flux.Duration(time.Minute),
+							Column:     "_value",
+							TimeColumn: "_time",
+							Edges:      "null",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "linearInterpolate1"},
+				},
+			},
+		},
+		{
+			Name:    "every must be positive",
+			Raw:     `import "interpolate" from(bucket:"mybucket") |> interpolate.linear(every: 0s)`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown edges",
+			Raw:     `import "interpolate" from(bucket:"mybucket") |> interpolate.linear(every: 1m, edges: "extrapolate")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestLinearInterpolateOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"linearInterpolate","kind":"linearInterpolate","spec":{"every":"1m","column":"_value","timeColumn":"_time","edges":"nearest"}}`)
+	op := &flux.Operation{
+		ID: "linearInterpolate",
+		Spec: &interpolate.LinearInterpolateOpSpec{
+			Every:      flux.Duration(time.Minute),
+			Column:     "_value",
+			TimeColumn: "_time",
+			Edges:      "nearest",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestLinearInterpolate_Process(t *testing.T) {
+	// gaps has a leading null value, a gap of missing rows, a null value and a trailing null value.
+	gaps := []flux.Table{&executetest.Table{
+		KeyCols: []string{"t"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TInt},
+			{Label: "t", Type: flux.TString},
+			{Label: "other", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), nil, "a", "x"},
+			{execute.Time(10), int64(1), "a", "x"},
+			{execute.Time(40), int64(4), "a", "x"},
+			{execute.Time(50), nil, "a", "x"},
+			{execute.Time(60), int64(2), "a", "x"},
+			{execute.Time(70), nil, "a", "x"},
+		},
+	}}
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "t", Type: flux.TString},
+		{Label: "other", Type: flux.TString},
+	}
+	testCases := []struct {
+		name    string
+		spec    *interpolate.LinearInterpolateProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "null edges",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      10,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesNull,
+			},
+			data: gaps,
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(0), nil, "a", "x"},
+					{execute.Time(10), 1.0, "a", "x"},
+					{execute.Time(20), 2.0, "a", nil},
+					{execute.Time(30), 3.0, "a", nil},
+					{execute.Time(40), 4.0, "a", "x"},
+					{execute.Time(50), 3.0, "a", "x"},
+					{execute.Time(60), 2.0, "a", "x"},
+					{execute.Time(70), nil, "a", "x"},
+				},
+			}},
+		},
+		{
+			name: "nearest edges",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      20,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesNearest,
+			},
+			data: gaps,
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(0), 1.0, "a", "x"},
+					{execute.Time(10), 1.0, "a", "x"},
+					{execute.Time(20), 2.0, "a", nil},
+					{execute.Time(40), 4.0, "a", "x"},
+					{execute.Time(50), 3.0, "a", "x"},
+					{execute.Time(60), 2.0, "a", "x"},
+					{execute.Time(70), 2.0, "a", "x"},
+				},
+			}},
+		},
+		{
+			name: "drop edges",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      30,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesDrop,
+			},
+			data: gaps,
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0, "a", "x"},
+					{execute.Time(30), 3.0, "a", nil},
+					{execute.Time(40), 4.0, "a", "x"},
+					{execute.Time(50), 3.0, "a", "x"},
+					{execute.Time(60), 2.0, "a", "x"},
+				},
+			}},
+		},
+		{
+			name: "unaligned times",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      10,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesNull,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 0.0},
+					{execute.Time(25), 5.0},
+					{execute.Time(60), 12.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), 0.0},
+					{execute.Time(10), 2.0},
+					{execute.Time(20), 4.0},
+					{execute.Time(25), 5.0},
+					{execute.Time(30), 6.0},
+					{execute.Time(40), 8.0},
+					{execute.Time(50), 10.0},
+					{execute.Time(60), 12.0},
+				},
+			}},
+		},
+		{
+			name: "duplicate times",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      10,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesNull,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0},
+					{execute.Time(10), nil},
+					{execute.Time(10), 3.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0},
+					{execute.Time(10), 1.0},
+					{execute.Time(10), 3.0},
+				},
+			}},
+		},
+		{
+			name: "no values",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      10,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesNearest,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), nil},
+					{execute.Time(20), nil},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), nil},
+					{execute.Time(10), nil},
+					{execute.Time(20), nil},
+				},
+			}},
+		},
+		{
+			name: "out of order times",
+			spec: &interpolate.LinearInterpolateProcedureSpec{
+				Every:      10,
+				Column:     "_value",
+				TimeColumn: "_time",
+				Edges:      interpolate.EdgesNull,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0},
+					{execute.Time(0), 2.0},
+				},
+			}},
+			wantErr: errors.New("interpolate.linear found out-of-order times in time column"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return interpolate.NewLinearInterpolateTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
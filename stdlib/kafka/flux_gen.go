@@ -21,11 +21,11 @@ var pkgAST = &ast.Package{
 			Errors: nil,
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
-					Column: 11,
-					Line:   3,
+					Column: 13,
+					Line:   4,
 				},
 				File:   "kafka.flux",
-				Source: "package kafka\n\nbuiltin to",
+				Source: "package kafka\n\nbuiltin to\nbuiltin from",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -66,6 +66,40 @@ var pkgAST = &ast.Package{
 				},
 				Name: "to",
 			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 13,
+						Line:   4,
+					},
+					File:   "kafka.flux",
+					Source: "builtin from",
+					Start: ast.Position{
+						Column: 1,
+						Line:   4,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 13,
+							Line:   4,
+						},
+						File:   "kafka.flux",
+						Source: "from",
+						Start: ast.Position{
+							Column: 9,
+							Line:   4,
+						},
+					},
+				},
+				Name: "from",
+			},
 		}},
 		Imports: nil,
 		Name:    "kafka.flux",
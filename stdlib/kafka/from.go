@@ -0,0 +1,405 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/lineprotocol"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+const (
+	// FromKafkaKind is the Kind for the FromKafka Flux function
+	FromKafkaKind = "fromKafka"
+
+	// FirstOffset selects the first available offset of a partition.
+	FirstOffset = -1
+
+	// LineProtocolFormat and JSONFormat are the supported message formats.
+	// JSON messages hold either a single metric or a batch of the form {"metrics": [...]},
+	// where each metric has the form {"name": ..., "tags": {...}, "fields": {...}, "timestamp": ...}.
+	LineProtocolFormat = "lineprotocol"
+	JSONFormat         = "json"
+)
+
+type FromKafkaOpSpec struct {
+	Brokers     []string      `json:"brokers"`
+	Topic       string        `json:"topic"`
+	Partition   int64         `json:"partition"`
+	StartOffset int64         `json:"startOffset"` // first offset to read, defaults to FirstOffset
+	StopOffset  int64         `json:"stopOffset"`  // offset at which to stop reading, if zero read to the end of the partition
+	Start       flux.Time     `json:"start"`       // earliest message time to keep, if zero keep all messages
+	Stop        flux.Time     `json:"stop"`        // latest message time (exclusive) to keep, if zero keep all messages
+	Format      string        `json:"format"`
+	Precision   time.Duration `json:"precision"`
+}
+
+func init() {
+	fromKafkaSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"brokers":     semantic.NewArrayPolyType(semantic.String),
+			"topic":       semantic.String,
+			"partition":   semantic.Int,
+			"startOffset": semantic.Int,
+			"stopOffset":  semantic.Int,
+			"start":       semantic.Time,
+			"stop":        semantic.Time,
+			"format":      semantic.String,
+			"precision":   semantic.Duration,
+		},
+		Required: semantic.LabelSet{"brokers", "topic"},
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("kafka", "from", flux.FunctionValue(FromKafkaKind, createFromKafkaOpSpec, fromKafkaSignature))
+	flux.RegisterOpSpec(FromKafkaKind, newFromKafkaOp)
+	plan.RegisterProcedureSpec(FromKafkaKind, newFromKafkaProcedure, FromKafkaKind)
+	execute.RegisterSource(FromKafkaKind, createFromKafkaSource)
+}
+
+// DefaultKafkaReaderFactory makes a KafkaReader, it is injectable for testing
+var DefaultKafkaReaderFactory = func(conf kafka.ReaderConfig) KafkaReader {
+	return kafka.NewReader(conf)
+}
+
+// KafkaReader is an interface for what we need from DefaultKafkaReaderFactory
+type KafkaReader interface {
+	io.Closer
+	ReadMessage(context.Context) (kafka.Message, error)
+	SetOffset(offset int64) error
+	// Offset returns the offset of the next message to read, or a negative value
+	// while the reader is still positioned at a symbolic offset.
+	Offset() int64
+	// ReadLag returns the number of messages between the current offset and the end of the partition.
+	ReadLag(context.Context) (int64, error)
+}
+
+// ReadArgs loads a flux.Arguments into FromKafkaOpSpec.  It sets several default values.
+// If the format isn't set, it defaults to line protocol.
+// If the precision isn't set, it defaults to nanoseconds.
+func (o *FromKafkaOpSpec) ReadArgs(args flux.Arguments) error {
+	brokers, err := args.GetRequiredArray("brokers", semantic.String)
+	if err != nil {
+		return err
+	}
+	if brokers.Len() < 1 {
+		return errors.New("at least one broker is required")
+	}
+	o.Brokers = make([]string, brokers.Len())
+	for i := range o.Brokers {
+		o.Brokers[i] = brokers.Get(i).Str()
+	}
+
+	o.Topic, err = args.GetRequiredString("topic")
+	if err != nil {
+		return err
+	}
+	if len(o.Topic) == 0 {
+		return errors.New("invalid topic name")
+	}
+
+	if o.Partition, _, err = args.GetInt("partition"); err != nil {
+		return err
+	}
+	if o.Partition < 0 {
+		return errors.New("partition must not be negative")
+	}
+
+	startOffset, ok, err := args.GetInt("startOffset")
+	if err != nil {
+		return err
+	}
+	o.StartOffset = FirstOffset
+	if ok {
+		if startOffset < 0 {
+			return errors.New("startOffset must not be negative")
+		}
+		o.StartOffset = startOffset
+	}
+
+	if o.StopOffset, ok, err = args.GetInt("stopOffset"); err != nil {
+		return err
+	}
+	if ok && o.StopOffset <= 0 {
+		return errors.New("stopOffset must be positive")
+	}
+	if ok && o.StartOffset != FirstOffset && o.StopOffset <= o.StartOffset {
+		return errors.New("stopOffset must be greater than startOffset")
+	}
+
+	if o.Start, _, err = args.GetTime("start"); err != nil {
+		return err
+	}
+	if o.Stop, _, err = args.GetTime("stop"); err != nil {
+		return err
+	}
+
+	o.Format, ok, err = args.GetString("format")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		o.Format = LineProtocolFormat
+	}
+	if o.Format != LineProtocolFormat && o.Format != JSONFormat {
+		return fmt.Errorf("unsupported format %q, must be one of %q or %q", o.Format, LineProtocolFormat, JSONFormat)
+	}
+
+	precision, ok, err := args.GetDuration("precision")
+	if err != nil {
+		return err
+	}
+	o.Precision = time.Nanosecond
+	if ok {
+		if precision <= 0 {
+			return errors.New("precision must be positive")
+		}
+		o.Precision = time.Duration(precision)
+	}
+	return nil
+}
+
+func createFromKafkaOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	s := new(FromKafkaOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newFromKafkaOp() flux.OperationSpec {
+	return new(FromKafkaOpSpec)
+}
+
+func (FromKafkaOpSpec) Kind() flux.OperationKind {
+	return FromKafkaKind
+}
+
+type FromKafkaProcedureSpec struct {
+	plan.DefaultCost
+	Spec *FromKafkaOpSpec
+}
+
+func newFromKafkaProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FromKafkaOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &FromKafkaProcedureSpec{Spec: spec}, nil
+}
+
+func (s *FromKafkaProcedureSpec) Kind() plan.ProcedureKind {
+	return FromKafkaKind
+}
+
+func (s *FromKafkaProcedureSpec) Copy() plan.ProcedureSpec {
+	spec := *s.Spec
+	spec.Brokers = append([]string(nil), s.Spec.Brokers...)
+	return &FromKafkaProcedureSpec{Spec: &spec}
+}
+
+func createFromKafkaSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*FromKafkaProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	s := &KafkaSource{
+		id:    dsid,
+		spec:  spec.Spec,
+		alloc: a.Allocator(),
+	}
+	if !spec.Spec.Start.IsZero() {
+		s.start = a.ResolveTime(spec.Spec.Start).Time()
+	}
+	if !spec.Spec.Stop.IsZero() {
+		s.stop = a.ResolveTime(spec.Spec.Stop).Time()
+	}
+	return s, nil
+}
+
+// KafkaSource reads a bounded range of messages from a single topic partition.
+// Every message is decoded into metrics, which are grouped into one table
+// per measurement, tag set and field.
+type KafkaSource struct {
+	id          execute.DatasetID
+	spec        *FromKafkaOpSpec
+	alloc       *memory.Allocator
+	start, stop time.Time
+	ts          []execute.Transformation
+}
+
+func (s *KafkaSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *KafkaSource) Run(ctx context.Context) {
+	err := s.run(ctx)
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *KafkaSource) run(ctx context.Context) error {
+	tables, err := s.read(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tbl := range tables {
+		for _, t := range s.ts {
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// read consumes the messages in the selected range and decodes them into tables.
+// Reading stops at the stop offset or at the end of the partition as it was when reading started,
+// so that the source never blocks waiting for new messages.
+func (s *KafkaSource) read(ctx context.Context) (tables []flux.Table, err error) {
+	r := DefaultKafkaReaderFactory(kafka.ReaderConfig{
+		Brokers:   s.spec.Brokers,
+		Topic:     s.spec.Topic,
+		Partition: int(s.spec.Partition),
+	})
+	defer func() {
+		if cerr := r.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if err := r.SetOffset(s.spec.StartOffset); err != nil {
+		return nil, err
+	}
+	lag, err := r.ReadLag(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read partition lag")
+	}
+
+	b := lineprotocol.NewTableBuilder(s.alloc)
+	p := &lineprotocol.Parser{Precision: s.spec.Precision}
+	if lag <= 0 {
+		return b.Tables()
+	}
+	// The end offset is captured once and compared to the position of the reader
+	// instead of counting messages, since compaction and retention leave gaps in the offsets.
+	end := int64(-1)
+	if off := r.Offset(); off >= 0 {
+		end = off + lag
+	}
+	for end < 0 || r.Offset() < end {
+		msg, err := r.ReadMessage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if end < 0 {
+			// The reader started at the first offset, it only knows its position after the first message.
+			lag, err := r.ReadLag(ctx)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read partition lag")
+			}
+			end = r.Offset() + lag
+		}
+		if s.spec.StopOffset > 0 && msg.Offset >= s.spec.StopOffset {
+			break
+		}
+		if !s.start.IsZero() && msg.Time.Before(s.start) {
+			continue
+		}
+		if !s.stop.IsZero() && !msg.Time.Before(s.stop) {
+			continue
+		}
+
+		var metrics []*lineprotocol.Metric
+		switch s.spec.Format {
+		case JSONFormat:
+			metrics, err = decodeJSONMetrics(msg.Value, s.spec.Precision, msg.Time)
+		default:
+			p.Now = func() time.Time { return msg.Time }
+			metrics, err = p.Parse(msg.Value)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode message at offset %d", msg.Offset)
+		}
+		for _, m := range metrics {
+			if err := b.Add(m); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.Tables()
+}
+
+// jsonMetric is the JSON representation of a single metric.
+type jsonMetric struct {
+	Name      string                 `json:"name"`
+	Tags      map[string]string      `json:"tags"`
+	Fields    map[string]interface{} `json:"fields"`
+	Timestamp *int64                 `json:"timestamp"`
+}
+
+// decodeJSONMetrics decodes a single metric or a batch of metrics.
+// Metrics without a timestamp get the time of the message.
+func decodeJSONMetrics(data []byte, precision time.Duration, now time.Time) ([]*lineprotocol.Metric, error) {
+	var batch struct {
+		Metrics []jsonMetric `json:"metrics"`
+	}
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, err
+	}
+	if batch.Metrics == nil {
+		var m jsonMetric
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		batch.Metrics = []jsonMetric{m}
+	}
+
+	metrics := make([]*lineprotocol.Metric, len(batch.Metrics))
+	for i, jm := range batch.Metrics {
+		if jm.Name == "" {
+			return nil, errors.New("metric is missing a name")
+		}
+		if len(jm.Fields) == 0 {
+			return nil, fmt.Errorf("metric %q has no fields", jm.Name)
+		}
+		m := &lineprotocol.Metric{
+			Name: jm.Name,
+			Time: now,
+		}
+		if jm.Timestamp != nil {
+			m.Time = values.Time(*jm.Timestamp * int64(precision)).Time()
+		}
+		for k, v := range jm.Tags {
+			m.Tags = append(m.Tags, lineprotocol.Tag{Key: k, Value: v})
+		}
+		for k, v := range jm.Fields {
+			switch v.(type) {
+			case float64, string, bool:
+			default:
+				return nil, fmt.Errorf("unsupported value %v for field %q", v, k)
+			}
+			m.Fields = append(m.Fields, lineprotocol.Field{Key: k, Value: v})
+		}
+		sortMetric(m)
+		metrics[i] = m
+	}
+	return metrics, nil
+}
+
+// sortMetric sorts the tags and fields of a metric decoded from an unordered JSON object.
+func sortMetric(m *lineprotocol.Metric) {
+	sort.Slice(m.Tags, func(i, j int) bool { return m.Tags[i].Key < m.Tags[j].Key })
+	sort.Slice(m.Fields, func(i, j int) bool { return m.Fields[i].Key < m.Fields[j].Key })
+}
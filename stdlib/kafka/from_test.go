@@ -0,0 +1,304 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	fkafka "github.com/influxdata/flux/stdlib/kafka"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+func TestFromKafka_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "no brokers",
+			Raw:     `import "kafka" kafka.from(brokers: [], topic: "metrics")`,
+			WantErr: true,
+		},
+		{
+			Name:    "unknown format",
+			Raw:     `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", format: "xml")`,
+			WantErr: true,
+		},
+		{
+			Name:    "stop before start",
+			Raw:     `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", startOffset: 10, stopOffset: 5)`,
+			WantErr: true,
+		},
+		{
+			Name: "defaults",
+			Raw:  `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromKafka0",
+						Spec: &fkafka.FromKafkaOpSpec{
+							Brokers:     []string{"b:9092"},
+							Topic:       "metrics",
+							StartOffset: fkafka.FirstOffset,
+							Format:      fkafka.LineProtocolFormat,
+							Precision:   time.Nanosecond,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "offsets and times",
+			Raw: `import "kafka"
+kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 2, startOffset: 100, stopOffset: 200,
+	start: 2018-01-01T00:00:00Z, stop: 2018-01-02T00:00:00Z, format: "json", precision: 1s)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromKafka0",
+						Spec: &fkafka.FromKafkaOpSpec{
+							Brokers:     []string{"b:9092"},
+							Topic:       "metrics",
+							Partition:   2,
+							StartOffset: 100,
+							StopOffset:  200,
+							Start:       flux.Time{Absolute: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+							Stop:        flux.Time{Absolute: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)},
+							Format:      fkafka.JSONFormat,
+							Precision:   time.Second,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+// fakeKafkaReader serves messages from memory ordered by offset.
+// Like a partition that was compacted, the offsets of the messages may have gaps.
+type fakeKafkaReader struct {
+	msgs   []kafka.Message
+	offset int64
+	config kafka.ReaderConfig
+	closed bool
+}
+
+func (r *fakeKafkaReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func (r *fakeKafkaReader) SetOffset(offset int64) error {
+	r.offset = offset
+	return nil
+}
+
+func (r *fakeKafkaReader) Offset() int64 {
+	return r.offset
+}
+
+func (r *fakeKafkaReader) ReadLag(context.Context) (int64, error) {
+	if len(r.msgs) == 0 {
+		return 0, nil
+	}
+	last := r.msgs[len(r.msgs)-1].Offset + 1
+	if r.offset == fkafka.FirstOffset {
+		return last - r.msgs[0].Offset, nil
+	}
+	return last - r.offset, nil
+}
+
+func (r *fakeKafkaReader) ReadMessage(context.Context) (kafka.Message, error) {
+	for _, msg := range r.msgs {
+		if r.offset == fkafka.FirstOffset || msg.Offset >= r.offset {
+			r.offset = msg.Offset + 1
+			return msg, nil
+		}
+	}
+	return kafka.Message{}, errors.New("read past the end of the partition")
+}
+
+func TestFromKafka_Run(t *testing.T) {
+	ts := func(sec int64) time.Time { return time.Unix(sec, 0).UTC() }
+	msgs := []kafka.Message{
+		{Offset: 0, Time: ts(1), Value: []byte("cpu,host=a usage=1 1000000000\ncpu,host=b usage=2 1000000000")},
+		{Offset: 1, Time: ts(2), Value: []byte("cpu,host=a usage=3 2000000000")},
+		{Offset: 2, Time: ts(3), Value: []byte("cpu,host=a usage=5 3000000000")},
+		{Offset: 3, Time: ts(4), Value: []byte(`{"metrics":[{"name":"cpu","tags":{"host":"a"},"fields":{"usage":7},"timestamp":4}]}`)},
+	}
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "_field", Type: flux.TString},
+		{Label: "_measurement", Type: flux.TString},
+		{Label: "host", Type: flux.TString},
+	}
+	keyCols := []string{"_field", "_measurement", "host"}
+
+	testCases := []struct {
+		name    string
+		query   string
+		msgs    []kafka.Message
+		want    []*executetest.Table
+		wantErr bool
+	}{
+		{
+			name:  "offsets",
+			query: `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 3, startOffset: 1, stopOffset: 3)`,
+			msgs:  msgs[:3],
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(2e9), 3.0, "usage", "cpu", "a"},
+					{execute.Time(3e9), 5.0, "usage", "cpu", "a"},
+				},
+			}},
+		},
+		{
+			name:  "times",
+			query: `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 3, start: 1970-01-01T00:00:00Z, stop: 1970-01-01T00:00:02Z)`,
+			msgs:  msgs[:3],
+			want: []*executetest.Table{
+				{
+					KeyCols: keyCols,
+					ColMeta: cols,
+					Data: [][]interface{}{
+						{execute.Time(1e9), 1.0, "usage", "cpu", "a"},
+					},
+				},
+				{
+					KeyCols: keyCols,
+					ColMeta: cols,
+					Data: [][]interface{}{
+						{execute.Time(1e9), 2.0, "usage", "cpu", "b"},
+					},
+				},
+			},
+		},
+		{
+			name:  "json",
+			query: `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 3, startOffset: 3, format: "json", precision: 1s)`,
+			msgs:  msgs,
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(4e9), 7.0, "usage", "cpu", "a"},
+				},
+			}},
+		},
+		{
+			name:  "offset gaps",
+			query: `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 3)`,
+			msgs: []kafka.Message{
+				{Offset: 4, Time: ts(1), Value: []byte("cpu,host=a usage=1 1000000000")},
+				{Offset: 7, Time: ts(2), Value: []byte("cpu,host=a usage=3 2000000000")},
+				{Offset: 12, Time: ts(3), Value: []byte("cpu,host=a usage=5 3000000000")},
+			},
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(1e9), 1.0, "usage", "cpu", "a"},
+					{execute.Time(2e9), 3.0, "usage", "cpu", "a"},
+					{execute.Time(3e9), 5.0, "usage", "cpu", "a"},
+				},
+			}},
+		},
+		{
+			name:  "json without timestamp",
+			query: `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 3, format: "json")`,
+			msgs: []kafka.Message{
+				{Offset: 0, Time: ts(5), Value: []byte(`{"name":"cpu","tags":{"host":"a"},"fields":{"usage":7}}`)},
+			},
+			want: []*executetest.Table{{
+				KeyCols: keyCols,
+				ColMeta: cols,
+				Data: [][]interface{}{
+					{execute.Time(5e9), 7.0, "usage", "cpu", "a"},
+				},
+			}},
+		},
+		{
+			name:    "invalid message",
+			query:   `import "kafka" kafka.from(brokers: ["b:9092"], topic: "metrics", partition: 3)`,
+			msgs:    []kafka.Message{{Value: []byte("not line protocol")}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			reader := &fakeKafkaReader{msgs: tc.msgs}
+			fkafka.DefaultKafkaReaderFactory = func(conf kafka.ReaderConfig) fkafka.KafkaReader {
+				reader.config = conf
+				return reader
+			}
+
+			querier := &querytest.Querier{
+				C: controltest.New(control.New(control.Config{
+					ConcurrencyQuota: 1,
+					MemoryBytesQuota: math.MaxInt64,
+				})),
+			}
+			q, err := querier.C.Query(context.Background(), lang.FluxCompiler{Query: tc.query})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Done()
+
+			var got []*executetest.Table
+			for _, res := range <-q.Ready() {
+				if err := res.Tables().Do(func(tbl flux.Table) error {
+					cpy, err := executetest.ConvertTable(tbl)
+					if err != nil {
+						return err
+					}
+					got = append(got, cpy)
+					return nil
+				}); err != nil {
+					if !tc.wantErr {
+						t.Fatal(err)
+					}
+					return
+				}
+			}
+			if err := q.Err(); err != nil {
+				if !tc.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected error")
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if reader.config.Partition != 3 || reader.config.Topic != "metrics" {
+				t.Errorf("unexpected reader config %+v", reader.config)
+			}
+			if !reader.closed {
+				t.Error("reader was not closed")
+			}
+		})
+	}
+}
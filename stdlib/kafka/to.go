@@ -7,16 +7,14 @@ import (
 	"fmt"
 	"io"
 	"sort"
-	"time"
 
 	"github.com/cespare/xxhash"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/lineprotocol"
 	"github.com/influxdata/flux/internal/pkg/syncutil"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
-	"github.com/influxdata/flux/values"
-	protocol "github.com/influxdata/line-protocol"
 	"github.com/pkg/errors"
 	kafka "github.com/segmentio/kafka-go"
 )
@@ -244,35 +242,6 @@ func NewToKafkaTransformation(d execute.Dataset, cache execute.TableBuilderCache
 	}
 }
 
-type toKafkaMetric struct {
-	tags   []*protocol.Tag
-	fields []*protocol.Field
-	name   string
-	t      time.Time
-}
-
-func (m *toKafkaMetric) TagList() []*protocol.Tag {
-	return m.tags
-}
-func (m *toKafkaMetric) FieldList() []*protocol.Field {
-	return m.fields
-}
-func (m *toKafkaMetric) truncateTagsAndFields() {
-	m.fields = m.fields[:0]
-	m.tags = m.tags[:0]
-}
-func (m *toKafkaMetric) Name() string {
-	return m.name
-}
-func (m *toKafkaMetric) Time() time.Time {
-	return m.t
-}
-
-type idxType struct {
-	Idx  int
-	Type flux.ColType
-}
-
 func (t *ToKafkaTransformation) Process(id execute.DatasetID, tbl flux.Table) (err error) {
 	w := DefaultKafkaWriterFactory(kafka.WriterConfig{
 		Brokers:       t.spec.Spec.Brokers,
@@ -296,37 +265,17 @@ func (t *ToKafkaTransformation) Process(id execute.DatasetID, tbl flux.Table) (e
 	}()
 	pr, pw := io.Pipe() // TODO: replce the pipe with something faster
 	// I'd like a linereader in line-protocol
-	m := &toKafkaMetric{}
-	e := protocol.NewEncoder(pw)
-	e.FailOnFieldErr(true)
-	e.SetFieldSortOrder(protocol.SortFields)
-	cols := tbl.Cols()
-	labels := make(map[string]idxType, len(cols))
-	for i, col := range cols {
-		labels[col.Label] = idxType{Idx: i, Type: col.Type}
-	}
-	// do time
-	timeColLabel := t.spec.Spec.TimeColumn
-	timeColIdx, ok := labels[timeColLabel]
-	if !ok {
-		return errors.New("Could not get time column")
-	}
-	if timeColIdx.Type != flux.TTime {
-		return fmt.Errorf("column %s is not of type %s", timeColLabel, timeColIdx.Type)
-	}
-	var measurementNameCol string
-	if t.spec.Spec.Name == "" {
-		measurementNameCol = t.spec.Spec.NameColumn
-	}
-	// check if each col is a tag or value and cache this value for the loop
-	colMetadatas := tbl.Cols()
-	isTag := make([]bool, len(colMetadatas))
-	isValue := make([]bool, len(colMetadatas))
-	for i, col := range colMetadatas {
-		isValue[i] = sort.SearchStrings(t.spec.Spec.ValueColumns, col.Label) < len(t.spec.Spec.ValueColumns) && t.spec.Spec.ValueColumns[sort.SearchStrings(t.spec.Spec.ValueColumns, col.Label)] == col.Label
-		isTag[i] = sort.SearchStrings(t.spec.Spec.TagColumns, col.Label) < len(t.spec.Spec.TagColumns) && t.spec.Spec.TagColumns[sort.SearchStrings(t.spec.Spec.TagColumns, col.Label)] == col.Label
+	e := lineprotocol.NewEncoder(pw)
+	m, err := lineprotocol.NewRowMetric(tbl.Cols(), lineprotocol.RowMetricConfig{
+		Name:         t.spec.Spec.Name,
+		NameColumn:   t.spec.Spec.NameColumn,
+		TimeColumn:   t.spec.Spec.TimeColumn,
+		TagColumns:   t.spec.Spec.TagColumns,
+		ValueColumns: t.spec.Spec.ValueColumns,
+	})
+	if err != nil {
+		return err
 	}
-	m.name = t.spec.Spec.Name
 
 	builder, new := t.cache.TableBuilder(tbl.Key())
 	if new {
@@ -340,43 +289,12 @@ func (t *ToKafkaTransformation) Process(id execute.DatasetID, tbl flux.Table) (e
 		if err := tbl.Do(func(er flux.ColReader) error {
 			l := er.Len()
 			for i := 0; i < l; i++ {
-				m.truncateTagsAndFields()
-				for j, col := range er.Cols() {
-					switch {
-					case col.Label == timeColLabel:
-						m.t = values.Time(er.Times(j).Value(i)).Time()
-					case measurementNameCol != "" && measurementNameCol == col.Label:
-						if col.Type != flux.TString {
-							return errors.New("invalid type for measurement column")
-						}
-						m.name = er.Strings(j).ValueString(i)
-					case isTag[j]:
-						if col.Type != flux.TString {
-							return errors.New("invalid type for measurement column")
-						}
-						m.tags = append(m.tags, &protocol.Tag{Key: col.Label, Value: er.Strings(j).ValueString(i)})
-					case isValue[j]:
-						switch col.Type {
-						case flux.TFloat:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Floats(j).Value(i)})
-						case flux.TInt:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Ints(j).Value(i)})
-						case flux.TUInt:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.UInts(j).Value(i)})
-						case flux.TString:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Strings(j).ValueString(i)})
-						case flux.TTime:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: values.Time(er.Times(j).Value(i))})
-						case flux.TBool:
-							m.fields = append(m.fields, &protocol.Field{Key: col.Label, Value: er.Bools(j).Value(i)})
-						default:
-							return fmt.Errorf("invalid type for column %s", col.Label)
-						}
-					}
-				}
-				_, err := e.Encode(m)
-				if err != nil {
+				if ok, err := m.Set(er, i); err != nil {
 					return err
+				} else if ok {
+					if _, err := e.Encode(m); err != nil {
+						return err
+					}
 				}
 				if err := execute.AppendRecord(i, er, builder); err != nil {
 					return err
@@ -0,0 +1,179 @@
+package lineprotocol
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	lp "github.com/influxdata/flux/internal/lineprotocol"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FromLineProtocolKind is the Kind for the FromLineProtocol Flux function
+	FromLineProtocolKind = "fromLineProtocol"
+)
+
+type FromLineProtocolOpSpec struct {
+	Text      string        `json:"text"`
+	File      string        `json:"file"`
+	Precision time.Duration `json:"precision"`
+}
+
+func init() {
+	fromLineProtocolSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"text":      semantic.String,
+			"file":      semantic.String,
+			"precision": semantic.Duration,
+		},
+		Required: nil,
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("lineprotocol", "from", flux.FunctionValue(FromLineProtocolKind, createFromLineProtocolOpSpec, fromLineProtocolSignature))
+	flux.RegisterOpSpec(FromLineProtocolKind, newFromLineProtocolOp)
+	plan.RegisterProcedureSpec(FromLineProtocolKind, newFromLineProtocolProcedure, FromLineProtocolKind)
+	execute.RegisterSource(FromLineProtocolKind, createFromLineProtocolSource)
+}
+
+// ReadArgs loads a flux.Arguments into FromLineProtocolOpSpec.
+// Exactly one of text or file must be set.
+// If the precision isn't set, it defaults to nanoseconds.
+func (o *FromLineProtocolOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	if o.Text, _, err = args.GetString("text"); err != nil {
+		return err
+	}
+	if o.File, _, err = args.GetString("file"); err != nil {
+		return err
+	}
+	if o.Text == "" && o.File == "" {
+		return errors.New("must provide line protocol text or filename")
+	}
+	if o.Text != "" && o.File != "" {
+		return errors.New("must provide exactly one of the parameters text or file")
+	}
+
+	precision, ok, err := args.GetDuration("precision")
+	if err != nil {
+		return err
+	}
+	o.Precision = time.Nanosecond
+	if ok {
+		if precision <= 0 {
+			return errors.New("precision must be positive")
+		}
+		o.Precision = time.Duration(precision)
+	}
+	return nil
+}
+
+func createFromLineProtocolOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	s := new(FromLineProtocolOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newFromLineProtocolOp() flux.OperationSpec {
+	return new(FromLineProtocolOpSpec)
+}
+
+func (FromLineProtocolOpSpec) Kind() flux.OperationKind {
+	return FromLineProtocolKind
+}
+
+type FromLineProtocolProcedureSpec struct {
+	plan.DefaultCost
+	Spec *FromLineProtocolOpSpec
+}
+
+func newFromLineProtocolProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*FromLineProtocolOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &FromLineProtocolProcedureSpec{Spec: spec}, nil
+}
+
+func (s *FromLineProtocolProcedureSpec) Kind() plan.ProcedureKind {
+	return FromLineProtocolKind
+}
+
+func (s *FromLineProtocolProcedureSpec) Copy() plan.ProcedureSpec {
+	spec := *s.Spec
+	return &FromLineProtocolProcedureSpec{Spec: &spec}
+}
+
+func createFromLineProtocolSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*FromLineProtocolProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	text := []byte(spec.Spec.Text)
+	// if File is non-empty then Text is empty
+	if spec.Spec.File != "" {
+		var err error
+		if text, err = ioutil.ReadFile(spec.Spec.File); err != nil {
+			return nil, err
+		}
+	}
+
+	now := a.ResolveTime(flux.Now).Time()
+	p := &lp.Parser{
+		Precision: spec.Spec.Precision,
+		Now:       func() time.Time { return now },
+	}
+	metrics, err := p.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	b := lp.NewTableBuilder(a.Allocator())
+	for _, m := range metrics {
+		if err := b.Add(m); err != nil {
+			return nil, err
+		}
+	}
+	tables, err := b.Tables()
+	if err != nil {
+		return nil, err
+	}
+	return &LineProtocolSource{id: dsid, tables: tables}, nil
+}
+
+// LineProtocolSource produces one table per measurement, tag set and field of the parsed metrics.
+// Lines without a timestamp are assigned the time of the query.
+type LineProtocolSource struct {
+	id     execute.DatasetID
+	tables []flux.Table
+	ts     []execute.Transformation
+}
+
+func (s *LineProtocolSource) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *LineProtocolSource) Run(ctx context.Context) {
+	err := s.run()
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *LineProtocolSource) run() error {
+	for _, tbl := range s.tables {
+		for _, t := range s.ts {
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package lineprotocol_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/lineprotocol"
+)
+
+func TestFromLineProtocol_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "no args",
+			Raw:     `import "lineprotocol" lineprotocol.from()`,
+			WantErr: true,
+		},
+		{
+			Name:    "text and file",
+			Raw:     `import "lineprotocol" lineprotocol.from(text: "m f=1", file: "m.lp")`,
+			WantErr: true,
+		},
+		{
+			Name:    "invalid precision",
+			Raw:     `import "lineprotocol" lineprotocol.from(text: "m f=1", precision: 0s)`,
+			WantErr: true,
+		},
+		{
+			Name: "defaults",
+			Raw:  `import "lineprotocol" lineprotocol.from(text: "m f=1")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromLineProtocol0",
+						Spec: &lineprotocol.FromLineProtocolOpSpec{
+							Text:      "m f=1",
+							Precision: time.Nanosecond,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "file and precision",
+			Raw:  `import "lineprotocol" lineprotocol.from(file: "m.lp", precision: 1s)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromLineProtocol0",
+						Spec: &lineprotocol.FromLineProtocolOpSpec{
+							File:      "m.lp",
+							Precision: time.Second,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestFromLineProtocol_Run(t *testing.T) {
+	query := `import "lineprotocol"
+lineprotocol.from(precision: 1s, text: "
+cpu,host=a usage=1.5,count=2i 1
+cpu,host=a usage=2.5,count=3i 2
+# a comment
+mem,host=b free=10u 1
+")`
+	querier := &querytest.Querier{
+		C: controltest.New(control.New(control.Config{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+		})),
+	}
+	q, err := querier.C.Query(context.Background(), lang.FluxCompiler{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+
+	var got []*executetest.Table
+	for _, res := range <-q.Ready() {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			cpy, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, cpy)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	table := func(field string, typ flux.ColType, data ...[]interface{}) *executetest.Table {
+		return &executetest.Table{
+			KeyCols: []string{"_field", "_measurement", "host"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_value", Type: typ},
+				{Label: "_field", Type: flux.TString},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "host", Type: flux.TString},
+			},
+			Data: data,
+		}
+	}
+	want := []*executetest.Table{
+		table("count", flux.TInt,
+			[]interface{}{execute.Time(1e9), int64(2), "count", "cpu", "a"},
+			[]interface{}{execute.Time(2e9), int64(3), "count", "cpu", "a"},
+		),
+		table("free", flux.TUInt,
+			[]interface{}{execute.Time(1e9), uint64(10), "free", "mem", "b"},
+		),
+		table("usage", flux.TFloat,
+			[]interface{}{execute.Time(1e9), 1.5, "usage", "cpu", "a"},
+			[]interface{}{execute.Time(2e9), 2.5, "usage", "cpu", "a"},
+		),
+	}
+	executetest.NormalizeTables(got)
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,281 @@
+package lineprotocol
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	lp "github.com/influxdata/flux/internal/lineprotocol"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ToLineProtocolKind is the Kind for the ToLineProtocol Flux function
+	ToLineProtocolKind = "toLineProtocol"
+)
+
+type ToLineProtocolOpSpec struct {
+	File         string   `json:"file"`
+	Name         string   `json:"name"`
+	NameColumn   string   `json:"nameColumn"` // either name or name_column must be set, if none is set try to use the "_measurement" column.
+	TimeColumn   string   `json:"timeColumn"`
+	FieldColumn  string   `json:"fieldColumn"` // holds the field keys, if empty the value columns are the field keys
+	TagColumns   []string `json:"tagColumns"`  // if empty, the string columns of the group key are the tags
+	ValueColumns []string `json:"valueColumns"`
+}
+
+func init() {
+	toLineProtocolSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"file":         semantic.String,
+			"name":         semantic.String,
+			"nameColumn":   semantic.String,
+			"timeColumn":   semantic.String,
+			"fieldColumn":  semantic.String,
+			"tagColumns":   semantic.NewArrayPolyType(semantic.String),
+			"valueColumns": semantic.NewArrayPolyType(semantic.String),
+		},
+		[]string{"file"},
+	)
+	flux.RegisterPackageValue("lineprotocol", "to", flux.FunctionValueWithSideEffect(ToLineProtocolKind, createToLineProtocolOpSpec, toLineProtocolSignature))
+	flux.RegisterOpSpec(ToLineProtocolKind, func() flux.OperationSpec { return &ToLineProtocolOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(ToLineProtocolKind, newToLineProtocolProcedure, ToLineProtocolKind)
+	execute.RegisterTransformation(ToLineProtocolKind, createToLineProtocolTransformation)
+}
+
+// ReadArgs loads a flux.Arguments into ToLineProtocolOpSpec.  It sets several default values.
+// If neither name nor nameColumn is set, the name column defaults to _measurement.
+// If the time column isn't set, it defaults to execute.DefaultTimeColLabel.
+// If the field column isn't set, it defaults to _field.
+// If the value columns aren't set, they default to []string{execute.DefaultValueColLabel}.
+func (o *ToLineProtocolOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	if o.File, err = args.GetRequiredString("file"); err != nil {
+		return err
+	}
+	if o.File == "" {
+		return errors.New("invalid file name")
+	}
+
+	var ok bool
+	if o.Name, ok, err = args.GetString("name"); err != nil {
+		return err
+	}
+	if !ok {
+		if o.NameColumn, ok, err = args.GetString("nameColumn"); err != nil {
+			return err
+		}
+		if !ok {
+			o.NameColumn = lp.MeasurementColLabel
+		}
+	}
+
+	if o.TimeColumn, ok, err = args.GetString("timeColumn"); err != nil {
+		return err
+	}
+	if !ok {
+		o.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if o.FieldColumn, ok, err = args.GetString("fieldColumn"); err != nil {
+		return err
+	}
+	if !ok {
+		o.FieldColumn = lp.FieldColLabel
+	}
+
+	if o.TagColumns, err = readSortedStrings(args, "tagColumns"); err != nil {
+		return err
+	}
+	if o.ValueColumns, err = readSortedStrings(args, "valueColumns"); err != nil {
+		return err
+	}
+	if len(o.ValueColumns) == 0 {
+		o.ValueColumns = []string{execute.DefaultValueColLabel}
+	}
+	return nil
+}
+
+// readSortedStrings reads the named array of strings and sorts it.
+func readSortedStrings(args flux.Arguments, name string) ([]string, error) {
+	arr, ok, err := args.GetArray(name, semantic.String)
+	if err != nil || !ok {
+		return nil, err
+	}
+	strs := make([]string, arr.Len())
+	for i := range strs {
+		strs[i] = arr.Get(i).Str()
+	}
+	sort.Strings(strs)
+	return strs, nil
+}
+
+func createToLineProtocolOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToLineProtocolOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToLineProtocolOpSpec) Kind() flux.OperationKind {
+	return ToLineProtocolKind
+}
+
+type ToLineProtocolProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToLineProtocolOpSpec
+}
+
+func (o *ToLineProtocolProcedureSpec) Kind() plan.ProcedureKind {
+	return ToLineProtocolKind
+}
+
+func (o *ToLineProtocolProcedureSpec) Copy() plan.ProcedureSpec {
+	s := *o.Spec
+	s.TagColumns = append([]string(nil), o.Spec.TagColumns...)
+	s.ValueColumns = append([]string(nil), o.Spec.ValueColumns...)
+	return &ToLineProtocolProcedureSpec{Spec: &s}
+}
+
+func newToLineProtocolProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToLineProtocolOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ToLineProtocolProcedureSpec{Spec: spec}, nil
+}
+
+func createToLineProtocolTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToLineProtocolProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewToLineProtocolTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// ToLineProtocolTransformation writes one line per row of every table it receives to a file
+// and passes the tables on unchanged.
+type ToLineProtocolTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	spec  *ToLineProtocolProcedureSpec
+	f     *os.File
+}
+
+// NewToLineProtocolTransformation creates a ToLineProtocolTransformation.
+// The file is created, or truncated if it exists.
+func NewToLineProtocolTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ToLineProtocolProcedureSpec) (*ToLineProtocolTransformation, error) {
+	f, err := os.Create(spec.Spec.File)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create line protocol file")
+	}
+	return &ToLineProtocolTransformation{
+		d:     d,
+		cache: cache,
+		spec:  spec,
+		f:     f,
+	}, nil
+}
+
+func (t *ToLineProtocolTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *ToLineProtocolTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	m, err := lp.NewRowMetric(tbl.Cols(), lp.RowMetricConfig{
+		Name:         t.spec.Spec.Name,
+		NameColumn:   t.spec.Spec.NameColumn,
+		TimeColumn:   t.spec.Spec.TimeColumn,
+		FieldColumn:  t.spec.Spec.FieldColumn,
+		TagColumns:   t.tagColumns(tbl.Key()),
+		ValueColumns: t.spec.Spec.ValueColumns,
+	})
+	if err != nil {
+		return err
+	}
+
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if created {
+		if err := execute.AddTableCols(tbl, builder); err != nil {
+			return err
+		}
+	}
+
+	// The lines of a table are written at once so a failed table leaves no partial lines behind.
+	var buf bytes.Buffer
+	e := lp.NewEncoder(&buf)
+	err = tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			if ok, err := m.Set(cr, i); err != nil {
+				return err
+			} else if ok {
+				if _, err := e.Encode(m); err != nil {
+					return err
+				}
+			}
+			if err := execute.AppendRecord(i, cr, builder); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := t.f.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, "failed to write line protocol file")
+	}
+	return nil
+}
+
+// tagColumns returns the tag columns of the spec.
+// If there are none, the string columns of the group key are the tags,
+// except for the _start and _stop columns and the columns that hold the name or the field key.
+func (t *ToLineProtocolTransformation) tagColumns(key flux.GroupKey) []string {
+	if len(t.spec.Spec.TagColumns) > 0 {
+		return t.spec.Spec.TagColumns
+	}
+	var tags []string
+	for _, c := range key.Cols() {
+		switch c.Label {
+		case execute.DefaultStartColLabel, execute.DefaultStopColLabel, t.spec.Spec.NameColumn, t.spec.Spec.FieldColumn:
+			continue
+		}
+		if c.Type == flux.TString {
+			tags = append(tags, c.Label)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (t *ToLineProtocolTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateWatermark(pt)
+}
+
+func (t *ToLineProtocolTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *ToLineProtocolTransformation) Finish(id execute.DatasetID, err error) {
+	if cerr := t.f.Close(); cerr != nil && err == nil {
+		err = errors.Wrap(cerr, "failed to close line protocol file")
+	}
+	t.d.Finish(err)
+}
@@ -0,0 +1,256 @@
+package lineprotocol_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/lineprotocol"
+)
+
+func TestToLineProtocol_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `import "lineprotocol" from(bucket:"mybucket") |> lineprotocol.to(file: "out.lp")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mybucket"},
+					},
+					{
+						ID: "toLineProtocol1",
+						Spec: &lineprotocol.ToLineProtocolOpSpec{
+							File:         "out.lp",
+							NameColumn:   "_measurement",
+							TimeColumn:   "_time",
+							FieldColumn:  "_field",
+							ValueColumns: []string{"_value"},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toLineProtocol1"},
+				},
+			},
+		},
+		{
+			Name: "all options",
+			Raw:  `import "lineprotocol" from(bucket:"mybucket") |> lineprotocol.to(file: "out.lp", name: "m", timeColumn: "t", fieldColumn: "", tagColumns: ["b", "a"], valueColumns: ["y", "x"])`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mybucket"},
+					},
+					{
+						ID: "toLineProtocol1",
+						Spec: &lineprotocol.ToLineProtocolOpSpec{
+							File:         "out.lp",
+							Name:         "m",
+							TimeColumn:   "t",
+							TagColumns:   []string{"a", "b"},
+							ValueColumns: []string{"x", "y"},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toLineProtocol1"},
+				},
+			},
+		},
+		{
+			Name:    "missing file",
+			Raw:     `import "lineprotocol" from(bucket:"mybucket") |> lineprotocol.to()`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestToLineProtocol_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    lineprotocol.ToLineProtocolOpSpec
+		tables  []*executetest.Table
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "influxdb shaped tables",
+			spec: lineprotocol.ToLineProtocolOpSpec{
+				NameColumn:   "_measurement",
+				TimeColumn:   "_time",
+				FieldColumn:  "_field",
+				ValueColumns: []string{"_value"},
+			},
+			tables: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "_stop", "_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(0), execute.Time(10), execute.Time(1), 1.5, "usage", "cpu", "a"},
+						{execute.Time(0), execute.Time(10), execute.Time(2), 2.5, "usage", "cpu", "a"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(3), int64(7), "count", "mem"},
+					},
+				},
+			},
+			want: "cpu,host=a usage=1.5 1\ncpu,host=a usage=2.5 2\nmem count=7i 3\n",
+		},
+		{
+			name: "value columns",
+			spec: lineprotocol.ToLineProtocolOpSpec{
+				Name:         "m",
+				TimeColumn:   "_time",
+				TagColumns:   []string{"host"},
+				ValueColumns: []string{"a", "b"},
+			},
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "a", Type: flux.TBool},
+					{Label: "b", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "x", true, "s"},
+				},
+			}},
+			want: "m,host=x a=true,b=\"s\" 1\n",
+		},
+		{
+			name: "nulls",
+			spec: lineprotocol.ToLineProtocolOpSpec{
+				Name:         "m",
+				TimeColumn:   "_time",
+				TagColumns:   []string{"host"},
+				ValueColumns: []string{"idle", "usage"},
+			},
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "idle", Type: flux.TInt},
+					{Label: "usage", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					// A null field is left out.
+					{execute.Time(1), "x", int64(90), nil},
+					// A null tag is left out.
+					{execute.Time(2), nil, int64(80), 1.5},
+					// A row without fields is dropped.
+					{execute.Time(3), "x", nil, nil},
+					// A row without time is dropped.
+					{nil, "x", int64(70), 2.5},
+				},
+			}},
+			want: "m,host=x idle=90i 1\nm idle=80i,usage=1.5 2\n",
+		},
+		{
+			name: "missing time column",
+			spec: lineprotocol.ToLineProtocolOpSpec{
+				NameColumn:   "_measurement",
+				TimeColumn:   "_time",
+				ValueColumns: []string{"_value"},
+			},
+			tables: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_measurement", Type: flux.TString},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"m", 1.0},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lineprotocolto")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			spec := tc.spec
+			spec.File = filepath.Join(dir, "out.lp")
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			tr, err := lineprotocol.NewToLineProtocolTransformation(d, c, &lineprotocol.ToLineProtocolProcedureSpec{Spec: &spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			parentID := executetest.RandomDatasetID()
+			for _, tbl := range tc.tables {
+				if err = tr.Process(parentID, tbl); err != nil {
+					break
+				}
+			}
+			tr.Finish(parentID, err)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			got, err := ioutil.ReadFile(spec.File)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, string(got)) {
+				t.Errorf("unexpected lines -want/+got\n%s", cmp.Diff(tc.want, string(got)))
+			}
+
+			// The tables are passed on unchanged.
+			gotTables, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			executetest.NormalizeTables(gotTables)
+			executetest.NormalizeTables(tc.tables)
+			sort.Sort(executetest.SortedTables(gotTables))
+			sort.Sort(executetest.SortedTables(tc.tables))
+			if !cmp.Equal(tc.tables, gotTables) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.tables, gotTables))
+			}
+		})
+	}
+}
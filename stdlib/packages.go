@@ -3,12 +3,16 @@
 package stdlib
 
 import (
+	_ "github.com/influxdata/flux/stdlib/array"
 	_ "github.com/influxdata/flux/stdlib/csv"
 	_ "github.com/influxdata/flux/stdlib/generate"
 	_ "github.com/influxdata/flux/stdlib/http"
 	_ "github.com/influxdata/flux/stdlib/influxdata/influxdb"
 	_ "github.com/influxdata/flux/stdlib/influxdata/influxdb/v1"
+	_ "github.com/influxdata/flux/stdlib/interpolate"
 	_ "github.com/influxdata/flux/stdlib/kafka"
+	_ "github.com/influxdata/flux/stdlib/lineprotocol"
+	_ "github.com/influxdata/flux/stdlib/prometheus"
 	_ "github.com/influxdata/flux/stdlib/sql"
 	_ "github.com/influxdata/flux/stdlib/system"
 	_ "github.com/influxdata/flux/stdlib/testing"
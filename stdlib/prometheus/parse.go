@@ -0,0 +1,85 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+)
+
+const ParsePrometheusKind = "parsePrometheus"
+
+type ParsePrometheusOpSpec struct {
+	Text string `json:"text"`
+}
+
+func init() {
+	parseSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"text": semantic.String,
+		},
+		Required: semantic.LabelSet{"text"},
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("prometheus", "parse", flux.FunctionValue(ParsePrometheusKind, createParsePrometheusOpSpec, parseSignature))
+	flux.RegisterOpSpec(ParsePrometheusKind, newParsePrometheusOp)
+	plan.RegisterProcedureSpec(ParsePrometheusKind, newParsePrometheusProcedure, ParsePrometheusKind)
+	execute.RegisterSource(ParsePrometheusKind, createParsePrometheusSource)
+}
+
+func createParsePrometheusOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(ParsePrometheusOpSpec)
+	var err error
+	if spec.Text, err = args.GetRequiredString("text"); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newParsePrometheusOp() flux.OperationSpec {
+	return new(ParsePrometheusOpSpec)
+}
+
+func (ParsePrometheusOpSpec) Kind() flux.OperationKind {
+	return ParsePrometheusKind
+}
+
+type ParsePrometheusProcedureSpec struct {
+	plan.DefaultCost
+	Text string
+}
+
+func newParsePrometheusProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ParsePrometheusOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ParsePrometheusProcedureSpec{Text: spec.Text}, nil
+}
+
+func (s *ParsePrometheusProcedureSpec) Kind() plan.ProcedureKind {
+	return ParsePrometheusKind
+}
+
+func (s *ParsePrometheusProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+func createParsePrometheusSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*ParsePrometheusProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+	return &Source{
+		id: dsid,
+		read: func(context.Context) ([]byte, error) {
+			return []byte(spec.Text), nil
+		},
+		now:   a.ResolveTime(flux.Now).Time(),
+		alloc: a.Allocator(),
+	}, nil
+}
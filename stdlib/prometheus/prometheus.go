@@ -0,0 +1,255 @@
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	// MeasurementColLabel is the label of the column holding the metric name.
+	MeasurementColLabel = "_measurement"
+	// FieldColLabel is the label of the column holding the kind of the sample.
+	FieldColLabel = "_field"
+	// UpperBoundColLabel is the label of the column holding the upper bound of the histogram buckets.
+	UpperBoundColLabel = "le"
+	// QuantileColLabel is the label of the column holding the quantile of the summary samples.
+	QuantileColLabel = "quantile"
+)
+
+// The values of the _field column.
+// Counters, gauges and untyped metrics have a single sample named after their type.
+// Histograms have one bucket sample per upper bound, summaries one quantile sample per quantile,
+// and both have a sum and a count sample.
+const (
+	CounterField  = "counter"
+	GaugeField    = "gauge"
+	UntypedField  = "untyped"
+	BucketField   = "bucket"
+	QuantileField = "quantile"
+	SumField      = "sum"
+	CountField    = "count"
+)
+
+// Parse decodes metrics in the Prometheus text exposition format into tables.
+// There is one table per metric name, label set and _field, grouped by _measurement, _field and the label names.
+// Each table has the columns _time, _value, _field, _measurement and one column per label.
+// The bucket tables of a histogram also have the float column le, so they can be passed to histogramQuantile,
+// and the quantile tables of a summary have the float column quantile.
+// Samples without a timestamp are assigned the time now.
+func Parse(text []byte, now time.Time, alloc *memory.Allocator) ([]flux.Table, error) {
+	var p expfmt.TextParser
+	families, err := p.TextToMetricFamilies(bytes.NewReader(text))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse prometheus metrics")
+	}
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b := &tableBuilder{alloc: alloc, tables: execute.NewGroupLookup()}
+	for _, name := range names {
+		family := families[name]
+		for _, m := range family.Metric {
+			t := now
+			if m.TimestampMs != nil {
+				t = time.Unix(0, m.GetTimestampMs()*int64(time.Millisecond))
+			}
+			if err := b.addMetric(name, family.GetType(), m, values.ConvertTime(t)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b.build()
+}
+
+type tableBuilder struct {
+	alloc  *memory.Allocator
+	tables *execute.GroupLookup
+}
+
+func (b *tableBuilder) addMetric(name string, typ dto.MetricType, m *dto.Metric, t values.Time) error {
+	switch typ {
+	case dto.MetricType_COUNTER:
+		return b.add(name, CounterField, m.Label, t, m.GetCounter().GetValue(), "", 0)
+	case dto.MetricType_GAUGE:
+		return b.add(name, GaugeField, m.Label, t, m.GetGauge().GetValue(), "", 0)
+	case dto.MetricType_UNTYPED:
+		return b.add(name, UntypedField, m.Label, t, m.GetUntyped().GetValue(), "", 0)
+	case dto.MetricType_HISTOGRAM:
+		h := m.GetHistogram()
+		for _, bucket := range h.Bucket {
+			if err := b.add(name, BucketField, m.Label, t, float64(bucket.GetCumulativeCount()), UpperBoundColLabel, bucket.GetUpperBound()); err != nil {
+				return err
+			}
+		}
+		if err := b.add(name, SumField, m.Label, t, h.GetSampleSum(), "", 0); err != nil {
+			return err
+		}
+		return b.add(name, CountField, m.Label, t, float64(h.GetSampleCount()), "", 0)
+	case dto.MetricType_SUMMARY:
+		s := m.GetSummary()
+		for _, q := range s.Quantile {
+			if err := b.add(name, QuantileField, m.Label, t, q.GetValue(), QuantileColLabel, q.GetQuantile()); err != nil {
+				return err
+			}
+		}
+		if err := b.add(name, SumField, m.Label, t, s.GetSampleSum(), "", 0); err != nil {
+			return err
+		}
+		return b.add(name, CountField, m.Label, t, float64(s.GetSampleCount()), "", 0)
+	default:
+		return errors.Errorf("unsupported type %v of prometheus metric %q", typ, name)
+	}
+}
+
+// add appends a sample to its table, the bound column is only added if its label is not empty.
+func (b *tableBuilder) add(name, field string, labels []*dto.LabelPair, t values.Time, v float64, boundLabel string, bound float64) error {
+	key := seriesKey(name, field, labels)
+	builder, err := b.tableBuilder(key, boundLabel)
+	if err != nil {
+		return err
+	}
+	if err := builder.AppendTime(0, t); err != nil {
+		return err
+	}
+	if err := builder.AppendFloat(1, v); err != nil {
+		return err
+	}
+	for j, c := range key.Cols() {
+		if err := builder.AppendValue(execute.ColIdx(c.Label, builder.Cols()), key.Value(j)); err != nil {
+			return err
+		}
+	}
+	if boundLabel != "" {
+		if err := builder.AppendFloat(len(builder.Cols())-1, bound); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *tableBuilder) tableBuilder(key flux.GroupKey, boundLabel string) (*execute.ColListTableBuilder, error) {
+	if v, ok := b.tables.Lookup(key); ok {
+		return v.(*execute.ColListTableBuilder), nil
+	}
+
+	builder := execute.NewColListTableBuilder(key, b.alloc)
+	cols := []flux.ColMeta{
+		{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		{Label: execute.DefaultValueColLabel, Type: flux.TFloat},
+	}
+	cols = append(cols, key.Cols()...)
+	if boundLabel != "" {
+		if execute.ColIdx(boundLabel, cols) >= 0 {
+			return nil, errors.Errorf("prometheus metric %v has a label named %q", key, boundLabel)
+		}
+		cols = append(cols, flux.ColMeta{Label: boundLabel, Type: flux.TFloat})
+	}
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	b.tables.Set(key, builder)
+	return builder, nil
+}
+
+// build returns the tables ordered by group key, with the rows of each table sorted by time.
+func (b *tableBuilder) build() ([]flux.Table, error) {
+	var tables []flux.Table
+	var err error
+	b.tables.Range(func(key flux.GroupKey, value interface{}) {
+		if err != nil {
+			return
+		}
+		builder := value.(*execute.ColListTableBuilder)
+		builder.Sort([]string{execute.DefaultTimeColLabel}, false)
+		var tbl flux.Table
+		tbl, err = builder.Table()
+		tables = append(tables, tbl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// seriesKey returns the group key of the table holding the samples of a metric, sorted by label.
+func seriesKey(name, field string, labels []*dto.LabelPair) flux.GroupKey {
+	cols := make([]flux.ColMeta, 0, len(labels)+2)
+	vs := make([]values.Value, 0, len(labels)+2)
+	cols = append(cols,
+		flux.ColMeta{Label: FieldColLabel, Type: flux.TString},
+		flux.ColMeta{Label: MeasurementColLabel, Type: flux.TString},
+	)
+	vs = append(vs, values.NewString(field), values.NewString(name))
+	for _, l := range labels {
+		cols = append(cols, flux.ColMeta{Label: l.GetName(), Type: flux.TString})
+		vs = append(vs, values.NewString(l.GetValue()))
+	}
+	sort.Sort(keySorter{cols: cols, values: vs})
+	return execute.NewGroupKey(cols, vs)
+}
+
+type keySorter struct {
+	cols   []flux.ColMeta
+	values []values.Value
+}
+
+func (s keySorter) Len() int           { return len(s.cols) }
+func (s keySorter) Less(i, j int) bool { return s.cols[i].Label < s.cols[j].Label }
+func (s keySorter) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+// Source produces the tables of the metrics returned by read.
+type Source struct {
+	id    execute.DatasetID
+	read  func(ctx context.Context) ([]byte, error)
+	now   time.Time
+	alloc *memory.Allocator
+	ts    []execute.Transformation
+}
+
+func (s *Source) AddTransformation(t execute.Transformation) {
+	s.ts = append(s.ts, t)
+}
+
+func (s *Source) Run(ctx context.Context) {
+	err := s.run(ctx)
+	for _, t := range s.ts {
+		t.Finish(s.id, err)
+	}
+}
+
+func (s *Source) run(ctx context.Context) error {
+	text, err := s.read(ctx)
+	if err != nil {
+		return err
+	}
+	tables, err := Parse(text, s.now, s.alloc)
+	if err != nil {
+		return err
+	}
+	for _, tbl := range tables {
+		for _, t := range s.ts {
+			if err := t.Process(s.id, tbl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
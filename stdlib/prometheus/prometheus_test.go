@@ -0,0 +1,277 @@
+package prometheus_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	fhttp "github.com/influxdata/flux/stdlib/http"
+	"github.com/influxdata/flux/stdlib/prometheus"
+)
+
+func TestPrometheus_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name:    "scrape no url",
+			Raw:     `import "prometheus" prometheus.scrape()`,
+			WantErr: true,
+		},
+		{
+			Name:    "scrape bad scheme",
+			Raw:     `import "prometheus" prometheus.scrape(url: "ftp://localhost/metrics")`,
+			WantErr: true,
+		},
+		{
+			Name:    "scrape invalid timeout",
+			Raw:     `import "prometheus" prometheus.scrape(url: "http://localhost/metrics", timeout: 0s)`,
+			WantErr: true,
+		},
+		{
+			Name: "scrape",
+			Raw:  `import "prometheus" prometheus.scrape(url: "http://localhost:9090/metrics")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "scrapePrometheus0",
+						Spec: &prometheus.ScrapePrometheusOpSpec{
+							URL:     "http://localhost:9090/metrics",
+							Timeout: prometheus.DefaultScrapeTimeout,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:    "parse no text",
+			Raw:     `import "prometheus" prometheus.parse()`,
+			WantErr: true,
+		},
+		{
+			Name: "parse",
+			Raw:  `import "prometheus" prometheus.parse(text: "up 1")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "parsePrometheus0",
+						Spec: &prometheus.ParsePrometheusOpSpec{Text: "up 1"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestScrapePrometheusOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"scrapePrometheus","kind":"scrapePrometheus","spec":{"url":"http://localhost/metrics","timeout":1000000000}}`)
+	op := &flux.Operation{
+		ID: "scrapePrometheus",
+		Spec: &prometheus.ScrapePrometheusOpSpec{
+			URL:     "http://localhost/metrics",
+			Timeout: time.Second,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestParse(t *testing.T) {
+	text := `
+# TYPE http_requests_total counter
+http_requests_total{code="200",method="get"} 10 1000
+http_requests_total{code="500",method="get"} 2 1000
+# TYPE temperature gauge
+temperature 21.5
+up 1
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 1
+latency_seconds_bucket{le="0.5"} 3
+latency_seconds_bucket{le="+Inf"} 4
+latency_seconds_sum 1.2
+latency_seconds_count 4
+# TYPE rpc_seconds summary
+rpc_seconds{quantile="0.5"} 0.2
+rpc_seconds{quantile="0.9"} 0.7
+rpc_seconds_sum 3
+rpc_seconds_count 10
+`
+	now := time.Unix(60, 0)
+	tables, err := prometheus.Parse([]byte(text), now, executetest.UnlimitedAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*executetest.Table
+	for _, tbl := range tables {
+		cpy, err := executetest.ConvertTable(tbl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, cpy)
+	}
+
+	table := func(keyCols []string, extra []flux.ColMeta, data ...[]interface{}) *executetest.Table {
+		cols := []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+		}
+		return &executetest.Table{
+			KeyCols: keyCols,
+			ColMeta: append(cols, extra...),
+			Data:    data,
+		}
+	}
+	key := []string{"_field", "_measurement"}
+	requestsKey := []string{"_field", "_measurement", "code", "method"}
+	requestsCols := []flux.ColMeta{
+		{Label: "code", Type: flux.TString},
+		{Label: "method", Type: flux.TString},
+	}
+	want := []*executetest.Table{
+		table(key, []flux.ColMeta{{Label: "le", Type: flux.TFloat}},
+			[]interface{}{execute.Time(60e9), 1.0, "bucket", "latency_seconds", 0.1},
+			[]interface{}{execute.Time(60e9), 3.0, "bucket", "latency_seconds", 0.5},
+			[]interface{}{execute.Time(60e9), 4.0, "bucket", "latency_seconds", math.Inf(1)},
+		),
+		table(key, nil, []interface{}{execute.Time(60e9), 4.0, "count", "latency_seconds"}),
+		table(key, nil, []interface{}{execute.Time(60e9), 10.0, "count", "rpc_seconds"}),
+		table(requestsKey, requestsCols, []interface{}{execute.Time(1e9), 10.0, "counter", "http_requests_total", "200", "get"}),
+		table(requestsKey, requestsCols, []interface{}{execute.Time(1e9), 2.0, "counter", "http_requests_total", "500", "get"}),
+		table(key, nil, []interface{}{execute.Time(60e9), 21.5, "gauge", "temperature"}),
+		table(key, []flux.ColMeta{{Label: "quantile", Type: flux.TFloat}},
+			[]interface{}{execute.Time(60e9), 0.2, "quantile", "rpc_seconds", 0.5},
+			[]interface{}{execute.Time(60e9), 0.7, "quantile", "rpc_seconds", 0.9},
+		),
+		table(key, nil, []interface{}{execute.Time(60e9), 1.2, "sum", "latency_seconds"}),
+		table(key, nil, []interface{}{execute.Time(60e9), 3.0, "sum", "rpc_seconds"}),
+		table(key, nil, []interface{}{execute.Time(60e9), 1.0, "untyped", "up"}),
+	}
+	executetest.NormalizeTables(got)
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	if _, err := prometheus.Parse([]byte("up{"), time.Unix(0, 0), executetest.UnlimitedAllocator); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// countingClient wraps a Client and counts the requests made through it.
+type countingClient struct {
+	client fhttp.Client
+	n      int
+}
+
+func (c *countingClient) Do(req *http.Request) (*http.Response, error) {
+	c.n++
+	return c.client.Do(req)
+}
+
+func runQuery(t *testing.T, query string, client fhttp.Client) ([]*executetest.Table, error) {
+	t.Helper()
+	querier := &querytest.Querier{
+		C: controltest.New(control.New(control.Config{
+			ConcurrencyQuota: 1,
+			MemoryBytesQuota: math.MaxInt64,
+			ExecutorDependencies: execute.Dependencies{
+				fhttp.ClientDependency: client,
+			},
+		})),
+	}
+	q, err := querier.C.Query(context.Background(), lang.FluxCompiler{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+
+	var got []*executetest.Table
+	for _, res := range <-q.Ready() {
+		if err := res.Tables().Do(func(tbl flux.Table) error {
+			cpy, err := executetest.ConvertTable(tbl)
+			if err != nil {
+				return err
+			}
+			got = append(got, cpy)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return got, q.Err()
+}
+
+func TestScrape_Run(t *testing.T) {
+	var gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`# TYPE http_requests_total counter
+http_requests_total{handler="/api"} 10 1000
+http_requests_total{handler="/api"} 12 2000
+`))
+	}))
+	defer server.Close()
+
+	client := &countingClient{client: server.Client()}
+	got, err := runQuery(t, `import "prometheus" prometheus.scrape(url: "`+server.URL+`")`, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*executetest.Table{{
+		KeyCols: []string{"_field", "_measurement", "handler"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "_field", Type: flux.TString},
+			{Label: "_measurement", Type: flux.TString},
+			{Label: "handler", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1e9), 10.0, "counter", "http_requests_total", "/api"},
+			{execute.Time(2e9), 12.0, "counter", "http_requests_total", "/api"},
+		},
+	}}
+	executetest.NormalizeTables(got)
+	executetest.NormalizeTables(want)
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, got))
+	}
+	if client.n != 1 {
+		t.Errorf("expected dependency client to be used once, got %d requests", client.n)
+	}
+	if gotAccept == "" {
+		t.Error("expected an Accept header")
+	}
+}
+
+func TestScrape_StatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := runQuery(t, `import "prometheus" prometheus.scrape(url: "`+server.URL+`")`, server.Client()); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,161 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	fhttp "github.com/influxdata/flux/stdlib/http"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	ScrapePrometheusKind = "scrapePrometheus"
+	DefaultScrapeTimeout = 10 * time.Second
+)
+
+type ScrapePrometheusOpSpec struct {
+	URL     string        `json:"url"`
+	Timeout time.Duration `json:"timeout"`
+}
+
+func init() {
+	scrapeSignature := semantic.FunctionPolySignature{
+		Parameters: map[string]semantic.PolyType{
+			"url":     semantic.String,
+			"timeout": semantic.Duration,
+		},
+		Required: semantic.LabelSet{"url"},
+		Return:   flux.TableObjectType,
+	}
+	flux.RegisterPackageValue("prometheus", "scrape", flux.FunctionValue(ScrapePrometheusKind, createScrapePrometheusOpSpec, scrapeSignature))
+	flux.RegisterOpSpec(ScrapePrometheusKind, newScrapePrometheusOp)
+	plan.RegisterProcedureSpec(ScrapePrometheusKind, newScrapePrometheusProcedure, ScrapePrometheusKind)
+	execute.RegisterSource(ScrapePrometheusKind, createScrapePrometheusSource)
+}
+
+// ReadArgs loads a flux.Arguments into ScrapePrometheusOpSpec.
+// If the timeout isn't set, it defaults to DefaultScrapeTimeout.
+func (o *ScrapePrometheusOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	if o.URL, err = args.GetRequiredString("url"); err != nil {
+		return err
+	}
+	u, err := url.ParseRequestURI(o.URL)
+	if err != nil {
+		return err
+	}
+	if !(u.Scheme == "https" || u.Scheme == "http") {
+		return fmt.Errorf("scheme must be http or https but was %s", u.Scheme)
+	}
+
+	timeout, ok, err := args.GetDuration("timeout")
+	if err != nil {
+		return err
+	}
+	o.Timeout = DefaultScrapeTimeout
+	if ok {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		o.Timeout = time.Duration(timeout)
+	}
+	return nil
+}
+
+func createScrapePrometheusOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	spec := new(ScrapePrometheusOpSpec)
+	if err := spec.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newScrapePrometheusOp() flux.OperationSpec {
+	return new(ScrapePrometheusOpSpec)
+}
+
+func (ScrapePrometheusOpSpec) Kind() flux.OperationKind {
+	return ScrapePrometheusKind
+}
+
+type ScrapePrometheusProcedureSpec struct {
+	plan.DefaultCost
+	URL     string
+	Timeout time.Duration
+}
+
+func newScrapePrometheusProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ScrapePrometheusOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ScrapePrometheusProcedureSpec{
+		URL:     spec.URL,
+		Timeout: spec.Timeout,
+	}, nil
+}
+
+func (s *ScrapePrometheusProcedureSpec) Kind() plan.ProcedureKind {
+	return ScrapePrometheusKind
+}
+
+func (s *ScrapePrometheusProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	return &ns
+}
+
+// createScrapePrometheusSource creates a source that requests the metrics with the Client provided
+// under the http.ClientDependency, or with http.DefaultClient if none was provided.
+func createScrapePrometheusSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*ScrapePrometheusProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	client, err := fhttp.ClientFromDependencies(a.Dependencies())
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Source{
+		id: dsid,
+		read: func(ctx context.Context) ([]byte, error) {
+			return scrape(ctx, client, spec)
+		},
+		now:   a.ResolveTime(flux.Now).Time(),
+		alloc: a.Allocator(),
+	}, nil
+}
+
+func scrape(ctx context.Context, client fhttp.Client, spec *ScrapePrometheusProcedureSpec) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, spec.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	req.Header.Set("User-Agent", fhttp.DefaultToHTTPUserAgent)
+
+	ctx, cancel := context.WithTimeout(ctx, spec.Timeout)
+	defer cancel()
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to scrape %s", spec.URL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape %s: unexpected status %s", spec.URL, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,197 @@
+package sql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+)
+
+// Dialect describes how sql.from and sql.to talk to the databases behind a database/sql driver.
+//
+// Dialects are registered by driver name with RegisterDialect.
+// Programs that link in additional drivers register a dialect for each of them to make them available to Flux.
+type Dialect interface {
+	// ColumnType returns the Flux type of a result column.
+	ColumnType(ct *sql.ColumnType) flux.ColType
+	// SQLType returns the SQL type used when creating a column of the Flux type.
+	SQLType(typ flux.ColType) (string, error)
+	// QuoteIdentifier quotes a table or column name.
+	QuoteIdentifier(name string) string
+	// Placeholder returns the nth (1-based) bind parameter of a statement.
+	Placeholder(n int) string
+	// Pushdown reports which operations the database can evaluate in place of Flux.
+	Pushdown() PushdownCapabilities
+}
+
+// PushdownCapabilities lists the operations that may be pushed into the query sent to a database.
+type PushdownCapabilities struct {
+	// Limit reports whether the database supports
+	// SELECT * FROM (query) AS alias LIMIT n OFFSET m.
+	Limit bool
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = make(map[string]Dialect)
+)
+
+// RegisterDialect makes a dialect available for the database/sql driver with the given name.
+// It panics if a dialect is already registered for the driver.
+func RegisterDialect(driverName string, d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	if d == nil {
+		panic("sql: RegisterDialect dialect is nil")
+	}
+	if _, dup := dialects[driverName]; dup {
+		panic(fmt.Sprintf("sql: RegisterDialect called twice for driver %s", driverName))
+	}
+	dialects[driverName] = d
+}
+
+// DialectFor returns the dialect registered for the driver.
+func DialectFor(driverName string) (Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	d, ok := dialects[driverName]
+	return d, ok
+}
+
+// lookupDialect returns the dialect of a driver that is both registered with database/sql and has a dialect.
+func lookupDialect(driverName string) (Dialect, error) {
+	d, ok := DialectFor(driverName)
+	if !ok || !driverRegistered(driverName) {
+		return nil, fmt.Errorf("sql driver %s not supported", driverName)
+	}
+	return d, nil
+}
+
+func driverRegistered(name string) bool {
+	for _, d := range sql.Drivers() {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	RegisterDialect("postgres", PostgresDialect{})
+	RegisterDialect("mysql", MySQLDialect{})
+}
+
+// GenericDialect is a dialect for databases that follow standard SQL.
+// It quotes identifiers with double quotes and uses ? placeholders.
+// Other dialects can embed it and override what differs.
+type GenericDialect struct{}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ColumnType derives the Flux type of a result column from its scan type,
+// falling back to the database type name when the driver does not report a specific scan type.
+// Columns of unknown type are read as strings.
+func (GenericDialect) ColumnType(ct *sql.ColumnType) flux.ColType {
+	if st := ct.ScanType(); st != nil {
+		if st == timeType {
+			return flux.TTime
+		}
+		switch st.Kind() {
+		case reflect.Bool:
+			return flux.TBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return flux.TInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return flux.TUInt
+		case reflect.Float32, reflect.Float64:
+			return flux.TFloat
+		}
+	}
+	switch strings.ToUpper(ct.DatabaseTypeName()) {
+	case "BOOL", "BOOLEAN":
+		return flux.TBool
+	case "INT", "INT2", "INT4", "INT8", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "YEAR":
+		return flux.TInt
+	case "FLOAT", "FLOAT4", "FLOAT8", "REAL", "DOUBLE", "DOUBLE PRECISION", "DECIMAL", "NUMERIC":
+		return flux.TFloat
+	case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return flux.TTime
+	default:
+		return flux.TString
+	}
+}
+
+func (GenericDialect) SQLType(typ flux.ColType) (string, error) {
+	switch typ {
+	case flux.TInt:
+		return "BIGINT", nil
+	case flux.TUInt:
+		// most databases have no unsigned integers, use a type wide enough for every uint64
+		return "NUMERIC(20)", nil
+	case flux.TFloat:
+		return "DOUBLE PRECISION", nil
+	case flux.TString:
+		return "TEXT", nil
+	case flux.TBool:
+		return "BOOLEAN", nil
+	case flux.TTime:
+		return "TIMESTAMP", nil
+	default:
+		return "", fmt.Errorf("unsupported column type %s", typ)
+	}
+}
+
+func (GenericDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func (GenericDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (GenericDialect) Pushdown() PushdownCapabilities {
+	return PushdownCapabilities{}
+}
+
+// PostgresDialect is the dialect of the postgres driver.
+type PostgresDialect struct {
+	GenericDialect
+}
+
+func (PostgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (PostgresDialect) Pushdown() PushdownCapabilities {
+	return PushdownCapabilities{Limit: true}
+}
+
+// MySQLDialect is the dialect of the mysql driver.
+type MySQLDialect struct {
+	GenericDialect
+}
+
+func (d MySQLDialect) SQLType(typ flux.ColType) (string, error) {
+	switch typ {
+	case flux.TUInt:
+		return "BIGINT UNSIGNED", nil
+	case flux.TFloat:
+		return "DOUBLE", nil
+	case flux.TTime:
+		return "DATETIME(6)", nil
+	default:
+		return d.GenericDialect.SQLType(typ)
+	}
+}
+
+func (MySQLDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func (MySQLDialect) Pushdown() PushdownCapabilities {
+	return PushdownCapabilities{Limit: true}
+}
@@ -0,0 +1,80 @@
+package sql_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	fsql "github.com/influxdata/flux/stdlib/sql"
+)
+
+func TestDialects(t *testing.T) {
+	testCases := []struct {
+		driver      string
+		quoted      string
+		placeholder string
+		types       map[flux.ColType]string
+	}{
+		{
+			driver:      "postgres",
+			quoted:      `"my ""table"""`,
+			placeholder: "$2",
+			types: map[flux.ColType]string{
+				flux.TInt:    "BIGINT",
+				flux.TUInt:   "NUMERIC(20)",
+				flux.TFloat:  "DOUBLE PRECISION",
+				flux.TString: "TEXT",
+				flux.TBool:   "BOOLEAN",
+				flux.TTime:   "TIMESTAMP",
+			},
+		},
+		{
+			driver:      "mysql",
+			quoted:      "`my \"table\"`",
+			placeholder: "?",
+			types: map[flux.ColType]string{
+				flux.TInt:    "BIGINT",
+				flux.TUInt:   "BIGINT UNSIGNED",
+				flux.TFloat:  "DOUBLE",
+				flux.TString: "TEXT",
+				flux.TBool:   "BOOLEAN",
+				flux.TTime:   "DATETIME(6)",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.driver, func(t *testing.T) {
+			d, ok := fsql.DialectFor(tc.driver)
+			if !ok {
+				t.Fatalf("no dialect registered for %s", tc.driver)
+			}
+			if got := d.QuoteIdentifier(`my "table"`); got != tc.quoted {
+				t.Errorf("unexpected quoted identifier: want %s got %s", tc.quoted, got)
+			}
+			if got := d.Placeholder(2); got != tc.placeholder {
+				t.Errorf("unexpected placeholder: want %s got %s", tc.placeholder, got)
+			}
+			for typ, want := range tc.types {
+				got, err := d.SQLType(typ)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("unexpected SQL type for %v: want %s got %s", typ, want, got)
+				}
+			}
+			if !d.Pushdown().Limit {
+				t.Error("expected limit pushdown")
+			}
+		})
+	}
+}
+
+func TestRegisterDialect_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when registering a dialect twice")
+		}
+	}()
+	fsql.RegisterDialect("postgres", fsql.GenericDialect{})
+}
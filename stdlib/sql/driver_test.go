@@ -0,0 +1,180 @@
+package sql_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	fsql "github.com/influxdata/flux/stdlib/sql"
+)
+
+// fakeDriver records the statements executed against each data source name
+// and answers every query with the data source's result.
+// A statement whose recorded event, the query followed by its arguments,
+// contains the data source's failOn string returns an error.
+type fakeDriver struct {
+	mu  sync.Mutex
+	dbs map[string]*fakeDB
+}
+
+type fakeDB struct {
+	failOn string
+	result *fakeResult
+	events []string
+}
+
+type fakeColumn struct {
+	name     string
+	typeName string
+	// scanType defaults to interface{}, like drivers that do not report one.
+	scanType reflect.Type
+}
+
+type fakeResult struct {
+	cols []fakeColumn
+	rows [][]driver.Value
+}
+
+var testDriver = &fakeDriver{dbs: make(map[string]*fakeDB)}
+
+// testDialect is the dialect of the fake driver, it supports every pushdown.
+type testDialect struct {
+	fsql.GenericDialect
+}
+
+func (testDialect) Pushdown() fsql.PushdownCapabilities {
+	return fsql.PushdownCapabilities{Limit: true}
+}
+
+func init() {
+	sql.Register("fluxtest", testDriver)
+	fsql.RegisterDialect("fluxtest", testDialect{})
+}
+
+// newFakeDB registers a new data source name and returns it.
+func (d *fakeDriver) newFakeDB(name, failOn string, result *fakeResult) *fakeDB {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	db := &fakeDB{failOn: failOn, result: result}
+	d.dbs[name] = db
+	return db
+}
+
+func (d *fakeDriver) record(name, event string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dbs[name].events = append(d.dbs[name].events, event)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.dbs[name]; !ok {
+		return nil, fmt.Errorf("unknown data source %q", name)
+	}
+	return &fakeConn{d: d, name: name}, nil
+}
+
+type fakeConn struct {
+	d    *fakeDriver
+	name string
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.d.record(c.name, "BEGIN")
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.d.record(c.name, "COMMIT")
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.d.record(c.name, "ROLLBACK")
+	return nil
+}
+
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) run(args []driver.Value) (*fakeDB, error) {
+	s.c.d.mu.Lock()
+	db := s.c.d.dbs[s.c.name]
+	s.c.d.mu.Unlock()
+	event := fmt.Sprintf("%s %v", s.query, args)
+	if db.failOn != "" && strings.Contains(event, db.failOn) {
+		return nil, errors.New("fake failure")
+	}
+	s.c.d.record(s.c.name, event)
+	return db, nil
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if _, err := s.run(args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	db, err := s.run(args)
+	if err != nil {
+		return nil, err
+	}
+	if db.result == nil {
+		return nil, errors.New("no result")
+	}
+	return &fakeRows{result: db.result}, nil
+}
+
+type fakeRows struct {
+	result *fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string {
+	names := make([]string, len(r.result.cols))
+	for i, c := range r.result.cols {
+		names[i] = c.name
+	}
+	return names
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(i int) string {
+	return r.result.cols[i].typeName
+}
+
+func (r *fakeRows) ColumnTypeScanType(i int) reflect.Type {
+	if t := r.result.cols[i].scanType; t != nil {
+		return t
+	}
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
@@ -1,10 +1,10 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-
-	"reflect"
+	"strconv"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -12,16 +12,24 @@ import (
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/universe"
 	"github.com/influxdata/flux/values"
 	_ "github.com/lib/pq"
 )
 
-const FromSQLKind = "fromSQL"
+const (
+	FromSQLKind = "fromSQL"
+
+	// DefaultFromSQLBatchSize is the default maximum number of rows in each table produced by sql.from.
+	DefaultFromSQLBatchSize = 10000
+)
 
 type FromSQLOpSpec struct {
-	DriverName     string `json:"driverName,omitempty"`
-	DataSourceName string `json:"dataSourceName,omitempty"`
-	Query          string `json:"query,omitempty"`
+	DriverName     string        `json:"driverName,omitempty"`
+	DataSourceName string        `json:"dataSourceName,omitempty"`
+	Query          string        `json:"query,omitempty"`
+	Params         []interface{} `json:"params,omitempty"`
+	BatchSize      int64         `json:"batchSize,omitempty"`
 }
 
 func init() {
@@ -30,6 +38,8 @@ func init() {
 			"driverName":     semantic.String,
 			"dataSourceName": semantic.String,
 			"query":          semantic.String,
+			"params":         semantic.NewArrayPolyType(semantic.Tvar(1)),
+			"batchSize":      semantic.Int,
 		},
 		Required: semantic.LabelSet{"driverName", "dataSourceName", "query"},
 		Return:   flux.TableObjectType,
@@ -38,6 +48,7 @@ func init() {
 	flux.RegisterOpSpec(FromSQLKind, newFromSQLOp)
 	plan.RegisterProcedureSpec(FromSQLKind, newFromSQLProcedure, FromSQLKind)
 	execute.RegisterSource(FromSQLKind, createFromSQLSource)
+	plan.RegisterPhysicalRules(MergeSQLFromLimitRule{})
 }
 
 func createFromSQLOpSpec(args flux.Arguments, administration *flux.Administration) (flux.OperationSpec, error) {
@@ -61,6 +72,28 @@ func createFromSQLOpSpec(args flux.Arguments, administration *flux.Administratio
 		spec.Query = query
 	}
 
+	// params may be an array of any element type, so it is not read with GetArray.
+	if v, ok := args.Get("params"); ok {
+		if v.Type().Nature() != semantic.Array {
+			return nil, fmt.Errorf("keyword argument %q should be of kind %v, but got %v", "params", semantic.Array, v.Type().Nature())
+		}
+		params := v.Array()
+		spec.Params = make([]interface{}, params.Len())
+		params.Range(func(i int, v values.Value) {
+			spec.Params[i] = sqlValue(v)
+		})
+	}
+
+	if batchSize, ok, err := args.GetInt("batchSize"); err != nil {
+		return nil, err
+	} else if !ok {
+		spec.BatchSize = DefaultFromSQLBatchSize
+	} else if batchSize <= 0 {
+		return nil, fmt.Errorf("batchSize must be positive, got %d", batchSize)
+	} else {
+		spec.BatchSize = batchSize
+	}
+
 	return spec, nil
 }
 
@@ -77,6 +110,13 @@ type FromSQLProcedureSpec struct {
 	DriverName     string
 	DataSourceName string
 	Query          string
+	Params         []interface{}
+	BatchSize      int64
+
+	// LimitSet is true when a limit has been pushed into the query.
+	LimitSet bool
+	Limit    int64
+	Offset   int64
 }
 
 func newFromSQLProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -89,6 +129,8 @@ func newFromSQLProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.Pr
 		DriverName:     spec.DriverName,
 		DataSourceName: spec.DataSourceName,
 		Query:          spec.Query,
+		Params:         spec.Params,
+		BatchSize:      spec.BatchSize,
 	}, nil
 }
 
@@ -101,30 +143,95 @@ func (s *FromSQLProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.DriverName = s.DriverName
 	ns.DataSourceName = s.DataSourceName
 	ns.Query = s.Query
+	ns.Params = append([]interface{}(nil), s.Params...)
+	ns.BatchSize = s.BatchSize
+	ns.LimitSet = s.LimitSet
+	ns.Limit = s.Limit
+	ns.Offset = s.Offset
 	return ns
 }
 
+// query returns the query sent to the database, including any pushed down operations.
+func (s *FromSQLProcedureSpec) query() string {
+	if !s.LimitSet {
+		return s.Query
+	}
+	return fmt.Sprintf("SELECT * FROM (%s) AS flux_subquery LIMIT %d OFFSET %d", s.Query, s.Limit, s.Offset)
+}
+
+// MergeSQLFromLimitRule pushes a `limit` into a `sql.from` when the dialect of its driver supports it.
+type MergeSQLFromLimitRule struct{}
+
+// Name returns the name of the rule
+func (rule MergeSQLFromLimitRule) Name() string {
+	return "MergeSQLFromLimitRule"
+}
+
+// Pattern returns the pattern that matches `sql.from -> limit`
+func (rule MergeSQLFromLimitRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.LimitKind, plan.Pat(FromSQLKind))
+}
+
+// Rewrite merges a `sql.from -> limit` into a single `sql.from`
+func (rule MergeSQLFromLimitRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	from := node.Predecessors()[0]
+	fromSpec := from.ProcedureSpec().(*FromSQLProcedureSpec)
+	limitSpec := node.ProcedureSpec().(*universe.LimitProcedureSpec)
+
+	if fromSpec.LimitSet || len(from.Successors()) != 1 {
+		return node, false, nil
+	}
+	if d, ok := DialectFor(fromSpec.DriverName); !ok || !d.Pushdown().Limit {
+		return node, false, nil
+	}
+
+	fromLimit := fromSpec.Copy().(*FromSQLProcedureSpec)
+	fromLimit.LimitSet = true
+	fromLimit.Limit = limitSpec.N
+	fromLimit.Offset = limitSpec.Offset
+
+	merged, err := plan.MergePhysicalPlanNodes(node, from, fromLimit)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
 func createFromSQLSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	spec, ok := prSpec.(*FromSQLProcedureSpec)
 	if !ok {
 		return nil, fmt.Errorf("invalid spec type %T", prSpec)
 	}
 
-	if spec.DriverName != "postgres" && spec.DriverName != "mysql" {
-		return nil, fmt.Errorf("sql driver %s not supported", spec.DriverName)
+	dialect, err := lookupDialect(spec.DriverName)
+	if err != nil {
+		return nil, err
 	}
 
-	SQLIterator := SQLIterator{id: dsid, spec: spec, administration: a}
+	SQLIterator := SQLIterator{id: dsid, spec: spec, dialect: dialect, administration: a}
 
 	return execute.CreateSourceFromDecoder(&SQLIterator, dsid, a)
 }
 
+// SQLIterator runs a query and streams its result as tables of at most BatchSize rows.
+// The tables share the empty group key.
 type SQLIterator struct {
 	id             execute.DatasetID
 	administration execute.Administration
 	spec           *FromSQLProcedureSpec
+	dialect        Dialect
 	db             *sql.DB
 	rows           *sql.Rows
+	cols           []flux.ColMeta
+	// hasRow reports whether rows holds a row that has not been decoded yet.
+	hasRow bool
+}
+
+func (c *SQLIterator) context() context.Context {
+	if ctx := c.administration.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
 }
 
 func (c *SQLIterator) Connect() error {
@@ -132,7 +239,8 @@ func (c *SQLIterator) Connect() error {
 	if err != nil {
 		return err
 	}
-	if err = db.Ping(); err != nil {
+	if err = db.PingContext(c.context()); err != nil {
+		db.Close()
 		return err
 	}
 	c.db = db
@@ -141,106 +249,163 @@ func (c *SQLIterator) Connect() error {
 }
 
 func (c *SQLIterator) Fetch() (bool, error) {
-	rows, err := c.db.Query(c.spec.Query)
+	if c.rows != nil {
+		// The query has already run, the remaining rows make up the next table.
+		return c.hasRow, nil
+	}
+
+	rows, err := c.db.QueryContext(c.context(), c.spec.query(), c.spec.Params...)
 	if err != nil {
 		return false, err
 	}
 	c.rows = rows
 
-	return false, nil
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return false, err
+	}
+	c.cols = make([]flux.ColMeta, len(columnTypes))
+	for i, ct := range columnTypes {
+		c.cols[i] = flux.ColMeta{Label: ct.Name(), Type: c.dialect.ColumnType(ct)}
+	}
+
+	c.hasRow = rows.Next()
+	return c.hasRow, rows.Err()
 }
 
+// Decode reads the next table, holding at most BatchSize rows.
 func (c *SQLIterator) Decode() (flux.Table, error) {
-	groupKey := execute.NewGroupKey(nil, nil)
-	builder := execute.NewColListTableBuilder(groupKey, c.administration.Allocator())
-
-	firstRow := true
-	for c.rows.Next() {
-		columnNames, err := c.rows.Columns()
-		if err != nil {
+	builder := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), c.administration.Allocator())
+	for _, col := range c.cols {
+		if _, err := builder.AddCol(col); err != nil {
 			return nil, err
 		}
-		columns := make([]interface{}, len(columnNames))
-		columnPointers := make([]interface{}, len(columnNames))
-		for i := 0; i < len(columnNames); i++ {
-			columnPointers[i] = &columns[i]
-		}
+	}
+
+	batchSize := c.spec.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultFromSQLBatchSize
+	}
+	columns := make([]interface{}, len(c.cols))
+	columnPointers := make([]interface{}, len(c.cols))
+	for i := range columns {
+		columnPointers[i] = &columns[i]
+	}
+	for n := int64(0); c.hasRow && n < batchSize; n++ {
 		if err := c.rows.Scan(columnPointers...); err != nil {
 			return nil, err
 		}
-
-		if firstRow {
-			for i, col := range columns {
-				var dataType flux.ColType
-				switch col.(type) {
-				case bool:
-					dataType = flux.TBool
-				case int64:
-					dataType = flux.TInt
-				case uint64:
-					dataType = flux.TUInt
-				case float64:
-					dataType = flux.TFloat
-				case string:
-					dataType = flux.TString
-				case []uint8:
-					// Hack for MySQL, might need to work with charset?
-					dataType = flux.TString
-				case time.Time:
-					dataType = flux.TTime
-				default:
-					fmt.Println(i, reflect.TypeOf(col))
-					execute.PanicUnknownType(flux.TInvalid)
-				}
-
-				_, err := builder.AddCol(flux.ColMeta{Label: columnNames[i], Type: dataType})
-				if err != nil {
-					return nil, err
-				}
-			}
-			firstRow = false
-		}
-
 		for i, col := range columns {
-			switch col.(type) {
-			case bool:
-				if err := builder.AppendBool(i, col.(bool)); err != nil {
-					return nil, err
-				}
-			case int64:
-				if err := builder.AppendInt(i, col.(int64)); err != nil {
-					return nil, err
-				}
-			case uint64:
-				if err := builder.AppendUInt(i, col.(uint64)); err != nil {
-					return nil, err
-				}
-			case float64:
-				if err := builder.AppendFloat(i, col.(float64)); err != nil {
-					return nil, err
-				}
-			case string:
-				if err := builder.AppendString(i, col.(string)); err != nil {
-					return nil, err
-				}
-			case []uint8:
-				// Hack for MySQL, might need to work with charset?
-				if err := builder.AppendString(i, string(col.([]uint8))); err != nil {
-					return nil, err
-				}
-			case time.Time:
-				if err := builder.AppendTime(i, values.ConvertTime(col.(time.Time))); err != nil {
-					return nil, err
-				}
-			default:
-				execute.PanicUnknownType(flux.TInvalid)
+			if err := appendSQLValue(builder, i, c.cols[i].Type, col); err != nil {
+				return nil, fmt.Errorf("column %s: %v", c.cols[i].Label, err)
 			}
 		}
+		c.hasRow = c.rows.Next()
+	}
+	if err := c.rows.Err(); err != nil {
+		return nil, err
 	}
-
 	return builder.Table()
 }
 
 func (c *SQLIterator) Close() error {
+	if c.rows != nil {
+		c.rows.Close()
+	}
 	return c.db.Close()
 }
+
+var timeLayout = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// appendSQLValue converts a scanned value to the column type and appends it, NULLs become Flux nulls.
+func appendSQLValue(b *execute.ColListTableBuilder, j int, typ flux.ColType, v interface{}) error {
+	if v == nil {
+		return b.AppendNil(j)
+	}
+	// Some drivers, notably MySQL, return most values as text.
+	raw, isRaw := v.([]byte)
+	switch typ {
+	case flux.TBool:
+		switch v := v.(type) {
+		case bool:
+			return b.AppendBool(j, v)
+		case int64:
+			return b.AppendBool(j, v != 0)
+		}
+		if isRaw {
+			bv, err := strconv.ParseBool(string(raw))
+			if err != nil {
+				return err
+			}
+			return b.AppendBool(j, bv)
+		}
+	case flux.TInt:
+		if v, ok := v.(int64); ok {
+			return b.AppendInt(j, v)
+		}
+		if isRaw {
+			iv, err := strconv.ParseInt(string(raw), 10, 64)
+			if err != nil {
+				return err
+			}
+			return b.AppendInt(j, iv)
+		}
+	case flux.TUInt:
+		switch v := v.(type) {
+		case uint64:
+			return b.AppendUInt(j, v)
+		case int64:
+			return b.AppendUInt(j, uint64(v))
+		}
+		if isRaw {
+			uv, err := strconv.ParseUint(string(raw), 10, 64)
+			if err != nil {
+				return err
+			}
+			return b.AppendUInt(j, uv)
+		}
+	case flux.TFloat:
+		switch v := v.(type) {
+		case float64:
+			return b.AppendFloat(j, v)
+		case int64:
+			return b.AppendFloat(j, float64(v))
+		}
+		if isRaw {
+			fv, err := strconv.ParseFloat(string(raw), 64)
+			if err != nil {
+				return err
+			}
+			return b.AppendFloat(j, fv)
+		}
+	case flux.TString:
+		switch v := v.(type) {
+		case string:
+			return b.AppendString(j, v)
+		case []byte:
+			return b.AppendString(j, string(v))
+		default:
+			return b.AppendString(j, fmt.Sprint(v))
+		}
+	case flux.TTime:
+		switch v := v.(type) {
+		case time.Time:
+			return b.AppendTime(j, values.ConvertTime(v))
+		case string:
+			raw, isRaw = []byte(v), true
+		}
+		if isRaw {
+			for _, layout := range timeLayout {
+				if t, err := time.Parse(layout, string(raw)); err == nil {
+					return b.AppendTime(j, values.ConvertTime(t))
+				}
+			}
+			return fmt.Errorf("cannot parse %q as time", raw)
+		}
+	}
+	return fmt.Errorf("cannot convert %T to %v", v, typ)
+}
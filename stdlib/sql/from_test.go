@@ -0,0 +1,236 @@
+package sql_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/control"
+	"github.com/influxdata/flux/control/controltest"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/querytest"
+	fsql "github.com/influxdata/flux/stdlib/sql"
+)
+
+func TestFromSQL_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `import "sql" sql.from(driverName: "postgres", dataSourceName: "postgres://localhost", query: "SELECT * FROM t")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromSQL0",
+						Spec: &fsql.FromSQLOpSpec{
+							DriverName:     "postgres",
+							DataSourceName: "postgres://localhost",
+							Query:          "SELECT * FROM t",
+							BatchSize:      fsql.DefaultFromSQLBatchSize,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "params and batch size",
+			Raw:  `import "sql" sql.from(driverName: "postgres", dataSourceName: "postgres://localhost", query: "SELECT * FROM t WHERE a = $1 OR a = $2", params: ["x", "y"], batchSize: 100)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "fromSQL0",
+						Spec: &fsql.FromSQLOpSpec{
+							DriverName:     "postgres",
+							DataSourceName: "postgres://localhost",
+							Query:          "SELECT * FROM t WHERE a = $1 OR a = $2",
+							Params:         []interface{}{"x", "y"},
+							BatchSize:      100,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:    "invalid batch size",
+			Raw:     `import "sql" sql.from(driverName: "postgres", dataSourceName: "postgres://localhost", query: "SELECT * FROM t", batchSize: -1)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestFromSQL_Run(t *testing.T) {
+	ts := func(sec int64) time.Time { return time.Unix(sec, 0).UTC() }
+	result := &fakeResult{
+		cols: []fakeColumn{
+			{name: "time", typeName: "TIMESTAMPTZ", scanType: reflect.TypeOf(time.Time{})},
+			{name: "host", typeName: "TEXT", scanType: reflect.TypeOf("")},
+			{name: "value", typeName: "NUMERIC"},
+			{name: "count", typeName: "BIGINT", scanType: reflect.TypeOf(uint64(0))},
+			{name: "ok", typeName: "BOOL"},
+			{name: "created", typeName: "DATETIME"},
+		},
+		rows: [][]driver.Value{
+			{ts(1), "a", []byte("1.5"), uint64(1), true, []byte("2018-01-01 00:00:00")},
+			{ts(2), "b", nil, uint64(2), nil, []byte("2018-01-02 00:00:00")},
+			{ts(3), nil, float64(3), nil, false, nil},
+		},
+	}
+	cols := []flux.ColMeta{
+		{Label: "time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "value", Type: flux.TFloat},
+		{Label: "count", Type: flux.TUInt},
+		{Label: "ok", Type: flux.TBool},
+		{Label: "created", Type: flux.TTime},
+	}
+	rows := [][]interface{}{
+		{execute.Time(1e9), "a", 1.5, uint64(1), true, execute.Time(1514764800e9)},
+		{execute.Time(2e9), "b", nil, uint64(2), nil, execute.Time(1514851200e9)},
+		{execute.Time(3e9), nil, 3.0, nil, false, nil},
+	}
+
+	testCases := []struct {
+		name       string
+		query      string
+		result     *fakeResult
+		want       []*executetest.Table
+		wantEvents []string
+		wantErr    bool
+	}{
+		{
+			name:   "types and nulls",
+			query:  `import "sql" sql.from(driverName: "fluxtest", dataSourceName: "%s", query: "SELECT * FROM t")`,
+			result: result,
+			want: []*executetest.Table{{
+				ColMeta: cols,
+				Data:    rows,
+			}},
+			wantEvents: []string{"SELECT * FROM t []"},
+		},
+		{
+			name:   "batches and params",
+			query:  `import "sql" sql.from(driverName: "fluxtest", dataSourceName: "%s", query: "SELECT * FROM t WHERE time > ?", params: [2018-01-01T00:00:00Z], batchSize: 2)`,
+			result: result,
+			want: []*executetest.Table{
+				{ColMeta: cols, Data: rows[:2]},
+				{ColMeta: cols, Data: rows[2:]},
+			},
+			wantEvents: []string{"SELECT * FROM t WHERE time > ? [2018-01-01 00:00:00 +0000 UTC]"},
+		},
+		{
+			name:  "batches grouped and sorted",
+			query: `import "sql" sql.from(driverName: "fluxtest", dataSourceName: "%s", query: "SELECT * FROM t", batchSize: 2) |> group() |> sort(columns: ["time"])`,
+			result: &fakeResult{
+				cols: result.cols[:3],
+				rows: [][]driver.Value{
+					{ts(3), "c", float64(3)},
+					{ts(1), "a", float64(1)},
+					{ts(2), "b", float64(2)},
+				},
+			},
+			want: []*executetest.Table{{
+				ColMeta: cols[:3],
+				Data: [][]interface{}{
+					{execute.Time(1e9), "a", 1.0},
+					{execute.Time(2e9), "b", 2.0},
+					{execute.Time(3e9), "c", 3.0},
+				},
+			}},
+			wantEvents: []string{"SELECT * FROM t []"},
+		},
+		{
+			name:   "batches grouped and counted",
+			query:  `import "sql" sql.from(driverName: "fluxtest", dataSourceName: "%s", query: "SELECT * FROM t", batchSize: 2) |> group() |> count(columns: ["count"])`,
+			result: result,
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{{Label: "count", Type: flux.TInt}},
+				Data:    [][]interface{}{{int64(3)}},
+			}},
+			wantEvents: []string{"SELECT * FROM t []"},
+		},
+		{
+			name:  "empty result",
+			query: `import "sql" sql.from(driverName: "fluxtest", dataSourceName: "%s", query: "SELECT * FROM t")`,
+			result: &fakeResult{
+				cols: result.cols[:2],
+			},
+			want: []*executetest.Table{{
+				ColMeta: cols[:2],
+			}},
+			wantEvents: []string{"SELECT * FROM t []"},
+		},
+		{
+			name:    "invalid value",
+			query:   `import "sql" sql.from(driverName: "fluxtest", dataSourceName: "%s", query: "SELECT * FROM t")`,
+			result:  &fakeResult{cols: result.cols[2:3], rows: [][]driver.Value{{[]byte("abc")}}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			db := testDriver.newFakeDB(t.Name(), "", tc.result)
+
+			querier := &querytest.Querier{
+				C: controltest.New(control.New(control.Config{
+					ConcurrencyQuota: 1,
+					MemoryBytesQuota: math.MaxInt64,
+				})),
+			}
+			q, err := querier.C.Query(context.Background(), lang.FluxCompiler{Query: fmt.Sprintf(tc.query, t.Name())})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer q.Done()
+
+			var got []*executetest.Table
+			for _, res := range <-q.Ready() {
+				if err := res.Tables().Do(func(tbl flux.Table) error {
+					cpy, err := executetest.ConvertTable(tbl)
+					if err != nil {
+						return err
+					}
+					got = append(got, cpy)
+					return nil
+				}); err != nil {
+					if !tc.wantErr {
+						t.Fatal(err)
+					}
+					return
+				}
+			}
+			if err := q.Err(); err != nil {
+				if !tc.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected error")
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+			if !cmp.Equal(tc.wantEvents, db.events) {
+				t.Errorf("unexpected statements -want/+got\n%s", cmp.Diff(tc.wantEvents, db.events))
+			}
+		})
+	}
+}
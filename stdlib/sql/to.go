@@ -0,0 +1,393 @@
+package sql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ToSQLKind is the Kind for the ToSQL Flux function
+	ToSQLKind = "toSQL"
+
+	// DefaultToSQLBatchSize is the default number of rows inserted per statement.
+	DefaultToSQLBatchSize = 1000
+
+	// DryRunStatementColLabel is the column holding the generated statements in dry-run mode.
+	DryRunStatementColLabel = "statement"
+)
+
+type ToSQLOpSpec struct {
+	DriverName     string `json:"driverName,omitempty"`
+	DataSourceName string `json:"dataSourceName,omitempty"`
+	Table          string `json:"table,omitempty"`
+	BatchSize      int64  `json:"batchSize,omitempty"`
+	CreateTable    bool   `json:"createTable,omitempty"` // create the table if it does not exist
+	DryRun         bool   `json:"dryRun,omitempty"`      // return the statements instead of connecting to the database
+}
+
+func init() {
+	toSQLSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"driverName":     semantic.String,
+			"dataSourceName": semantic.String,
+			"table":          semantic.String,
+			"batchSize":      semantic.Int,
+			"createTable":    semantic.Bool,
+			"dryRun":         semantic.Bool,
+		},
+		[]string{"driverName", "dataSourceName", "table"},
+	)
+	flux.RegisterPackageValue("sql", "to", flux.FunctionValueWithSideEffect(ToSQLKind, createToSQLOpSpec, toSQLSignature))
+	flux.RegisterOpSpec(ToSQLKind, func() flux.OperationSpec { return &ToSQLOpSpec{} })
+	plan.RegisterProcedureSpecWithSideEffect(ToSQLKind, newToSQLProcedure, ToSQLKind)
+	execute.RegisterTransformation(ToSQLKind, createToSQLTransformation)
+}
+
+// ReadArgs loads a flux.Arguments into ToSQLOpSpec.
+// If the batchSize isn't set, it defaults to DefaultToSQLBatchSize.
+func (o *ToSQLOpSpec) ReadArgs(args flux.Arguments) error {
+	var err error
+	if o.DriverName, err = args.GetRequiredString("driverName"); err != nil {
+		return err
+	}
+	if o.DataSourceName, err = args.GetRequiredString("dataSourceName"); err != nil {
+		return err
+	}
+	if o.Table, err = args.GetRequiredString("table"); err != nil {
+		return err
+	}
+	if len(o.Table) == 0 {
+		return errors.New("invalid table name")
+	}
+
+	batchSize, ok, err := args.GetInt("batchSize")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		batchSize = DefaultToSQLBatchSize
+	}
+	if batchSize <= 0 {
+		return fmt.Errorf("batchSize must be positive, got %d", batchSize)
+	}
+	o.BatchSize = batchSize
+
+	if o.CreateTable, _, err = args.GetBool("createTable"); err != nil {
+		return err
+	}
+	if o.DryRun, _, err = args.GetBool("dryRun"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func createToSQLOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+	s := new(ToSQLOpSpec)
+	if err := s.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (ToSQLOpSpec) Kind() flux.OperationKind {
+	return ToSQLKind
+}
+
+type ToSQLProcedureSpec struct {
+	plan.DefaultCost
+	Spec *ToSQLOpSpec
+}
+
+func (o *ToSQLProcedureSpec) Kind() plan.ProcedureKind {
+	return ToSQLKind
+}
+
+func (o *ToSQLProcedureSpec) Copy() plan.ProcedureSpec {
+	s := *o.Spec
+	return &ToSQLProcedureSpec{Spec: &s}
+}
+
+func newToSQLProcedure(qs flux.OperationSpec, a plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ToSQLOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	return &ToSQLProcedureSpec{Spec: spec}, nil
+}
+
+func createToSQLTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ToSQLProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewToSQLTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+// ToSQLTransformation inserts every table it receives into a database table and passes the tables on unchanged.
+// The rows of each table are inserted in batches, with a single statement per batch,
+// inside one transaction per table, so that a table that fails to insert leaves no rows behind.
+// When the destination table is created, every table must have the columns of the first table.
+// In dry-run mode, each table is replaced by a table with its group key and the statements it generated.
+type ToSQLTransformation struct {
+	d       execute.Dataset
+	cache   execute.TableBuilderCache
+	spec    *ToSQLProcedureSpec
+	dialect Dialect
+	db      *sql.DB
+	// columns are the columns of the created destination table, nil until it is created.
+	columns []flux.ColMeta
+}
+
+// NewToSQLTransformation creates a ToSQLTransformation.
+// The database is not opened in dry-run mode.
+func NewToSQLTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ToSQLProcedureSpec) (*ToSQLTransformation, error) {
+	dialect, err := lookupDialect(spec.Spec.DriverName)
+	if err != nil {
+		return nil, err
+	}
+	t := &ToSQLTransformation{
+		d:       d,
+		cache:   cache,
+		spec:    spec,
+		dialect: dialect,
+	}
+	if !spec.Spec.DryRun {
+		db, err := sql.Open(spec.Spec.DriverName, spec.Spec.DataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		t.db = db
+	}
+	return t, nil
+}
+
+func (t *ToSQLTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *ToSQLTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	builder, created := t.cache.TableBuilder(key)
+	if t.spec.Spec.DryRun {
+		if created {
+			if err := execute.AddTableKeyCols(key, builder); err != nil {
+				return err
+			}
+			if _, err := builder.AddCol(flux.ColMeta{Label: DryRunStatementColLabel, Type: flux.TString}); err != nil {
+				return err
+			}
+		}
+		return t.write(tbl, builder, func(stmt string, args ...interface{}) error {
+			if err := execute.AppendKeyValues(key, builder); err != nil {
+				return err
+			}
+			return builder.AppendString(len(key.Cols()), stmt)
+		})
+	}
+
+	if created {
+		if err := execute.AddTableCols(tbl, builder); err != nil {
+			return err
+		}
+	}
+	tx, err := t.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := t.write(tbl, builder, func(stmt string, args ...interface{}) error {
+		_, err := tx.Exec(stmt, args...)
+		return err
+	}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// write runs the statements creating the destination table, if needed, and inserting the rows of a table.
+// Unless in dry-run mode, the rows are also appended to the builder.
+func (t *ToSQLTransformation) write(tbl flux.Table, builder execute.TableBuilder, exec func(stmt string, args ...interface{}) error) error {
+	cols := tbl.Cols()
+	if t.spec.Spec.CreateTable {
+		if t.columns == nil {
+			stmt, err := createTableStatement(t.dialect, t.spec.Spec.Table, cols)
+			if err != nil {
+				return err
+			}
+			if err := exec(stmt); err != nil {
+				return errors.Wrapf(err, "failed to create table %s", t.spec.Spec.Table)
+			}
+			t.columns = cols
+		} else if !sameColumns(cols, t.columns) {
+			return fmt.Errorf("table with key %v has columns %s, but table %s was created with columns %s",
+				tbl.Key(), columnsString(cols), t.spec.Spec.Table, columnsString(t.columns))
+		}
+	}
+
+	batchSize := int(t.spec.Spec.BatchSize)
+	if batchSize <= 0 {
+		batchSize = DefaultToSQLBatchSize
+	}
+	args := make([]interface{}, 0, batchSize*len(cols))
+	rows := 0
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		stmt := insertStatement(t.dialect, t.spec.Spec.Table, cols, rows)
+		if err := exec(stmt, args...); err != nil {
+			return errors.Wrapf(err, "failed to insert %d rows into %s", rows, t.spec.Spec.Table)
+		}
+		args = args[:0]
+		rows = 0
+		return nil
+	}
+
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			for j := range cols {
+				args = append(args, sqlValue(execute.ValueForRow(cr, i, j)))
+			}
+			rows++
+			if rows == batchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if t.spec.Spec.DryRun {
+				continue
+			}
+			if err := execute.AppendRecord(i, cr, builder); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (t *ToSQLTransformation) UpdateWatermark(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateWatermark(pt)
+}
+
+func (t *ToSQLTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+
+func (t *ToSQLTransformation) Finish(id execute.DatasetID, err error) {
+	if t.db != nil {
+		if cerr := t.db.Close(); err == nil {
+			err = cerr
+		}
+	}
+	t.d.Finish(err)
+}
+
+// createTableStatement returns the statement creating a table with the given columns if it does not exist.
+func createTableStatement(d Dialect, table string, cols []flux.ColMeta) (string, error) {
+	defs := make([]string, len(cols))
+	for i, c := range cols {
+		typ, err := d.SQLType(c.Type)
+		if err != nil {
+			return "", errors.Wrapf(err, "column %s", c.Label)
+		}
+		defs[i] = d.QuoteIdentifier(c.Label) + " " + typ
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", d.QuoteIdentifier(table), strings.Join(defs, ", ")), nil
+}
+
+// sameColumns reports whether two lists hold the same columns, in any order.
+func sameColumns(a, b []flux.ColMeta) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, c := range a {
+		j := execute.ColIdx(c.Label, b)
+		if j < 0 || b[j].Type != c.Type {
+			return false
+		}
+	}
+	return true
+}
+
+// columnsString formats columns as a list of labels and types.
+func columnsString(cols []flux.ColMeta) string {
+	defs := make([]string, len(cols))
+	for i, c := range cols {
+		defs[i] = c.Label + " " + c.Type.String()
+	}
+	return "(" + strings.Join(defs, ", ") + ")"
+}
+
+// insertStatement returns a statement inserting n rows with the given columns.
+func insertStatement(d Dialect, table string, cols []flux.ColMeta, n int) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(d.QuoteIdentifier(table))
+	b.WriteString(" (")
+	for i, c := range cols {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(d.QuoteIdentifier(c.Label))
+	}
+	b.WriteString(") VALUES ")
+	p := 0
+	for r := 0; r < n; r++ {
+		if r > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for i := range cols {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			p++
+			b.WriteString(d.Placeholder(p))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// sqlValue converts a Flux value to a value accepted by database/sql, nulls become nil.
+func sqlValue(v values.Value) interface{} {
+	if v.IsNull() {
+		return nil
+	}
+	switch v.Type() {
+	case semantic.String:
+		return v.Str()
+	case semantic.Int:
+		return v.Int()
+	case semantic.UInt:
+		return v.UInt()
+	case semantic.Float:
+		return v.Float()
+	case semantic.Bool:
+		return v.Bool()
+	case semantic.Time:
+		return v.Time().Time()
+	default:
+		return nil
+	}
+}
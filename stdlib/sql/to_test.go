@@ -0,0 +1,299 @@
+package sql_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	_ "github.com/influxdata/flux/builtin" // We need to import the builtins for the tests to work.
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	fsql "github.com/influxdata/flux/stdlib/sql"
+)
+
+func TestToSQL_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `import "sql" from(bucket:"mybucket") |> sql.to(driverName: "postgres", dataSourceName: "postgres://localhost", table: "reports")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mybucket"},
+					},
+					{
+						ID: "toSQL1",
+						Spec: &fsql.ToSQLOpSpec{
+							DriverName:     "postgres",
+							DataSourceName: "postgres://localhost",
+							Table:          "reports",
+							BatchSize:      fsql.DefaultToSQLBatchSize,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toSQL1"},
+				},
+			},
+		},
+		{
+			Name: "all options",
+			Raw:  `import "sql" from(bucket:"mybucket") |> sql.to(driverName: "mysql", dataSourceName: "user@/db", table: "reports", batchSize: 50, createTable: true, dryRun: true)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID:   "from0",
+						Spec: &influxdb.FromOpSpec{Bucket: "mybucket"},
+					},
+					{
+						ID: "toSQL1",
+						Spec: &fsql.ToSQLOpSpec{
+							DriverName:     "mysql",
+							DataSourceName: "user@/db",
+							Table:          "reports",
+							BatchSize:      50,
+							CreateTable:    true,
+							DryRun:         true,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "toSQL1"},
+				},
+			},
+		},
+		{
+			Name:    "missing table",
+			Raw:     `import "sql" from(bucket:"mybucket") |> sql.to(driverName: "postgres", dataSourceName: "postgres://localhost")`,
+			WantErr: true,
+		},
+		{
+			Name:    "invalid batch size",
+			Raw:     `import "sql" from(bucket:"mybucket") |> sql.to(driverName: "postgres", dataSourceName: "postgres://localhost", table: "reports", batchSize: 0)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestToSQL_Process(t *testing.T) {
+	table := func() flux.Table {
+		return &executetest.Table{
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "host", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+				{Label: "count", Type: flux.TInt},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), "a", 2.0, int64(1)},
+				{execute.Time(1e9), "b", nil, int64(2)},
+				{execute.Time(2e9), "a", 3.0, nil},
+			},
+		}
+	}
+	ts := func(sec int64) time.Time { return time.Unix(sec, 0).UTC() }
+	testCases := []struct {
+		name    string
+		spec    fsql.ToSQLOpSpec
+		tables  int
+		failOn  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single batch",
+			spec: fsql.ToSQLOpSpec{Table: "reports", BatchSize: 10},
+			want: []string{
+				"BEGIN",
+				fmt.Sprintf(`INSERT INTO "reports" ("_time", "host", "_value", "count") VALUES (?, ?, ?, ?), (?, ?, ?, ?), (?, ?, ?, ?) %v`,
+					[]driver.Value{ts(0), "a", 2.0, int64(1), ts(1), "b", nil, int64(2), ts(2), "a", 3.0, nil}),
+				"COMMIT",
+			},
+		},
+		{
+			name: "create table and batches",
+			spec: fsql.ToSQLOpSpec{Table: "reports", BatchSize: 2, CreateTable: true},
+			want: []string{
+				"BEGIN",
+				`CREATE TABLE IF NOT EXISTS "reports" ("_time" TIMESTAMP, "host" TEXT, "_value" DOUBLE PRECISION, "count" BIGINT) []`,
+				fmt.Sprintf(`INSERT INTO "reports" ("_time", "host", "_value", "count") VALUES (?, ?, ?, ?), (?, ?, ?, ?) %v`,
+					[]driver.Value{ts(0), "a", 2.0, int64(1), ts(1), "b", nil, int64(2)}),
+				fmt.Sprintf(`INSERT INTO "reports" ("_time", "host", "_value", "count") VALUES (?, ?, ?, ?) %v`,
+					[]driver.Value{ts(2), "a", 3.0, nil}),
+				"COMMIT",
+			},
+		},
+		{
+			name:   "create table once",
+			spec:   fsql.ToSQLOpSpec{Table: "reports", BatchSize: 10, CreateTable: true},
+			tables: 2,
+			want: []string{
+				"BEGIN",
+				`CREATE TABLE IF NOT EXISTS "reports" ("_time" TIMESTAMP, "host" TEXT, "_value" DOUBLE PRECISION, "count" BIGINT) []`,
+				fmt.Sprintf(`INSERT INTO "reports" ("_time", "host", "_value", "count") VALUES (?, ?, ?, ?), (?, ?, ?, ?), (?, ?, ?, ?) %v`,
+					[]driver.Value{ts(0), "a", 2.0, int64(1), ts(1), "b", nil, int64(2), ts(2), "a", 3.0, nil}),
+				"COMMIT",
+				"BEGIN",
+				fmt.Sprintf(`INSERT INTO "reports" ("_time", "host", "_value", "count") VALUES (?, ?, ?, ?), (?, ?, ?, ?), (?, ?, ?, ?) %v`,
+					[]driver.Value{ts(0), "a", 2.0, int64(1), ts(1), "b", nil, int64(2), ts(2), "a", 3.0, nil}),
+				"COMMIT",
+			},
+		},
+		{
+			name:    "failed insert rolls back",
+			spec:    fsql.ToSQLOpSpec{Table: "reports", BatchSize: 10},
+			failOn:  "INSERT",
+			want:    []string{"BEGIN", "ROLLBACK"},
+			wantErr: true,
+		},
+		{
+			name: "failed batch rolls back the table",
+			spec: fsql.ToSQLOpSpec{Table: "reports", BatchSize: 2},
+			// Fail the second batch, which holds the last row.
+			failOn: fmt.Sprintf("%v", []driver.Value{ts(2), "a", 3.0, nil}),
+			want: []string{
+				"BEGIN",
+				fmt.Sprintf(`INSERT INTO "reports" ("_time", "host", "_value", "count") VALUES (?, ?, ?, ?), (?, ?, ?, ?) %v`,
+					[]driver.Value{ts(0), "a", 2.0, int64(1), ts(1), "b", nil, int64(2)}),
+				"ROLLBACK",
+			},
+			wantErr: true,
+		},
+		{
+			name: "dry run",
+			spec: fsql.ToSQLOpSpec{Table: "reports", BatchSize: 2, CreateTable: true, DryRun: true},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec
+			spec.DriverName = "fluxtest"
+			spec.DataSourceName = t.Name()
+			db := testDriver.newFakeDB(spec.DataSourceName, tc.failOn, nil)
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			tr, err := fsql.NewToSQLTransformation(d, c, &fsql.ToSQLProcedureSpec{Spec: &spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			tables := tc.tables
+			if tables == 0 {
+				tables = 1
+			}
+			for i := 0; i < tables && err == nil; i++ {
+				err = tr.Process(executetest.RandomDatasetID(), table())
+			}
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tr.Finish(executetest.RandomDatasetID(), nil)
+			if !cmp.Equal(tc.want, db.events) {
+				t.Errorf("unexpected statements -want/+got\n%s", cmp.Diff(tc.want, db.events))
+			}
+		})
+	}
+}
+
+func TestToSQL_CreatedSchemaMismatch(t *testing.T) {
+	table := func(cols []flux.ColMeta) flux.Table {
+		return &executetest.Table{
+			KeyCols: []string{"host"},
+			ColMeta: cols,
+			Data:    [][]interface{}{{execute.Time(0), "a", 1.0}},
+		}
+	}
+	spec := fsql.ToSQLOpSpec{DriverName: "fluxtest", DataSourceName: t.Name(), Table: "reports", CreateTable: true}
+	testDriver.newFakeDB(spec.DataSourceName, "", nil)
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	c.SetTriggerSpec(execute.DefaultTriggerSpec)
+	tr, err := fsql.NewToSQLTransformation(d, c, &fsql.ToSQLProcedureSpec{Spec: &spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tr.Finish(executetest.RandomDatasetID(), nil)
+
+	if err := tr.Process(executetest.RandomDatasetID(), table([]flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "_value", Type: flux.TFloat},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	err = tr.Process(executetest.RandomDatasetID(), table([]flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "host", Type: flux.TString},
+		{Label: "_value", Type: flux.TInt},
+	}))
+	want := `table with key {host=a} has columns (_time time, host string, _value int), but table reports was created with columns (_time time, host string, _value float)`
+	if err == nil || err.Error() != want {
+		t.Errorf("unexpected error: got %v want %s", err, want)
+	}
+}
+
+func TestToSQL_DryRun(t *testing.T) {
+	data := []flux.Table{&executetest.Table{
+		KeyCols: []string{"host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "host", Type: flux.TString},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), "a", 2.0},
+			{execute.Time(1e9), "a", 3.0},
+			{execute.Time(2e9), "a", 4.0},
+		},
+	}}
+	want := []*executetest.Table{{
+		KeyCols: []string{"host"},
+		ColMeta: []flux.ColMeta{
+			{Label: "host", Type: flux.TString},
+			{Label: "statement", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{"a", `CREATE TABLE IF NOT EXISTS "reports" ("_time" TIMESTAMP, "host" TEXT, "_value" DOUBLE PRECISION)`},
+			{"a", `INSERT INTO "reports" ("_time", "host", "_value") VALUES (?, ?, ?), (?, ?, ?)`},
+			{"a", `INSERT INTO "reports" ("_time", "host", "_value") VALUES (?, ?, ?)`},
+		},
+	}}
+	spec := &fsql.ToSQLOpSpec{DriverName: "fluxtest", DataSourceName: t.Name(), Table: "reports", BatchSize: 2, CreateTable: true, DryRun: true}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			tr, err := fsql.NewToSQLTransformation(d, c, &fsql.ToSQLProcedureSpec{Spec: spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return tr
+		},
+	)
+}
+
+func TestToSQL_UnknownDriver(t *testing.T) {
+	d := executetest.NewDataset(executetest.RandomDatasetID())
+	c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+	spec := &fsql.ToSQLOpSpec{DriverName: "nosuchdriver", DataSourceName: "x", Table: "reports"}
+	if _, err := fsql.NewToSQLTransformation(d, c, &fsql.ToSQLProcedureSpec{Spec: spec}); err == nil {
+		t.Error("expected error for unregistered driver")
+	}
+}
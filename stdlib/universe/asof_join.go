@@ -0,0 +1,476 @@
+package universe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+)
+
+// asOfJoinTransformation joins every row of the left stream with the row of the right stream closest in time.
+// A left table is joined with the right table that has the same values in the join columns of their group keys.
+// Both tables are sorted by time, so they are joined in a single pass over their rows
+// and the joined table is written as the rows of the left table are read.
+//
+// The right tables are kept until the left stream is finished, since several left tables may join with the same right table.
+// A left table is only kept until its right table arrives or the right stream is finished.
+type asOfJoinTransformation struct {
+	mu sync.Mutex
+
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+	alloc *memory.Allocator
+
+	leftID, rightID execute.DatasetID
+	names           map[execute.DatasetID]string
+
+	on        []string
+	tolerance values.Duration
+	direction string
+
+	// right holds the tables of the right stream by the values of their join columns.
+	right *execute.GroupLookup
+	// rightSchema holds the columns of the first table of the right stream,
+	// the left tables without a right table have null values in these columns.
+	rightSchema *schema
+	// pending holds the left tables waiting for their right table by the values of their join columns.
+	pending *execute.GroupLookup
+
+	parentState map[execute.DatasetID]*mergeJoinParentState
+}
+
+// NewAsOfJoinTransformation creates a transformation joining the tables of two streams with the asof join method.
+func NewAsOfJoinTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *MergeJoinProcedureSpec, parents []execute.DatasetID, tableNames map[execute.DatasetID]string, alloc *memory.Allocator) *asOfJoinTransformation {
+	on := make([]string, len(spec.On))
+	copy(on, spec.On)
+	sort.Strings(on)
+
+	t := &asOfJoinTransformation{
+		d:           d,
+		cache:       cache,
+		alloc:       alloc,
+		leftID:      parents[0],
+		rightID:     parents[1],
+		names:       tableNames,
+		on:          on,
+		tolerance:   values.Duration(spec.Tolerance),
+		direction:   spec.Direction,
+		right:       execute.NewGroupLookup(),
+		pending:     execute.NewGroupLookup(),
+		parentState: make(map[execute.DatasetID]*mergeJoinParentState),
+	}
+	for _, id := range parents {
+		t.parentState[id] = new(mergeJoinParentState)
+	}
+	return t
+}
+
+func (t *asOfJoinTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+// Process joins a left table as soon as its right table is known.
+// A right table is kept to join the left tables with the same values in the join columns.
+func (t *asOfJoinTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key, err := t.joinKey(tbl.Key())
+	if err != nil {
+		return err
+	}
+	if id == t.rightID {
+		return t.insertRight(key, tbl)
+	}
+
+	if right := t.rightTable(key); right != nil || t.parentState[t.rightID].finished {
+		return t.join(tbl, right)
+	}
+	builder, err := t.copyTable(tbl)
+	if err != nil {
+		return err
+	}
+	tables, _ := t.pending.Lookup(key)
+	pending, _ := tables.([]*execute.ColListTableBuilder)
+	t.pending.Set(key, append(pending, builder))
+	return nil
+}
+
+// joinKey returns the values of the join columns of a group key.
+func (t *asOfJoinTransformation) joinKey(key flux.GroupKey) (flux.GroupKey, error) {
+	cols := make([]flux.ColMeta, 0, len(t.on))
+	vs := make([]values.Value, 0, len(t.on))
+	for _, label := range t.on {
+		j := execute.ColIdx(label, key.Cols())
+		if j < 0 {
+			return nil, fmt.Errorf("the join columns of an as-of join must be in the group key, %q is not in the group key %v", label, key)
+		}
+		cols = append(cols, key.Cols()[j])
+		vs = append(vs, key.Value(j))
+	}
+	return execute.NewGroupKey(cols, vs), nil
+}
+
+// rightTable returns the right table with the given values in the join columns, or nil if there is none.
+// A null value in the join columns never has a match.
+func (t *asOfJoinTransformation) rightTable(key flux.GroupKey) *execute.ColListTableBuilder {
+	if hasNull(key) {
+		return nil
+	}
+	right, ok := t.right.Lookup(key)
+	if !ok {
+		return nil
+	}
+	return right.(*execute.ColListTableBuilder)
+}
+
+func hasNull(key flux.GroupKey) bool {
+	for j := range key.Cols() {
+		if key.IsNull(j) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertRight keeps the rows of a right table that have a time and joins the left tables waiting for it.
+func (t *asOfJoinTransformation) insertRight(key flux.GroupKey, tbl flux.Table) error {
+	if t.rightSchema == nil {
+		t.rightSchema = &schema{
+			key:     append([]flux.ColMeta(nil), tbl.Key().Cols()...),
+			columns: append([]flux.ColMeta(nil), tbl.Cols()...),
+		}
+	}
+	// A null value in the join columns never has a match.
+	if hasNull(key) {
+		return tbl.Do(func(flux.ColReader) error { return nil })
+	}
+	if t.rightTable(key) != nil {
+		return fmt.Errorf("the right stream of an as-of join must have a single table for each value of the join columns, found several tables with %v", key)
+	}
+
+	timeIdx, err := asOfTimeIdx(tbl.Cols())
+	if err != nil {
+		return err
+	}
+	builder := execute.NewColListTableBuilder(tbl.Key(), t.alloc)
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return err
+	}
+	last := int64(math.MinInt64)
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			if times.IsNull(i) {
+				continue
+			}
+			if times.Value(i) < last {
+				return errAsOfUnsorted
+			}
+			last = times.Value(i)
+			if err := execute.AppendRecord(i, cr, builder); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if tables, ok := t.pending.Delete(key); ok {
+		for _, left := range tables.([]*execute.ColListTableBuilder) {
+			if err := t.joinBuffered(left, builder); err != nil {
+				return err
+			}
+		}
+	}
+	// The right table is only kept for the left tables that have yet to arrive.
+	if t.parentState[t.leftID].finished {
+		builder.ClearData()
+		return nil
+	}
+	t.right.Set(key, builder)
+	return nil
+}
+
+// copyTable copies the rows of a left table that waits for its right table.
+func (t *asOfJoinTransformation) copyTable(tbl flux.Table) (*execute.ColListTableBuilder, error) {
+	builder := execute.NewColListTableBuilder(tbl.Key(), t.alloc)
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return nil, err
+	}
+	if err := execute.AppendTable(tbl, builder); err != nil {
+		return nil, err
+	}
+	return builder, nil
+}
+
+// joinBuffered joins a left table that waited for its right table and releases it.
+func (t *asOfJoinTransformation) joinBuffered(left, right *execute.ColListTableBuilder) error {
+	tbl, err := left.Table()
+	if err != nil {
+		return err
+	}
+	defer left.ClearData()
+	return t.join(tbl, right)
+}
+
+// errAsOfUnsorted is returned when a table joined by an as-of join is not sorted by time.
+var errAsOfUnsorted = fmt.Errorf("an as-of join requires tables sorted by %q", asOfTimeColumn)
+
+// asOfTimeIdx returns the index of the time column compared by an as-of join.
+func asOfTimeIdx(cols []flux.ColMeta) (int, error) {
+	j := execute.ColIdx(asOfTimeColumn, cols)
+	if j < 0 || cols[j].Type != flux.TTime {
+		return -1, fmt.Errorf("an as-of join requires a %q column of type time", asOfTimeColumn)
+	}
+	return j, nil
+}
+
+// join joins every row of a left table with the row of its right table closest in time,
+// in the direction of the join and within the tolerance.
+// The right table is nil if the left table has none, its rows are joined with null values.
+func (t *asOfJoinTransformation) join(left flux.Table, right *execute.ColListTableBuilder) error {
+	var rightCols, rightKeyCols []flux.ColMeta
+	var rightKey flux.GroupKey
+	if right != nil {
+		rightCols, rightKey = right.Cols(), right.Key()
+	} else if t.rightSchema != nil {
+		rightCols, rightKeyCols = t.rightSchema.columns, t.rightSchema.key
+	}
+
+	on := make(map[string]bool, len(t.on)+1)
+	for _, label := range t.on {
+		on[label] = true
+	}
+	on[asOfTimeColumn] = true
+
+	shared := sharedColumns(left.Cols(), rightCols)
+	s := schema{columns: make([]flux.ColMeta, 0, len(left.Cols())+len(rightCols))}
+	schemaMap := make(map[tableCol]flux.ColMeta, len(left.Cols())+len(rightCols))
+	added := make(map[string]bool, len(left.Cols())+len(rightCols))
+	addColumnsToSchema(t.names[t.leftID], left.Cols(), added, shared, on, &s, schemaMap)
+	addColumnsToSchema(t.names[t.rightID], rightCols, added, shared, on, &s, schemaMap)
+	sort.Sort(s)
+
+	key := t.postJoinGroupKey(left.Key(), rightKey, rightKeyCols, schemaMap)
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("duplicate table with key %v", key)
+	}
+	for _, column := range s.columns {
+		if _, err := builder.AddCol(column); err != nil {
+			return err
+		}
+	}
+
+	// The values of the join columns are taken from the left row.
+	leftIdx := make([]int, len(s.columns))
+	rightIdx := make([]int, len(s.columns))
+	for j := range s.columns {
+		leftIdx[j], rightIdx[j] = -1, -1
+	}
+	for k, column := range left.Cols() {
+		leftIdx[execute.ColIdx(schemaMap[tableCol{table: t.names[t.leftID], col: column.Label}].Label, s.columns)] = k
+	}
+	var rcr flux.ColReader
+	var rightTimes *array.Int64
+	if right != nil {
+		rcr = colReader(right)
+		for k, column := range rcr.Cols() {
+			if j := execute.ColIdx(schemaMap[tableCol{table: t.names[t.rightID], col: column.Label}].Label, s.columns); leftIdx[j] < 0 {
+				rightIdx[j] = k
+			}
+		}
+		timeIdx, err := asOfTimeIdx(rcr.Cols())
+		if err != nil {
+			return err
+		}
+		rightTimes = rcr.Times(timeIdx)
+	}
+
+	timeIdx, err := asOfTimeIdx(left.Cols())
+	if err != nil {
+		return err
+	}
+	// next is the first row of the right table that is not before the time of the current left row.
+	next := 0
+	last := int64(math.MinInt64)
+	return left.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			r := -1
+			if times.IsValid(i) {
+				ts := times.Value(i)
+				if ts < last {
+					return errAsOfUnsorted
+				}
+				last = ts
+				if rightTimes != nil {
+					for next < rightTimes.Len() && rightTimes.Value(next) < ts {
+						next++
+					}
+					r = t.closestRow(ts, rightTimes, next)
+				}
+			}
+
+			for j, column := range s.columns {
+				var v values.Value
+				switch {
+				case leftIdx[j] >= 0:
+					v = execute.ValueForRow(cr, i, leftIdx[j])
+				case r >= 0 && rightIdx[j] >= 0:
+					v = execute.ValueForRow(rcr, r, rightIdx[j])
+				default:
+					v = values.NewNull(flux.SemanticType(column.Type))
+				}
+				if err := builder.AppendValue(j, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// closestRow returns the row closest to the time ts in the direction of the join, within the tolerance,
+// or -1 if there is none. The times are sorted and next is the first row not before ts.
+func (t *asOfJoinTransformation) closestRow(ts int64, times *array.Int64, next int) int {
+	backward, forward := -1, -1
+	if next < times.Len() {
+		forward = next
+		if times.Value(next) == ts {
+			backward = next
+		}
+	}
+	if backward < 0 && next > 0 {
+		backward = next - 1
+	}
+
+	r := -1
+	switch t.direction {
+	case "backward":
+		r = backward
+	case "forward":
+		r = forward
+	case "nearest":
+		r = backward
+		if r < 0 || forward >= 0 && times.Value(forward)-ts < ts-times.Value(backward) {
+			r = forward
+		}
+	}
+	if r < 0 {
+		return -1
+	}
+
+	d := times.Value(r) - ts
+	if d < 0 {
+		d = -d
+	}
+	if values.Duration(d) > t.tolerance {
+		return -1
+	}
+	return r
+}
+
+// postJoinGroupKey returns the group key of the table joining a left table with its right table.
+// If the left table has no right table, the columns of the group key of the first right table are null.
+func (t *asOfJoinTransformation) postJoinGroupKey(left, right flux.GroupKey, rightKeyCols []flux.ColMeta, schemaMap map[tableCol]flux.ColMeta) flux.GroupKey {
+	key := groupKey{}
+	added := make(map[string]bool)
+	add := func(id execute.DatasetID, cols []flux.ColMeta, value func(j int) values.Value) {
+		for j, column := range cols {
+			colMeta := schemaMap[tableCol{table: t.names[id], col: column.Label}]
+			if !added[colMeta.Label] {
+				key.cols = append(key.cols, colMeta)
+				key.vals = append(key.vals, value(j))
+			}
+			added[colMeta.Label] = true
+		}
+	}
+
+	add(t.leftID, left.Cols(), left.Value)
+	if right != nil {
+		add(t.rightID, right.Cols(), right.Value)
+	} else {
+		add(t.rightID, rightKeyCols, func(j int) values.Value {
+			return values.NewNull(flux.SemanticType(rightKeyCols[j].Type))
+		})
+	}
+
+	sort.Sort(key)
+	return execute.NewGroupKey(key.cols, key.vals)
+}
+
+func (t *asOfJoinTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parentState[id].mark = mark
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.mark < min {
+			min = state.mark
+		}
+	}
+
+	return t.d.UpdateWatermark(min)
+}
+
+func (t *asOfJoinTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.parentState[id].processing = pt
+
+	min := execute.Time(math.MaxInt64)
+	for _, state := range t.parentState {
+		if state.processing < min {
+			min = state.processing
+		}
+	}
+
+	return t.d.UpdateProcessingTime(min)
+}
+
+// Finish joins the left tables that are still waiting once the right stream is finished,
+// they have no right table.
+func (t *asOfJoinTransformation) Finish(id execute.DatasetID, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		t.d.Finish(err)
+		return
+	}
+
+	t.parentState[id].finished = true
+	switch id {
+	case t.rightID:
+		t.pending.Range(func(key flux.GroupKey, tables interface{}) {
+			for _, left := range tables.([]*execute.ColListTableBuilder) {
+				if err == nil {
+					err = t.joinBuffered(left, nil)
+				}
+			}
+		})
+		t.pending = execute.NewGroupLookup()
+	case t.leftID:
+		t.right.Range(func(key flux.GroupKey, right interface{}) {
+			right.(*execute.ColListTableBuilder).ClearData()
+		})
+		t.right = execute.NewGroupLookup()
+	}
+	if err != nil {
+		t.d.Finish(err)
+		return
+	}
+
+	if t.parentState[t.leftID].finished && t.parentState[t.rightID].finished {
+		t.d.Finish(nil)
+	}
+}
@@ -0,0 +1,110 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+const DoubleEMAKind = "doubleEMA"
+
+type DoubleEMAOpSpec struct {
+	MovingAverageConfig
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", DoubleEMAKind, flux.FunctionValue(DoubleEMAKind, createDoubleEMAOpSpec, movingAverageSignature()))
+	flux.RegisterOpSpec(DoubleEMAKind, newDoubleEMAOp)
+	plan.RegisterProcedureSpec(DoubleEMAKind, newDoubleEMAProcedure, DoubleEMAKind)
+	execute.RegisterTransformation(DoubleEMAKind, createDoubleEMATransformation)
+}
+
+func createDoubleEMAOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(DoubleEMAOpSpec)
+	if err := spec.MovingAverageConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newDoubleEMAOp() flux.OperationSpec {
+	return new(DoubleEMAOpSpec)
+}
+
+func (s *DoubleEMAOpSpec) Kind() flux.OperationKind {
+	return DoubleEMAKind
+}
+
+type DoubleEMAProcedureSpec struct {
+	plan.DefaultCost
+	MovingAverageConfig
+}
+
+func newDoubleEMAProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*DoubleEMAOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &DoubleEMAProcedureSpec{
+		MovingAverageConfig: spec.MovingAverageConfig,
+	}, nil
+}
+
+func (s *DoubleEMAProcedureSpec) Kind() plan.ProcedureKind {
+	return DoubleEMAKind
+}
+func (s *DoubleEMAProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(DoubleEMAProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createDoubleEMATransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*DoubleEMAProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewDoubleEMATransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewDoubleEMATransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *DoubleEMAProcedureSpec) execute.Transformation {
+	n := int(spec.N)
+	return newAveragingTransformation(d, cache, DoubleEMAKind, spec.Column, func() averager {
+		return newDoubleExponentialAverager(n)
+	})
+}
+
+// doubleExponentialAverager computes 2*EMA - EMA(EMA),
+// where the exponential moving averages are those of exponentialAverager.
+type doubleExponentialAverager struct {
+	ema, emaOfEMA *exponentialAverager
+}
+
+func newDoubleExponentialAverager(n int) *doubleExponentialAverager {
+	return &doubleExponentialAverager{
+		ema:      newExponentialAverager(n),
+		emaOfEMA: newExponentialAverager(n),
+	}
+}
+
+func (a *doubleExponentialAverager) add(v float64) (float64, bool) {
+	ema, ok := a.ema.add(v)
+	if !ok {
+		return 0, false
+	}
+	emaOfEMA, ok := a.emaOfEMA.add(ema)
+	if !ok {
+		return 0, false
+	}
+	return 2*ema - emaOfEMA, true
+}
@@ -0,0 +1,61 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestDoubleEMAOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"doubleEMA","kind":"doubleEMA","spec":{"n":3,"column":"_value"}}`)
+	op := &flux.Operation{
+		ID: "doubleEMA",
+		Spec: &universe.DoubleEMAOpSpec{
+			MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: "_value"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestDoubleEMA_Process(t *testing.T) {
+	spec := &universe.DoubleEMAProcedureSpec{
+		MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: execute.DefaultValueColLabel},
+	}
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 0.0},
+			{execute.Time(2), 0.0},
+			{execute.Time(3), 0.0},
+			{execute.Time(4), 6.0},
+			{execute.Time(5), 9.0},
+			{execute.Time(6), 3.0},
+		},
+	}}
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(5), 9.0},
+			{execute.Time(6), 5.25},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return universe.NewDoubleEMATransformation(d, c, spec)
+		},
+	)
+}
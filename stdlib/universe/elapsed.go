@@ -0,0 +1,218 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const ElapsedKind = "elapsed"
+
+type ElapsedOpSpec struct {
+	Unit       flux.Duration `json:"unit"`
+	TimeColumn string        `json:"timeColumn"`
+	ColumnName string        `json:"columnName"`
+}
+
+func init() {
+	elapsedSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"unit":       semantic.Duration,
+			"timeColumn": semantic.String,
+			"columnName": semantic.String,
+		},
+		nil,
+	)
+
+	flux.RegisterPackageValue("universe", ElapsedKind, flux.FunctionValue(ElapsedKind, createElapsedOpSpec, elapsedSignature))
+	flux.RegisterOpSpec(ElapsedKind, newElapsedOp)
+	plan.RegisterProcedureSpec(ElapsedKind, newElapsedProcedure, ElapsedKind)
+	execute.RegisterTransformation(ElapsedKind, createElapsedTransformation)
+}
+
+func createElapsedOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ElapsedOpSpec)
+
+	if unit, ok, err := args.GetDuration("unit"); err != nil {
+		return nil, err
+	} else if ok {
+		if unit <= 0 {
+			return nil, errors.New("unit must be positive")
+		}
+		spec.Unit = unit
+	} else {
+		//Default is 1s
+		spec.Unit = flux.Duration(time.Second)
+	}
+
+	if timeValue, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = timeValue
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if name, ok, err := args.GetString("columnName"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ColumnName = name
+	} else {
+		spec.ColumnName = "elapsed"
+	}
+	return spec, nil
+}
+
+func newElapsedOp() flux.OperationSpec {
+	return new(ElapsedOpSpec)
+}
+
+func (s *ElapsedOpSpec) Kind() flux.OperationKind {
+	return ElapsedKind
+}
+
+type ElapsedProcedureSpec struct {
+	plan.DefaultCost
+	Unit       flux.Duration `json:"unit"`
+	TimeColumn string        `json:"timeColumn"`
+	ColumnName string        `json:"columnName"`
+}
+
+func newElapsedProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ElapsedOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &ElapsedProcedureSpec{
+		Unit:       spec.Unit,
+		TimeColumn: spec.TimeColumn,
+		ColumnName: spec.ColumnName,
+	}, nil
+}
+
+func (s *ElapsedProcedureSpec) Kind() plan.ProcedureKind {
+	return ElapsedKind
+}
+func (s *ElapsedProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ElapsedProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createElapsedTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ElapsedProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewElapsedTransformation(d, cache, s)
+	return t, d, nil
+}
+
+// elapsedTransformation adds a column with the time elapsed since the previous row, as a number of units.
+// The first row of each table is dropped, since its elapsed time is undefined.
+type elapsedTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	spec ElapsedProcedureSpec
+}
+
+func NewElapsedTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ElapsedProcedureSpec) *elapsedTransformation {
+	return &elapsedTransformation{
+		d:     d,
+		cache: cache,
+		spec:  *spec,
+	}
+}
+
+func (t *elapsedTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *elapsedTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	builder, created := t.cache.TableBuilder(tbl.Key())
+	if !created {
+		return fmt.Errorf("elapsed found duplicate table with key: %v", tbl.Key())
+	}
+
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.TimeColumn)
+	}
+	if typ := cols[timeIdx].Type; typ != flux.TTime {
+		return fmt.Errorf("time column %q is of type %v, expected time", t.spec.TimeColumn, typ)
+	}
+	if execute.ColIdx(t.spec.ColumnName, cols) >= 0 {
+		return fmt.Errorf("elapsed column %q conflicts with an existing column", t.spec.ColumnName)
+	}
+
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+	elapsedIdx, err := builder.AddCol(flux.ColMeta{
+		Label: t.spec.ColumnName,
+		Type:  flux.TInt,
+	})
+	if err != nil {
+		return err
+	}
+
+	var prevTime values.Time
+	first := true
+	return tbl.Do(func(cr flux.ColReader) error {
+		if cr.Times(timeIdx).NullN() > 0 {
+			return fmt.Errorf("elapsed found null time in time column")
+		}
+
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			tm := values.Time(cr.Times(timeIdx).Value(i))
+			if first {
+				// We need to drop the first row since its elapsed time is undefined
+				prevTime = tm
+				first = false
+				continue
+			}
+			if tm < prevTime {
+				return fmt.Errorf("elapsed found out-of-order times in time column")
+			}
+
+			for j := range cols {
+				if err := builder.AppendValue(j, execute.ValueForRow(cr, i, j)); err != nil {
+					return err
+				}
+			}
+			if err := builder.AppendInt(elapsedIdx, int64(tm-prevTime)/int64(t.spec.Unit)); err != nil {
+				return err
+			}
+			prevTime = tm
+		}
+		return nil
+	})
+}
+
+func (t *elapsedTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *elapsedTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *elapsedTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
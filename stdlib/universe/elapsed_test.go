@@ -0,0 +1,192 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestElapsed_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mybucket") |> elapsed()`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "elapsed1",
+						Spec: &universe.ElapsedOpSpec{
+							Unit:       flux.Duration(time.Second),
+							TimeColumn: "_time",
+							ColumnName: "elapsed",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "elapsed1"},
+				},
+			},
+		},
+		{
+			Name: "unit and column name",
+			Raw:  `from(bucket:"mybucket") |> elapsed(unit: 1ms, columnName: "dt")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "elapsed1",
+						Spec: &universe.ElapsedOpSpec{
+							Unit:       flux.Duration(time.Millisecond),
+							TimeColumn: "_time",
+							ColumnName: "dt",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "elapsed1"},
+				},
+			},
+		},
+		{
+			Name:    "zero unit",
+			Raw:     `from(bucket:"mybucket") |> elapsed(unit: 0s)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestElapsedOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"elapsed","kind":"elapsed","spec":{"unit":"1m","timeColumn":"_time","columnName":"elapsed"}}`)
+	op := &flux.Operation{
+		ID: "elapsed",
+		Spec: &universe.ElapsedOpSpec{
+			Unit:       flux.Duration(time.Minute),
+			TimeColumn: "_time",
+			ColumnName: "elapsed",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestElapsed_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *universe.ElapsedProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "elapsed",
+			spec: &universe.ElapsedProcedureSpec{
+				Unit:       2,
+				TimeColumn: "_time",
+				ColumnName: "elapsed",
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a"},
+					{execute.Time(3), nil, "a"},
+					{execute.Time(3), 3.0, "a"},
+					{execute.Time(10), 4.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t", Type: flux.TString},
+					{Label: "elapsed", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), nil, "a", int64(1)},
+					{execute.Time(3), 3.0, "a", int64(0)},
+					{execute.Time(10), 4.0, "a", int64(3)},
+				},
+			}},
+		},
+		{
+			name: "out of order",
+			spec: &universe.ElapsedProcedureSpec{
+				Unit:       1,
+				TimeColumn: "_time",
+				ColumnName: "elapsed",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 1.0},
+					{execute.Time(1), 2.0},
+				},
+			}},
+			wantErr: errors.New("elapsed found out-of-order times in time column"),
+		},
+		{
+			name: "existing column",
+			spec: &universe.ElapsedProcedureSpec{
+				Unit:       1,
+				TimeColumn: "_time",
+				ColumnName: "_value",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+				},
+			}},
+			wantErr: errors.New(`elapsed column "_value" conflicts with an existing column`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewElapsedTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
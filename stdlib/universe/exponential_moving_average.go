@@ -0,0 +1,115 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+const ExponentialMovingAverageKind = "exponentialMovingAverage"
+
+type ExponentialMovingAverageOpSpec struct {
+	MovingAverageConfig
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", ExponentialMovingAverageKind, flux.FunctionValue(ExponentialMovingAverageKind, createExponentialMovingAverageOpSpec, movingAverageSignature()))
+	flux.RegisterOpSpec(ExponentialMovingAverageKind, newExponentialMovingAverageOp)
+	plan.RegisterProcedureSpec(ExponentialMovingAverageKind, newExponentialMovingAverageProcedure, ExponentialMovingAverageKind)
+	execute.RegisterTransformation(ExponentialMovingAverageKind, createExponentialMovingAverageTransformation)
+}
+
+func createExponentialMovingAverageOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ExponentialMovingAverageOpSpec)
+	if err := spec.MovingAverageConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newExponentialMovingAverageOp() flux.OperationSpec {
+	return new(ExponentialMovingAverageOpSpec)
+}
+
+func (s *ExponentialMovingAverageOpSpec) Kind() flux.OperationKind {
+	return ExponentialMovingAverageKind
+}
+
+type ExponentialMovingAverageProcedureSpec struct {
+	plan.DefaultCost
+	MovingAverageConfig
+}
+
+func newExponentialMovingAverageProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ExponentialMovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &ExponentialMovingAverageProcedureSpec{
+		MovingAverageConfig: spec.MovingAverageConfig,
+	}, nil
+}
+
+func (s *ExponentialMovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return ExponentialMovingAverageKind
+}
+func (s *ExponentialMovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ExponentialMovingAverageProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createExponentialMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ExponentialMovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewExponentialMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewExponentialMovingAverageTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ExponentialMovingAverageProcedureSpec) execute.Transformation {
+	n := int(spec.N)
+	return newAveragingTransformation(d, cache, ExponentialMovingAverageKind, spec.Column, func() averager {
+		return newExponentialAverager(n)
+	})
+}
+
+// exponentialAverager computes the exponential moving average of the values with a smoothing factor of 2/(n+1).
+// It starts with the mean of the first n values.
+type exponentialAverager struct {
+	alpha   float64
+	first   *simpleAverager
+	ema     float64
+	defined bool
+}
+
+func newExponentialAverager(n int) *exponentialAverager {
+	return &exponentialAverager{
+		alpha: 2 / float64(n+1),
+		first: newSimpleAverager(n),
+	}
+}
+
+func (a *exponentialAverager) add(v float64) (float64, bool) {
+	if !a.defined {
+		ema, ok := a.first.add(v)
+		if !ok {
+			return 0, false
+		}
+		a.ema = ema
+		a.defined = true
+		return a.ema, true
+	}
+	a.ema = a.alpha*v + (1-a.alpha)*a.ema
+	return a.ema, true
+}
@@ -0,0 +1,63 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestExponentialMovingAverageOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"exponentialMovingAverage","kind":"exponentialMovingAverage","spec":{"n":3,"column":"_value"}}`)
+	op := &flux.Operation{
+		ID: "exponentialMovingAverage",
+		Spec: &universe.ExponentialMovingAverageOpSpec{
+			MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: "_value"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestExponentialMovingAverage_Process(t *testing.T) {
+	spec := &universe.ExponentialMovingAverageProcedureSpec{
+		MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: execute.DefaultValueColLabel},
+	}
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 0.0},
+			{execute.Time(2), 0.0},
+			{execute.Time(3), 0.0},
+			{execute.Time(4), 6.0},
+			{execute.Time(5), nil},
+			{execute.Time(6), 9.0},
+		},
+	}}
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(3), 0.0},
+			{execute.Time(4), 3.0},
+			{execute.Time(5), nil},
+			{execute.Time(6), 6.0},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return universe.NewExponentialMovingAverageTransformation(d, c, spec)
+		},
+	)
+}
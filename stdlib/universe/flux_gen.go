@@ -22,10 +22,10 @@ var pkgAST = &ast.Package{
 			Loc: &ast.SourceLocation{
 				End: ast.Position{
 					Column: 74,
-					Line:   246,
+					Line:   267,
 				},
 				File:   "universe.flux",
-				Source: "package universe\n\nimport \"system\"\n\n// now is a function option whose default behaviour is to return the current system time\noption now = system.time\n\n// Booleans\nbuiltin true\nbuiltin false\n\n// Transformation functions\nbuiltin columns\nbuiltin count\nbuiltin covariance\nbuiltin cumulativeSum\nbuiltin derivative\nbuiltin difference\nbuiltin distinct\nbuiltin drop\nbuiltin duplicate\nbuiltin fill\nbuiltin filter\nbuiltin first\nbuiltin group\nbuiltin histogram\nbuiltin histogramQuantile\nbuiltin integral\nbuiltin join\nbuiltin keep\nbuiltin keyValues\nbuiltin keys\nbuiltin last\nbuiltin limit\nbuiltin map\nbuiltin max\nbuiltin mean\nbuiltin min\nbuiltin percentile\nbuiltin pivot\nbuiltin range\nbuiltin rename\nbuiltin sample\nbuiltin set\nbuiltin shift\nbuiltin skew\nbuiltin spread\nbuiltin sort\nbuiltin stateTracking\nbuiltin stddev\nbuiltin sum\nbuiltin union\nbuiltin unique\nbuiltin window\nbuiltin yield\n\n\n// type conversion functions\nbuiltin bool\nbuiltin duration\nbuiltin float\nbuiltin int\nbuiltin string\nbuiltin time\nbuiltin uint\n\n\n// other builtins\nbuiltin inf\nbuiltin linearBins\nbuiltin logarithmicBins\n\n// covariance function with automatic join\ncov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])\n\npearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)\n\n// AggregateWindow applies an aggregate function to fixed windows of time.\n// The procedure is to window the data, perform an aggregate operation,\n// and then undo the windowing to produce an output table for every input table.\naggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)\n\n// Increase returns the total non-negative difference between values in a table.\n// A main usage case is tracking changes in counter values which may wrap over time when they hit\n// a threshold or are reset. In the case of a wrap/reset,\n// we can assume that the absolute delta between two points will be at least their non-negative difference.\nincrease = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)\n\n// median returns the 50th percentile.\n// By default an approximate percentile is computed, this can be disabled by passing exact:true.\n// Using the exact method requires that the entire data set can fit in memory.\nmedian = (method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)\n\n// influxFieldsAsCols aligns data into time-aligned tuples.\ninfluxFieldsAsCols = (tables=<-) =>\n    tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")\n\n// stateCount computes the number of consecutive records in a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state count will be incremented\n// When a point evaluates as false, the state count is reset.\n//\n// The state count will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state count.\nstateCount = (fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)\n\n// stateDuration computes the duration of a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state duration will be\n// incremented by the duration between points. When a point evaluates as false,\n// the state duration is reset.\n//\n// The state duration will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state duration.\n//\n// Note that as the first point in the given state has no previous point, its\n// state duration will be 0.\n//\n// The duration is represented as an integer in the units specified.\nstateDuration = (fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)\n\n// _sortLimit is a helper function, which sorts and limits a table.\n_sortLimit = (n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)\n\n// top sorts a table by columns and keeps only the top n records.\ntop = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)\n\n// top sorts a table by columns and keeps only the bottom n records.\nbottom = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)\n\n// _highestOrLowest is a helper function, which reduces all groups into a single group by specific tags and a reducer function,\n// then it selects the highest or lowest records based on the columns and the _sortLimit function.\n// The default reducer assumes no reducing needs to be performed.\n_highestOrLowest = (n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)\n\n// highestMax returns the top N records from all groups using the maximum of each group.\nhighestMax = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// highestAverage returns the top N records from all groups using the average of each group.\nhighestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )\n\n// highestCurrent returns the top N records from all groups using the last value of each group.\nhighestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// lowestMin returns the bottom N records from all groups using the minimum of each group.\nlowestMin = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\n// lowestAverage returns the bottom N records from all groups using the average of each group.\nlowestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )\n\n// lowestCurrent returns the bottom N records from all groups using the last value of each group.\nlowestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\ntoString = (tables=<-) => tables |> map(fn:(r) => string(v:r._value))\ntoInt = (tables=<-) => tables |> map(fn:(r) => int(v:r._value))\ntoUInt = (tables=<-) => tables |> map(fn:(r) => uint(v:r._value))\ntoFloat = (tables=<-) => tables |> map(fn:(r) => float(v:r._value))\ntoBool = (tables=<-) => tables |> map(fn:(r) => bool(v:r._value))\ntoTime = (tables=<-) => tables |> map(fn:(r) => time(v:r._value))\ntoDuration = (tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
+				Source: "package universe\n\nimport \"system\"\n\n// now is a function option whose default behaviour is to return the current system time\noption now = system.time\n\n// Booleans\nbuiltin true\nbuiltin false\n\n// Transformation functions\nbuiltin columns\nbuiltin count\nbuiltin covariance\nbuiltin cumulativeSum\nbuiltin derivative\nbuiltin difference\nbuiltin distinct\nbuiltin doubleEMA\nbuiltin drop\nbuiltin duplicate\nbuiltin elapsed\nbuiltin exponentialMovingAverage\nbuiltin fill\nbuiltin filter\nbuiltin first\nbuiltin group\nbuiltin histogram\nbuiltin histogramQuantile\nbuiltin holtWinters\nbuiltin integral\nbuiltin join\nbuiltin kaufmansAMA\nbuiltin keep\nbuiltin keyValues\nbuiltin keys\nbuiltin last\nbuiltin limit\nbuiltin map\nbuiltin max\nbuiltin mean\nbuiltin min\nbuiltin movingAverage\nbuiltin percentile\nbuiltin pivot\nbuiltin range\nbuiltin reduce\nbuiltin rename\nbuiltin rowWindow\nbuiltin sample\nbuiltin sessionWindow\nbuiltin set\nbuiltin shift\nbuiltin skew\nbuiltin spread\nbuiltin sort\nbuiltin stateTracking\nbuiltin stddev\nbuiltin sum\nbuiltin timeWeightedAvg\nbuiltin tripleEMA\nbuiltin union\nbuiltin unique\nbuiltin unpivot\nbuiltin window\nbuiltin yield\n\n\n// type conversion functions\nbuiltin bool\nbuiltin duration\nbuiltin float\nbuiltin int\nbuiltin string\nbuiltin time\nbuiltin uint\n\n\n// other builtins\nbuiltin inf\nbuiltin linearBins\nbuiltin logarithmicBins\n\n// covariance function with automatic join\ncov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])\n\npearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)\n\n// AggregateWindow applies an aggregate function to fixed windows of time.\n// The procedure is to window the data, perform an aggregate operation,\n// and then undo the windowing to produce an output table for every input table.\naggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)\n\n// timedMovingAverage computes the mean of the values in the period before each multiple of every.\n// The average is placed at the end of its period, and periods without values produce no average.\ntimedMovingAverage = (every, period, column=\"_value\", tables=<-) =>\n    tables\n        |> window(every:every, period:period)\n        |> mean(columns:[column])\n        |> duplicate(column:\"_stop\", as:\"_time\")\n        |> window(every:inf)\n\n// Increase returns the total non-negative difference between values in a table.\n// A main usage case is tracking changes in counter values which may wrap over time when they hit\n// a threshold or are reset. In the case of a wrap/reset,\n// we can assume that the absolute delta between two points will be at least their non-negative difference.\nincrease = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)\n\n// median returns the 50th percentile.\n// By default an approximate percentile is computed, this can be disabled by passing exact:true.\n// Using the exact method requires that the entire data set can fit in memory.\nmedian = (method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)\n\n// influxFieldsAsCols aligns data into time-aligned tuples.\ninfluxFieldsAsCols = (tables=<-) =>\n    tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")\n\n// stateCount computes the number of consecutive records in a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state count will be incremented\n// When a point evaluates as false, the state count is reset.\n//\n// The state count will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state count.\nstateCount = (fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)\n\n// stateDuration computes the duration of a given state.\n// The state is defined via the function fn. For each consecutive point for\n// which the expression evaluates as true, the state duration will be\n// incremented by the duration between points. When a point evaluates as false,\n// the state duration is reset.\n//\n// The state duration will be added as an additional column to each record. If the\n// expression evaluates as false, the value will be -1. If the expression\n// generates an error during evaluation, the point is discarded, and does not\n// affect the state duration.\n//\n// Note that as the first point in the given state has no previous point, its\n// state duration will be 0.\n//\n// The duration is represented as an integer in the units specified.\nstateDuration = (fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)\n\n// _sortLimit is a helper function, which sorts and limits a table.\n_sortLimit = (n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)\n\n// top sorts a table by columns and keeps only the top n records.\ntop = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)\n\n// top sorts a table by columns and keeps only the bottom n records.\nbottom = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)\n\n// _highestOrLowest is a helper function, which reduces all groups into a single group by specific tags and a reducer function,\n// then it selects the highest or lowest records based on the columns and the _sortLimit function.\n// The default reducer assumes no reducing needs to be performed.\n_highestOrLowest = (n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)\n\n// highestMax returns the top N records from all groups using the maximum of each group.\nhighestMax = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// highestAverage returns the top N records from all groups using the average of each group.\nhighestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )\n\n// highestCurrent returns the top N records from all groups using the last value of each group.\nhighestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )\n\n// lowestMin returns the bottom N records from all groups using the minimum of each group.\nlowestMin = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\n// lowestAverage returns the bottom N records from all groups using the average of each group.\nlowestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )\n\n// lowestCurrent returns the bottom N records from all groups using the last value of each group.\nlowestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )\n\ntoString = (tables=<-) => tables |> map(fn:(r) => string(v:r._value))\ntoInt = (tables=<-) => tables |> map(fn:(r) => int(v:r._value))\ntoUInt = (tables=<-) => tables |> map(fn:(r) => uint(v:r._value))\ntoFloat = (tables=<-) => tables |> map(fn:(r) => float(v:r._value))\ntoBool = (tables=<-) => tables |> map(fn:(r) => bool(v:r._value))\ntoTime = (tables=<-) => tables |> map(fn:(r) => time(v:r._value))\ntoDuration = (tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
 				Start: ast.Position{
 					Column: 1,
 					Line:   1,
@@ -447,11 +447,11 @@ var pkgAST = &ast.Package{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 18,
 						Line:   20,
 					},
 					File:   "universe.flux",
-					Source: "builtin drop",
+					Source: "builtin doubleEMA",
 					Start: ast.Position{
 						Column: 1,
 						Line:   20,
@@ -463,29 +463,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 18,
 							Line:   20,
 						},
 						File:   "universe.flux",
-						Source: "drop",
+						Source: "doubleEMA",
 						Start: ast.Position{
 							Column: 9,
 							Line:   20,
 						},
 					},
 				},
-				Name: "drop",
+				Name: "doubleEMA",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 18,
+						Column: 13,
 						Line:   21,
 					},
 					File:   "universe.flux",
-					Source: "builtin duplicate",
+					Source: "builtin drop",
 					Start: ast.Position{
 						Column: 1,
 						Line:   21,
@@ -497,29 +497,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 18,
+							Column: 13,
 							Line:   21,
 						},
 						File:   "universe.flux",
-						Source: "duplicate",
+						Source: "drop",
 						Start: ast.Position{
 							Column: 9,
 							Line:   21,
 						},
 					},
 				},
-				Name: "duplicate",
+				Name: "drop",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 18,
 						Line:   22,
 					},
 					File:   "universe.flux",
-					Source: "builtin fill",
+					Source: "builtin duplicate",
 					Start: ast.Position{
 						Column: 1,
 						Line:   22,
@@ -531,29 +531,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 18,
 							Line:   22,
 						},
 						File:   "universe.flux",
-						Source: "fill",
+						Source: "duplicate",
 						Start: ast.Position{
 							Column: 9,
 							Line:   22,
 						},
 					},
 				},
-				Name: "fill",
+				Name: "duplicate",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 16,
 						Line:   23,
 					},
 					File:   "universe.flux",
-					Source: "builtin filter",
+					Source: "builtin elapsed",
 					Start: ast.Position{
 						Column: 1,
 						Line:   23,
@@ -565,29 +565,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 16,
 							Line:   23,
 						},
 						File:   "universe.flux",
-						Source: "filter",
+						Source: "elapsed",
 						Start: ast.Position{
 							Column: 9,
 							Line:   23,
 						},
 					},
 				},
-				Name: "filter",
+				Name: "elapsed",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 33,
 						Line:   24,
 					},
 					File:   "universe.flux",
-					Source: "builtin first",
+					Source: "builtin exponentialMovingAverage",
 					Start: ast.Position{
 						Column: 1,
 						Line:   24,
@@ -599,29 +599,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 33,
 							Line:   24,
 						},
 						File:   "universe.flux",
-						Source: "first",
+						Source: "exponentialMovingAverage",
 						Start: ast.Position{
 							Column: 9,
 							Line:   24,
 						},
 					},
 				},
-				Name: "first",
+				Name: "exponentialMovingAverage",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 13,
 						Line:   25,
 					},
 					File:   "universe.flux",
-					Source: "builtin group",
+					Source: "builtin fill",
 					Start: ast.Position{
 						Column: 1,
 						Line:   25,
@@ -633,29 +633,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 13,
 							Line:   25,
 						},
 						File:   "universe.flux",
-						Source: "group",
+						Source: "fill",
 						Start: ast.Position{
 							Column: 9,
 							Line:   25,
 						},
 					},
 				},
-				Name: "group",
+				Name: "fill",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 18,
+						Column: 15,
 						Line:   26,
 					},
 					File:   "universe.flux",
-					Source: "builtin histogram",
+					Source: "builtin filter",
 					Start: ast.Position{
 						Column: 1,
 						Line:   26,
@@ -667,29 +667,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 18,
+							Column: 15,
 							Line:   26,
 						},
 						File:   "universe.flux",
-						Source: "histogram",
+						Source: "filter",
 						Start: ast.Position{
 							Column: 9,
 							Line:   26,
 						},
 					},
 				},
-				Name: "histogram",
+				Name: "filter",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 26,
+						Column: 14,
 						Line:   27,
 					},
 					File:   "universe.flux",
-					Source: "builtin histogramQuantile",
+					Source: "builtin first",
 					Start: ast.Position{
 						Column: 1,
 						Line:   27,
@@ -701,29 +701,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 26,
+							Column: 14,
 							Line:   27,
 						},
 						File:   "universe.flux",
-						Source: "histogramQuantile",
+						Source: "first",
 						Start: ast.Position{
 							Column: 9,
 							Line:   27,
 						},
 					},
 				},
-				Name: "histogramQuantile",
+				Name: "first",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 17,
+						Column: 14,
 						Line:   28,
 					},
 					File:   "universe.flux",
-					Source: "builtin integral",
+					Source: "builtin group",
 					Start: ast.Position{
 						Column: 1,
 						Line:   28,
@@ -735,29 +735,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 17,
+							Column: 14,
 							Line:   28,
 						},
 						File:   "universe.flux",
-						Source: "integral",
+						Source: "group",
 						Start: ast.Position{
 							Column: 9,
 							Line:   28,
 						},
 					},
 				},
-				Name: "integral",
+				Name: "group",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 18,
 						Line:   29,
 					},
 					File:   "universe.flux",
-					Source: "builtin join",
+					Source: "builtin histogram",
 					Start: ast.Position{
 						Column: 1,
 						Line:   29,
@@ -769,29 +769,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 18,
 							Line:   29,
 						},
 						File:   "universe.flux",
-						Source: "join",
+						Source: "histogram",
 						Start: ast.Position{
 							Column: 9,
 							Line:   29,
 						},
 					},
 				},
-				Name: "join",
+				Name: "histogram",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 26,
 						Line:   30,
 					},
 					File:   "universe.flux",
-					Source: "builtin keep",
+					Source: "builtin histogramQuantile",
 					Start: ast.Position{
 						Column: 1,
 						Line:   30,
@@ -803,29 +803,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 26,
 							Line:   30,
 						},
 						File:   "universe.flux",
-						Source: "keep",
+						Source: "histogramQuantile",
 						Start: ast.Position{
 							Column: 9,
 							Line:   30,
 						},
 					},
 				},
-				Name: "keep",
+				Name: "histogramQuantile",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 18,
+						Column: 20,
 						Line:   31,
 					},
 					File:   "universe.flux",
-					Source: "builtin keyValues",
+					Source: "builtin holtWinters",
 					Start: ast.Position{
 						Column: 1,
 						Line:   31,
@@ -837,29 +837,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 18,
+							Column: 20,
 							Line:   31,
 						},
 						File:   "universe.flux",
-						Source: "keyValues",
+						Source: "holtWinters",
 						Start: ast.Position{
 							Column: 9,
 							Line:   31,
 						},
 					},
 				},
-				Name: "keyValues",
+				Name: "holtWinters",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 17,
 						Line:   32,
 					},
 					File:   "universe.flux",
-					Source: "builtin keys",
+					Source: "builtin integral",
 					Start: ast.Position{
 						Column: 1,
 						Line:   32,
@@ -871,18 +871,18 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 17,
 							Line:   32,
 						},
 						File:   "universe.flux",
-						Source: "keys",
+						Source: "integral",
 						Start: ast.Position{
 							Column: 9,
 							Line:   32,
 						},
 					},
 				},
-				Name: "keys",
+				Name: "integral",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
@@ -893,7 +893,7 @@ var pkgAST = &ast.Package{
 						Line:   33,
 					},
 					File:   "universe.flux",
-					Source: "builtin last",
+					Source: "builtin join",
 					Start: ast.Position{
 						Column: 1,
 						Line:   33,
@@ -909,25 +909,25 @@ var pkgAST = &ast.Package{
 							Line:   33,
 						},
 						File:   "universe.flux",
-						Source: "last",
+						Source: "join",
 						Start: ast.Position{
 							Column: 9,
 							Line:   33,
 						},
 					},
 				},
-				Name: "last",
+				Name: "join",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 20,
 						Line:   34,
 					},
 					File:   "universe.flux",
-					Source: "builtin limit",
+					Source: "builtin kaufmansAMA",
 					Start: ast.Position{
 						Column: 1,
 						Line:   34,
@@ -939,29 +939,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 20,
 							Line:   34,
 						},
 						File:   "universe.flux",
-						Source: "limit",
+						Source: "kaufmansAMA",
 						Start: ast.Position{
 							Column: 9,
 							Line:   34,
 						},
 					},
 				},
-				Name: "limit",
+				Name: "kaufmansAMA",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 13,
 						Line:   35,
 					},
 					File:   "universe.flux",
-					Source: "builtin map",
+					Source: "builtin keep",
 					Start: ast.Position{
 						Column: 1,
 						Line:   35,
@@ -973,29 +973,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 13,
 							Line:   35,
 						},
 						File:   "universe.flux",
-						Source: "map",
+						Source: "keep",
 						Start: ast.Position{
 							Column: 9,
 							Line:   35,
 						},
 					},
 				},
-				Name: "map",
+				Name: "keep",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 18,
 						Line:   36,
 					},
 					File:   "universe.flux",
-					Source: "builtin max",
+					Source: "builtin keyValues",
 					Start: ast.Position{
 						Column: 1,
 						Line:   36,
@@ -1007,18 +1007,18 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 18,
 							Line:   36,
 						},
 						File:   "universe.flux",
-						Source: "max",
+						Source: "keyValues",
 						Start: ast.Position{
 							Column: 9,
 							Line:   36,
 						},
 					},
 				},
-				Name: "max",
+				Name: "keyValues",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
@@ -1029,7 +1029,7 @@ var pkgAST = &ast.Package{
 						Line:   37,
 					},
 					File:   "universe.flux",
-					Source: "builtin mean",
+					Source: "builtin keys",
 					Start: ast.Position{
 						Column: 1,
 						Line:   37,
@@ -1045,25 +1045,25 @@ var pkgAST = &ast.Package{
 							Line:   37,
 						},
 						File:   "universe.flux",
-						Source: "mean",
+						Source: "keys",
 						Start: ast.Position{
 							Column: 9,
 							Line:   37,
 						},
 					},
 				},
-				Name: "mean",
+				Name: "keys",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 13,
 						Line:   38,
 					},
 					File:   "universe.flux",
-					Source: "builtin min",
+					Source: "builtin last",
 					Start: ast.Position{
 						Column: 1,
 						Line:   38,
@@ -1075,29 +1075,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 13,
 							Line:   38,
 						},
 						File:   "universe.flux",
-						Source: "min",
+						Source: "last",
 						Start: ast.Position{
 							Column: 9,
 							Line:   38,
 						},
 					},
 				},
-				Name: "min",
+				Name: "last",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 19,
+						Column: 14,
 						Line:   39,
 					},
 					File:   "universe.flux",
-					Source: "builtin percentile",
+					Source: "builtin limit",
 					Start: ast.Position{
 						Column: 1,
 						Line:   39,
@@ -1109,29 +1109,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 19,
+							Column: 14,
 							Line:   39,
 						},
 						File:   "universe.flux",
-						Source: "percentile",
+						Source: "limit",
 						Start: ast.Position{
 							Column: 9,
 							Line:   39,
 						},
 					},
 				},
-				Name: "percentile",
+				Name: "limit",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 12,
 						Line:   40,
 					},
 					File:   "universe.flux",
-					Source: "builtin pivot",
+					Source: "builtin map",
 					Start: ast.Position{
 						Column: 1,
 						Line:   40,
@@ -1143,29 +1143,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 12,
 							Line:   40,
 						},
 						File:   "universe.flux",
-						Source: "pivot",
+						Source: "map",
 						Start: ast.Position{
 							Column: 9,
 							Line:   40,
 						},
 					},
 				},
-				Name: "pivot",
+				Name: "map",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 12,
 						Line:   41,
 					},
 					File:   "universe.flux",
-					Source: "builtin range",
+					Source: "builtin max",
 					Start: ast.Position{
 						Column: 1,
 						Line:   41,
@@ -1177,29 +1177,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 12,
 							Line:   41,
 						},
 						File:   "universe.flux",
-						Source: "range",
+						Source: "max",
 						Start: ast.Position{
 							Column: 9,
 							Line:   41,
 						},
 					},
 				},
-				Name: "range",
+				Name: "max",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 13,
 						Line:   42,
 					},
 					File:   "universe.flux",
-					Source: "builtin rename",
+					Source: "builtin mean",
 					Start: ast.Position{
 						Column: 1,
 						Line:   42,
@@ -1211,29 +1211,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 13,
 							Line:   42,
 						},
 						File:   "universe.flux",
-						Source: "rename",
+						Source: "mean",
 						Start: ast.Position{
 							Column: 9,
 							Line:   42,
 						},
 					},
 				},
-				Name: "rename",
+				Name: "mean",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 12,
 						Line:   43,
 					},
 					File:   "universe.flux",
-					Source: "builtin sample",
+					Source: "builtin min",
 					Start: ast.Position{
 						Column: 1,
 						Line:   43,
@@ -1245,29 +1245,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 12,
 							Line:   43,
 						},
 						File:   "universe.flux",
-						Source: "sample",
+						Source: "min",
 						Start: ast.Position{
 							Column: 9,
 							Line:   43,
 						},
 					},
 				},
-				Name: "sample",
+				Name: "min",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 22,
 						Line:   44,
 					},
 					File:   "universe.flux",
-					Source: "builtin set",
+					Source: "builtin movingAverage",
 					Start: ast.Position{
 						Column: 1,
 						Line:   44,
@@ -1279,29 +1279,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 22,
 							Line:   44,
 						},
 						File:   "universe.flux",
-						Source: "set",
+						Source: "movingAverage",
 						Start: ast.Position{
 							Column: 9,
 							Line:   44,
 						},
 					},
 				},
-				Name: "set",
+				Name: "movingAverage",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 19,
 						Line:   45,
 					},
 					File:   "universe.flux",
-					Source: "builtin shift",
+					Source: "builtin percentile",
 					Start: ast.Position{
 						Column: 1,
 						Line:   45,
@@ -1313,29 +1313,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 19,
 							Line:   45,
 						},
 						File:   "universe.flux",
-						Source: "shift",
+						Source: "percentile",
 						Start: ast.Position{
 							Column: 9,
 							Line:   45,
 						},
 					},
 				},
-				Name: "shift",
+				Name: "percentile",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 14,
 						Line:   46,
 					},
 					File:   "universe.flux",
-					Source: "builtin skew",
+					Source: "builtin pivot",
 					Start: ast.Position{
 						Column: 1,
 						Line:   46,
@@ -1347,29 +1347,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 14,
 							Line:   46,
 						},
 						File:   "universe.flux",
-						Source: "skew",
+						Source: "pivot",
 						Start: ast.Position{
 							Column: 9,
 							Line:   46,
 						},
 					},
 				},
-				Name: "skew",
+				Name: "pivot",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 14,
 						Line:   47,
 					},
 					File:   "universe.flux",
-					Source: "builtin spread",
+					Source: "builtin range",
 					Start: ast.Position{
 						Column: 1,
 						Line:   47,
@@ -1381,29 +1381,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 14,
 							Line:   47,
 						},
 						File:   "universe.flux",
-						Source: "spread",
+						Source: "range",
 						Start: ast.Position{
 							Column: 9,
 							Line:   47,
 						},
 					},
 				},
-				Name: "spread",
+				Name: "range",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 15,
 						Line:   48,
 					},
 					File:   "universe.flux",
-					Source: "builtin sort",
+					Source: "builtin reduce",
 					Start: ast.Position{
 						Column: 1,
 						Line:   48,
@@ -1415,29 +1415,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 15,
 							Line:   48,
 						},
 						File:   "universe.flux",
-						Source: "sort",
+						Source: "reduce",
 						Start: ast.Position{
 							Column: 9,
 							Line:   48,
 						},
 					},
 				},
-				Name: "sort",
+				Name: "reduce",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 22,
+						Column: 15,
 						Line:   49,
 					},
 					File:   "universe.flux",
-					Source: "builtin stateTracking",
+					Source: "builtin rename",
 					Start: ast.Position{
 						Column: 1,
 						Line:   49,
@@ -1449,29 +1449,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 22,
+							Column: 15,
 							Line:   49,
 						},
 						File:   "universe.flux",
-						Source: "stateTracking",
+						Source: "rename",
 						Start: ast.Position{
 							Column: 9,
 							Line:   49,
 						},
 					},
 				},
-				Name: "stateTracking",
+				Name: "rename",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 18,
 						Line:   50,
 					},
 					File:   "universe.flux",
-					Source: "builtin stddev",
+					Source: "builtin rowWindow",
 					Start: ast.Position{
 						Column: 1,
 						Line:   50,
@@ -1483,29 +1483,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 18,
 							Line:   50,
 						},
 						File:   "universe.flux",
-						Source: "stddev",
+						Source: "rowWindow",
 						Start: ast.Position{
 							Column: 9,
 							Line:   50,
 						},
 					},
 				},
-				Name: "stddev",
+				Name: "rowWindow",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 15,
 						Line:   51,
 					},
 					File:   "universe.flux",
-					Source: "builtin sum",
+					Source: "builtin sample",
 					Start: ast.Position{
 						Column: 1,
 						Line:   51,
@@ -1517,29 +1517,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 15,
 							Line:   51,
 						},
 						File:   "universe.flux",
-						Source: "sum",
+						Source: "sample",
 						Start: ast.Position{
 							Column: 9,
 							Line:   51,
 						},
 					},
 				},
-				Name: "sum",
+				Name: "sample",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 22,
 						Line:   52,
 					},
 					File:   "universe.flux",
-					Source: "builtin union",
+					Source: "builtin sessionWindow",
 					Start: ast.Position{
 						Column: 1,
 						Line:   52,
@@ -1551,29 +1551,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 22,
 							Line:   52,
 						},
 						File:   "universe.flux",
-						Source: "union",
+						Source: "sessionWindow",
 						Start: ast.Position{
 							Column: 9,
 							Line:   52,
 						},
 					},
 				},
-				Name: "union",
+				Name: "sessionWindow",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 12,
 						Line:   53,
 					},
 					File:   "universe.flux",
-					Source: "builtin unique",
+					Source: "builtin set",
 					Start: ast.Position{
 						Column: 1,
 						Line:   53,
@@ -1585,29 +1585,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 12,
 							Line:   53,
 						},
 						File:   "universe.flux",
-						Source: "unique",
+						Source: "set",
 						Start: ast.Position{
 							Column: 9,
 							Line:   53,
 						},
 					},
 				},
-				Name: "unique",
+				Name: "set",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 14,
 						Line:   54,
 					},
 					File:   "universe.flux",
-					Source: "builtin window",
+					Source: "builtin shift",
 					Start: ast.Position{
 						Column: 1,
 						Line:   54,
@@ -1619,29 +1619,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 14,
 							Line:   54,
 						},
 						File:   "universe.flux",
-						Source: "window",
+						Source: "shift",
 						Start: ast.Position{
 							Column: 9,
 							Line:   54,
 						},
 					},
 				},
-				Name: "window",
+				Name: "shift",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 13,
 						Line:   55,
 					},
 					File:   "universe.flux",
-					Source: "builtin yield",
+					Source: "builtin skew",
 					Start: ast.Position{
 						Column: 1,
 						Line:   55,
@@ -1653,18 +1653,52 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 13,
 							Line:   55,
 						},
 						File:   "universe.flux",
-						Source: "yield",
+						Source: "skew",
 						Start: ast.Position{
 							Column: 9,
 							Line:   55,
 						},
 					},
 				},
-				Name: "yield",
+				Name: "skew",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   56,
+					},
+					File:   "universe.flux",
+					Source: "builtin spread",
+					Start: ast.Position{
+						Column: 1,
+						Line:   56,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   56,
+						},
+						File:   "universe.flux",
+						Source: "spread",
+						Start: ast.Position{
+							Column: 9,
+							Line:   56,
+						},
+					},
+				},
+				Name: "spread",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
@@ -1672,10 +1706,78 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 13,
+						Line:   57,
+					},
+					File:   "universe.flux",
+					Source: "builtin sort",
+					Start: ast.Position{
+						Column: 1,
+						Line:   57,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 13,
+							Line:   57,
+						},
+						File:   "universe.flux",
+						Source: "sort",
+						Start: ast.Position{
+							Column: 9,
+							Line:   57,
+						},
+					},
+				},
+				Name: "sort",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 22,
+						Line:   58,
+					},
+					File:   "universe.flux",
+					Source: "builtin stateTracking",
+					Start: ast.Position{
+						Column: 1,
+						Line:   58,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 22,
+							Line:   58,
+						},
+						File:   "universe.flux",
+						Source: "stateTracking",
+						Start: ast.Position{
+							Column: 9,
+							Line:   58,
+						},
+					},
+				},
+				Name: "stateTracking",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
 						Line:   59,
 					},
 					File:   "universe.flux",
-					Source: "builtin bool",
+					Source: "builtin stddev",
 					Start: ast.Position{
 						Column: 1,
 						Line:   59,
@@ -1687,29 +1789,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 15,
 							Line:   59,
 						},
 						File:   "universe.flux",
-						Source: "bool",
+						Source: "stddev",
 						Start: ast.Position{
 							Column: 9,
 							Line:   59,
 						},
 					},
 				},
-				Name: "bool",
+				Name: "stddev",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 17,
+						Column: 12,
 						Line:   60,
 					},
 					File:   "universe.flux",
-					Source: "builtin duration",
+					Source: "builtin sum",
 					Start: ast.Position{
 						Column: 1,
 						Line:   60,
@@ -1721,29 +1823,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 17,
+							Column: 12,
 							Line:   60,
 						},
 						File:   "universe.flux",
-						Source: "duration",
+						Source: "sum",
 						Start: ast.Position{
 							Column: 9,
 							Line:   60,
 						},
 					},
 				},
-				Name: "duration",
+				Name: "sum",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 14,
+						Column: 24,
 						Line:   61,
 					},
 					File:   "universe.flux",
-					Source: "builtin float",
+					Source: "builtin timeWeightedAvg",
 					Start: ast.Position{
 						Column: 1,
 						Line:   61,
@@ -1755,29 +1857,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 14,
+							Column: 24,
 							Line:   61,
 						},
 						File:   "universe.flux",
-						Source: "float",
+						Source: "timeWeightedAvg",
 						Start: ast.Position{
 							Column: 9,
 							Line:   61,
 						},
 					},
 				},
-				Name: "float",
+				Name: "timeWeightedAvg",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
+						Column: 18,
 						Line:   62,
 					},
 					File:   "universe.flux",
-					Source: "builtin int",
+					Source: "builtin tripleEMA",
 					Start: ast.Position{
 						Column: 1,
 						Line:   62,
@@ -1789,29 +1891,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
+							Column: 18,
 							Line:   62,
 						},
 						File:   "universe.flux",
-						Source: "int",
+						Source: "tripleEMA",
 						Start: ast.Position{
 							Column: 9,
 							Line:   62,
 						},
 					},
 				},
-				Name: "int",
+				Name: "tripleEMA",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 15,
+						Column: 14,
 						Line:   63,
 					},
 					File:   "universe.flux",
-					Source: "builtin string",
+					Source: "builtin union",
 					Start: ast.Position{
 						Column: 1,
 						Line:   63,
@@ -1823,29 +1925,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 15,
+							Column: 14,
 							Line:   63,
 						},
 						File:   "universe.flux",
-						Source: "string",
+						Source: "union",
 						Start: ast.Position{
 							Column: 9,
 							Line:   63,
 						},
 					},
 				},
-				Name: "string",
+				Name: "union",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 15,
 						Line:   64,
 					},
 					File:   "universe.flux",
-					Source: "builtin time",
+					Source: "builtin unique",
 					Start: ast.Position{
 						Column: 1,
 						Line:   64,
@@ -1857,29 +1959,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 15,
 							Line:   64,
 						},
 						File:   "universe.flux",
-						Source: "time",
+						Source: "unique",
 						Start: ast.Position{
 							Column: 9,
 							Line:   64,
 						},
 					},
 				},
-				Name: "time",
+				Name: "unique",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 13,
+						Column: 16,
 						Line:   65,
 					},
 					File:   "universe.flux",
-					Source: "builtin uint",
+					Source: "builtin unpivot",
 					Start: ast.Position{
 						Column: 1,
 						Line:   65,
@@ -1891,32 +1993,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 13,
+							Column: 16,
 							Line:   65,
 						},
 						File:   "universe.flux",
-						Source: "uint",
+						Source: "unpivot",
 						Start: ast.Position{
 							Column: 9,
 							Line:   65,
 						},
 					},
 				},
-				Name: "uint",
+				Name: "unpivot",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 12,
-						Line:   69,
+						Column: 15,
+						Line:   66,
 					},
 					File:   "universe.flux",
-					Source: "builtin inf",
+					Source: "builtin window",
 					Start: ast.Position{
 						Column: 1,
-						Line:   69,
+						Line:   66,
 					},
 				},
 			},
@@ -1925,32 +2027,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 12,
-							Line:   69,
+							Column: 15,
+							Line:   66,
 						},
 						File:   "universe.flux",
-						Source: "inf",
+						Source: "window",
 						Start: ast.Position{
 							Column: 9,
-							Line:   69,
+							Line:   66,
 						},
 					},
 				},
-				Name: "inf",
+				Name: "window",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 19,
-						Line:   70,
+						Column: 14,
+						Line:   67,
 					},
 					File:   "universe.flux",
-					Source: "builtin linearBins",
+					Source: "builtin yield",
 					Start: ast.Position{
 						Column: 1,
-						Line:   70,
+						Line:   67,
 					},
 				},
 			},
@@ -1959,29 +2061,29 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 19,
-							Line:   70,
+							Column: 14,
+							Line:   67,
 						},
 						File:   "universe.flux",
-						Source: "linearBins",
+						Source: "yield",
 						Start: ast.Position{
 							Column: 9,
-							Line:   70,
+							Line:   67,
 						},
 					},
 				},
-				Name: "linearBins",
+				Name: "yield",
 			},
 		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 24,
+						Column: 13,
 						Line:   71,
 					},
 					File:   "universe.flux",
-					Source: "builtin logarithmicBins",
+					Source: "builtin bool",
 					Start: ast.Position{
 						Column: 1,
 						Line:   71,
@@ -1993,32 +2095,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 24,
+							Column: 13,
 							Line:   71,
 						},
 						File:   "universe.flux",
-						Source: "logarithmicBins",
+						Source: "bool",
 						Start: ast.Position{
 							Column: 9,
 							Line:   71,
 						},
 					},
 				},
-				Name: "logarithmicBins",
+				Name: "bool",
 			},
-		}, &ast.VariableAssignment{
+		}, &ast.BuiltinStatement{
 			BaseNode: ast.BaseNode{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 70,
-						Line:   79,
+						Column: 17,
+						Line:   72,
 					},
 					File:   "universe.flux",
-					Source: "cov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+					Source: "builtin duration",
 					Start: ast.Position{
 						Column: 1,
-						Line:   74,
+						Line:   72,
 					},
 				},
 			},
@@ -2027,168 +2129,1677 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 4,
-							Line:   74,
+							Column: 17,
+							Line:   72,
 						},
 						File:   "universe.flux",
-						Source: "cov",
+						Source: "duration",
+						Start: ast.Position{
+							Column: 9,
+							Line:   72,
+						},
+					},
+				},
+				Name: "duration",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 14,
+						Line:   73,
+					},
+					File:   "universe.flux",
+					Source: "builtin float",
+					Start: ast.Position{
+						Column: 1,
+						Line:   73,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 14,
+							Line:   73,
+						},
+						File:   "universe.flux",
+						Source: "float",
+						Start: ast.Position{
+							Column: 9,
+							Line:   73,
+						},
+					},
+				},
+				Name: "float",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   74,
+					},
+					File:   "universe.flux",
+					Source: "builtin int",
+					Start: ast.Position{
+						Column: 1,
+						Line:   74,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   74,
+						},
+						File:   "universe.flux",
+						Source: "int",
+						Start: ast.Position{
+							Column: 9,
+							Line:   74,
+						},
+					},
+				},
+				Name: "int",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 15,
+						Line:   75,
+					},
+					File:   "universe.flux",
+					Source: "builtin string",
+					Start: ast.Position{
+						Column: 1,
+						Line:   75,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 15,
+							Line:   75,
+						},
+						File:   "universe.flux",
+						Source: "string",
+						Start: ast.Position{
+							Column: 9,
+							Line:   75,
+						},
+					},
+				},
+				Name: "string",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 13,
+						Line:   76,
+					},
+					File:   "universe.flux",
+					Source: "builtin time",
+					Start: ast.Position{
+						Column: 1,
+						Line:   76,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 13,
+							Line:   76,
+						},
+						File:   "universe.flux",
+						Source: "time",
+						Start: ast.Position{
+							Column: 9,
+							Line:   76,
+						},
+					},
+				},
+				Name: "time",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 13,
+						Line:   77,
+					},
+					File:   "universe.flux",
+					Source: "builtin uint",
+					Start: ast.Position{
+						Column: 1,
+						Line:   77,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 13,
+							Line:   77,
+						},
+						File:   "universe.flux",
+						Source: "uint",
+						Start: ast.Position{
+							Column: 9,
+							Line:   77,
+						},
+					},
+				},
+				Name: "uint",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 12,
+						Line:   81,
+					},
+					File:   "universe.flux",
+					Source: "builtin inf",
+					Start: ast.Position{
+						Column: 1,
+						Line:   81,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 12,
+							Line:   81,
+						},
+						File:   "universe.flux",
+						Source: "inf",
+						Start: ast.Position{
+							Column: 9,
+							Line:   81,
+						},
+					},
+				},
+				Name: "inf",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 19,
+						Line:   82,
+					},
+					File:   "universe.flux",
+					Source: "builtin linearBins",
+					Start: ast.Position{
+						Column: 1,
+						Line:   82,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 19,
+							Line:   82,
+						},
+						File:   "universe.flux",
+						Source: "linearBins",
+						Start: ast.Position{
+							Column: 9,
+							Line:   82,
+						},
+					},
+				},
+				Name: "linearBins",
+			},
+		}, &ast.BuiltinStatement{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 24,
+						Line:   83,
+					},
+					File:   "universe.flux",
+					Source: "builtin logarithmicBins",
+					Start: ast.Position{
+						Column: 1,
+						Line:   83,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 24,
+							Line:   83,
+						},
+						File:   "universe.flux",
+						Source: "logarithmicBins",
+						Start: ast.Position{
+							Column: 9,
+							Line:   83,
+						},
+					},
+				},
+				Name: "logarithmicBins",
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 70,
+						Line:   91,
+					},
+					File:   "universe.flux",
+					Source: "cov = (x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+					Start: ast.Position{
+						Column: 1,
+						Line:   86,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 4,
+							Line:   86,
+						},
+						File:   "universe.flux",
+						Source: "cov",
+						Start: ast.Position{
+							Column: 1,
+							Line:   86,
+						},
+					},
+				},
+				Name: "cov",
+			},
+			Init: &ast.FunctionExpression{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 70,
+							Line:   91,
+						},
+						File:   "universe.flux",
+						Source: "(x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+						Start: ast.Position{
+							Column: 7,
+							Line:   86,
+						},
+					},
+				},
+				Body: &ast.PipeExpression{
+					Argument: &ast.CallExpression{
+						Arguments: []ast.Expression{&ast.ObjectExpression{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 14,
+										Line:   89,
+									},
+									File:   "universe.flux",
+									Source: "tables:{x:x, y:y},\n        on:on",
+									Start: ast.Position{
+										Column: 9,
+										Line:   88,
+									},
+								},
+							},
+							Properties: []*ast.Property{&ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 26,
+											Line:   88,
+										},
+										File:   "universe.flux",
+										Source: "tables:{x:x, y:y}",
+										Start: ast.Position{
+											Column: 9,
+											Line:   88,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 15,
+												Line:   88,
+											},
+											File:   "universe.flux",
+											Source: "tables",
+											Start: ast.Position{
+												Column: 9,
+												Line:   88,
+											},
+										},
+									},
+									Name: "tables",
+								},
+								Value: &ast.ObjectExpression{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 26,
+												Line:   88,
+											},
+											File:   "universe.flux",
+											Source: "{x:x, y:y}",
+											Start: ast.Position{
+												Column: 16,
+												Line:   88,
+											},
+										},
+									},
+									Properties: []*ast.Property{&ast.Property{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 20,
+													Line:   88,
+												},
+												File:   "universe.flux",
+												Source: "x:x",
+												Start: ast.Position{
+													Column: 17,
+													Line:   88,
+												},
+											},
+										},
+										Key: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 18,
+														Line:   88,
+													},
+													File:   "universe.flux",
+													Source: "x",
+													Start: ast.Position{
+														Column: 17,
+														Line:   88,
+													},
+												},
+											},
+											Name: "x",
+										},
+										Value: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 20,
+														Line:   88,
+													},
+													File:   "universe.flux",
+													Source: "x",
+													Start: ast.Position{
+														Column: 19,
+														Line:   88,
+													},
+												},
+											},
+											Name: "x",
+										},
+									}, &ast.Property{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 25,
+													Line:   88,
+												},
+												File:   "universe.flux",
+												Source: "y:y",
+												Start: ast.Position{
+													Column: 22,
+													Line:   88,
+												},
+											},
+										},
+										Key: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 23,
+														Line:   88,
+													},
+													File:   "universe.flux",
+													Source: "y",
+													Start: ast.Position{
+														Column: 22,
+														Line:   88,
+													},
+												},
+											},
+											Name: "y",
+										},
+										Value: &ast.Identifier{
+											BaseNode: ast.BaseNode{
+												Errors: nil,
+												Loc: &ast.SourceLocation{
+													End: ast.Position{
+														Column: 25,
+														Line:   88,
+													},
+													File:   "universe.flux",
+													Source: "y",
+													Start: ast.Position{
+														Column: 24,
+														Line:   88,
+													},
+												},
+											},
+											Name: "y",
+										},
+									}},
+								},
+							}, &ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 14,
+											Line:   89,
+										},
+										File:   "universe.flux",
+										Source: "on:on",
+										Start: ast.Position{
+											Column: 9,
+											Line:   89,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 11,
+												Line:   89,
+											},
+											File:   "universe.flux",
+											Source: "on",
+											Start: ast.Position{
+												Column: 9,
+												Line:   89,
+											},
+										},
+									},
+									Name: "on",
+								},
+								Value: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 14,
+												Line:   89,
+											},
+											File:   "universe.flux",
+											Source: "on",
+											Start: ast.Position{
+												Column: 12,
+												Line:   89,
+											},
+										},
+									},
+									Name: "on",
+								},
+							}},
+						}},
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 6,
+									Line:   90,
+								},
+								File:   "universe.flux",
+								Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )",
+								Start: ast.Position{
+									Column: 5,
+									Line:   87,
+								},
+							},
+						},
+						Callee: &ast.Identifier{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 9,
+										Line:   87,
+									},
+									File:   "universe.flux",
+									Source: "join",
+									Start: ast.Position{
+										Column: 5,
+										Line:   87,
+									},
+								},
+							},
+							Name: "join",
+						},
+					},
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 70,
+								Line:   91,
+							},
+							File:   "universe.flux",
+							Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+							Start: ast.Position{
+								Column: 5,
+								Line:   87,
+							},
+						},
+					},
+					Call: &ast.CallExpression{
+						Arguments: []ast.Expression{&ast.ObjectExpression{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 69,
+										Line:   91,
+									},
+									File:   "universe.flux",
+									Source: "pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"]",
+									Start: ast.Position{
+										Column: 19,
+										Line:   91,
+									},
+								},
+							},
+							Properties: []*ast.Property{&ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 36,
+											Line:   91,
+										},
+										File:   "universe.flux",
+										Source: "pearsonr:pearsonr",
+										Start: ast.Position{
+											Column: 19,
+											Line:   91,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 27,
+												Line:   91,
+											},
+											File:   "universe.flux",
+											Source: "pearsonr",
+											Start: ast.Position{
+												Column: 19,
+												Line:   91,
+											},
+										},
+									},
+									Name: "pearsonr",
+								},
+								Value: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 36,
+												Line:   91,
+											},
+											File:   "universe.flux",
+											Source: "pearsonr",
+											Start: ast.Position{
+												Column: 28,
+												Line:   91,
+											},
+										},
+									},
+									Name: "pearsonr",
+								},
+							}, &ast.Property{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 69,
+											Line:   91,
+										},
+										File:   "universe.flux",
+										Source: "columns:[\"_value_x\",\"_value_y\"]",
+										Start: ast.Position{
+											Column: 38,
+											Line:   91,
+										},
+									},
+								},
+								Key: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 45,
+												Line:   91,
+											},
+											File:   "universe.flux",
+											Source: "columns",
+											Start: ast.Position{
+												Column: 38,
+												Line:   91,
+											},
+										},
+									},
+									Name: "columns",
+								},
+								Value: &ast.ArrayExpression{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 69,
+												Line:   91,
+											},
+											File:   "universe.flux",
+											Source: "[\"_value_x\",\"_value_y\"]",
+											Start: ast.Position{
+												Column: 46,
+												Line:   91,
+											},
+										},
+									},
+									Elements: []ast.Expression{&ast.StringLiteral{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 57,
+													Line:   91,
+												},
+												File:   "universe.flux",
+												Source: "\"_value_x\"",
+												Start: ast.Position{
+													Column: 47,
+													Line:   91,
+												},
+											},
+										},
+										Value: "_value_x",
+									}, &ast.StringLiteral{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 68,
+													Line:   91,
+												},
+												File:   "universe.flux",
+												Source: "\"_value_y\"",
+												Start: ast.Position{
+													Column: 58,
+													Line:   91,
+												},
+											},
+										},
+										Value: "_value_y",
+									}},
+								},
+							}},
+						}},
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 70,
+									Line:   91,
+								},
+								File:   "universe.flux",
+								Source: "covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+								Start: ast.Position{
+									Column: 8,
+									Line:   91,
+								},
+							},
+						},
+						Callee: &ast.Identifier{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 18,
+										Line:   91,
+									},
+									File:   "universe.flux",
+									Source: "covariance",
+									Start: ast.Position{
+										Column: 8,
+										Line:   91,
+									},
+								},
+							},
+							Name: "covariance",
+						},
+					},
+				},
+				Params: []*ast.Property{&ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 9,
+								Line:   86,
+							},
+							File:   "universe.flux",
+							Source: "x",
+							Start: ast.Position{
+								Column: 8,
+								Line:   86,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 9,
+									Line:   86,
+								},
+								File:   "universe.flux",
+								Source: "x",
+								Start: ast.Position{
+									Column: 8,
+									Line:   86,
+								},
+							},
+						},
+						Name: "x",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 11,
+								Line:   86,
+							},
+							File:   "universe.flux",
+							Source: "y",
+							Start: ast.Position{
+								Column: 10,
+								Line:   86,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 11,
+									Line:   86,
+								},
+								File:   "universe.flux",
+								Source: "y",
+								Start: ast.Position{
+									Column: 10,
+									Line:   86,
+								},
+							},
+						},
+						Name: "y",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 14,
+								Line:   86,
+							},
+							File:   "universe.flux",
+							Source: "on",
+							Start: ast.Position{
+								Column: 12,
+								Line:   86,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 14,
+									Line:   86,
+								},
+								File:   "universe.flux",
+								Source: "on",
+								Start: ast.Position{
+									Column: 12,
+									Line:   86,
+								},
+							},
+						},
+						Name: "on",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 29,
+								Line:   86,
+							},
+							File:   "universe.flux",
+							Source: "pearsonr=false",
+							Start: ast.Position{
+								Column: 15,
+								Line:   86,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 23,
+									Line:   86,
+								},
+								File:   "universe.flux",
+								Source: "pearsonr",
+								Start: ast.Position{
+									Column: 15,
+									Line:   86,
+								},
+							},
+						},
+						Name: "pearsonr",
+					},
+					Value: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 29,
+									Line:   86,
+								},
+								File:   "universe.flux",
+								Source: "false",
+								Start: ast.Position{
+									Column: 24,
+									Line:   86,
+								},
+							},
+						},
+						Name: "false",
+					},
+				}},
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 59,
+						Line:   93,
+					},
+					File:   "universe.flux",
+					Source: "pearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
+					Start: ast.Position{
+						Column: 1,
+						Line:   93,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 9,
+							Line:   93,
+						},
+						File:   "universe.flux",
+						Source: "pearsonr",
 						Start: ast.Position{
 							Column: 1,
-							Line:   74,
+							Line:   93,
 						},
 					},
 				},
-				Name: "cov",
+				Name: "pearsonr",
 			},
 			Init: &ast.FunctionExpression{
 				BaseNode: ast.BaseNode{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 70,
-							Line:   79,
+							Column: 59,
+							Line:   93,
 						},
 						File:   "universe.flux",
-						Source: "(x,y,on,pearsonr=false) =>\n    join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+						Source: "(x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
 						Start: ast.Position{
-							Column: 7,
-							Line:   74,
+							Column: 12,
+							Line:   93,
 						},
 					},
 				},
-				Body: &ast.PipeExpression{
-					Argument: &ast.CallExpression{
-						Arguments: []ast.Expression{&ast.ObjectExpression{
+				Body: &ast.CallExpression{
+					Arguments: []ast.Expression{&ast.ObjectExpression{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 58,
+									Line:   93,
+								},
+								File:   "universe.flux",
+								Source: "x:x, y:y, on:on, pearsonr:true",
+								Start: ast.Position{
+									Column: 28,
+									Line:   93,
+								},
+							},
+						},
+						Properties: []*ast.Property{&ast.Property{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 31,
+										Line:   93,
+									},
+									File:   "universe.flux",
+									Source: "x:x",
+									Start: ast.Position{
+										Column: 28,
+										Line:   93,
+									},
+								},
+							},
+							Key: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 29,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "x",
+										Start: ast.Position{
+											Column: 28,
+											Line:   93,
+										},
+									},
+								},
+								Name: "x",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 31,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "x",
+										Start: ast.Position{
+											Column: 30,
+											Line:   93,
+										},
+									},
+								},
+								Name: "x",
+							},
+						}, &ast.Property{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 36,
+										Line:   93,
+									},
+									File:   "universe.flux",
+									Source: "y:y",
+									Start: ast.Position{
+										Column: 33,
+										Line:   93,
+									},
+								},
+							},
+							Key: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 34,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "y",
+										Start: ast.Position{
+											Column: 33,
+											Line:   93,
+										},
+									},
+								},
+								Name: "y",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 36,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "y",
+										Start: ast.Position{
+											Column: 35,
+											Line:   93,
+										},
+									},
+								},
+								Name: "y",
+							},
+						}, &ast.Property{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 43,
+										Line:   93,
+									},
+									File:   "universe.flux",
+									Source: "on:on",
+									Start: ast.Position{
+										Column: 38,
+										Line:   93,
+									},
+								},
+							},
+							Key: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 40,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "on",
+										Start: ast.Position{
+											Column: 38,
+											Line:   93,
+										},
+									},
+								},
+								Name: "on",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 43,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "on",
+										Start: ast.Position{
+											Column: 41,
+											Line:   93,
+										},
+									},
+								},
+								Name: "on",
+							},
+						}, &ast.Property{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 14,
-										Line:   77,
+										Column: 58,
+										Line:   93,
 									},
 									File:   "universe.flux",
-									Source: "tables:{x:x, y:y},\n        on:on",
+									Source: "pearsonr:true",
 									Start: ast.Position{
-										Column: 9,
-										Line:   76,
+										Column: 45,
+										Line:   93,
 									},
 								},
 							},
-							Properties: []*ast.Property{&ast.Property{
+							Key: &ast.Identifier{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 26,
-											Line:   76,
+											Column: 53,
+											Line:   93,
 										},
 										File:   "universe.flux",
-										Source: "tables:{x:x, y:y}",
+										Source: "pearsonr",
 										Start: ast.Position{
-											Column: 9,
-											Line:   76,
+											Column: 45,
+											Line:   93,
 										},
 									},
 								},
-								Key: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 15,
-												Line:   76,
-											},
-											File:   "universe.flux",
-											Source: "tables",
-											Start: ast.Position{
-												Column: 9,
-												Line:   76,
-											},
+								Name: "pearsonr",
+							},
+							Value: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 58,
+											Line:   93,
+										},
+										File:   "universe.flux",
+										Source: "true",
+										Start: ast.Position{
+											Column: 54,
+											Line:   93,
 										},
 									},
-									Name: "tables",
 								},
-								Value: &ast.ObjectExpression{
+								Name: "true",
+							},
+						}},
+					}},
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 59,
+								Line:   93,
+							},
+							File:   "universe.flux",
+							Source: "cov(x:x, y:y, on:on, pearsonr:true)",
+							Start: ast.Position{
+								Column: 24,
+								Line:   93,
+							},
+						},
+					},
+					Callee: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 27,
+									Line:   93,
+								},
+								File:   "universe.flux",
+								Source: "cov",
+								Start: ast.Position{
+									Column: 24,
+									Line:   93,
+								},
+							},
+						},
+						Name: "cov",
+					},
+				},
+				Params: []*ast.Property{&ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 14,
+								Line:   93,
+							},
+							File:   "universe.flux",
+							Source: "x",
+							Start: ast.Position{
+								Column: 13,
+								Line:   93,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 14,
+									Line:   93,
+								},
+								File:   "universe.flux",
+								Source: "x",
+								Start: ast.Position{
+									Column: 13,
+									Line:   93,
+								},
+							},
+						},
+						Name: "x",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 16,
+								Line:   93,
+							},
+							File:   "universe.flux",
+							Source: "y",
+							Start: ast.Position{
+								Column: 15,
+								Line:   93,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 16,
+									Line:   93,
+								},
+								File:   "universe.flux",
+								Source: "y",
+								Start: ast.Position{
+									Column: 15,
+									Line:   93,
+								},
+							},
+						},
+						Name: "y",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 19,
+								Line:   93,
+							},
+							File:   "universe.flux",
+							Source: "on",
+							Start: ast.Position{
+								Column: 17,
+								Line:   93,
+							},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 19,
+									Line:   93,
+								},
+								File:   "universe.flux",
+								Source: "on",
+								Start: ast.Position{
+									Column: 17,
+									Line:   93,
+								},
+							},
+						},
+						Name: "on",
+					},
+					Value: nil,
+				}},
+			},
+		}, &ast.VariableAssignment{
+			BaseNode: ast.BaseNode{
+				Errors: nil,
+				Loc: &ast.SourceLocation{
+					End: ast.Position{
+						Column: 49,
+						Line:   103,
+					},
+					File:   "universe.flux",
+					Source: "aggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+					Start: ast.Position{
+						Column: 1,
+						Line:   98,
+					},
+				},
+			},
+			ID: &ast.Identifier{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 16,
+							Line:   98,
+						},
+						File:   "universe.flux",
+						Source: "aggregateWindow",
+						Start: ast.Position{
+							Column: 1,
+							Line:   98,
+						},
+					},
+				},
+				Name: "aggregateWindow",
+			},
+			Init: &ast.FunctionExpression{
+				BaseNode: ast.BaseNode{
+					Errors: nil,
+					Loc: &ast.SourceLocation{
+						End: ast.Position{
+							Column: 49,
+							Line:   103,
+						},
+						File:   "universe.flux",
+						Source: "(every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+						Start: ast.Position{
+							Column: 19,
+							Line:   98,
+						},
+					},
+				},
+				Body: &ast.PipeExpression{
+					Argument: &ast.PipeExpression{
+						Argument: &ast.PipeExpression{
+							Argument: &ast.PipeExpression{
+								Argument: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 26,
-												Line:   76,
+												Column: 11,
+												Line:   99,
 											},
 											File:   "universe.flux",
-											Source: "{x:x, y:y}",
+											Source: "tables",
 											Start: ast.Position{
-												Column: 16,
-												Line:   76,
+												Column: 5,
+												Line:   99,
 											},
 										},
 									},
-									Properties: []*ast.Property{&ast.Property{
+									Name: "tables",
+								},
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 57,
+											Line:   100,
+										},
+										File:   "universe.flux",
+										Source: "tables\n        |> window(every:every, createEmpty: createEmpty)",
+										Start: ast.Position{
+											Column: 5,
+											Line:   99,
+										},
+									},
+								},
+								Call: &ast.CallExpression{
+									Arguments: []ast.Expression{&ast.ObjectExpression{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 20,
-													Line:   76,
+													Column: 56,
+													Line:   100,
 												},
 												File:   "universe.flux",
-												Source: "x:x",
+												Source: "every:every, createEmpty: createEmpty",
 												Start: ast.Position{
-													Column: 17,
-													Line:   76,
+													Column: 19,
+													Line:   100,
 												},
 											},
 										},
-										Key: &ast.Identifier{
+										Properties: []*ast.Property{&ast.Property{
 											BaseNode: ast.BaseNode{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 18,
-														Line:   76,
+														Column: 30,
+														Line:   100,
 													},
 													File:   "universe.flux",
-													Source: "x",
+													Source: "every:every",
 													Start: ast.Position{
-														Column: 17,
-														Line:   76,
+														Column: 19,
+														Line:   100,
 													},
 												},
 											},
-											Name: "x",
-										},
-										Value: &ast.Identifier{
+											Key: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 24,
+															Line:   100,
+														},
+														File:   "universe.flux",
+														Source: "every",
+														Start: ast.Position{
+															Column: 19,
+															Line:   100,
+														},
+													},
+												},
+												Name: "every",
+											},
+											Value: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 30,
+															Line:   100,
+														},
+														File:   "universe.flux",
+														Source: "every",
+														Start: ast.Position{
+															Column: 25,
+															Line:   100,
+														},
+													},
+												},
+												Name: "every",
+											},
+										}, &ast.Property{
 											BaseNode: ast.BaseNode{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 20,
-														Line:   76,
+														Column: 56,
+														Line:   100,
 													},
 													File:   "universe.flux",
-													Source: "x",
+													Source: "createEmpty: createEmpty",
 													Start: ast.Position{
-														Column: 19,
-														Line:   76,
+														Column: 32,
+														Line:   100,
 													},
 												},
 											},
-											Name: "x",
+											Key: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 43,
+															Line:   100,
+														},
+														File:   "universe.flux",
+														Source: "createEmpty",
+														Start: ast.Position{
+															Column: 32,
+															Line:   100,
+														},
+													},
+												},
+												Name: "createEmpty",
+											},
+											Value: &ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 56,
+															Line:   100,
+														},
+														File:   "universe.flux",
+														Source: "createEmpty",
+														Start: ast.Position{
+															Column: 45,
+															Line:   100,
+														},
+													},
+												},
+												Name: "createEmpty",
+											},
+										}},
+									}},
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 57,
+												Line:   100,
+											},
+											File:   "universe.flux",
+											Source: "window(every:every, createEmpty: createEmpty)",
+											Start: ast.Position{
+												Column: 12,
+												Line:   100,
+											},
 										},
-									}, &ast.Property{
+									},
+									Callee: &ast.Identifier{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 25,
-													Line:   76,
+													Column: 18,
+													Line:   100,
 												},
 												File:   "universe.flux",
-												Source: "y:y",
+												Source: "window",
 												Start: ast.Position{
-													Column: 22,
-													Line:   76,
+													Column: 12,
+													Line:   100,
+												},
+											},
+										},
+										Name: "window",
+									},
+								},
+							},
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 31,
+										Line:   101,
+									},
+									File:   "universe.flux",
+									Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)",
+									Start: ast.Position{
+										Column: 5,
+										Line:   99,
+									},
+								},
+							},
+							Call: &ast.CallExpression{
+								Arguments: []ast.Expression{&ast.ObjectExpression{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 30,
+												Line:   101,
+											},
+											File:   "universe.flux",
+											Source: "columns:columns",
+											Start: ast.Position{
+												Column: 15,
+												Line:   101,
+											},
+										},
+									},
+									Properties: []*ast.Property{&ast.Property{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 30,
+													Line:   101,
+												},
+												File:   "universe.flux",
+												Source: "columns:columns",
+												Start: ast.Position{
+													Column: 15,
+													Line:   101,
 												},
 											},
 										},
@@ -2197,139 +3808,259 @@ var pkgAST = &ast.Package{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 23,
-														Line:   76,
+														Column: 22,
+														Line:   101,
 													},
 													File:   "universe.flux",
-													Source: "y",
+													Source: "columns",
 													Start: ast.Position{
-														Column: 22,
-														Line:   76,
+														Column: 15,
+														Line:   101,
 													},
 												},
 											},
-											Name: "y",
+											Name: "columns",
 										},
 										Value: &ast.Identifier{
 											BaseNode: ast.BaseNode{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 25,
-														Line:   76,
+														Column: 30,
+														Line:   101,
 													},
 													File:   "universe.flux",
-													Source: "y",
+													Source: "columns",
 													Start: ast.Position{
-														Column: 24,
-														Line:   76,
+														Column: 23,
+														Line:   101,
 													},
 												},
 											},
-											Name: "y",
+											Name: "columns",
 										},
 									}},
+								}},
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 31,
+											Line:   101,
+										},
+										File:   "universe.flux",
+										Source: "fn(columns:columns)",
+										Start: ast.Position{
+											Column: 12,
+											Line:   101,
+										},
+									},
 								},
-							}, &ast.Property{
+								Callee: &ast.Identifier{
+									BaseNode: ast.BaseNode{
+										Errors: nil,
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 14,
+												Line:   101,
+											},
+											File:   "universe.flux",
+											Source: "fn",
+											Start: ast.Position{
+												Column: 12,
+												Line:   101,
+											},
+										},
+									},
+									Name: "fn",
+								},
+							},
+						},
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 48,
+									Line:   102,
+								},
+								File:   "universe.flux",
+								Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)",
+								Start: ast.Position{
+									Column: 5,
+									Line:   99,
+								},
+							},
+						},
+						Call: &ast.CallExpression{
+							Arguments: []ast.Expression{&ast.ObjectExpression{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 14,
-											Line:   77,
+											Column: 47,
+											Line:   102,
 										},
 										File:   "universe.flux",
-										Source: "on:on",
+										Source: "column:timeSrc,as:timeDst",
 										Start: ast.Position{
-											Column: 9,
-											Line:   77,
+											Column: 22,
+											Line:   102,
 										},
 									},
 								},
-								Key: &ast.Identifier{
+								Properties: []*ast.Property{&ast.Property{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 11,
-												Line:   77,
+												Column: 36,
+												Line:   102,
 											},
 											File:   "universe.flux",
-											Source: "on",
+											Source: "column:timeSrc",
 											Start: ast.Position{
-												Column: 9,
-												Line:   77,
+												Column: 22,
+												Line:   102,
 											},
 										},
 									},
-									Name: "on",
-								},
-								Value: &ast.Identifier{
+									Key: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 28,
+													Line:   102,
+												},
+												File:   "universe.flux",
+												Source: "column",
+												Start: ast.Position{
+													Column: 22,
+													Line:   102,
+												},
+											},
+										},
+										Name: "column",
+									},
+									Value: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 36,
+													Line:   102,
+												},
+												File:   "universe.flux",
+												Source: "timeSrc",
+												Start: ast.Position{
+													Column: 29,
+													Line:   102,
+												},
+											},
+										},
+										Name: "timeSrc",
+									},
+								}, &ast.Property{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 14,
-												Line:   77,
+												Column: 47,
+												Line:   102,
 											},
 											File:   "universe.flux",
-											Source: "on",
+											Source: "as:timeDst",
 											Start: ast.Position{
-												Column: 12,
-												Line:   77,
+												Column: 37,
+												Line:   102,
 											},
 										},
 									},
-									Name: "on",
-								},
+									Key: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 39,
+													Line:   102,
+												},
+												File:   "universe.flux",
+												Source: "as",
+												Start: ast.Position{
+													Column: 37,
+													Line:   102,
+												},
+											},
+										},
+										Name: "as",
+									},
+									Value: &ast.Identifier{
+										BaseNode: ast.BaseNode{
+											Errors: nil,
+											Loc: &ast.SourceLocation{
+												End: ast.Position{
+													Column: 47,
+													Line:   102,
+												},
+												File:   "universe.flux",
+												Source: "timeDst",
+												Start: ast.Position{
+													Column: 40,
+													Line:   102,
+												},
+											},
+										},
+										Name: "timeDst",
+									},
+								}},
 							}},
-						}},
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 6,
-									Line:   78,
-								},
-								File:   "universe.flux",
-								Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )",
-								Start: ast.Position{
-									Column: 5,
-									Line:   75,
-								},
-							},
-						},
-						Callee: &ast.Identifier{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 9,
-										Line:   75,
+										Column: 48,
+										Line:   102,
 									},
 									File:   "universe.flux",
-									Source: "join",
+									Source: "duplicate(column:timeSrc,as:timeDst)",
 									Start: ast.Position{
-										Column: 5,
-										Line:   75,
+										Column: 12,
+										Line:   102,
 									},
 								},
 							},
-							Name: "join",
+							Callee: &ast.Identifier{
+								BaseNode: ast.BaseNode{
+									Errors: nil,
+									Loc: &ast.SourceLocation{
+										End: ast.Position{
+											Column: 21,
+											Line:   102,
+										},
+										File:   "universe.flux",
+										Source: "duplicate",
+										Start: ast.Position{
+											Column: 12,
+											Line:   102,
+										},
+									},
+								},
+								Name: "duplicate",
+							},
 						},
 					},
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 70,
-								Line:   79,
+								Column: 49,
+								Line:   103,
 							},
 							File:   "universe.flux",
-							Source: "join(\n        tables:{x:x, y:y},\n        on:on,\n    )\n    |> covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
+							Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   75,
+								Line:   99,
 							},
 						},
 					},
@@ -2339,14 +4070,14 @@ var pkgAST = &ast.Package{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 69,
-										Line:   79,
+										Column: 48,
+										Line:   103,
 									},
 									File:   "universe.flux",
-									Source: "pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"]",
+									Source: "every:inf, timeColumn:timeDst",
 									Start: ast.Position{
 										Column: 19,
-										Line:   79,
+										Line:   103,
 									},
 								},
 							},
@@ -2355,14 +4086,14 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 36,
-											Line:   79,
+											Column: 28,
+											Line:   103,
 										},
 										File:   "universe.flux",
-										Source: "pearsonr:pearsonr",
+										Source: "every:inf",
 										Start: ast.Position{
 											Column: 19,
-											Line:   79,
+											Line:   103,
 										},
 									},
 								},
@@ -2371,50 +4102,50 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 27,
-												Line:   79,
+												Column: 24,
+												Line:   103,
 											},
 											File:   "universe.flux",
-											Source: "pearsonr",
+											Source: "every",
 											Start: ast.Position{
 												Column: 19,
-												Line:   79,
+												Line:   103,
 											},
 										},
 									},
-									Name: "pearsonr",
+									Name: "every",
 								},
 								Value: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 36,
-												Line:   79,
+												Column: 28,
+												Line:   103,
 											},
 											File:   "universe.flux",
-											Source: "pearsonr",
+											Source: "inf",
 											Start: ast.Position{
-												Column: 28,
-												Line:   79,
+												Column: 25,
+												Line:   103,
 											},
 										},
 									},
-									Name: "pearsonr",
+									Name: "inf",
 								},
 							}, &ast.Property{
 								BaseNode: ast.BaseNode{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 69,
-											Line:   79,
+											Column: 48,
+											Line:   103,
 										},
 										File:   "universe.flux",
-										Source: "columns:[\"_value_x\",\"_value_y\"]",
+										Source: "timeColumn:timeDst",
 										Start: ast.Position{
-											Column: 38,
-											Line:   79,
+											Column: 30,
+											Line:   103,
 										},
 									},
 								},
@@ -2423,191 +4154,87 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 45,
-												Line:   79,
+												Column: 40,
+												Line:   103,
 											},
 											File:   "universe.flux",
-											Source: "columns",
+											Source: "timeColumn",
 											Start: ast.Position{
-												Column: 38,
-												Line:   79,
+												Column: 30,
+												Line:   103,
 											},
 										},
 									},
-									Name: "columns",
+									Name: "timeColumn",
 								},
-								Value: &ast.ArrayExpression{
+								Value: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 69,
-												Line:   79,
-											},
-											File:   "universe.flux",
-											Source: "[\"_value_x\",\"_value_y\"]",
-											Start: ast.Position{
-												Column: 46,
-												Line:   79,
-											},
-										},
-									},
-									Elements: []ast.Expression{&ast.StringLiteral{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 57,
-													Line:   79,
-												},
-												File:   "universe.flux",
-												Source: "\"_value_x\"",
-												Start: ast.Position{
-													Column: 47,
-													Line:   79,
-												},
-											},
-										},
-										Value: "_value_x",
-									}, &ast.StringLiteral{
-										BaseNode: ast.BaseNode{
-											Errors: nil,
-											Loc: &ast.SourceLocation{
-												End: ast.Position{
-													Column: 68,
-													Line:   79,
-												},
-												File:   "universe.flux",
-												Source: "\"_value_y\"",
-												Start: ast.Position{
-													Column: 58,
-													Line:   79,
-												},
-											},
-										},
-										Value: "_value_y",
-									}},
-								},
-							}},
-						}},
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 70,
-									Line:   79,
-								},
-								File:   "universe.flux",
-								Source: "covariance(pearsonr:pearsonr, columns:[\"_value_x\",\"_value_y\"])",
-								Start: ast.Position{
-									Column: 8,
-									Line:   79,
-								},
-							},
-						},
-						Callee: &ast.Identifier{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 18,
-										Line:   79,
-									},
-									File:   "universe.flux",
-									Source: "covariance",
-									Start: ast.Position{
-										Column: 8,
-										Line:   79,
-									},
-								},
-							},
-							Name: "covariance",
-						},
-					},
-				},
-				Params: []*ast.Property{&ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 9,
-								Line:   74,
-							},
-							File:   "universe.flux",
-							Source: "x",
-							Start: ast.Position{
-								Column: 8,
-								Line:   74,
-							},
-						},
-					},
-					Key: &ast.Identifier{
+										Loc: &ast.SourceLocation{
+											End: ast.Position{
+												Column: 48,
+												Line:   103,
+											},
+											File:   "universe.flux",
+											Source: "timeDst",
+											Start: ast.Position{
+												Column: 41,
+												Line:   103,
+											},
+										},
+									},
+									Name: "timeDst",
+								},
+							}},
+						}},
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 9,
-									Line:   74,
+									Column: 49,
+									Line:   103,
 								},
 								File:   "universe.flux",
-								Source: "x",
+								Source: "window(every:inf, timeColumn:timeDst)",
 								Start: ast.Position{
-									Column: 8,
-									Line:   74,
+									Column: 12,
+									Line:   103,
 								},
 							},
 						},
-						Name: "x",
-					},
-					Value: nil,
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 11,
-								Line:   74,
-							},
-							File:   "universe.flux",
-							Source: "y",
-							Start: ast.Position{
-								Column: 10,
-								Line:   74,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 11,
-									Line:   74,
-								},
-								File:   "universe.flux",
-								Source: "y",
-								Start: ast.Position{
-									Column: 10,
-									Line:   74,
+						Callee: &ast.Identifier{
+							BaseNode: ast.BaseNode{
+								Errors: nil,
+								Loc: &ast.SourceLocation{
+									End: ast.Position{
+										Column: 18,
+										Line:   103,
+									},
+									File:   "universe.flux",
+									Source: "window",
+									Start: ast.Position{
+										Column: 12,
+										Line:   103,
+									},
 								},
 							},
+							Name: "window",
 						},
-						Name: "y",
 					},
-					Value: nil,
-				}, &ast.Property{
+				},
+				Params: []*ast.Property{&ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 14,
-								Line:   74,
+								Column: 25,
+								Line:   98,
 							},
 							File:   "universe.flux",
-							Source: "on",
+							Source: "every",
 							Start: ast.Position{
-								Column: 12,
-								Line:   74,
+								Column: 20,
+								Line:   98,
 							},
 						},
 					},
@@ -2616,18 +4243,18 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 14,
-									Line:   74,
+									Column: 25,
+									Line:   98,
 								},
 								File:   "universe.flux",
-								Source: "on",
+								Source: "every",
 								Start: ast.Position{
-									Column: 12,
-									Line:   74,
+									Column: 20,
+									Line:   98,
 								},
 							},
 						},
-						Name: "on",
+						Name: "every",
 					},
 					Value: nil,
 				}, &ast.Property{
@@ -2636,378 +4263,169 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 29,
-								Line:   74,
+								Line:   98,
 							},
 							File:   "universe.flux",
-							Source: "pearsonr=false",
+							Source: "fn",
 							Start: ast.Position{
-								Column: 15,
-								Line:   74,
+								Column: 27,
+								Line:   98,
 							},
 						},
 					},
 					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 23,
-									Line:   74,
-								},
-								File:   "universe.flux",
-								Source: "pearsonr",
-								Start: ast.Position{
-									Column: 15,
-									Line:   74,
-								},
-							},
-						},
-						Name: "pearsonr",
-					},
-					Value: &ast.Identifier{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   74,
-								},
-								File:   "universe.flux",
-								Source: "false",
-								Start: ast.Position{
-									Column: 24,
-									Line:   74,
-								},
-							},
-						},
-						Name: "false",
-					},
-				}},
-			},
-		}, &ast.VariableAssignment{
-			BaseNode: ast.BaseNode{
-				Errors: nil,
-				Loc: &ast.SourceLocation{
-					End: ast.Position{
-						Column: 59,
-						Line:   81,
-					},
-					File:   "universe.flux",
-					Source: "pearsonr = (x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
-					Start: ast.Position{
-						Column: 1,
-						Line:   81,
-					},
-				},
-			},
-			ID: &ast.Identifier{
-				BaseNode: ast.BaseNode{
-					Errors: nil,
-					Loc: &ast.SourceLocation{
-						End: ast.Position{
-							Column: 9,
-							Line:   81,
-						},
-						File:   "universe.flux",
-						Source: "pearsonr",
-						Start: ast.Position{
-							Column: 1,
-							Line:   81,
-						},
-					},
-				},
-				Name: "pearsonr",
-			},
-			Init: &ast.FunctionExpression{
-				BaseNode: ast.BaseNode{
-					Errors: nil,
-					Loc: &ast.SourceLocation{
-						End: ast.Position{
-							Column: 59,
-							Line:   81,
-						},
-						File:   "universe.flux",
-						Source: "(x,y,on) => cov(x:x, y:y, on:on, pearsonr:true)",
-						Start: ast.Position{
-							Column: 12,
-							Line:   81,
-						},
-					},
-				},
-				Body: &ast.CallExpression{
-					Arguments: []ast.Expression{&ast.ObjectExpression{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 58,
-									Line:   81,
+									Line:   98,
 								},
 								File:   "universe.flux",
-								Source: "x:x, y:y, on:on, pearsonr:true",
+								Source: "fn",
 								Start: ast.Position{
-									Column: 28,
-									Line:   81,
-								},
-							},
-						},
-						Properties: []*ast.Property{&ast.Property{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 31,
-										Line:   81,
-									},
-									File:   "universe.flux",
-									Source: "x:x",
-									Start: ast.Position{
-										Column: 28,
-										Line:   81,
-									},
-								},
-							},
-							Key: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 29,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "x",
-										Start: ast.Position{
-											Column: 28,
-											Line:   81,
-										},
-									},
-								},
-								Name: "x",
-							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 31,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "x",
-										Start: ast.Position{
-											Column: 30,
-											Line:   81,
-										},
-									},
-								},
-								Name: "x",
-							},
-						}, &ast.Property{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 36,
-										Line:   81,
-									},
-									File:   "universe.flux",
-									Source: "y:y",
-									Start: ast.Position{
-										Column: 33,
-										Line:   81,
-									},
-								},
-							},
-							Key: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 34,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "y",
-										Start: ast.Position{
-											Column: 33,
-											Line:   81,
-										},
-									},
+									Column: 27,
+									Line:   98,
 								},
-								Name: "y",
 							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 36,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "y",
-										Start: ast.Position{
-											Column: 35,
-											Line:   81,
-										},
-									},
-								},
-								Name: "y",
+						},
+						Name: "fn",
+					},
+					Value: nil,
+				}, &ast.Property{
+					BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 49,
+								Line:   98,
 							},
-						}, &ast.Property{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 43,
-										Line:   81,
-									},
-									File:   "universe.flux",
-									Source: "on:on",
-									Start: ast.Position{
-										Column: 38,
-										Line:   81,
-									},
-								},
+							File:   "universe.flux",
+							Source: "columns=[\"_value\"]",
+							Start: ast.Position{
+								Column: 31,
+								Line:   98,
 							},
-							Key: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 40,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "on",
-										Start: ast.Position{
-											Column: 38,
-											Line:   81,
-										},
-									},
+						},
+					},
+					Key: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 38,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "columns",
+								Start: ast.Position{
+									Column: 31,
+									Line:   98,
 								},
-								Name: "on",
 							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 43,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "on",
-										Start: ast.Position{
-											Column: 41,
-											Line:   81,
-										},
-									},
+						},
+						Name: "columns",
+					},
+					Value: &ast.ArrayExpression{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 49,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "[\"_value\"]",
+								Start: ast.Position{
+									Column: 39,
+									Line:   98,
 								},
-								Name: "on",
 							},
-						}, &ast.Property{
+						},
+						Elements: []ast.Expression{&ast.StringLiteral{
 							BaseNode: ast.BaseNode{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 58,
-										Line:   81,
+										Column: 48,
+										Line:   98,
 									},
 									File:   "universe.flux",
-									Source: "pearsonr:true",
+									Source: "\"_value\"",
 									Start: ast.Position{
-										Column: 45,
-										Line:   81,
-									},
-								},
-							},
-							Key: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 53,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "pearsonr",
-										Start: ast.Position{
-											Column: 45,
-											Line:   81,
-										},
-									},
-								},
-								Name: "pearsonr",
-							},
-							Value: &ast.Identifier{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 58,
-											Line:   81,
-										},
-										File:   "universe.flux",
-										Source: "true",
-										Start: ast.Position{
-											Column: 54,
-											Line:   81,
-										},
+										Column: 40,
+										Line:   98,
 									},
 								},
-								Name: "true",
 							},
+							Value: "_value",
 						}},
-					}},
+					},
+				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 59,
-								Line:   81,
+								Column: 66,
+								Line:   98,
 							},
 							File:   "universe.flux",
-							Source: "cov(x:x, y:y, on:on, pearsonr:true)",
+							Source: "timeSrc=\"_stop\"",
 							Start: ast.Position{
-								Column: 24,
-								Line:   81,
+								Column: 51,
+								Line:   98,
 							},
 						},
 					},
-					Callee: &ast.Identifier{
+					Key: &ast.Identifier{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 27,
-									Line:   81,
+									Column: 58,
+									Line:   98,
 								},
 								File:   "universe.flux",
-								Source: "cov",
+								Source: "timeSrc",
 								Start: ast.Position{
-									Column: 24,
-									Line:   81,
+									Column: 51,
+									Line:   98,
 								},
 							},
 						},
-						Name: "cov",
+						Name: "timeSrc",
 					},
-				},
-				Params: []*ast.Property{&ast.Property{
+					Value: &ast.StringLiteral{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 66,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "\"_stop\"",
+								Start: ast.Position{
+									Column: 59,
+									Line:   98,
+								},
+							},
+						},
+						Value: "_stop",
+					},
+				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 14,
-								Line:   81,
+								Column: 82,
+								Line:   98,
 							},
 							File:   "universe.flux",
-							Source: "x",
+							Source: "timeDst=\"_time\"",
 							Start: ast.Position{
-								Column: 13,
-								Line:   81,
+								Column: 67,
+								Line:   98,
 							},
 						},
 					},
@@ -3016,33 +4434,50 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 14,
-									Line:   81,
+									Column: 74,
+									Line:   98,
 								},
 								File:   "universe.flux",
-								Source: "x",
+								Source: "timeDst",
 								Start: ast.Position{
-									Column: 13,
-									Line:   81,
+									Column: 67,
+									Line:   98,
 								},
 							},
 						},
-						Name: "x",
+						Name: "timeDst",
+					},
+					Value: &ast.StringLiteral{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 82,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "\"_time\"",
+								Start: ast.Position{
+									Column: 75,
+									Line:   98,
+								},
+							},
+						},
+						Value: "_time",
 					},
-					Value: nil,
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 16,
-								Line:   81,
+								Column: 100,
+								Line:   98,
 							},
 							File:   "universe.flux",
-							Source: "y",
+							Source: "createEmpty=true",
 							Start: ast.Position{
-								Column: 15,
-								Line:   81,
+								Column: 84,
+								Line:   98,
 							},
 						},
 					},
@@ -3051,33 +4486,50 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 16,
-									Line:   81,
+									Column: 95,
+									Line:   98,
+								},
+								File:   "universe.flux",
+								Source: "createEmpty",
+								Start: ast.Position{
+									Column: 84,
+									Line:   98,
+								},
+							},
+						},
+						Name: "createEmpty",
+					},
+					Value: &ast.Identifier{
+						BaseNode: ast.BaseNode{
+							Errors: nil,
+							Loc: &ast.SourceLocation{
+								End: ast.Position{
+									Column: 100,
+									Line:   98,
 								},
 								File:   "universe.flux",
-								Source: "y",
+								Source: "true",
 								Start: ast.Position{
-									Column: 15,
-									Line:   81,
+									Column: 96,
+									Line:   98,
 								},
 							},
 						},
-						Name: "y",
+						Name: "true",
 					},
-					Value: nil,
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 19,
-								Line:   81,
+								Column: 111,
+								Line:   98,
 							},
 							File:   "universe.flux",
-							Source: "on",
+							Source: "tables=<-",
 							Start: ast.Position{
-								Column: 17,
-								Line:   81,
+								Column: 102,
+								Line:   98,
 							},
 						},
 					},
@@ -3086,20 +4538,34 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 19,
-									Line:   81,
+									Column: 108,
+									Line:   98,
 								},
 								File:   "universe.flux",
-								Source: "on",
+								Source: "tables",
 								Start: ast.Position{
-									Column: 17,
-									Line:   81,
+									Column: 102,
+									Line:   98,
 								},
 							},
 						},
-						Name: "on",
+						Name: "tables",
 					},
-					Value: nil,
+					Value: &ast.PipeLiteral{BaseNode: ast.BaseNode{
+						Errors: nil,
+						Loc: &ast.SourceLocation{
+							End: ast.Position{
+								Column: 111,
+								Line:   98,
+							},
+							File:   "universe.flux",
+							Source: "<-",
+							Start: ast.Position{
+								Column: 109,
+								Line:   98,
+							},
+						},
+					}},
 				}},
 			},
 		}, &ast.VariableAssignment{
@@ -3107,14 +4573,14 @@ var pkgAST = &ast.Package{
 				Errors: nil,
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
-						Column: 49,
-						Line:   91,
+						Column: 29,
+						Line:   112,
 					},
 					File:   "universe.flux",
-					Source: "aggregateWindow = (every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+					Source: "timedMovingAverage = (every, period, column=\"_value\", tables=<-) =>\n    tables\n        |> window(every:every, period:period)\n        |> mean(columns:[column])\n        |> duplicate(column:\"_stop\", as:\"_time\")\n        |> window(every:inf)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   86,
+						Line:   107,
 					},
 				},
 			},
@@ -3123,32 +4589,32 @@ var pkgAST = &ast.Package{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 16,
-							Line:   86,
+							Column: 19,
+							Line:   107,
 						},
 						File:   "universe.flux",
-						Source: "aggregateWindow",
+						Source: "timedMovingAverage",
 						Start: ast.Position{
 							Column: 1,
-							Line:   86,
+							Line:   107,
 						},
 					},
 				},
-				Name: "aggregateWindow",
+				Name: "timedMovingAverage",
 			},
 			Init: &ast.FunctionExpression{
 				BaseNode: ast.BaseNode{
 					Errors: nil,
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
-							Column: 49,
-							Line:   91,
+							Column: 29,
+							Line:   112,
 						},
 						File:   "universe.flux",
-						Source: "(every, fn, columns=[\"_value\"], timeSrc=\"_stop\",timeDst=\"_time\", createEmpty=true, tables=<-) =>\n    tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+						Source: "(every, period, column=\"_value\", tables=<-) =>\n    tables\n        |> window(every:every, period:period)\n        |> mean(columns:[column])\n        |> duplicate(column:\"_stop\", as:\"_time\")\n        |> window(every:inf)",
 						Start: ast.Position{
-							Column: 19,
-							Line:   86,
+							Column: 22,
+							Line:   107,
 						},
 					},
 				},
@@ -3162,13 +4628,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 11,
-												Line:   87,
+												Line:   108,
 											},
 											File:   "universe.flux",
 											Source: "tables",
 											Start: ast.Position{
 												Column: 5,
-												Line:   87,
+												Line:   108,
 											},
 										},
 									},
@@ -3178,14 +4644,14 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 57,
-											Line:   88,
+											Column: 46,
+											Line:   109,
 										},
 										File:   "universe.flux",
-										Source: "tables\n        |> window(every:every, createEmpty: createEmpty)",
+										Source: "tables\n        |> window(every:every, period:period)",
 										Start: ast.Position{
 											Column: 5,
-											Line:   87,
+											Line:   108,
 										},
 									},
 								},
@@ -3195,14 +4661,14 @@ var pkgAST = &ast.Package{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 56,
-													Line:   88,
+													Column: 45,
+													Line:   109,
 												},
 												File:   "universe.flux",
-												Source: "every:every, createEmpty: createEmpty",
+												Source: "every:every, period:period",
 												Start: ast.Position{
 													Column: 19,
-													Line:   88,
+													Line:   109,
 												},
 											},
 										},
@@ -3212,13 +4678,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 30,
-														Line:   88,
+														Line:   109,
 													},
 													File:   "universe.flux",
 													Source: "every:every",
 													Start: ast.Position{
 														Column: 19,
-														Line:   88,
+														Line:   109,
 													},
 												},
 											},
@@ -3228,13 +4694,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 24,
-															Line:   88,
+															Line:   109,
 														},
 														File:   "universe.flux",
 														Source: "every",
 														Start: ast.Position{
 															Column: 19,
-															Line:   88,
+															Line:   109,
 														},
 													},
 												},
@@ -3246,13 +4712,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 30,
-															Line:   88,
+															Line:   109,
 														},
 														File:   "universe.flux",
 														Source: "every",
 														Start: ast.Position{
 															Column: 25,
-															Line:   88,
+															Line:   109,
 														},
 													},
 												},
@@ -3263,14 +4729,14 @@ var pkgAST = &ast.Package{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 56,
-														Line:   88,
+														Column: 45,
+														Line:   109,
 													},
 													File:   "universe.flux",
-													Source: "createEmpty: createEmpty",
+													Source: "period:period",
 													Start: ast.Position{
 														Column: 32,
-														Line:   88,
+														Line:   109,
 													},
 												},
 											},
@@ -3279,36 +4745,36 @@ var pkgAST = &ast.Package{
 													Errors: nil,
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
-															Column: 43,
-															Line:   88,
+															Column: 38,
+															Line:   109,
 														},
 														File:   "universe.flux",
-														Source: "createEmpty",
+														Source: "period",
 														Start: ast.Position{
 															Column: 32,
-															Line:   88,
+															Line:   109,
 														},
 													},
 												},
-												Name: "createEmpty",
+												Name: "period",
 											},
 											Value: &ast.Identifier{
 												BaseNode: ast.BaseNode{
 													Errors: nil,
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
-															Column: 56,
-															Line:   88,
+															Column: 45,
+															Line:   109,
 														},
 														File:   "universe.flux",
-														Source: "createEmpty",
+														Source: "period",
 														Start: ast.Position{
-															Column: 45,
-															Line:   88,
+															Column: 39,
+															Line:   109,
 														},
 													},
 												},
-												Name: "createEmpty",
+												Name: "period",
 											},
 										}},
 									}},
@@ -3316,14 +4782,14 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 57,
-												Line:   88,
+												Column: 46,
+												Line:   109,
 											},
 											File:   "universe.flux",
-											Source: "window(every:every, createEmpty: createEmpty)",
+											Source: "window(every:every, period:period)",
 											Start: ast.Position{
 												Column: 12,
-												Line:   88,
+												Line:   109,
 											},
 										},
 									},
@@ -3333,13 +4799,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 18,
-													Line:   88,
+													Line:   109,
 												},
 												File:   "universe.flux",
 												Source: "window",
 												Start: ast.Position{
 													Column: 12,
-													Line:   88,
+													Line:   109,
 												},
 											},
 										},
@@ -3351,14 +4817,14 @@ var pkgAST = &ast.Package{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 31,
-										Line:   89,
+										Column: 34,
+										Line:   110,
 									},
 									File:   "universe.flux",
-									Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)",
+									Source: "tables\n        |> window(every:every, period:period)\n        |> mean(columns:[column])",
 									Start: ast.Position{
 										Column: 5,
-										Line:   87,
+										Line:   108,
 									},
 								},
 							},
@@ -3368,14 +4834,14 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 30,
-												Line:   89,
+												Column: 33,
+												Line:   110,
 											},
 											File:   "universe.flux",
-											Source: "columns:columns",
+											Source: "columns:[column]",
 											Start: ast.Position{
-												Column: 15,
-												Line:   89,
+												Column: 17,
+												Line:   110,
 											},
 										},
 									},
@@ -3384,14 +4850,14 @@ var pkgAST = &ast.Package{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 30,
-													Line:   89,
+													Column: 33,
+													Line:   110,
 												},
 												File:   "universe.flux",
-												Source: "columns:columns",
+												Source: "columns:[column]",
 												Start: ast.Position{
-													Column: 15,
-													Line:   89,
+													Column: 17,
+													Line:   110,
 												},
 											},
 										},
@@ -3400,36 +4866,53 @@ var pkgAST = &ast.Package{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 22,
-														Line:   89,
+														Column: 24,
+														Line:   110,
 													},
 													File:   "universe.flux",
 													Source: "columns",
 													Start: ast.Position{
-														Column: 15,
-														Line:   89,
+														Column: 17,
+														Line:   110,
 													},
 												},
 											},
 											Name: "columns",
 										},
-										Value: &ast.Identifier{
+										Value: &ast.ArrayExpression{
 											BaseNode: ast.BaseNode{
 												Errors: nil,
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
-														Column: 30,
-														Line:   89,
+														Column: 33,
+														Line:   110,
 													},
 													File:   "universe.flux",
-													Source: "columns",
+													Source: "[column]",
 													Start: ast.Position{
-														Column: 23,
-														Line:   89,
+														Column: 25,
+														Line:   110,
 													},
 												},
 											},
-											Name: "columns",
+											Elements: []ast.Expression{&ast.Identifier{
+												BaseNode: ast.BaseNode{
+													Errors: nil,
+													Loc: &ast.SourceLocation{
+														End: ast.Position{
+															Column: 32,
+															Line:   110,
+														},
+														File:   "universe.flux",
+														Source: "column",
+														Start: ast.Position{
+															Column: 26,
+															Line:   110,
+														},
+													},
+												},
+												Name: "column",
+											}},
 										},
 									}},
 								}},
@@ -3437,14 +4920,14 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 31,
-											Line:   89,
+											Column: 34,
+											Line:   110,
 										},
 										File:   "universe.flux",
-										Source: "fn(columns:columns)",
+										Source: "mean(columns:[column])",
 										Start: ast.Position{
 											Column: 12,
-											Line:   89,
+											Line:   110,
 										},
 									},
 								},
@@ -3453,18 +4936,18 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 14,
-												Line:   89,
+												Column: 16,
+												Line:   110,
 											},
 											File:   "universe.flux",
-											Source: "fn",
+											Source: "mean",
 											Start: ast.Position{
 												Column: 12,
-												Line:   89,
+												Line:   110,
 											},
 										},
 									},
-									Name: "fn",
+									Name: "mean",
 								},
 							},
 						},
@@ -3472,14 +4955,14 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 48,
-									Line:   90,
+									Column: 49,
+									Line:   111,
 								},
 								File:   "universe.flux",
-								Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)",
+								Source: "tables\n        |> window(every:every, period:period)\n        |> mean(columns:[column])\n        |> duplicate(column:\"_stop\", as:\"_time\")",
 								Start: ast.Position{
 									Column: 5,
-									Line:   87,
+									Line:   108,
 								},
 							},
 						},
@@ -3489,14 +4972,14 @@ var pkgAST = &ast.Package{
 									Errors: nil,
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
-											Column: 47,
-											Line:   90,
+											Column: 48,
+											Line:   111,
 										},
 										File:   "universe.flux",
-										Source: "column:timeSrc,as:timeDst",
+										Source: "column:\"_stop\", as:\"_time\"",
 										Start: ast.Position{
 											Column: 22,
-											Line:   90,
+											Line:   111,
 										},
 									},
 								},
@@ -3506,13 +4989,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 36,
-												Line:   90,
+												Line:   111,
 											},
 											File:   "universe.flux",
-											Source: "column:timeSrc",
+											Source: "column:\"_stop\"",
 											Start: ast.Position{
 												Column: 22,
-												Line:   90,
+												Line:   111,
 											},
 										},
 									},
@@ -3522,49 +5005,49 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 28,
-													Line:   90,
+													Line:   111,
 												},
 												File:   "universe.flux",
 												Source: "column",
 												Start: ast.Position{
 													Column: 22,
-													Line:   90,
+													Line:   111,
 												},
 											},
 										},
 										Name: "column",
 									},
-									Value: &ast.Identifier{
+									Value: &ast.StringLiteral{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   90,
+													Line:   111,
 												},
 												File:   "universe.flux",
-												Source: "timeSrc",
+												Source: "\"_stop\"",
 												Start: ast.Position{
 													Column: 29,
-													Line:   90,
+													Line:   111,
 												},
 											},
 										},
-										Name: "timeSrc",
+										Value: "_stop",
 									},
 								}, &ast.Property{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 47,
-												Line:   90,
+												Column: 48,
+												Line:   111,
 											},
 											File:   "universe.flux",
-											Source: "as:timeDst",
+											Source: "as:\"_time\"",
 											Start: ast.Position{
-												Column: 37,
-												Line:   90,
+												Column: 38,
+												Line:   111,
 											},
 										},
 									},
@@ -3573,36 +5056,36 @@ var pkgAST = &ast.Package{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 39,
-													Line:   90,
+													Column: 40,
+													Line:   111,
 												},
 												File:   "universe.flux",
 												Source: "as",
 												Start: ast.Position{
-													Column: 37,
-													Line:   90,
+													Column: 38,
+													Line:   111,
 												},
 											},
 										},
 										Name: "as",
 									},
-									Value: &ast.Identifier{
+									Value: &ast.StringLiteral{
 										BaseNode: ast.BaseNode{
 											Errors: nil,
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
-													Column: 47,
-													Line:   90,
+													Column: 48,
+													Line:   111,
 												},
 												File:   "universe.flux",
-												Source: "timeDst",
+												Source: "\"_time\"",
 												Start: ast.Position{
-													Column: 40,
-													Line:   90,
+													Column: 41,
+													Line:   111,
 												},
 											},
 										},
-										Name: "timeDst",
+										Value: "_time",
 									},
 								}},
 							}},
@@ -3610,14 +5093,14 @@ var pkgAST = &ast.Package{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 48,
-										Line:   90,
+										Column: 49,
+										Line:   111,
 									},
 									File:   "universe.flux",
-									Source: "duplicate(column:timeSrc,as:timeDst)",
+									Source: "duplicate(column:\"_stop\", as:\"_time\")",
 									Start: ast.Position{
 										Column: 12,
-										Line:   90,
+										Line:   111,
 									},
 								},
 							},
@@ -3627,13 +5110,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   90,
+											Line:   111,
 										},
 										File:   "universe.flux",
 										Source: "duplicate",
 										Start: ast.Position{
 											Column: 12,
-											Line:   90,
+											Line:   111,
 										},
 									},
 								},
@@ -3645,14 +5128,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 49,
-								Line:   91,
+								Column: 29,
+								Line:   112,
 							},
 							File:   "universe.flux",
-							Source: "tables\n        |> window(every:every, createEmpty: createEmpty)\n        |> fn(columns:columns)\n        |> duplicate(column:timeSrc,as:timeDst)\n        |> window(every:inf, timeColumn:timeDst)",
+							Source: "tables\n        |> window(every:every, period:period)\n        |> mean(columns:[column])\n        |> duplicate(column:\"_stop\", as:\"_time\")\n        |> window(every:inf)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   87,
+								Line:   108,
 							},
 						},
 					},
@@ -3662,14 +5145,14 @@ var pkgAST = &ast.Package{
 								Errors: nil,
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
-										Column: 48,
-										Line:   91,
+										Column: 28,
+										Line:   112,
 									},
 									File:   "universe.flux",
-									Source: "every:inf, timeColumn:timeDst",
+									Source: "every:inf",
 									Start: ast.Position{
 										Column: 19,
-										Line:   91,
+										Line:   112,
 									},
 								},
 							},
@@ -3679,65 +5162,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 28,
-											Line:   91,
+											Line:   112,
 										},
 										File:   "universe.flux",
 										Source: "every:inf",
 										Start: ast.Position{
 											Column: 19,
-											Line:   91,
-										},
-									},
-								},
-								Key: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 24,
-												Line:   91,
-											},
-											File:   "universe.flux",
-											Source: "every",
-											Start: ast.Position{
-												Column: 19,
-												Line:   91,
-											},
-										},
-									},
-									Name: "every",
-								},
-								Value: &ast.Identifier{
-									BaseNode: ast.BaseNode{
-										Errors: nil,
-										Loc: &ast.SourceLocation{
-											End: ast.Position{
-												Column: 28,
-												Line:   91,
-											},
-											File:   "universe.flux",
-											Source: "inf",
-											Start: ast.Position{
-												Column: 25,
-												Line:   91,
-											},
-										},
-									},
-									Name: "inf",
-								},
-							}, &ast.Property{
-								BaseNode: ast.BaseNode{
-									Errors: nil,
-									Loc: &ast.SourceLocation{
-										End: ast.Position{
-											Column: 48,
-											Line:   91,
-										},
-										File:   "universe.flux",
-										Source: "timeColumn:timeDst",
-										Start: ast.Position{
-											Column: 30,
-											Line:   91,
+											Line:   112,
 										},
 									},
 								},
@@ -3746,36 +5177,36 @@ var pkgAST = &ast.Package{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 40,
-												Line:   91,
+												Column: 24,
+												Line:   112,
 											},
 											File:   "universe.flux",
-											Source: "timeColumn",
+											Source: "every",
 											Start: ast.Position{
-												Column: 30,
-												Line:   91,
+												Column: 19,
+												Line:   112,
 											},
 										},
 									},
-									Name: "timeColumn",
+									Name: "every",
 								},
 								Value: &ast.Identifier{
 									BaseNode: ast.BaseNode{
 										Errors: nil,
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
-												Column: 48,
-												Line:   91,
+												Column: 28,
+												Line:   112,
 											},
 											File:   "universe.flux",
-											Source: "timeDst",
+											Source: "inf",
 											Start: ast.Position{
-												Column: 41,
-												Line:   91,
+												Column: 25,
+												Line:   112,
 											},
 										},
 									},
-									Name: "timeDst",
+									Name: "inf",
 								},
 							}},
 						}},
@@ -3783,14 +5214,14 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 49,
-									Line:   91,
+									Column: 29,
+									Line:   112,
 								},
 								File:   "universe.flux",
-								Source: "window(every:inf, timeColumn:timeDst)",
+								Source: "window(every:inf)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   91,
+									Line:   112,
 								},
 							},
 						},
@@ -3800,13 +5231,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 18,
-										Line:   91,
+										Line:   112,
 									},
 									File:   "universe.flux",
 									Source: "window",
 									Start: ast.Position{
 										Column: 12,
-										Line:   91,
+										Line:   112,
 									},
 								},
 							},
@@ -3819,14 +5250,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 25,
-								Line:   86,
+								Column: 28,
+								Line:   107,
 							},
 							File:   "universe.flux",
 							Source: "every",
 							Start: ast.Position{
-								Column: 20,
-								Line:   86,
+								Column: 23,
+								Line:   107,
 							},
 						},
 					},
@@ -3835,14 +5266,14 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 25,
-									Line:   86,
+									Column: 28,
+									Line:   107,
 								},
 								File:   "universe.flux",
 								Source: "every",
 								Start: ast.Position{
-									Column: 20,
-									Line:   86,
+									Column: 23,
+									Line:   107,
 								},
 							},
 						},
@@ -3854,14 +5285,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 29,
-								Line:   86,
+								Column: 36,
+								Line:   107,
 							},
 							File:   "universe.flux",
-							Source: "fn",
+							Source: "period",
 							Start: ast.Position{
-								Column: 27,
-								Line:   86,
+								Column: 30,
+								Line:   107,
 							},
 						},
 					},
@@ -3870,18 +5301,18 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 29,
-									Line:   86,
+									Column: 36,
+									Line:   107,
 								},
 								File:   "universe.flux",
-								Source: "fn",
+								Source: "period",
 								Start: ast.Position{
-									Column: 27,
-									Line:   86,
+									Column: 30,
+									Line:   107,
 								},
 							},
 						},
-						Name: "fn",
+						Name: "period",
 					},
 					Value: nil,
 				}, &ast.Property{
@@ -3889,187 +5320,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 49,
-								Line:   86,
-							},
-							File:   "universe.flux",
-							Source: "columns=[\"_value\"]",
-							Start: ast.Position{
-								Column: 31,
-								Line:   86,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 38,
-									Line:   86,
-								},
-								File:   "universe.flux",
-								Source: "columns",
-								Start: ast.Position{
-									Column: 31,
-									Line:   86,
-								},
-							},
-						},
-						Name: "columns",
-					},
-					Value: &ast.ArrayExpression{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 49,
-									Line:   86,
-								},
-								File:   "universe.flux",
-								Source: "[\"_value\"]",
-								Start: ast.Position{
-									Column: 39,
-									Line:   86,
-								},
-							},
-						},
-						Elements: []ast.Expression{&ast.StringLiteral{
-							BaseNode: ast.BaseNode{
-								Errors: nil,
-								Loc: &ast.SourceLocation{
-									End: ast.Position{
-										Column: 48,
-										Line:   86,
-									},
-									File:   "universe.flux",
-									Source: "\"_value\"",
-									Start: ast.Position{
-										Column: 40,
-										Line:   86,
-									},
-								},
-							},
-							Value: "_value",
-						}},
-					},
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 66,
-								Line:   86,
-							},
-							File:   "universe.flux",
-							Source: "timeSrc=\"_stop\"",
-							Start: ast.Position{
-								Column: 51,
-								Line:   86,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 58,
-									Line:   86,
-								},
-								File:   "universe.flux",
-								Source: "timeSrc",
-								Start: ast.Position{
-									Column: 51,
-									Line:   86,
-								},
-							},
-						},
-						Name: "timeSrc",
-					},
-					Value: &ast.StringLiteral{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 66,
-									Line:   86,
-								},
-								File:   "universe.flux",
-								Source: "\"_stop\"",
-								Start: ast.Position{
-									Column: 59,
-									Line:   86,
-								},
-							},
-						},
-						Value: "_stop",
-					},
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 82,
-								Line:   86,
-							},
-							File:   "universe.flux",
-							Source: "timeDst=\"_time\"",
-							Start: ast.Position{
-								Column: 67,
-								Line:   86,
-							},
-						},
-					},
-					Key: &ast.Identifier{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 74,
-									Line:   86,
-								},
-								File:   "universe.flux",
-								Source: "timeDst",
-								Start: ast.Position{
-									Column: 67,
-									Line:   86,
-								},
-							},
-						},
-						Name: "timeDst",
-					},
-					Value: &ast.StringLiteral{
-						BaseNode: ast.BaseNode{
-							Errors: nil,
-							Loc: &ast.SourceLocation{
-								End: ast.Position{
-									Column: 82,
-									Line:   86,
-								},
-								File:   "universe.flux",
-								Source: "\"_time\"",
-								Start: ast.Position{
-									Column: 75,
-									Line:   86,
-								},
-							},
-						},
-						Value: "_time",
-					},
-				}, &ast.Property{
-					BaseNode: ast.BaseNode{
-						Errors: nil,
-						Loc: &ast.SourceLocation{
-							End: ast.Position{
-								Column: 100,
-								Line:   86,
+								Column: 53,
+								Line:   107,
 							},
 							File:   "universe.flux",
-							Source: "createEmpty=true",
+							Source: "column=\"_value\"",
 							Start: ast.Position{
-								Column: 84,
-								Line:   86,
+								Column: 38,
+								Line:   107,
 							},
 						},
 					},
@@ -4078,50 +5336,50 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 95,
-									Line:   86,
+									Column: 44,
+									Line:   107,
 								},
 								File:   "universe.flux",
-								Source: "createEmpty",
+								Source: "column",
 								Start: ast.Position{
-									Column: 84,
-									Line:   86,
+									Column: 38,
+									Line:   107,
 								},
 							},
 						},
-						Name: "createEmpty",
+						Name: "column",
 					},
-					Value: &ast.Identifier{
+					Value: &ast.StringLiteral{
 						BaseNode: ast.BaseNode{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 100,
-									Line:   86,
+									Column: 53,
+									Line:   107,
 								},
 								File:   "universe.flux",
-								Source: "true",
+								Source: "\"_value\"",
 								Start: ast.Position{
-									Column: 96,
-									Line:   86,
+									Column: 45,
+									Line:   107,
 								},
 							},
 						},
-						Name: "true",
+						Value: "_value",
 					},
 				}, &ast.Property{
 					BaseNode: ast.BaseNode{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 111,
-								Line:   86,
+								Column: 64,
+								Line:   107,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
-								Column: 102,
-								Line:   86,
+								Column: 55,
+								Line:   107,
 							},
 						},
 					},
@@ -4130,14 +5388,14 @@ var pkgAST = &ast.Package{
 							Errors: nil,
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
-									Column: 108,
-									Line:   86,
+									Column: 61,
+									Line:   107,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
-									Column: 102,
-									Line:   86,
+									Column: 55,
+									Line:   107,
 								},
 							},
 						},
@@ -4147,14 +5405,14 @@ var pkgAST = &ast.Package{
 						Errors: nil,
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
-								Column: 111,
-								Line:   86,
+								Column: 64,
+								Line:   107,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
-								Column: 109,
-								Line:   86,
+								Column: 62,
+								Line:   107,
 							},
 						},
 					}},
@@ -4166,13 +5424,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 43,
-						Line:   100,
+						Line:   121,
 					},
 					File:   "universe.flux",
 					Source: "increase = (tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   97,
+						Line:   118,
 					},
 				},
 			},
@@ -4182,13 +5440,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 9,
-							Line:   97,
+							Line:   118,
 						},
 						File:   "universe.flux",
 						Source: "increase",
 						Start: ast.Position{
 							Column: 1,
-							Line:   97,
+							Line:   118,
 						},
 					},
 				},
@@ -4200,13 +5458,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 43,
-							Line:   100,
+							Line:   121,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-, columns=[\"_value\"]) =>\n    tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
 						Start: ast.Position{
 							Column: 12,
-							Line:   97,
+							Line:   118,
 						},
 					},
 				},
@@ -4218,13 +5476,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 11,
-										Line:   98,
+										Line:   119,
 									},
 									File:   "universe.flux",
 									Source: "tables",
 									Start: ast.Position{
 										Column: 5,
-										Line:   98,
+										Line:   119,
 									},
 								},
 							},
@@ -4235,13 +5493,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 58,
-									Line:   99,
+									Line:   120,
 								},
 								File:   "universe.flux",
 								Source: "tables\n        |> difference(nonNegative: true, columns:columns)",
 								Start: ast.Position{
 									Column: 5,
-									Line:   98,
+									Line:   119,
 								},
 							},
 						},
@@ -4252,13 +5510,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 57,
-											Line:   99,
+											Line:   120,
 										},
 										File:   "universe.flux",
 										Source: "nonNegative: true, columns:columns",
 										Start: ast.Position{
 											Column: 23,
-											Line:   99,
+											Line:   120,
 										},
 									},
 								},
@@ -4268,13 +5526,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 40,
-												Line:   99,
+												Line:   120,
 											},
 											File:   "universe.flux",
 											Source: "nonNegative: true",
 											Start: ast.Position{
 												Column: 23,
-												Line:   99,
+												Line:   120,
 											},
 										},
 									},
@@ -4284,13 +5542,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 34,
-													Line:   99,
+													Line:   120,
 												},
 												File:   "universe.flux",
 												Source: "nonNegative",
 												Start: ast.Position{
 													Column: 23,
-													Line:   99,
+													Line:   120,
 												},
 											},
 										},
@@ -4302,13 +5560,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 40,
-													Line:   99,
+													Line:   120,
 												},
 												File:   "universe.flux",
 												Source: "true",
 												Start: ast.Position{
 													Column: 36,
-													Line:   99,
+													Line:   120,
 												},
 											},
 										},
@@ -4320,13 +5578,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 57,
-												Line:   99,
+												Line:   120,
 											},
 											File:   "universe.flux",
 											Source: "columns:columns",
 											Start: ast.Position{
 												Column: 42,
-												Line:   99,
+												Line:   120,
 											},
 										},
 									},
@@ -4336,13 +5594,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 49,
-													Line:   99,
+													Line:   120,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 42,
-													Line:   99,
+													Line:   120,
 												},
 											},
 										},
@@ -4354,13 +5612,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 57,
-													Line:   99,
+													Line:   120,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 50,
-													Line:   99,
+													Line:   120,
 												},
 											},
 										},
@@ -4373,13 +5631,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 58,
-										Line:   99,
+										Line:   120,
 									},
 									File:   "universe.flux",
 									Source: "difference(nonNegative: true, columns:columns)",
 									Start: ast.Position{
 										Column: 12,
-										Line:   99,
+										Line:   120,
 									},
 								},
 							},
@@ -4389,13 +5647,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 22,
-											Line:   99,
+											Line:   120,
 										},
 										File:   "universe.flux",
 										Source: "difference",
 										Start: ast.Position{
 											Column: 12,
-											Line:   99,
+											Line:   120,
 										},
 									},
 								},
@@ -4408,13 +5666,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   100,
+								Line:   121,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> difference(nonNegative: true, columns:columns)\n        |> cumulativeSum(columns: columns)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   98,
+								Line:   119,
 							},
 						},
 					},
@@ -4425,13 +5683,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 42,
-										Line:   100,
+										Line:   121,
 									},
 									File:   "universe.flux",
 									Source: "columns: columns",
 									Start: ast.Position{
 										Column: 26,
-										Line:   100,
+										Line:   121,
 									},
 								},
 							},
@@ -4441,13 +5699,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   100,
+											Line:   121,
 										},
 										File:   "universe.flux",
 										Source: "columns: columns",
 										Start: ast.Position{
 											Column: 26,
-											Line:   100,
+											Line:   121,
 										},
 									},
 								},
@@ -4457,13 +5715,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 33,
-												Line:   100,
+												Line:   121,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 26,
-												Line:   100,
+												Line:   121,
 											},
 										},
 									},
@@ -4475,13 +5733,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   100,
+												Line:   121,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 35,
-												Line:   100,
+												Line:   121,
 											},
 										},
 									},
@@ -4494,13 +5752,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 43,
-									Line:   100,
+									Line:   121,
 								},
 								File:   "universe.flux",
 								Source: "cumulativeSum(columns: columns)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   100,
+									Line:   121,
 								},
 							},
 						},
@@ -4510,13 +5768,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 25,
-										Line:   100,
+										Line:   121,
 									},
 									File:   "universe.flux",
 									Source: "cumulativeSum",
 									Start: ast.Position{
 										Column: 12,
-										Line:   100,
+										Line:   121,
 									},
 								},
 							},
@@ -4530,13 +5788,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   97,
+								Line:   118,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 13,
-								Line:   97,
+								Line:   118,
 							},
 						},
 					},
@@ -4546,13 +5804,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   97,
+									Line:   118,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 13,
-									Line:   97,
+									Line:   118,
 								},
 							},
 						},
@@ -4563,13 +5821,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   97,
+								Line:   118,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 20,
-								Line:   97,
+								Line:   118,
 							},
 						},
 					}},
@@ -4579,13 +5837,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 42,
-								Line:   97,
+								Line:   118,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 24,
-								Line:   97,
+								Line:   118,
 							},
 						},
 					},
@@ -4595,13 +5853,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   97,
+									Line:   118,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 24,
-									Line:   97,
+									Line:   118,
 								},
 							},
 						},
@@ -4613,13 +5871,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 42,
-									Line:   97,
+									Line:   118,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 32,
-									Line:   97,
+									Line:   118,
 								},
 							},
 						},
@@ -4629,13 +5887,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 41,
-										Line:   97,
+										Line:   118,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 33,
-										Line:   97,
+										Line:   118,
 									},
 								},
 							},
@@ -4650,13 +5908,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 78,
-						Line:   107,
+						Line:   128,
 					},
 					File:   "universe.flux",
 					Source: "median = (method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   105,
+						Line:   126,
 					},
 				},
 			},
@@ -4666,13 +5924,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   105,
+							Line:   126,
 						},
 						File:   "universe.flux",
 						Source: "median",
 						Start: ast.Position{
 							Column: 1,
-							Line:   105,
+							Line:   126,
 						},
 					},
 				},
@@ -4684,13 +5942,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 78,
-							Line:   107,
+							Line:   128,
 						},
 						File:   "universe.flux",
 						Source: "(method=\"estimate_tdigest\", compression=0.0, tables=<-) =>\n    tables\n        |> percentile(percentile:0.5, method:method, compression:compression)",
 						Start: ast.Position{
 							Column: 10,
-							Line:   105,
+							Line:   126,
 						},
 					},
 				},
@@ -4701,13 +5959,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   106,
+									Line:   127,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   106,
+									Line:   127,
 								},
 							},
 						},
@@ -4718,13 +5976,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 78,
-								Line:   107,
+								Line:   128,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> percentile(percentile:0.5, method:method, compression:compression)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   106,
+								Line:   127,
 							},
 						},
 					},
@@ -4735,13 +5993,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 77,
-										Line:   107,
+										Line:   128,
 									},
 									File:   "universe.flux",
 									Source: "percentile:0.5, method:method, compression:compression",
 									Start: ast.Position{
 										Column: 23,
-										Line:   107,
+										Line:   128,
 									},
 								},
 							},
@@ -4751,13 +6009,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 37,
-											Line:   107,
+											Line:   128,
 										},
 										File:   "universe.flux",
 										Source: "percentile:0.5",
 										Start: ast.Position{
 											Column: 23,
-											Line:   107,
+											Line:   128,
 										},
 									},
 								},
@@ -4767,13 +6025,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 33,
-												Line:   107,
+												Line:   128,
 											},
 											File:   "universe.flux",
 											Source: "percentile",
 											Start: ast.Position{
 												Column: 23,
-												Line:   107,
+												Line:   128,
 											},
 										},
 									},
@@ -4785,13 +6043,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 37,
-												Line:   107,
+												Line:   128,
 											},
 											File:   "universe.flux",
 											Source: "0.5",
 											Start: ast.Position{
 												Column: 34,
-												Line:   107,
+												Line:   128,
 											},
 										},
 									},
@@ -4803,13 +6061,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 52,
-											Line:   107,
+											Line:   128,
 										},
 										File:   "universe.flux",
 										Source: "method:method",
 										Start: ast.Position{
 											Column: 39,
-											Line:   107,
+											Line:   128,
 										},
 									},
 								},
@@ -4819,13 +6077,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 45,
-												Line:   107,
+												Line:   128,
 											},
 											File:   "universe.flux",
 											Source: "method",
 											Start: ast.Position{
 												Column: 39,
-												Line:   107,
+												Line:   128,
 											},
 										},
 									},
@@ -4837,13 +6095,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 52,
-												Line:   107,
+												Line:   128,
 											},
 											File:   "universe.flux",
 											Source: "method",
 											Start: ast.Position{
 												Column: 46,
-												Line:   107,
+												Line:   128,
 											},
 										},
 									},
@@ -4855,13 +6113,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   107,
+											Line:   128,
 										},
 										File:   "universe.flux",
 										Source: "compression:compression",
 										Start: ast.Position{
 											Column: 54,
-											Line:   107,
+											Line:   128,
 										},
 									},
 								},
@@ -4871,13 +6129,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   107,
+												Line:   128,
 											},
 											File:   "universe.flux",
 											Source: "compression",
 											Start: ast.Position{
 												Column: 54,
-												Line:   107,
+												Line:   128,
 											},
 										},
 									},
@@ -4889,13 +6147,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   107,
+												Line:   128,
 											},
 											File:   "universe.flux",
 											Source: "compression",
 											Start: ast.Position{
 												Column: 66,
-												Line:   107,
+												Line:   128,
 											},
 										},
 									},
@@ -4908,13 +6166,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 78,
-									Line:   107,
+									Line:   128,
 								},
 								File:   "universe.flux",
 								Source: "percentile(percentile:0.5, method:method, compression:compression)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   107,
+									Line:   128,
 								},
 							},
 						},
@@ -4924,13 +6182,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   107,
+										Line:   128,
 									},
 									File:   "universe.flux",
 									Source: "percentile",
 									Start: ast.Position{
 										Column: 12,
-										Line:   107,
+										Line:   128,
 									},
 								},
 							},
@@ -4944,13 +6202,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 36,
-								Line:   105,
+								Line:   126,
 							},
 							File:   "universe.flux",
 							Source: "method=\"estimate_tdigest\"",
 							Start: ast.Position{
 								Column: 11,
-								Line:   105,
+								Line:   126,
 							},
 						},
 					},
@@ -4960,13 +6218,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   105,
+									Line:   126,
 								},
 								File:   "universe.flux",
 								Source: "method",
 								Start: ast.Position{
 									Column: 11,
-									Line:   105,
+									Line:   126,
 								},
 							},
 						},
@@ -4978,13 +6236,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 36,
-									Line:   105,
+									Line:   126,
 								},
 								File:   "universe.flux",
 								Source: "\"estimate_tdigest\"",
 								Start: ast.Position{
 									Column: 18,
-									Line:   105,
+									Line:   126,
 								},
 							},
 						},
@@ -4996,13 +6254,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   105,
+								Line:   126,
 							},
 							File:   "universe.flux",
 							Source: "compression=0.0",
 							Start: ast.Position{
 								Column: 38,
-								Line:   105,
+								Line:   126,
 							},
 						},
 					},
@@ -5012,13 +6270,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 49,
-									Line:   105,
+									Line:   126,
 								},
 								File:   "universe.flux",
 								Source: "compression",
 								Start: ast.Position{
 									Column: 38,
-									Line:   105,
+									Line:   126,
 								},
 							},
 						},
@@ -5030,13 +6288,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   105,
+									Line:   126,
 								},
 								File:   "universe.flux",
 								Source: "0.0",
 								Start: ast.Position{
 									Column: 50,
-									Line:   105,
+									Line:   126,
 								},
 							},
 						},
@@ -5048,13 +6306,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   105,
+								Line:   126,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 55,
-								Line:   105,
+								Line:   126,
 							},
 						},
 					},
@@ -5064,13 +6322,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 61,
-									Line:   105,
+									Line:   126,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 55,
-									Line:   105,
+									Line:   126,
 								},
 							},
 						},
@@ -5081,13 +6339,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   105,
+								Line:   126,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 62,
-								Line:   105,
+								Line:   126,
 							},
 						},
 					}},
@@ -5099,13 +6357,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 81,
-						Line:   112,
+						Line:   133,
 					},
 					File:   "universe.flux",
 					Source: "influxFieldsAsCols = (tables=<-) =>\n    tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")",
 					Start: ast.Position{
 						Column: 1,
-						Line:   110,
+						Line:   131,
 					},
 				},
 			},
@@ -5115,13 +6373,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 19,
-							Line:   110,
+							Line:   131,
 						},
 						File:   "universe.flux",
 						Source: "influxFieldsAsCols",
 						Start: ast.Position{
 							Column: 1,
-							Line:   110,
+							Line:   131,
 						},
 					},
 				},
@@ -5133,13 +6391,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 81,
-							Line:   112,
+							Line:   133,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) =>\n    tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")",
 						Start: ast.Position{
 							Column: 22,
-							Line:   110,
+							Line:   131,
 						},
 					},
 				},
@@ -5150,13 +6408,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   111,
+									Line:   132,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   111,
+									Line:   132,
 								},
 							},
 						},
@@ -5167,13 +6425,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 81,
-								Line:   112,
+								Line:   133,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")",
 							Start: ast.Position{
 								Column: 5,
-								Line:   111,
+								Line:   132,
 							},
 						},
 					},
@@ -5184,13 +6442,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 80,
-										Line:   112,
+										Line:   133,
 									},
 									File:   "universe.flux",
 									Source: "rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\"",
 									Start: ast.Position{
 										Column: 18,
-										Line:   112,
+										Line:   133,
 									},
 								},
 							},
@@ -5200,13 +6458,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 34,
-											Line:   112,
+											Line:   133,
 										},
 										File:   "universe.flux",
 										Source: "rowKey:[\"_time\"]",
 										Start: ast.Position{
 											Column: 18,
-											Line:   112,
+											Line:   133,
 										},
 									},
 								},
@@ -5216,13 +6474,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   112,
+												Line:   133,
 											},
 											File:   "universe.flux",
 											Source: "rowKey",
 											Start: ast.Position{
 												Column: 18,
-												Line:   112,
+												Line:   133,
 											},
 										},
 									},
@@ -5234,13 +6492,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 34,
-												Line:   112,
+												Line:   133,
 											},
 											File:   "universe.flux",
 											Source: "[\"_time\"]",
 											Start: ast.Position{
 												Column: 25,
-												Line:   112,
+												Line:   133,
 											},
 										},
 									},
@@ -5250,13 +6508,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 33,
-													Line:   112,
+													Line:   133,
 												},
 												File:   "universe.flux",
 												Source: "\"_time\"",
 												Start: ast.Position{
 													Column: 26,
-													Line:   112,
+													Line:   133,
 												},
 											},
 										},
@@ -5269,13 +6527,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 57,
-											Line:   112,
+											Line:   133,
 										},
 										File:   "universe.flux",
 										Source: "columnKey: [\"_field\"]",
 										Start: ast.Position{
 											Column: 36,
-											Line:   112,
+											Line:   133,
 										},
 									},
 								},
@@ -5285,13 +6543,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 45,
-												Line:   112,
+												Line:   133,
 											},
 											File:   "universe.flux",
 											Source: "columnKey",
 											Start: ast.Position{
 												Column: 36,
-												Line:   112,
+												Line:   133,
 											},
 										},
 									},
@@ -5303,13 +6561,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 57,
-												Line:   112,
+												Line:   133,
 											},
 											File:   "universe.flux",
 											Source: "[\"_field\"]",
 											Start: ast.Position{
 												Column: 47,
-												Line:   112,
+												Line:   133,
 											},
 										},
 									},
@@ -5319,13 +6577,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 56,
-													Line:   112,
+													Line:   133,
 												},
 												File:   "universe.flux",
 												Source: "\"_field\"",
 												Start: ast.Position{
 													Column: 48,
-													Line:   112,
+													Line:   133,
 												},
 											},
 										},
@@ -5338,13 +6596,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 80,
-											Line:   112,
+											Line:   133,
 										},
 										File:   "universe.flux",
 										Source: "valueColumn: \"_value\"",
 										Start: ast.Position{
 											Column: 59,
-											Line:   112,
+											Line:   133,
 										},
 									},
 								},
@@ -5354,13 +6612,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 70,
-												Line:   112,
+												Line:   133,
 											},
 											File:   "universe.flux",
 											Source: "valueColumn",
 											Start: ast.Position{
 												Column: 59,
-												Line:   112,
+												Line:   133,
 											},
 										},
 									},
@@ -5372,13 +6630,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 80,
-												Line:   112,
+												Line:   133,
 											},
 											File:   "universe.flux",
 											Source: "\"_value\"",
 											Start: ast.Position{
 												Column: 72,
-												Line:   112,
+												Line:   133,
 											},
 										},
 									},
@@ -5391,13 +6649,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 81,
-									Line:   112,
+									Line:   133,
 								},
 								File:   "universe.flux",
 								Source: "pivot(rowKey:[\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")",
 								Start: ast.Position{
 									Column: 12,
-									Line:   112,
+									Line:   133,
 								},
 							},
 						},
@@ -5407,13 +6665,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 17,
-										Line:   112,
+										Line:   133,
 									},
 									File:   "universe.flux",
 									Source: "pivot",
 									Start: ast.Position{
 										Column: 12,
-										Line:   112,
+										Line:   133,
 									},
 								},
 							},
@@ -5427,13 +6685,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 32,
-								Line:   110,
+								Line:   131,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 23,
-								Line:   110,
+								Line:   131,
 							},
 						},
 					},
@@ -5443,13 +6701,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   110,
+									Line:   131,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 23,
-									Line:   110,
+									Line:   131,
 								},
 							},
 						},
@@ -5460,13 +6718,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 32,
-								Line:   110,
+								Line:   131,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 30,
-								Line:   110,
+								Line:   131,
 							},
 						},
 					}},
@@ -5478,13 +6736,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 52,
-						Line:   125,
+						Line:   146,
 					},
 					File:   "universe.flux",
 					Source: "stateCount = (fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   123,
+						Line:   144,
 					},
 				},
 			},
@@ -5494,13 +6752,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   123,
+							Line:   144,
 						},
 						File:   "universe.flux",
 						Source: "stateCount",
 						Start: ast.Position{
 							Column: 1,
-							Line:   123,
+							Line:   144,
 						},
 					},
 				},
@@ -5512,13 +6770,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 52,
-							Line:   125,
+							Line:   146,
 						},
 						File:   "universe.flux",
 						Source: "(fn, column=\"stateCount\", tables=<-) =>\n    tables\n        |> stateTracking(countColumn:column, fn:fn)",
 						Start: ast.Position{
 							Column: 14,
-							Line:   123,
+							Line:   144,
 						},
 					},
 				},
@@ -5529,13 +6787,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   124,
+									Line:   145,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   124,
+									Line:   145,
 								},
 							},
 						},
@@ -5546,13 +6804,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 52,
-								Line:   125,
+								Line:   146,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> stateTracking(countColumn:column, fn:fn)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   124,
+								Line:   145,
 							},
 						},
 					},
@@ -5563,13 +6821,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 51,
-										Line:   125,
+										Line:   146,
 									},
 									File:   "universe.flux",
 									Source: "countColumn:column, fn:fn",
 									Start: ast.Position{
 										Column: 26,
-										Line:   125,
+										Line:   146,
 									},
 								},
 							},
@@ -5579,13 +6837,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 44,
-											Line:   125,
+											Line:   146,
 										},
 										File:   "universe.flux",
 										Source: "countColumn:column",
 										Start: ast.Position{
 											Column: 26,
-											Line:   125,
+											Line:   146,
 										},
 									},
 								},
@@ -5595,13 +6853,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 37,
-												Line:   125,
+												Line:   146,
 											},
 											File:   "universe.flux",
 											Source: "countColumn",
 											Start: ast.Position{
 												Column: 26,
-												Line:   125,
+												Line:   146,
 											},
 										},
 									},
@@ -5613,13 +6871,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 44,
-												Line:   125,
+												Line:   146,
 											},
 											File:   "universe.flux",
 											Source: "column",
 											Start: ast.Position{
 												Column: 38,
-												Line:   125,
+												Line:   146,
 											},
 										},
 									},
@@ -5631,13 +6889,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 51,
-											Line:   125,
+											Line:   146,
 										},
 										File:   "universe.flux",
 										Source: "fn:fn",
 										Start: ast.Position{
 											Column: 46,
-											Line:   125,
+											Line:   146,
 										},
 									},
 								},
@@ -5647,13 +6905,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 48,
-												Line:   125,
+												Line:   146,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 46,
-												Line:   125,
+												Line:   146,
 											},
 										},
 									},
@@ -5665,13 +6923,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 51,
-												Line:   125,
+												Line:   146,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 49,
-												Line:   125,
+												Line:   146,
 											},
 										},
 									},
@@ -5684,13 +6942,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 52,
-									Line:   125,
+									Line:   146,
 								},
 								File:   "universe.flux",
 								Source: "stateTracking(countColumn:column, fn:fn)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   125,
+									Line:   146,
 								},
 							},
 						},
@@ -5700,13 +6958,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 25,
-										Line:   125,
+										Line:   146,
 									},
 									File:   "universe.flux",
 									Source: "stateTracking",
 									Start: ast.Position{
 										Column: 12,
-										Line:   125,
+										Line:   146,
 									},
 								},
 							},
@@ -5720,13 +6978,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 17,
-								Line:   123,
+								Line:   144,
 							},
 							File:   "universe.flux",
 							Source: "fn",
 							Start: ast.Position{
 								Column: 15,
-								Line:   123,
+								Line:   144,
 							},
 						},
 					},
@@ -5736,13 +6994,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   123,
+									Line:   144,
 								},
 								File:   "universe.flux",
 								Source: "fn",
 								Start: ast.Position{
 									Column: 15,
-									Line:   123,
+									Line:   144,
 								},
 							},
 						},
@@ -5755,13 +7013,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 38,
-								Line:   123,
+								Line:   144,
 							},
 							File:   "universe.flux",
 							Source: "column=\"stateCount\"",
 							Start: ast.Position{
 								Column: 19,
-								Line:   123,
+								Line:   144,
 							},
 						},
 					},
@@ -5771,13 +7029,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 25,
-									Line:   123,
+									Line:   144,
 								},
 								File:   "universe.flux",
 								Source: "column",
 								Start: ast.Position{
 									Column: 19,
-									Line:   123,
+									Line:   144,
 								},
 							},
 						},
@@ -5789,13 +7047,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 38,
-									Line:   123,
+									Line:   144,
 								},
 								File:   "universe.flux",
 								Source: "\"stateCount\"",
 								Start: ast.Position{
 									Column: 26,
-									Line:   123,
+									Line:   144,
 								},
 							},
 						},
@@ -5807,13 +7065,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 49,
-								Line:   123,
+								Line:   144,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 40,
-								Line:   123,
+								Line:   144,
 							},
 						},
 					},
@@ -5823,13 +7081,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 46,
-									Line:   123,
+									Line:   144,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 40,
-									Line:   123,
+									Line:   144,
 								},
 							},
 						},
@@ -5840,13 +7098,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 49,
-								Line:   123,
+								Line:   144,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 47,
-								Line:   123,
+								Line:   144,
 							},
 						},
 					}},
@@ -5858,13 +7116,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 97,
-						Line:   144,
+						Line:   165,
 					},
 					File:   "universe.flux",
 					Source: "stateDuration = (fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   142,
+						Line:   163,
 					},
 				},
 			},
@@ -5874,13 +7132,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   142,
+							Line:   163,
 						},
 						File:   "universe.flux",
 						Source: "stateDuration",
 						Start: ast.Position{
 							Column: 1,
-							Line:   142,
+							Line:   163,
 						},
 					},
 				},
@@ -5892,13 +7150,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 97,
-							Line:   144,
+							Line:   165,
 						},
 						File:   "universe.flux",
 						Source: "(fn, column=\"stateDuration\", timeColumn=\"_time\", unit=1s, tables=<-) =>\n    tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 						Start: ast.Position{
 							Column: 17,
-							Line:   142,
+							Line:   163,
 						},
 					},
 				},
@@ -5909,13 +7167,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   143,
+									Line:   164,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   143,
+									Line:   164,
 								},
 							},
 						},
@@ -5926,13 +7184,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 97,
-								Line:   144,
+								Line:   165,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   143,
+								Line:   164,
 							},
 						},
 					},
@@ -5943,13 +7201,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 96,
-										Line:   144,
+										Line:   165,
 									},
 									File:   "universe.flux",
 									Source: "durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit",
 									Start: ast.Position{
 										Column: 26,
-										Line:   144,
+										Line:   165,
 									},
 								},
 							},
@@ -5959,13 +7217,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 47,
-											Line:   144,
+											Line:   165,
 										},
 										File:   "universe.flux",
 										Source: "durationColumn:column",
 										Start: ast.Position{
 											Column: 26,
-											Line:   144,
+											Line:   165,
 										},
 									},
 								},
@@ -5975,13 +7233,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 40,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "durationColumn",
 											Start: ast.Position{
 												Column: 26,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -5993,13 +7251,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 47,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "column",
 											Start: ast.Position{
 												Column: 41,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6011,13 +7269,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 70,
-											Line:   144,
+											Line:   165,
 										},
 										File:   "universe.flux",
 										Source: "timeColumn:timeColumn",
 										Start: ast.Position{
 											Column: 49,
-											Line:   144,
+											Line:   165,
 										},
 									},
 								},
@@ -6027,13 +7285,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 59,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "timeColumn",
 											Start: ast.Position{
 												Column: 49,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6045,13 +7303,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 70,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "timeColumn",
 											Start: ast.Position{
 												Column: 60,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6063,13 +7321,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   144,
+											Line:   165,
 										},
 										File:   "universe.flux",
 										Source: "fn:fn",
 										Start: ast.Position{
 											Column: 72,
-											Line:   144,
+											Line:   165,
 										},
 									},
 								},
@@ -6079,13 +7337,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 74,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 72,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6097,13 +7355,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 75,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6115,13 +7373,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 96,
-											Line:   144,
+											Line:   165,
 										},
 										File:   "universe.flux",
 										Source: "durationUnit:unit",
 										Start: ast.Position{
 											Column: 79,
-											Line:   144,
+											Line:   165,
 										},
 									},
 								},
@@ -6131,13 +7389,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 91,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "durationUnit",
 											Start: ast.Position{
 												Column: 79,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6149,13 +7407,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 96,
-												Line:   144,
+												Line:   165,
 											},
 											File:   "universe.flux",
 											Source: "unit",
 											Start: ast.Position{
 												Column: 92,
-												Line:   144,
+												Line:   165,
 											},
 										},
 									},
@@ -6168,13 +7426,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 97,
-									Line:   144,
+									Line:   165,
 								},
 								File:   "universe.flux",
 								Source: "stateTracking(durationColumn:column, timeColumn:timeColumn, fn:fn, durationUnit:unit)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   144,
+									Line:   165,
 								},
 							},
 						},
@@ -6184,13 +7442,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 25,
-										Line:   144,
+										Line:   165,
 									},
 									File:   "universe.flux",
 									Source: "stateTracking",
 									Start: ast.Position{
 										Column: 12,
-										Line:   144,
+										Line:   165,
 									},
 								},
 							},
@@ -6204,13 +7462,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   142,
+								Line:   163,
 							},
 							File:   "universe.flux",
 							Source: "fn",
 							Start: ast.Position{
 								Column: 18,
-								Line:   142,
+								Line:   163,
 							},
 						},
 					},
@@ -6220,13 +7478,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "fn",
 								Start: ast.Position{
 									Column: 18,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6239,13 +7497,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 44,
-								Line:   142,
+								Line:   163,
 							},
 							File:   "universe.flux",
 							Source: "column=\"stateDuration\"",
 							Start: ast.Position{
 								Column: 22,
-								Line:   142,
+								Line:   163,
 							},
 						},
 					},
@@ -6255,13 +7513,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 28,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "column",
 								Start: ast.Position{
 									Column: 22,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6273,13 +7531,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "\"stateDuration\"",
 								Start: ast.Position{
 									Column: 29,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6291,13 +7549,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   142,
+								Line:   163,
 							},
 							File:   "universe.flux",
 							Source: "timeColumn=\"_time\"",
 							Start: ast.Position{
 								Column: 46,
-								Line:   142,
+								Line:   163,
 							},
 						},
 					},
@@ -6307,13 +7565,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "timeColumn",
 								Start: ast.Position{
 									Column: 46,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6325,13 +7583,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "\"_time\"",
 								Start: ast.Position{
 									Column: 57,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6343,13 +7601,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 73,
-								Line:   142,
+								Line:   163,
 							},
 							File:   "universe.flux",
 							Source: "unit=1s",
 							Start: ast.Position{
 								Column: 66,
-								Line:   142,
+								Line:   163,
 							},
 						},
 					},
@@ -6359,13 +7617,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 70,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "unit",
 								Start: ast.Position{
 									Column: 66,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6377,13 +7635,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 73,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "1s",
 								Start: ast.Position{
 									Column: 71,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6398,13 +7656,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 84,
-								Line:   142,
+								Line:   163,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 75,
-								Line:   142,
+								Line:   163,
 							},
 						},
 					},
@@ -6414,13 +7672,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 81,
-									Line:   142,
+									Line:   163,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 75,
-									Line:   142,
+									Line:   163,
 								},
 							},
 						},
@@ -6431,13 +7689,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 84,
-								Line:   142,
+								Line:   163,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 82,
-								Line:   142,
+								Line:   163,
 							},
 						},
 					}},
@@ -6449,13 +7707,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 22,
-						Line:   150,
+						Line:   171,
 					},
 					File:   "universe.flux",
 					Source: "_sortLimit = (n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   147,
+						Line:   168,
 					},
 				},
 			},
@@ -6465,13 +7723,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   147,
+							Line:   168,
 						},
 						File:   "universe.flux",
 						Source: "_sortLimit",
 						Start: ast.Position{
 							Column: 1,
-							Line:   147,
+							Line:   168,
 						},
 					},
 				},
@@ -6483,13 +7741,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 22,
-							Line:   150,
+							Line:   171,
 						},
 						File:   "universe.flux",
 						Source: "(n, desc, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)",
 						Start: ast.Position{
 							Column: 14,
-							Line:   147,
+							Line:   168,
 						},
 					},
 				},
@@ -6501,13 +7759,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 11,
-										Line:   148,
+										Line:   169,
 									},
 									File:   "universe.flux",
 									Source: "tables",
 									Start: ast.Position{
 										Column: 5,
-										Line:   148,
+										Line:   169,
 									},
 								},
 							},
@@ -6518,13 +7776,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   149,
+									Line:   170,
 								},
 								File:   "universe.flux",
 								Source: "tables\n        |> sort(columns:columns, desc:desc)",
 								Start: ast.Position{
 									Column: 5,
-									Line:   148,
+									Line:   169,
 								},
 							},
 						},
@@ -6535,13 +7793,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   149,
+											Line:   170,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns, desc:desc",
 										Start: ast.Position{
 											Column: 17,
-											Line:   149,
+											Line:   170,
 										},
 									},
 								},
@@ -6551,13 +7809,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   149,
+												Line:   170,
 											},
 											File:   "universe.flux",
 											Source: "columns:columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   149,
+												Line:   170,
 											},
 										},
 									},
@@ -6567,13 +7825,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 24,
-													Line:   149,
+													Line:   170,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 17,
-													Line:   149,
+													Line:   170,
 												},
 											},
 										},
@@ -6585,13 +7843,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 32,
-													Line:   149,
+													Line:   170,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 25,
-													Line:   149,
+													Line:   170,
 												},
 											},
 										},
@@ -6603,13 +7861,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   149,
+												Line:   170,
 											},
 											File:   "universe.flux",
 											Source: "desc:desc",
 											Start: ast.Position{
 												Column: 34,
-												Line:   149,
+												Line:   170,
 											},
 										},
 									},
@@ -6619,13 +7877,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 38,
-													Line:   149,
+													Line:   170,
 												},
 												File:   "universe.flux",
 												Source: "desc",
 												Start: ast.Position{
 													Column: 34,
-													Line:   149,
+													Line:   170,
 												},
 											},
 										},
@@ -6637,13 +7895,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 43,
-													Line:   149,
+													Line:   170,
 												},
 												File:   "universe.flux",
 												Source: "desc",
 												Start: ast.Position{
 													Column: 39,
-													Line:   149,
+													Line:   170,
 												},
 											},
 										},
@@ -6656,13 +7914,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 44,
-										Line:   149,
+										Line:   170,
 									},
 									File:   "universe.flux",
 									Source: "sort(columns:columns, desc:desc)",
 									Start: ast.Position{
 										Column: 12,
-										Line:   149,
+										Line:   170,
 									},
 								},
 							},
@@ -6672,13 +7930,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 16,
-											Line:   149,
+											Line:   170,
 										},
 										File:   "universe.flux",
 										Source: "sort",
 										Start: ast.Position{
 											Column: 12,
-											Line:   149,
+											Line:   170,
 										},
 									},
 								},
@@ -6691,13 +7949,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   150,
+								Line:   171,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> sort(columns:columns, desc:desc)\n        |> limit(n:n)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   148,
+								Line:   169,
 							},
 						},
 					},
@@ -6708,13 +7966,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 21,
-										Line:   150,
+										Line:   171,
 									},
 									File:   "universe.flux",
 									Source: "n:n",
 									Start: ast.Position{
 										Column: 18,
-										Line:   150,
+										Line:   171,
 									},
 								},
 							},
@@ -6724,13 +7982,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   150,
+											Line:   171,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 18,
-											Line:   150,
+											Line:   171,
 										},
 									},
 								},
@@ -6740,13 +7998,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 19,
-												Line:   150,
+												Line:   171,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 18,
-												Line:   150,
+												Line:   171,
 											},
 										},
 									},
@@ -6758,13 +8016,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 21,
-												Line:   150,
+												Line:   171,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 20,
-												Line:   150,
+												Line:   171,
 											},
 										},
 									},
@@ -6777,13 +8035,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 22,
-									Line:   150,
+									Line:   171,
 								},
 								File:   "universe.flux",
 								Source: "limit(n:n)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   150,
+									Line:   171,
 								},
 							},
 						},
@@ -6793,13 +8051,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 17,
-										Line:   150,
+										Line:   171,
 									},
 									File:   "universe.flux",
 									Source: "limit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   150,
+										Line:   171,
 									},
 								},
 							},
@@ -6813,13 +8071,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 16,
-								Line:   147,
+								Line:   168,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 15,
-								Line:   147,
+								Line:   168,
 							},
 						},
 					},
@@ -6829,13 +8087,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 16,
-									Line:   147,
+									Line:   168,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 15,
-									Line:   147,
+									Line:   168,
 								},
 							},
 						},
@@ -6848,13 +8106,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   147,
+								Line:   168,
 							},
 							File:   "universe.flux",
 							Source: "desc",
 							Start: ast.Position{
 								Column: 18,
-								Line:   147,
+								Line:   168,
 							},
 						},
 					},
@@ -6864,13 +8122,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 22,
-									Line:   147,
+									Line:   168,
 								},
 								File:   "universe.flux",
 								Source: "desc",
 								Start: ast.Position{
 									Column: 18,
-									Line:   147,
+									Line:   168,
 								},
 							},
 						},
@@ -6883,13 +8141,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 42,
-								Line:   147,
+								Line:   168,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 24,
-								Line:   147,
+								Line:   168,
 							},
 						},
 					},
@@ -6899,13 +8157,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   147,
+									Line:   168,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 24,
-									Line:   147,
+									Line:   168,
 								},
 							},
 						},
@@ -6917,13 +8175,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 42,
-									Line:   147,
+									Line:   168,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 32,
-									Line:   147,
+									Line:   168,
 								},
 							},
 						},
@@ -6933,13 +8191,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 41,
-										Line:   147,
+										Line:   168,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 33,
-										Line:   147,
+										Line:   168,
 									},
 								},
 							},
@@ -6952,13 +8210,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   147,
+								Line:   168,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 44,
-								Line:   147,
+								Line:   168,
 							},
 						},
 					},
@@ -6968,13 +8226,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 50,
-									Line:   147,
+									Line:   168,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 44,
-									Line:   147,
+									Line:   168,
 								},
 							},
 						},
@@ -6985,13 +8243,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   147,
+								Line:   168,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 51,
-								Line:   147,
+								Line:   168,
 							},
 						},
 					}},
@@ -7003,13 +8261,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 55,
-						Line:   155,
+						Line:   176,
 					},
 					File:   "universe.flux",
 					Source: "top = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   153,
+						Line:   174,
 					},
 				},
 			},
@@ -7019,13 +8277,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 4,
-							Line:   153,
+							Line:   174,
 						},
 						File:   "universe.flux",
 						Source: "top",
 						Start: ast.Position{
 							Column: 1,
-							Line:   153,
+							Line:   174,
 						},
 					},
 				},
@@ -7037,13 +8295,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 55,
-							Line:   155,
+							Line:   176,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:true)",
 						Start: ast.Position{
 							Column: 7,
-							Line:   153,
+							Line:   174,
 						},
 					},
 				},
@@ -7054,13 +8312,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   154,
+									Line:   175,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   154,
+									Line:   175,
 								},
 							},
 						},
@@ -7071,13 +8329,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 55,
-								Line:   155,
+								Line:   176,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _sortLimit(n:n, columns:columns, desc:true)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   154,
+								Line:   175,
 							},
 						},
 					},
@@ -7088,13 +8346,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 54,
-										Line:   155,
+										Line:   176,
 									},
 									File:   "universe.flux",
 									Source: "n:n, columns:columns, desc:true",
 									Start: ast.Position{
 										Column: 23,
-										Line:   155,
+										Line:   176,
 									},
 								},
 							},
@@ -7104,13 +8362,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 26,
-											Line:   155,
+											Line:   176,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 23,
-											Line:   155,
+											Line:   176,
 										},
 									},
 								},
@@ -7120,13 +8378,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   155,
+												Line:   176,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 23,
-												Line:   155,
+												Line:   176,
 											},
 										},
 									},
@@ -7138,13 +8396,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 26,
-												Line:   155,
+												Line:   176,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 25,
-												Line:   155,
+												Line:   176,
 											},
 										},
 									},
@@ -7156,13 +8414,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   155,
+											Line:   176,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 28,
-											Line:   155,
+											Line:   176,
 										},
 									},
 								},
@@ -7172,13 +8430,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   155,
+												Line:   176,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 28,
-												Line:   155,
+												Line:   176,
 											},
 										},
 									},
@@ -7190,13 +8448,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   155,
+												Line:   176,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 36,
-												Line:   155,
+												Line:   176,
 											},
 										},
 									},
@@ -7208,13 +8466,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 54,
-											Line:   155,
+											Line:   176,
 										},
 										File:   "universe.flux",
 										Source: "desc:true",
 										Start: ast.Position{
 											Column: 45,
-											Line:   155,
+											Line:   176,
 										},
 									},
 								},
@@ -7224,13 +8482,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 49,
-												Line:   155,
+												Line:   176,
 											},
 											File:   "universe.flux",
 											Source: "desc",
 											Start: ast.Position{
 												Column: 45,
-												Line:   155,
+												Line:   176,
 											},
 										},
 									},
@@ -7242,13 +8500,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 54,
-												Line:   155,
+												Line:   176,
 											},
 											File:   "universe.flux",
 											Source: "true",
 											Start: ast.Position{
 												Column: 50,
-												Line:   155,
+												Line:   176,
 											},
 										},
 									},
@@ -7261,13 +8519,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 55,
-									Line:   155,
+									Line:   176,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit(n:n, columns:columns, desc:true)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   155,
+									Line:   176,
 								},
 							},
 						},
@@ -7277,13 +8535,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   155,
+										Line:   176,
 									},
 									File:   "universe.flux",
 									Source: "_sortLimit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   155,
+										Line:   176,
 									},
 								},
 							},
@@ -7297,13 +8555,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 9,
-								Line:   153,
+								Line:   174,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 8,
-								Line:   153,
+								Line:   174,
 							},
 						},
 					},
@@ -7313,13 +8571,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 9,
-									Line:   153,
+									Line:   174,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 8,
-									Line:   153,
+									Line:   174,
 								},
 							},
 						},
@@ -7332,13 +8590,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 29,
-								Line:   153,
+								Line:   174,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 11,
-								Line:   153,
+								Line:   174,
 							},
 						},
 					},
@@ -7348,13 +8606,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 18,
-									Line:   153,
+									Line:   174,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 11,
-									Line:   153,
+									Line:   174,
 								},
 							},
 						},
@@ -7366,13 +8624,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   153,
+									Line:   174,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 19,
-									Line:   153,
+									Line:   174,
 								},
 							},
 						},
@@ -7382,13 +8640,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   153,
+										Line:   174,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 20,
-										Line:   153,
+										Line:   174,
 									},
 								},
 							},
@@ -7401,13 +8659,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   153,
+								Line:   174,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 31,
-								Line:   153,
+								Line:   174,
 							},
 						},
 					},
@@ -7417,13 +8675,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 37,
-									Line:   153,
+									Line:   174,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 31,
-									Line:   153,
+									Line:   174,
 								},
 							},
 						},
@@ -7434,13 +8692,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   153,
+								Line:   174,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 38,
-								Line:   153,
+								Line:   174,
 							},
 						},
 					}},
@@ -7452,13 +8710,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 56,
-						Line:   160,
+						Line:   181,
 					},
 					File:   "universe.flux",
 					Source: "bottom = (n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   158,
+						Line:   179,
 					},
 				},
 			},
@@ -7468,13 +8726,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   158,
+							Line:   179,
 						},
 						File:   "universe.flux",
 						Source: "bottom",
 						Start: ast.Position{
 							Column: 1,
-							Line:   158,
+							Line:   179,
 						},
 					},
 				},
@@ -7486,13 +8744,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 56,
-							Line:   160,
+							Line:   181,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], tables=<-) =>\n    tables\n        |> _sortLimit(n:n, columns:columns, desc:false)",
 						Start: ast.Position{
 							Column: 10,
-							Line:   158,
+							Line:   179,
 						},
 					},
 				},
@@ -7503,13 +8761,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   159,
+									Line:   180,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   159,
+									Line:   180,
 								},
 							},
 						},
@@ -7520,13 +8778,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 56,
-								Line:   160,
+								Line:   181,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _sortLimit(n:n, columns:columns, desc:false)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   159,
+								Line:   180,
 							},
 						},
 					},
@@ -7537,13 +8795,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 55,
-										Line:   160,
+										Line:   181,
 									},
 									File:   "universe.flux",
 									Source: "n:n, columns:columns, desc:false",
 									Start: ast.Position{
 										Column: 23,
-										Line:   160,
+										Line:   181,
 									},
 								},
 							},
@@ -7553,13 +8811,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 26,
-											Line:   160,
+											Line:   181,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 23,
-											Line:   160,
+											Line:   181,
 										},
 									},
 								},
@@ -7569,13 +8827,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   160,
+												Line:   181,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 23,
-												Line:   160,
+												Line:   181,
 											},
 										},
 									},
@@ -7587,13 +8845,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 26,
-												Line:   160,
+												Line:   181,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 25,
-												Line:   160,
+												Line:   181,
 											},
 										},
 									},
@@ -7605,13 +8863,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   160,
+											Line:   181,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 28,
-											Line:   160,
+											Line:   181,
 										},
 									},
 								},
@@ -7621,13 +8879,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   160,
+												Line:   181,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 28,
-												Line:   160,
+												Line:   181,
 											},
 										},
 									},
@@ -7639,13 +8897,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   160,
+												Line:   181,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 36,
-												Line:   160,
+												Line:   181,
 											},
 										},
 									},
@@ -7657,13 +8915,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 55,
-											Line:   160,
+											Line:   181,
 										},
 										File:   "universe.flux",
 										Source: "desc:false",
 										Start: ast.Position{
 											Column: 45,
-											Line:   160,
+											Line:   181,
 										},
 									},
 								},
@@ -7673,13 +8931,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 49,
-												Line:   160,
+												Line:   181,
 											},
 											File:   "universe.flux",
 											Source: "desc",
 											Start: ast.Position{
 												Column: 45,
-												Line:   160,
+												Line:   181,
 											},
 										},
 									},
@@ -7691,13 +8949,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 55,
-												Line:   160,
+												Line:   181,
 											},
 											File:   "universe.flux",
 											Source: "false",
 											Start: ast.Position{
 												Column: 50,
-												Line:   160,
+												Line:   181,
 											},
 										},
 									},
@@ -7710,13 +8968,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   160,
+									Line:   181,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit(n:n, columns:columns, desc:false)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   160,
+									Line:   181,
 								},
 							},
 						},
@@ -7726,13 +8984,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   160,
+										Line:   181,
 									},
 									File:   "universe.flux",
 									Source: "_sortLimit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   160,
+										Line:   181,
 									},
 								},
 							},
@@ -7746,13 +9004,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 12,
-								Line:   158,
+								Line:   179,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 11,
-								Line:   158,
+								Line:   179,
 							},
 						},
 					},
@@ -7762,13 +9020,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 12,
-									Line:   158,
+									Line:   179,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 11,
-									Line:   158,
+									Line:   179,
 								},
 							},
 						},
@@ -7781,13 +9039,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 32,
-								Line:   158,
+								Line:   179,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 14,
-								Line:   158,
+								Line:   179,
 							},
 						},
 					},
@@ -7797,13 +9055,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 21,
-									Line:   158,
+									Line:   179,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 14,
-									Line:   158,
+									Line:   179,
 								},
 							},
 						},
@@ -7815,13 +9073,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 32,
-									Line:   158,
+									Line:   179,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 22,
-									Line:   158,
+									Line:   179,
 								},
 							},
 						},
@@ -7831,13 +9089,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 31,
-										Line:   158,
+										Line:   179,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 23,
-										Line:   158,
+										Line:   179,
 									},
 								},
 							},
@@ -7850,13 +9108,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   158,
+								Line:   179,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 34,
-								Line:   158,
+								Line:   179,
 							},
 						},
 					},
@@ -7866,13 +9124,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 40,
-									Line:   158,
+									Line:   179,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 34,
-									Line:   158,
+									Line:   179,
 								},
 							},
 						},
@@ -7883,13 +9141,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   158,
+								Line:   179,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 41,
-								Line:   158,
+								Line:   179,
 							},
 						},
 					}},
@@ -7901,13 +9159,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 44,
-						Line:   170,
+						Line:   191,
 					},
 					File:   "universe.flux",
 					Source: "_highestOrLowest = (n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)",
 					Start: ast.Position{
 						Column: 1,
-						Line:   165,
+						Line:   186,
 					},
 				},
 			},
@@ -7917,13 +9175,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 17,
-							Line:   165,
+							Line:   186,
 						},
 						File:   "universe.flux",
 						Source: "_highestOrLowest",
 						Start: ast.Position{
 							Column: 1,
-							Line:   165,
+							Line:   186,
 						},
 					},
 				},
@@ -7935,13 +9193,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 44,
-							Line:   170,
+							Line:   191,
 						},
 						File:   "universe.flux",
 						Source: "(n, _sortLimit, reducer, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)",
 						Start: ast.Position{
 							Column: 20,
-							Line:   165,
+							Line:   186,
 						},
 					},
 				},
@@ -7955,13 +9213,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 11,
-												Line:   166,
+												Line:   187,
 											},
 											File:   "universe.flux",
 											Source: "tables",
 											Start: ast.Position{
 												Column: 5,
-												Line:   166,
+												Line:   187,
 											},
 										},
 									},
@@ -7972,13 +9230,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 39,
-											Line:   167,
+											Line:   188,
 										},
 										File:   "universe.flux",
 										Source: "tables\n        |> group(columns:groupColumns)",
 										Start: ast.Position{
 											Column: 5,
-											Line:   166,
+											Line:   187,
 										},
 									},
 								},
@@ -7989,13 +9247,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 38,
-													Line:   167,
+													Line:   188,
 												},
 												File:   "universe.flux",
 												Source: "columns:groupColumns",
 												Start: ast.Position{
 													Column: 18,
-													Line:   167,
+													Line:   188,
 												},
 											},
 										},
@@ -8005,13 +9263,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 38,
-														Line:   167,
+														Line:   188,
 													},
 													File:   "universe.flux",
 													Source: "columns:groupColumns",
 													Start: ast.Position{
 														Column: 18,
-														Line:   167,
+														Line:   188,
 													},
 												},
 											},
@@ -8021,13 +9279,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 25,
-															Line:   167,
+															Line:   188,
 														},
 														File:   "universe.flux",
 														Source: "columns",
 														Start: ast.Position{
 															Column: 18,
-															Line:   167,
+															Line:   188,
 														},
 													},
 												},
@@ -8039,13 +9297,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 38,
-															Line:   167,
+															Line:   188,
 														},
 														File:   "universe.flux",
 														Source: "groupColumns",
 														Start: ast.Position{
 															Column: 26,
-															Line:   167,
+															Line:   188,
 														},
 													},
 												},
@@ -8058,13 +9316,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 39,
-												Line:   167,
+												Line:   188,
 											},
 											File:   "universe.flux",
 											Source: "group(columns:groupColumns)",
 											Start: ast.Position{
 												Column: 12,
-												Line:   167,
+												Line:   188,
 											},
 										},
 									},
@@ -8074,13 +9332,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 17,
-													Line:   167,
+													Line:   188,
 												},
 												File:   "universe.flux",
 												Source: "group",
 												Start: ast.Position{
 													Column: 12,
-													Line:   167,
+													Line:   188,
 												},
 											},
 										},
@@ -8093,13 +9351,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 21,
-										Line:   168,
+										Line:   189,
 									},
 									File:   "universe.flux",
 									Source: "tables\n        |> group(columns:groupColumns)\n        |> reducer()",
 									Start: ast.Position{
 										Column: 5,
-										Line:   166,
+										Line:   187,
 									},
 								},
 							},
@@ -8110,13 +9368,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 21,
-											Line:   168,
+											Line:   189,
 										},
 										File:   "universe.flux",
 										Source: "reducer()",
 										Start: ast.Position{
 											Column: 12,
-											Line:   168,
+											Line:   189,
 										},
 									},
 								},
@@ -8126,13 +9384,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 19,
-												Line:   168,
+												Line:   189,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 12,
-												Line:   168,
+												Line:   189,
 											},
 										},
 									},
@@ -8145,13 +9403,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   169,
+									Line:   190,
 								},
 								File:   "universe.flux",
 								Source: "tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])",
 								Start: ast.Position{
 									Column: 5,
-									Line:   166,
+									Line:   187,
 								},
 							},
 						},
@@ -8162,13 +9420,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 28,
-											Line:   169,
+											Line:   190,
 										},
 										File:   "universe.flux",
 										Source: "columns:[]",
 										Start: ast.Position{
 											Column: 18,
-											Line:   169,
+											Line:   190,
 										},
 									},
 								},
@@ -8178,13 +9436,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 28,
-												Line:   169,
+												Line:   190,
 											},
 											File:   "universe.flux",
 											Source: "columns:[]",
 											Start: ast.Position{
 												Column: 18,
-												Line:   169,
+												Line:   190,
 											},
 										},
 									},
@@ -8194,13 +9452,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 25,
-													Line:   169,
+													Line:   190,
 												},
 												File:   "universe.flux",
 												Source: "columns",
 												Start: ast.Position{
 													Column: 18,
-													Line:   169,
+													Line:   190,
 												},
 											},
 										},
@@ -8212,13 +9470,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 28,
-													Line:   169,
+													Line:   190,
 												},
 												File:   "universe.flux",
 												Source: "[]",
 												Start: ast.Position{
 													Column: 26,
-													Line:   169,
+													Line:   190,
 												},
 											},
 										},
@@ -8231,13 +9489,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 29,
-										Line:   169,
+										Line:   190,
 									},
 									File:   "universe.flux",
 									Source: "group(columns:[])",
 									Start: ast.Position{
 										Column: 12,
-										Line:   169,
+										Line:   190,
 									},
 								},
 							},
@@ -8247,13 +9505,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 17,
-											Line:   169,
+											Line:   190,
 										},
 										File:   "universe.flux",
 										Source: "group",
 										Start: ast.Position{
 											Column: 12,
-											Line:   169,
+											Line:   190,
 										},
 									},
 								},
@@ -8266,13 +9524,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 44,
-								Line:   170,
+								Line:   191,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> group(columns:groupColumns)\n        |> reducer()\n        |> group(columns:[])\n        |> _sortLimit(n:n, columns:columns)",
 							Start: ast.Position{
 								Column: 5,
-								Line:   166,
+								Line:   187,
 							},
 						},
 					},
@@ -8283,13 +9541,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 43,
-										Line:   170,
+										Line:   191,
 									},
 									File:   "universe.flux",
 									Source: "n:n, columns:columns",
 									Start: ast.Position{
 										Column: 23,
-										Line:   170,
+										Line:   191,
 									},
 								},
 							},
@@ -8299,13 +9557,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 26,
-											Line:   170,
+											Line:   191,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 23,
-											Line:   170,
+											Line:   191,
 										},
 									},
 								},
@@ -8315,13 +9573,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   170,
+												Line:   191,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 23,
-												Line:   170,
+												Line:   191,
 											},
 										},
 									},
@@ -8333,13 +9591,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 26,
-												Line:   170,
+												Line:   191,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 25,
-												Line:   170,
+												Line:   191,
 											},
 										},
 									},
@@ -8351,13 +9609,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 43,
-											Line:   170,
+											Line:   191,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 28,
-											Line:   170,
+											Line:   191,
 										},
 									},
 								},
@@ -8367,13 +9625,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   170,
+												Line:   191,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 28,
-												Line:   170,
+												Line:   191,
 											},
 										},
 									},
@@ -8385,13 +9643,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   170,
+												Line:   191,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 36,
-												Line:   170,
+												Line:   191,
 											},
 										},
 									},
@@ -8404,13 +9662,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 44,
-									Line:   170,
+									Line:   191,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit(n:n, columns:columns)",
 								Start: ast.Position{
 									Column: 12,
-									Line:   170,
+									Line:   191,
 								},
 							},
 						},
@@ -8420,13 +9678,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 22,
-										Line:   170,
+										Line:   191,
 									},
 									File:   "universe.flux",
 									Source: "_sortLimit",
 									Start: ast.Position{
 										Column: 12,
-										Line:   170,
+										Line:   191,
 									},
 								},
 							},
@@ -8440,13 +9698,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 21,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					},
@@ -8456,13 +9714,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 22,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 21,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8475,13 +9733,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 34,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "_sortLimit",
 							Start: ast.Position{
 								Column: 24,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					},
@@ -8491,13 +9749,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 34,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "_sortLimit",
 								Start: ast.Position{
 									Column: 24,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8510,13 +9768,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 43,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "reducer",
 							Start: ast.Position{
 								Column: 36,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					},
@@ -8526,13 +9784,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 43,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "reducer",
 								Start: ast.Position{
 									Column: 36,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8545,13 +9803,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 63,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 45,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					},
@@ -8561,13 +9819,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 52,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 45,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8579,13 +9837,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 63,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 53,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8595,13 +9853,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 62,
-										Line:   165,
+										Line:   186,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 54,
-										Line:   165,
+										Line:   186,
 									},
 								},
 							},
@@ -8614,13 +9872,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 80,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 65,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					},
@@ -8630,13 +9888,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 77,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 65,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8648,13 +9906,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 80,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 78,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8666,13 +9924,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 91,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 82,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					},
@@ -8682,13 +9940,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 88,
-									Line:   165,
+									Line:   186,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 82,
-									Line:   165,
+									Line:   186,
 								},
 							},
 						},
@@ -8699,13 +9957,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 91,
-								Line:   165,
+								Line:   186,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 89,
-								Line:   165,
+								Line:   186,
 							},
 						},
 					}},
@@ -8717,13 +9975,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   182,
+						Line:   203,
 					},
 					File:   "universe.flux",
 					Source: "highestMax = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   173,
+						Line:   194,
 					},
 				},
 			},
@@ -8733,13 +9991,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   173,
+							Line:   194,
 						},
 						File:   "universe.flux",
 						Source: "highestMax",
 						Start: ast.Position{
 							Column: 1,
-							Line:   173,
+							Line:   194,
 						},
 					},
 				},
@@ -8751,13 +10009,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   182,
+							Line:   203,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 						Start: ast.Position{
 							Column: 14,
-							Line:   173,
+							Line:   194,
 						},
 					},
 				},
@@ -8768,13 +10026,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   174,
+									Line:   195,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   174,
+									Line:   195,
 								},
 							},
 						},
@@ -8785,13 +10043,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   182,
+								Line:   203,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   174,
+								Line:   195,
 							},
 						},
 					},
@@ -8802,13 +10060,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   181,
+										Line:   202,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top",
 									Start: ast.Position{
 										Column: 17,
-										Line:   176,
+										Line:   197,
 									},
 								},
 							},
@@ -8818,13 +10076,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   176,
+											Line:   197,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   176,
+											Line:   197,
 										},
 									},
 								},
@@ -8834,13 +10092,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   176,
+												Line:   197,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   176,
+												Line:   197,
 											},
 										},
 									},
@@ -8852,13 +10110,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   176,
+												Line:   197,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   176,
+												Line:   197,
 											},
 										},
 									},
@@ -8870,13 +10128,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   177,
+											Line:   198,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   177,
+											Line:   198,
 										},
 									},
 								},
@@ -8886,13 +10144,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   177,
+												Line:   198,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   177,
+												Line:   198,
 											},
 										},
 									},
@@ -8904,13 +10162,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   177,
+												Line:   198,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   177,
+												Line:   198,
 											},
 										},
 									},
@@ -8922,13 +10180,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   178,
+											Line:   199,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   178,
+											Line:   199,
 										},
 									},
 								},
@@ -8938,13 +10196,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   178,
+												Line:   199,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   178,
+												Line:   199,
 											},
 										},
 									},
@@ -8956,13 +10214,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   178,
+												Line:   199,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   178,
+												Line:   199,
 											},
 										},
 									},
@@ -8974,13 +10232,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 73,
-											Line:   180,
+											Line:   201,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> max(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   180,
+											Line:   201,
 										},
 									},
 								},
@@ -8990,13 +10248,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   180,
+												Line:   201,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   180,
+												Line:   201,
 											},
 										},
 									},
@@ -9008,13 +10266,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 73,
-												Line:   180,
+												Line:   201,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> max(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   180,
+												Line:   201,
 											},
 										},
 									},
@@ -9025,13 +10283,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   180,
+														Line:   201,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   180,
+														Line:   201,
 													},
 												},
 											},
@@ -9042,13 +10300,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   180,
+													Line:   201,
 												},
 												File:   "universe.flux",
 												Source: "tables |> max(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   180,
+													Line:   201,
 												},
 											},
 										},
@@ -9059,13 +10317,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 72,
-															Line:   180,
+															Line:   201,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 55,
-															Line:   180,
+															Line:   201,
 														},
 													},
 												},
@@ -9075,13 +10333,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 72,
-																Line:   180,
+																Line:   201,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 55,
-																Line:   180,
+																Line:   201,
 															},
 														},
 													},
@@ -9091,13 +10349,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 61,
-																	Line:   180,
+																	Line:   201,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 55,
-																	Line:   180,
+																	Line:   201,
 																},
 															},
 														},
@@ -9110,13 +10368,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 69,
-																		Line:   180,
+																		Line:   201,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 62,
-																		Line:   180,
+																		Line:   201,
 																	},
 																},
 															},
@@ -9127,13 +10385,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 72,
-																	Line:   180,
+																	Line:   201,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 62,
-																	Line:   180,
+																	Line:   201,
 																},
 															},
 														},
@@ -9143,13 +10401,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 71,
-																		Line:   180,
+																		Line:   201,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 70,
-																		Line:   180,
+																		Line:   201,
 																	},
 																},
 															},
@@ -9163,13 +10421,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 73,
-														Line:   180,
+														Line:   201,
 													},
 													File:   "universe.flux",
 													Source: "max(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   180,
+														Line:   201,
 													},
 												},
 											},
@@ -9179,13 +10437,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 54,
-															Line:   180,
+															Line:   201,
 														},
 														File:   "universe.flux",
 														Source: "max",
 														Start: ast.Position{
 															Column: 51,
-															Line:   180,
+															Line:   201,
 														},
 													},
 												},
@@ -9199,13 +10457,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   180,
+													Line:   201,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   180,
+													Line:   201,
 												},
 											},
 										},
@@ -9215,13 +10473,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   180,
+														Line:   201,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   180,
+														Line:   201,
 													},
 												},
 											},
@@ -9232,13 +10490,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   180,
+													Line:   201,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   180,
+													Line:   201,
 												},
 											},
 										}},
@@ -9250,13 +10508,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   181,
+											Line:   202,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: top",
 										Start: ast.Position{
 											Column: 17,
-											Line:   181,
+											Line:   202,
 										},
 									},
 								},
@@ -9266,13 +10524,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   181,
+												Line:   202,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   181,
+												Line:   202,
 											},
 										},
 									},
@@ -9284,13 +10542,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   181,
+												Line:   202,
 											},
 											File:   "universe.flux",
 											Source: "top",
 											Start: ast.Position{
 												Column: 29,
-												Line:   181,
+												Line:   202,
 											},
 										},
 									},
@@ -9303,13 +10561,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   182,
+									Line:   203,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> max(column:columns[0]),\n                _sortLimit: top,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   175,
+									Line:   196,
 								},
 							},
 						},
@@ -9319,13 +10577,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   175,
+										Line:   196,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   175,
+										Line:   196,
 									},
 								},
 							},
@@ -9339,13 +10597,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 16,
-								Line:   173,
+								Line:   194,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 15,
-								Line:   173,
+								Line:   194,
 							},
 						},
 					},
@@ -9355,13 +10613,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 16,
-									Line:   173,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 15,
-									Line:   173,
+									Line:   194,
 								},
 							},
 						},
@@ -9374,13 +10632,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 36,
-								Line:   173,
+								Line:   194,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 18,
-								Line:   173,
+								Line:   194,
 							},
 						},
 					},
@@ -9390,13 +10648,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 25,
-									Line:   173,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 18,
-									Line:   173,
+									Line:   194,
 								},
 							},
 						},
@@ -9408,13 +10666,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 36,
-									Line:   173,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 26,
-									Line:   173,
+									Line:   194,
 								},
 							},
 						},
@@ -9424,13 +10682,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   173,
+										Line:   194,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 27,
-										Line:   173,
+										Line:   194,
 									},
 								},
 							},
@@ -9443,13 +10701,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 53,
-								Line:   173,
+								Line:   194,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 38,
-								Line:   173,
+								Line:   194,
 							},
 						},
 					},
@@ -9459,13 +10717,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 50,
-									Line:   173,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 38,
-									Line:   173,
+									Line:   194,
 								},
 							},
 						},
@@ -9477,13 +10735,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   173,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 51,
-									Line:   173,
+									Line:   194,
 								},
 							},
 						},
@@ -9495,13 +10753,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   173,
+								Line:   194,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 55,
-								Line:   173,
+								Line:   194,
 							},
 						},
 					},
@@ -9511,13 +10769,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 61,
-									Line:   173,
+									Line:   194,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 55,
-									Line:   173,
+									Line:   194,
 								},
 							},
 						},
@@ -9528,13 +10786,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   173,
+								Line:   194,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 62,
-								Line:   173,
+								Line:   194,
 							},
 						},
 					}},
@@ -9546,13 +10804,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   193,
+						Line:   214,
 					},
 					File:   "universe.flux",
 					Source: "highestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   185,
+						Line:   206,
 					},
 				},
 			},
@@ -9562,13 +10820,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 15,
-							Line:   185,
+							Line:   206,
 						},
 						File:   "universe.flux",
 						Source: "highestAverage",
 						Start: ast.Position{
 							Column: 1,
-							Line:   185,
+							Line:   206,
 						},
 					},
 				},
@@ -9580,13 +10838,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   193,
+							Line:   214,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 						Start: ast.Position{
 							Column: 18,
-							Line:   185,
+							Line:   206,
 						},
 					},
 				},
@@ -9597,13 +10855,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   186,
+									Line:   207,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   186,
+									Line:   207,
 								},
 							},
 						},
@@ -9614,13 +10872,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   193,
+								Line:   214,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   186,
+								Line:   207,
 							},
 						},
 					},
@@ -9631,13 +10889,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   192,
+										Line:   213,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top",
 									Start: ast.Position{
 										Column: 17,
-										Line:   188,
+										Line:   209,
 									},
 								},
 							},
@@ -9647,13 +10905,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   188,
+											Line:   209,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   188,
+											Line:   209,
 										},
 									},
 								},
@@ -9663,13 +10921,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   188,
+												Line:   209,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   188,
+												Line:   209,
 											},
 										},
 									},
@@ -9681,13 +10939,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   188,
+												Line:   209,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   188,
+												Line:   209,
 											},
 										},
 									},
@@ -9699,13 +10957,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   189,
+											Line:   210,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   189,
+											Line:   210,
 										},
 									},
 								},
@@ -9715,13 +10973,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   189,
+												Line:   210,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   189,
+												Line:   210,
 											},
 										},
 									},
@@ -9733,13 +10991,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   189,
+												Line:   210,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   189,
+												Line:   210,
 											},
 										},
 									},
@@ -9751,13 +11009,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   190,
+											Line:   211,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   190,
+											Line:   211,
 										},
 									},
 								},
@@ -9767,13 +11025,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   190,
+												Line:   211,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   190,
+												Line:   211,
 											},
 										},
 									},
@@ -9785,13 +11043,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   190,
+												Line:   211,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   190,
+												Line:   211,
 											},
 										},
 									},
@@ -9803,13 +11061,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   191,
+											Line:   212,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> mean(columns:[columns[0]])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   191,
+											Line:   212,
 										},
 									},
 								},
@@ -9819,13 +11077,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   191,
+												Line:   212,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   191,
+												Line:   212,
 											},
 										},
 									},
@@ -9837,13 +11095,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   191,
+												Line:   212,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> mean(columns:[columns[0]])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   191,
+												Line:   212,
 											},
 										},
 									},
@@ -9854,13 +11112,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   191,
+														Line:   212,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   191,
+														Line:   212,
 													},
 												},
 											},
@@ -9871,13 +11129,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 77,
-													Line:   191,
+													Line:   212,
 												},
 												File:   "universe.flux",
 												Source: "tables |> mean(columns:[columns[0]])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   191,
+													Line:   212,
 												},
 											},
 										},
@@ -9888,13 +11146,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 76,
-															Line:   191,
+															Line:   212,
 														},
 														File:   "universe.flux",
 														Source: "columns:[columns[0]]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   191,
+															Line:   212,
 														},
 													},
 												},
@@ -9904,13 +11162,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 76,
-																Line:   191,
+																Line:   212,
 															},
 															File:   "universe.flux",
 															Source: "columns:[columns[0]]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   191,
+																Line:   212,
 															},
 														},
 													},
@@ -9920,13 +11178,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 63,
-																	Line:   191,
+																	Line:   212,
 																},
 																File:   "universe.flux",
 																Source: "columns",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   191,
+																	Line:   212,
 																},
 															},
 														},
@@ -9938,13 +11196,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 76,
-																	Line:   191,
+																	Line:   212,
 																},
 																File:   "universe.flux",
 																Source: "[columns[0]]",
 																Start: ast.Position{
 																	Column: 64,
-																	Line:   191,
+																	Line:   212,
 																},
 															},
 														},
@@ -9955,13 +11213,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 72,
-																			Line:   191,
+																			Line:   212,
 																		},
 																		File:   "universe.flux",
 																		Source: "columns",
 																		Start: ast.Position{
 																			Column: 65,
-																			Line:   191,
+																			Line:   212,
 																		},
 																	},
 																},
@@ -9972,13 +11230,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 75,
-																		Line:   191,
+																		Line:   212,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns[0]",
 																	Start: ast.Position{
 																		Column: 65,
-																		Line:   191,
+																		Line:   212,
 																	},
 																},
 															},
@@ -9988,13 +11246,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 74,
-																			Line:   191,
+																			Line:   212,
 																		},
 																		File:   "universe.flux",
 																		Source: "0",
 																		Start: ast.Position{
 																			Column: 73,
-																			Line:   191,
+																			Line:   212,
 																		},
 																	},
 																},
@@ -10009,13 +11267,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 77,
-														Line:   191,
+														Line:   212,
 													},
 													File:   "universe.flux",
 													Source: "mean(columns:[columns[0]])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   191,
+														Line:   212,
 													},
 												},
 											},
@@ -10025,13 +11283,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   191,
+															Line:   212,
 														},
 														File:   "universe.flux",
 														Source: "mean",
 														Start: ast.Position{
 															Column: 51,
-															Line:   191,
+															Line:   212,
 														},
 													},
 												},
@@ -10045,13 +11303,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   191,
+													Line:   212,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   191,
+													Line:   212,
 												},
 											},
 										},
@@ -10061,13 +11319,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   191,
+														Line:   212,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   191,
+														Line:   212,
 													},
 												},
 											},
@@ -10078,13 +11336,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   191,
+													Line:   212,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   191,
+													Line:   212,
 												},
 											},
 										}},
@@ -10096,13 +11354,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   192,
+											Line:   213,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: top",
 										Start: ast.Position{
 											Column: 17,
-											Line:   192,
+											Line:   213,
 										},
 									},
 								},
@@ -10112,13 +11370,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   192,
+												Line:   213,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   192,
+												Line:   213,
 											},
 										},
 									},
@@ -10130,13 +11388,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   192,
+												Line:   213,
 											},
 											File:   "universe.flux",
 											Source: "top",
 											Start: ast.Position{
 												Column: 29,
-												Line:   192,
+												Line:   213,
 											},
 										},
 									},
@@ -10149,13 +11407,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   193,
+									Line:   214,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: top,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   187,
+									Line:   208,
 								},
 							},
 						},
@@ -10165,13 +11423,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   187,
+										Line:   208,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   187,
+										Line:   208,
 									},
 								},
 							},
@@ -10185,13 +11443,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   185,
+								Line:   206,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 19,
-								Line:   185,
+								Line:   206,
 							},
 						},
 					},
@@ -10201,13 +11459,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   185,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 19,
-									Line:   185,
+									Line:   206,
 								},
 							},
 						},
@@ -10220,13 +11478,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   185,
+								Line:   206,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 22,
-								Line:   185,
+								Line:   206,
 							},
 						},
 					},
@@ -10236,13 +11494,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   185,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 22,
-									Line:   185,
+									Line:   206,
 								},
 							},
 						},
@@ -10254,13 +11512,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 40,
-									Line:   185,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 30,
-									Line:   185,
+									Line:   206,
 								},
 							},
 						},
@@ -10270,13 +11528,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   185,
+										Line:   206,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 31,
-										Line:   185,
+										Line:   206,
 									},
 								},
 							},
@@ -10289,13 +11547,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 57,
-								Line:   185,
+								Line:   206,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 42,
-								Line:   185,
+								Line:   206,
 							},
 						},
 					},
@@ -10305,13 +11563,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 54,
-									Line:   185,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 42,
-									Line:   185,
+									Line:   206,
 								},
 							},
 						},
@@ -10323,13 +11581,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 57,
-									Line:   185,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 55,
-									Line:   185,
+									Line:   206,
 								},
 							},
 						},
@@ -10341,13 +11599,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   185,
+								Line:   206,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 59,
-								Line:   185,
+								Line:   206,
 							},
 						},
 					},
@@ -10357,13 +11615,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 65,
-									Line:   185,
+									Line:   206,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 59,
-									Line:   185,
+									Line:   206,
 								},
 							},
 						},
@@ -10374,13 +11632,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   185,
+								Line:   206,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 66,
-								Line:   185,
+								Line:   206,
 							},
 						},
 					}},
@@ -10392,13 +11650,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   204,
+						Line:   225,
 					},
 					File:   "universe.flux",
 					Source: "highestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   196,
+						Line:   217,
 					},
 				},
 			},
@@ -10408,13 +11666,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 15,
-							Line:   196,
+							Line:   217,
 						},
 						File:   "universe.flux",
 						Source: "highestCurrent",
 						Start: ast.Position{
 							Column: 1,
-							Line:   196,
+							Line:   217,
 						},
 					},
 				},
@@ -10426,13 +11684,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   204,
+							Line:   225,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 						Start: ast.Position{
 							Column: 18,
-							Line:   196,
+							Line:   217,
 						},
 					},
 				},
@@ -10443,13 +11701,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   197,
+									Line:   218,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   197,
+									Line:   218,
 								},
 							},
 						},
@@ -10460,13 +11718,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   204,
+								Line:   225,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   197,
+								Line:   218,
 							},
 						},
 					},
@@ -10477,13 +11735,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 32,
-										Line:   203,
+										Line:   224,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top",
 									Start: ast.Position{
 										Column: 17,
-										Line:   199,
+										Line:   220,
 									},
 								},
 							},
@@ -10493,13 +11751,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   199,
+											Line:   220,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   199,
+											Line:   220,
 										},
 									},
 								},
@@ -10509,13 +11767,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   199,
+												Line:   220,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   199,
+												Line:   220,
 											},
 										},
 									},
@@ -10527,13 +11785,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   199,
+												Line:   220,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   199,
+												Line:   220,
 											},
 										},
 									},
@@ -10545,13 +11803,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   200,
+											Line:   221,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   200,
+											Line:   221,
 										},
 									},
 								},
@@ -10561,13 +11819,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   200,
+												Line:   221,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   200,
+												Line:   221,
 											},
 										},
 									},
@@ -10579,13 +11837,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   200,
+												Line:   221,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   200,
+												Line:   221,
 											},
 										},
 									},
@@ -10597,13 +11855,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   201,
+											Line:   222,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   201,
+											Line:   222,
 										},
 									},
 								},
@@ -10613,13 +11871,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   201,
+												Line:   222,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   201,
+												Line:   222,
 											},
 										},
 									},
@@ -10631,13 +11889,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   201,
+												Line:   222,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   201,
+												Line:   222,
 											},
 										},
 									},
@@ -10649,13 +11907,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 74,
-											Line:   202,
+											Line:   223,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> last(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   202,
+											Line:   223,
 										},
 									},
 								},
@@ -10665,13 +11923,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   202,
+												Line:   223,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   202,
+												Line:   223,
 											},
 										},
 									},
@@ -10683,13 +11941,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 74,
-												Line:   202,
+												Line:   223,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> last(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   202,
+												Line:   223,
 											},
 										},
 									},
@@ -10700,13 +11958,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   202,
+														Line:   223,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   202,
+														Line:   223,
 													},
 												},
 											},
@@ -10717,13 +11975,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 74,
-													Line:   202,
+													Line:   223,
 												},
 												File:   "universe.flux",
 												Source: "tables |> last(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   202,
+													Line:   223,
 												},
 											},
 										},
@@ -10734,13 +11992,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 73,
-															Line:   202,
+															Line:   223,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   202,
+															Line:   223,
 														},
 													},
 												},
@@ -10750,13 +12008,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 73,
-																Line:   202,
+																Line:   223,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   202,
+																Line:   223,
 															},
 														},
 													},
@@ -10766,13 +12024,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 62,
-																	Line:   202,
+																	Line:   223,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   202,
+																	Line:   223,
 																},
 															},
 														},
@@ -10785,13 +12043,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 70,
-																		Line:   202,
+																		Line:   223,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 63,
-																		Line:   202,
+																		Line:   223,
 																	},
 																},
 															},
@@ -10802,13 +12060,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 73,
-																	Line:   202,
+																	Line:   223,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 63,
-																	Line:   202,
+																	Line:   223,
 																},
 															},
 														},
@@ -10818,13 +12076,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 72,
-																		Line:   202,
+																		Line:   223,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 71,
-																		Line:   202,
+																		Line:   223,
 																	},
 																},
 															},
@@ -10838,13 +12096,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 74,
-														Line:   202,
+														Line:   223,
 													},
 													File:   "universe.flux",
 													Source: "last(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   202,
+														Line:   223,
 													},
 												},
 											},
@@ -10854,13 +12112,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   202,
+															Line:   223,
 														},
 														File:   "universe.flux",
 														Source: "last",
 														Start: ast.Position{
 															Column: 51,
-															Line:   202,
+															Line:   223,
 														},
 													},
 												},
@@ -10874,13 +12132,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   202,
+													Line:   223,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   202,
+													Line:   223,
 												},
 											},
 										},
@@ -10890,13 +12148,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   202,
+														Line:   223,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   202,
+														Line:   223,
 													},
 												},
 											},
@@ -10907,13 +12165,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   202,
+													Line:   223,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   202,
+													Line:   223,
 												},
 											},
 										}},
@@ -10925,13 +12183,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   203,
+											Line:   224,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: top",
 										Start: ast.Position{
 											Column: 17,
-											Line:   203,
+											Line:   224,
 										},
 									},
 								},
@@ -10941,13 +12199,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   203,
+												Line:   224,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   203,
+												Line:   224,
 											},
 										},
 									},
@@ -10959,13 +12217,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   203,
+												Line:   224,
 											},
 											File:   "universe.flux",
 											Source: "top",
 											Start: ast.Position{
 												Column: 29,
-												Line:   203,
+												Line:   224,
 											},
 										},
 									},
@@ -10978,13 +12236,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   204,
+									Line:   225,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: top,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   198,
+									Line:   219,
 								},
 							},
 						},
@@ -10994,13 +12252,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   198,
+										Line:   219,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   198,
+										Line:   219,
 									},
 								},
 							},
@@ -11014,13 +12272,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   196,
+								Line:   217,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 19,
-								Line:   196,
+								Line:   217,
 							},
 						},
 					},
@@ -11030,13 +12288,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 20,
-									Line:   196,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 19,
-									Line:   196,
+									Line:   217,
 								},
 							},
 						},
@@ -11049,13 +12307,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 40,
-								Line:   196,
+								Line:   217,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 22,
-								Line:   196,
+								Line:   217,
 							},
 						},
 					},
@@ -11065,13 +12323,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 29,
-									Line:   196,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 22,
-									Line:   196,
+									Line:   217,
 								},
 							},
 						},
@@ -11083,13 +12341,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 40,
-									Line:   196,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 30,
-									Line:   196,
+									Line:   217,
 								},
 							},
 						},
@@ -11099,13 +12357,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   196,
+										Line:   217,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 31,
-										Line:   196,
+										Line:   217,
 									},
 								},
 							},
@@ -11118,13 +12376,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 57,
-								Line:   196,
+								Line:   217,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 42,
-								Line:   196,
+								Line:   217,
 							},
 						},
 					},
@@ -11134,13 +12392,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 54,
-									Line:   196,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 42,
-									Line:   196,
+									Line:   217,
 								},
 							},
 						},
@@ -11152,13 +12410,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 57,
-									Line:   196,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 55,
-									Line:   196,
+									Line:   217,
 								},
 							},
 						},
@@ -11170,13 +12428,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   196,
+								Line:   217,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 59,
-								Line:   196,
+								Line:   217,
 							},
 						},
 					},
@@ -11186,13 +12444,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 65,
-									Line:   196,
+									Line:   217,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 59,
-									Line:   196,
+									Line:   217,
 								},
 							},
 						},
@@ -11203,13 +12461,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   196,
+								Line:   217,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 66,
-								Line:   196,
+								Line:   217,
 							},
 						},
 					}},
@@ -11221,13 +12479,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   216,
+						Line:   237,
 					},
 					File:   "universe.flux",
 					Source: "lowestMin = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   207,
+						Line:   228,
 					},
 				},
 			},
@@ -11237,13 +12495,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 10,
-							Line:   207,
+							Line:   228,
 						},
 						File:   "universe.flux",
 						Source: "lowestMin",
 						Start: ast.Position{
 							Column: 1,
-							Line:   207,
+							Line:   228,
 						},
 					},
 				},
@@ -11255,13 +12513,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   216,
+							Line:   237,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 						Start: ast.Position{
 							Column: 13,
-							Line:   207,
+							Line:   228,
 						},
 					},
 				},
@@ -11272,13 +12530,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   208,
+									Line:   229,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   208,
+									Line:   229,
 								},
 							},
 						},
@@ -11289,13 +12547,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   216,
+								Line:   237,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   208,
+								Line:   229,
 							},
 						},
 					},
@@ -11306,13 +12564,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   215,
+										Line:   236,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom",
 									Start: ast.Position{
 										Column: 17,
-										Line:   210,
+										Line:   231,
 									},
 								},
 							},
@@ -11322,13 +12580,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   210,
+											Line:   231,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   210,
+											Line:   231,
 										},
 									},
 								},
@@ -11338,13 +12596,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   210,
+												Line:   231,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   210,
+												Line:   231,
 											},
 										},
 									},
@@ -11356,13 +12614,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   210,
+												Line:   231,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   210,
+												Line:   231,
 											},
 										},
 									},
@@ -11374,13 +12632,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   211,
+											Line:   232,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   211,
+											Line:   232,
 										},
 									},
 								},
@@ -11390,13 +12648,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   211,
+												Line:   232,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   211,
+												Line:   232,
 											},
 										},
 									},
@@ -11408,13 +12666,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   211,
+												Line:   232,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   211,
+												Line:   232,
 											},
 										},
 									},
@@ -11426,13 +12684,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   212,
+											Line:   233,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   212,
+											Line:   233,
 										},
 									},
 								},
@@ -11442,13 +12700,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   212,
+												Line:   233,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   212,
+												Line:   233,
 											},
 										},
 									},
@@ -11460,13 +12718,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   212,
+												Line:   233,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   212,
+												Line:   233,
 											},
 										},
 									},
@@ -11478,13 +12736,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 73,
-											Line:   214,
+											Line:   235,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> min(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   214,
+											Line:   235,
 										},
 									},
 								},
@@ -11494,13 +12752,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   214,
+												Line:   235,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   214,
+												Line:   235,
 											},
 										},
 									},
@@ -11512,13 +12770,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 73,
-												Line:   214,
+												Line:   235,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> min(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   214,
+												Line:   235,
 											},
 										},
 									},
@@ -11529,13 +12787,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   214,
+														Line:   235,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   214,
+														Line:   235,
 													},
 												},
 											},
@@ -11546,13 +12804,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   214,
+													Line:   235,
 												},
 												File:   "universe.flux",
 												Source: "tables |> min(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   214,
+													Line:   235,
 												},
 											},
 										},
@@ -11563,13 +12821,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 72,
-															Line:   214,
+															Line:   235,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 55,
-															Line:   214,
+															Line:   235,
 														},
 													},
 												},
@@ -11579,13 +12837,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 72,
-																Line:   214,
+																Line:   235,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 55,
-																Line:   214,
+																Line:   235,
 															},
 														},
 													},
@@ -11595,13 +12853,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 61,
-																	Line:   214,
+																	Line:   235,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 55,
-																	Line:   214,
+																	Line:   235,
 																},
 															},
 														},
@@ -11614,13 +12872,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 69,
-																		Line:   214,
+																		Line:   235,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 62,
-																		Line:   214,
+																		Line:   235,
 																	},
 																},
 															},
@@ -11631,13 +12889,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 72,
-																	Line:   214,
+																	Line:   235,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 62,
-																	Line:   214,
+																	Line:   235,
 																},
 															},
 														},
@@ -11647,13 +12905,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 71,
-																		Line:   214,
+																		Line:   235,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 70,
-																		Line:   214,
+																		Line:   235,
 																	},
 																},
 															},
@@ -11667,13 +12925,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 73,
-														Line:   214,
+														Line:   235,
 													},
 													File:   "universe.flux",
 													Source: "min(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   214,
+														Line:   235,
 													},
 												},
 											},
@@ -11683,13 +12941,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 54,
-															Line:   214,
+															Line:   235,
 														},
 														File:   "universe.flux",
 														Source: "min",
 														Start: ast.Position{
 															Column: 51,
-															Line:   214,
+															Line:   235,
 														},
 													},
 												},
@@ -11703,13 +12961,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   214,
+													Line:   235,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   214,
+													Line:   235,
 												},
 											},
 										},
@@ -11719,13 +12977,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   214,
+														Line:   235,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   214,
+														Line:   235,
 													},
 												},
 											},
@@ -11736,13 +12994,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   214,
+													Line:   235,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   214,
+													Line:   235,
 												},
 											},
 										}},
@@ -11754,13 +13012,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 35,
-											Line:   215,
+											Line:   236,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: bottom",
 										Start: ast.Position{
 											Column: 17,
-											Line:   215,
+											Line:   236,
 										},
 									},
 								},
@@ -11770,13 +13028,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   215,
+												Line:   236,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   215,
+												Line:   236,
 											},
 										},
 									},
@@ -11788,13 +13046,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   215,
+												Line:   236,
 											},
 											File:   "universe.flux",
 											Source: "bottom",
 											Start: ast.Position{
 												Column: 29,
-												Line:   215,
+												Line:   236,
 											},
 										},
 									},
@@ -11807,13 +13065,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   216,
+									Line:   237,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                // TODO(nathanielc): Once max/min support selecting based on multiple columns change this to pass all columns.\n                reducer: (tables=<-) => tables |> min(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   209,
+									Line:   230,
 								},
 							},
 						},
@@ -11823,13 +13081,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   209,
+										Line:   230,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   209,
+										Line:   230,
 									},
 								},
 							},
@@ -11843,13 +13101,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 15,
-								Line:   207,
+								Line:   228,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 14,
-								Line:   207,
+								Line:   228,
 							},
 						},
 					},
@@ -11859,13 +13117,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 15,
-									Line:   207,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 14,
-									Line:   207,
+									Line:   228,
 								},
 							},
 						},
@@ -11878,13 +13136,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 35,
-								Line:   207,
+								Line:   228,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 17,
-								Line:   207,
+								Line:   228,
 							},
 						},
 					},
@@ -11894,13 +13152,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 24,
-									Line:   207,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 17,
-									Line:   207,
+									Line:   228,
 								},
 							},
 						},
@@ -11912,13 +13170,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 35,
-									Line:   207,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 25,
-									Line:   207,
+									Line:   228,
 								},
 							},
 						},
@@ -11928,13 +13186,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 34,
-										Line:   207,
+										Line:   228,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 26,
-										Line:   207,
+										Line:   228,
 									},
 								},
 							},
@@ -11947,13 +13205,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 52,
-								Line:   207,
+								Line:   228,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 37,
-								Line:   207,
+								Line:   228,
 							},
 						},
 					},
@@ -11963,13 +13221,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 49,
-									Line:   207,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 37,
-									Line:   207,
+									Line:   228,
 								},
 							},
 						},
@@ -11981,13 +13239,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 52,
-									Line:   207,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 50,
-									Line:   207,
+									Line:   228,
 								},
 							},
 						},
@@ -11999,13 +13257,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 63,
-								Line:   207,
+								Line:   228,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 54,
-								Line:   207,
+								Line:   228,
 							},
 						},
 					},
@@ -12015,13 +13273,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 60,
-									Line:   207,
+									Line:   228,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 54,
-									Line:   207,
+									Line:   228,
 								},
 							},
 						},
@@ -12032,13 +13290,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 63,
-								Line:   207,
+								Line:   228,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 61,
-								Line:   207,
+								Line:   228,
 							},
 						},
 					}},
@@ -12050,13 +13308,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   227,
+						Line:   248,
 					},
 					File:   "universe.flux",
 					Source: "lowestAverage = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   219,
+						Line:   240,
 					},
 				},
 			},
@@ -12066,13 +13324,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   219,
+							Line:   240,
 						},
 						File:   "universe.flux",
 						Source: "lowestAverage",
 						Start: ast.Position{
 							Column: 1,
-							Line:   219,
+							Line:   240,
 						},
 					},
 				},
@@ -12084,13 +13342,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   227,
+							Line:   248,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 						Start: ast.Position{
 							Column: 17,
-							Line:   219,
+							Line:   240,
 						},
 					},
 				},
@@ -12101,13 +13359,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   220,
+									Line:   241,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   220,
+									Line:   241,
 								},
 							},
 						},
@@ -12118,13 +13376,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   227,
+								Line:   248,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   220,
+								Line:   241,
 							},
 						},
 					},
@@ -12135,13 +13393,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   226,
+										Line:   247,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom",
 									Start: ast.Position{
 										Column: 17,
-										Line:   222,
+										Line:   243,
 									},
 								},
 							},
@@ -12151,13 +13409,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   222,
+											Line:   243,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   222,
+											Line:   243,
 										},
 									},
 								},
@@ -12167,13 +13425,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   222,
+												Line:   243,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   222,
+												Line:   243,
 											},
 										},
 									},
@@ -12185,13 +13443,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   222,
+												Line:   243,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   222,
+												Line:   243,
 											},
 										},
 									},
@@ -12203,13 +13461,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   223,
+											Line:   244,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   223,
+											Line:   244,
 										},
 									},
 								},
@@ -12219,13 +13477,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   223,
+												Line:   244,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   223,
+												Line:   244,
 											},
 										},
 									},
@@ -12237,13 +13495,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   223,
+												Line:   244,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   223,
+												Line:   244,
 											},
 										},
 									},
@@ -12255,13 +13513,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   224,
+											Line:   245,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   224,
+											Line:   245,
 										},
 									},
 								},
@@ -12271,13 +13529,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   224,
+												Line:   245,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   224,
+												Line:   245,
 											},
 										},
 									},
@@ -12289,13 +13547,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   224,
+												Line:   245,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   224,
+												Line:   245,
 											},
 										},
 									},
@@ -12307,13 +13565,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 77,
-											Line:   225,
+											Line:   246,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> mean(columns:[columns[0]])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   225,
+											Line:   246,
 										},
 									},
 								},
@@ -12323,13 +13581,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   225,
+												Line:   246,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   225,
+												Line:   246,
 											},
 										},
 									},
@@ -12341,13 +13599,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 77,
-												Line:   225,
+												Line:   246,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> mean(columns:[columns[0]])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   225,
+												Line:   246,
 											},
 										},
 									},
@@ -12358,13 +13616,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   225,
+														Line:   246,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   225,
+														Line:   246,
 													},
 												},
 											},
@@ -12375,13 +13633,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 77,
-													Line:   225,
+													Line:   246,
 												},
 												File:   "universe.flux",
 												Source: "tables |> mean(columns:[columns[0]])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   225,
+													Line:   246,
 												},
 											},
 										},
@@ -12392,13 +13650,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 76,
-															Line:   225,
+															Line:   246,
 														},
 														File:   "universe.flux",
 														Source: "columns:[columns[0]]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   225,
+															Line:   246,
 														},
 													},
 												},
@@ -12408,13 +13666,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 76,
-																Line:   225,
+																Line:   246,
 															},
 															File:   "universe.flux",
 															Source: "columns:[columns[0]]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   225,
+																Line:   246,
 															},
 														},
 													},
@@ -12424,13 +13682,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 63,
-																	Line:   225,
+																	Line:   246,
 																},
 																File:   "universe.flux",
 																Source: "columns",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   225,
+																	Line:   246,
 																},
 															},
 														},
@@ -12442,13 +13700,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 76,
-																	Line:   225,
+																	Line:   246,
 																},
 																File:   "universe.flux",
 																Source: "[columns[0]]",
 																Start: ast.Position{
 																	Column: 64,
-																	Line:   225,
+																	Line:   246,
 																},
 															},
 														},
@@ -12459,13 +13717,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 72,
-																			Line:   225,
+																			Line:   246,
 																		},
 																		File:   "universe.flux",
 																		Source: "columns",
 																		Start: ast.Position{
 																			Column: 65,
-																			Line:   225,
+																			Line:   246,
 																		},
 																	},
 																},
@@ -12476,13 +13734,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 75,
-																		Line:   225,
+																		Line:   246,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns[0]",
 																	Start: ast.Position{
 																		Column: 65,
-																		Line:   225,
+																		Line:   246,
 																	},
 																},
 															},
@@ -12492,13 +13750,13 @@ var pkgAST = &ast.Package{
 																	Loc: &ast.SourceLocation{
 																		End: ast.Position{
 																			Column: 74,
-																			Line:   225,
+																			Line:   246,
 																		},
 																		File:   "universe.flux",
 																		Source: "0",
 																		Start: ast.Position{
 																			Column: 73,
-																			Line:   225,
+																			Line:   246,
 																		},
 																	},
 																},
@@ -12513,13 +13771,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 77,
-														Line:   225,
+														Line:   246,
 													},
 													File:   "universe.flux",
 													Source: "mean(columns:[columns[0]])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   225,
+														Line:   246,
 													},
 												},
 											},
@@ -12529,13 +13787,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   225,
+															Line:   246,
 														},
 														File:   "universe.flux",
 														Source: "mean",
 														Start: ast.Position{
 															Column: 51,
-															Line:   225,
+															Line:   246,
 														},
 													},
 												},
@@ -12549,13 +13807,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   225,
+													Line:   246,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   225,
+													Line:   246,
 												},
 											},
 										},
@@ -12565,13 +13823,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   225,
+														Line:   246,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   225,
+														Line:   246,
 													},
 												},
 											},
@@ -12582,13 +13840,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   225,
+													Line:   246,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   225,
+													Line:   246,
 												},
 											},
 										}},
@@ -12600,13 +13858,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 35,
-											Line:   226,
+											Line:   247,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: bottom",
 										Start: ast.Position{
 											Column: 17,
-											Line:   226,
+											Line:   247,
 										},
 									},
 								},
@@ -12616,13 +13874,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   226,
+												Line:   247,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   226,
+												Line:   247,
 											},
 										},
 									},
@@ -12634,13 +13892,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   226,
+												Line:   247,
 											},
 											File:   "universe.flux",
 											Source: "bottom",
 											Start: ast.Position{
 												Column: 29,
-												Line:   226,
+												Line:   247,
 											},
 										},
 									},
@@ -12653,13 +13911,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   227,
+									Line:   248,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> mean(columns:[columns[0]]),\n                _sortLimit: bottom,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   221,
+									Line:   242,
 								},
 							},
 						},
@@ -12669,13 +13927,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   221,
+										Line:   242,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   221,
+										Line:   242,
 									},
 								},
 							},
@@ -12689,13 +13947,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   219,
+								Line:   240,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 18,
-								Line:   219,
+								Line:   240,
 							},
 						},
 					},
@@ -12705,13 +13963,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   219,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 18,
-									Line:   219,
+									Line:   240,
 								},
 							},
 						},
@@ -12724,13 +13982,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 39,
-								Line:   219,
+								Line:   240,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 21,
-								Line:   219,
+								Line:   240,
 							},
 						},
 					},
@@ -12740,13 +13998,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 28,
-									Line:   219,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 21,
-									Line:   219,
+									Line:   240,
 								},
 							},
 						},
@@ -12758,13 +14016,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 39,
-									Line:   219,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 29,
-									Line:   219,
+									Line:   240,
 								},
 							},
 						},
@@ -12774,13 +14032,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   219,
+										Line:   240,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 30,
-										Line:   219,
+										Line:   240,
 									},
 								},
 							},
@@ -12793,13 +14051,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 56,
-								Line:   219,
+								Line:   240,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 41,
-								Line:   219,
+								Line:   240,
 							},
 						},
 					},
@@ -12809,13 +14067,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   219,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 41,
-									Line:   219,
+									Line:   240,
 								},
 							},
 						},
@@ -12827,13 +14085,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   219,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 54,
-									Line:   219,
+									Line:   240,
 								},
 							},
 						},
@@ -12845,13 +14103,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   219,
+								Line:   240,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 58,
-								Line:   219,
+								Line:   240,
 							},
 						},
 					},
@@ -12861,13 +14119,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   219,
+									Line:   240,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 58,
-									Line:   219,
+									Line:   240,
 								},
 							},
 						},
@@ -12878,13 +14136,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   219,
+								Line:   240,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 65,
-								Line:   219,
+								Line:   240,
 							},
 						},
 					}},
@@ -12896,13 +14154,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 14,
-						Line:   238,
+						Line:   259,
 					},
 					File:   "universe.flux",
 					Source: "lowestCurrent = (n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 					Start: ast.Position{
 						Column: 1,
-						Line:   230,
+						Line:   251,
 					},
 				},
 			},
@@ -12912,13 +14170,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   230,
+							Line:   251,
 						},
 						File:   "universe.flux",
 						Source: "lowestCurrent",
 						Start: ast.Position{
 							Column: 1,
-							Line:   230,
+							Line:   251,
 						},
 					},
 				},
@@ -12930,13 +14188,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 14,
-							Line:   238,
+							Line:   259,
 						},
 						File:   "universe.flux",
 						Source: "(n, columns=[\"_value\"], groupColumns=[], tables=<-) =>\n    tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 						Start: ast.Position{
 							Column: 17,
-							Line:   230,
+							Line:   251,
 						},
 					},
 				},
@@ -12947,13 +14205,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 11,
-									Line:   231,
+									Line:   252,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 5,
-									Line:   231,
+									Line:   252,
 								},
 							},
 						},
@@ -12964,13 +14222,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 14,
-								Line:   238,
+								Line:   259,
 							},
 							File:   "universe.flux",
 							Source: "tables\n        |> _highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 							Start: ast.Position{
 								Column: 5,
-								Line:   231,
+								Line:   252,
 							},
 						},
 					},
@@ -12981,13 +14239,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 35,
-										Line:   237,
+										Line:   258,
 									},
 									File:   "universe.flux",
 									Source: "n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom",
 									Start: ast.Position{
 										Column: 17,
-										Line:   233,
+										Line:   254,
 									},
 								},
 							},
@@ -12997,13 +14255,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 20,
-											Line:   233,
+											Line:   254,
 										},
 										File:   "universe.flux",
 										Source: "n:n",
 										Start: ast.Position{
 											Column: 17,
-											Line:   233,
+											Line:   254,
 										},
 									},
 								},
@@ -13013,13 +14271,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 18,
-												Line:   233,
+												Line:   254,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 17,
-												Line:   233,
+												Line:   254,
 											},
 										},
 									},
@@ -13031,13 +14289,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 20,
-												Line:   233,
+												Line:   254,
 											},
 											File:   "universe.flux",
 											Source: "n",
 											Start: ast.Position{
 												Column: 19,
-												Line:   233,
+												Line:   254,
 											},
 										},
 									},
@@ -13049,13 +14307,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 32,
-											Line:   234,
+											Line:   255,
 										},
 										File:   "universe.flux",
 										Source: "columns:columns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   234,
+											Line:   255,
 										},
 									},
 								},
@@ -13065,13 +14323,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   234,
+												Line:   255,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   234,
+												Line:   255,
 											},
 										},
 									},
@@ -13083,13 +14341,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 32,
-												Line:   234,
+												Line:   255,
 											},
 											File:   "universe.flux",
 											Source: "columns",
 											Start: ast.Position{
 												Column: 25,
-												Line:   234,
+												Line:   255,
 											},
 										},
 									},
@@ -13101,13 +14359,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 42,
-											Line:   235,
+											Line:   256,
 										},
 										File:   "universe.flux",
 										Source: "groupColumns:groupColumns",
 										Start: ast.Position{
 											Column: 17,
-											Line:   235,
+											Line:   256,
 										},
 									},
 								},
@@ -13117,13 +14375,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 29,
-												Line:   235,
+												Line:   256,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 17,
-												Line:   235,
+												Line:   256,
 											},
 										},
 									},
@@ -13135,13 +14393,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   235,
+												Line:   256,
 											},
 											File:   "universe.flux",
 											Source: "groupColumns",
 											Start: ast.Position{
 												Column: 30,
-												Line:   235,
+												Line:   256,
 											},
 										},
 									},
@@ -13153,13 +14411,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 74,
-											Line:   236,
+											Line:   257,
 										},
 										File:   "universe.flux",
 										Source: "reducer: (tables=<-) => tables |> last(column:columns[0])",
 										Start: ast.Position{
 											Column: 17,
-											Line:   236,
+											Line:   257,
 										},
 									},
 								},
@@ -13169,13 +14427,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 24,
-												Line:   236,
+												Line:   257,
 											},
 											File:   "universe.flux",
 											Source: "reducer",
 											Start: ast.Position{
 												Column: 17,
-												Line:   236,
+												Line:   257,
 											},
 										},
 									},
@@ -13187,13 +14445,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 74,
-												Line:   236,
+												Line:   257,
 											},
 											File:   "universe.flux",
 											Source: "(tables=<-) => tables |> last(column:columns[0])",
 											Start: ast.Position{
 												Column: 26,
-												Line:   236,
+												Line:   257,
 											},
 										},
 									},
@@ -13204,13 +14462,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 47,
-														Line:   236,
+														Line:   257,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 41,
-														Line:   236,
+														Line:   257,
 													},
 												},
 											},
@@ -13221,13 +14479,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 74,
-													Line:   236,
+													Line:   257,
 												},
 												File:   "universe.flux",
 												Source: "tables |> last(column:columns[0])",
 												Start: ast.Position{
 													Column: 41,
-													Line:   236,
+													Line:   257,
 												},
 											},
 										},
@@ -13238,13 +14496,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 73,
-															Line:   236,
+															Line:   257,
 														},
 														File:   "universe.flux",
 														Source: "column:columns[0]",
 														Start: ast.Position{
 															Column: 56,
-															Line:   236,
+															Line:   257,
 														},
 													},
 												},
@@ -13254,13 +14512,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 73,
-																Line:   236,
+																Line:   257,
 															},
 															File:   "universe.flux",
 															Source: "column:columns[0]",
 															Start: ast.Position{
 																Column: 56,
-																Line:   236,
+																Line:   257,
 															},
 														},
 													},
@@ -13270,13 +14528,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 62,
-																	Line:   236,
+																	Line:   257,
 																},
 																File:   "universe.flux",
 																Source: "column",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   236,
+																	Line:   257,
 																},
 															},
 														},
@@ -13289,13 +14547,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 70,
-																		Line:   236,
+																		Line:   257,
 																	},
 																	File:   "universe.flux",
 																	Source: "columns",
 																	Start: ast.Position{
 																		Column: 63,
-																		Line:   236,
+																		Line:   257,
 																	},
 																},
 															},
@@ -13306,13 +14564,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 73,
-																	Line:   236,
+																	Line:   257,
 																},
 																File:   "universe.flux",
 																Source: "columns[0]",
 																Start: ast.Position{
 																	Column: 63,
-																	Line:   236,
+																	Line:   257,
 																},
 															},
 														},
@@ -13322,13 +14580,13 @@ var pkgAST = &ast.Package{
 																Loc: &ast.SourceLocation{
 																	End: ast.Position{
 																		Column: 72,
-																		Line:   236,
+																		Line:   257,
 																	},
 																	File:   "universe.flux",
 																	Source: "0",
 																	Start: ast.Position{
 																		Column: 71,
-																		Line:   236,
+																		Line:   257,
 																	},
 																},
 															},
@@ -13342,13 +14600,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 74,
-														Line:   236,
+														Line:   257,
 													},
 													File:   "universe.flux",
 													Source: "last(column:columns[0])",
 													Start: ast.Position{
 														Column: 51,
-														Line:   236,
+														Line:   257,
 													},
 												},
 											},
@@ -13358,13 +14616,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 55,
-															Line:   236,
+															Line:   257,
 														},
 														File:   "universe.flux",
 														Source: "last",
 														Start: ast.Position{
 															Column: 51,
-															Line:   236,
+															Line:   257,
 														},
 													},
 												},
@@ -13378,13 +14636,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   236,
+													Line:   257,
 												},
 												File:   "universe.flux",
 												Source: "tables=<-",
 												Start: ast.Position{
 													Column: 27,
-													Line:   236,
+													Line:   257,
 												},
 											},
 										},
@@ -13394,13 +14652,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 33,
-														Line:   236,
+														Line:   257,
 													},
 													File:   "universe.flux",
 													Source: "tables",
 													Start: ast.Position{
 														Column: 27,
-														Line:   236,
+														Line:   257,
 													},
 												},
 											},
@@ -13411,13 +14669,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 36,
-													Line:   236,
+													Line:   257,
 												},
 												File:   "universe.flux",
 												Source: "<-",
 												Start: ast.Position{
 													Column: 34,
-													Line:   236,
+													Line:   257,
 												},
 											},
 										}},
@@ -13429,13 +14687,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 35,
-											Line:   237,
+											Line:   258,
 										},
 										File:   "universe.flux",
 										Source: "_sortLimit: bottom",
 										Start: ast.Position{
 											Column: 17,
-											Line:   237,
+											Line:   258,
 										},
 									},
 								},
@@ -13445,13 +14703,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 27,
-												Line:   237,
+												Line:   258,
 											},
 											File:   "universe.flux",
 											Source: "_sortLimit",
 											Start: ast.Position{
 												Column: 17,
-												Line:   237,
+												Line:   258,
 											},
 										},
 									},
@@ -13463,13 +14721,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 35,
-												Line:   237,
+												Line:   258,
 											},
 											File:   "universe.flux",
 											Source: "bottom",
 											Start: ast.Position{
 												Column: 29,
-												Line:   237,
+												Line:   258,
 											},
 										},
 									},
@@ -13482,13 +14740,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 14,
-									Line:   238,
+									Line:   259,
 								},
 								File:   "universe.flux",
 								Source: "_highestOrLowest(\n                n:n,\n                columns:columns,\n                groupColumns:groupColumns,\n                reducer: (tables=<-) => tables |> last(column:columns[0]),\n                _sortLimit: bottom,\n            )",
 								Start: ast.Position{
 									Column: 12,
-									Line:   232,
+									Line:   253,
 								},
 							},
 						},
@@ -13498,13 +14756,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 28,
-										Line:   232,
+										Line:   253,
 									},
 									File:   "universe.flux",
 									Source: "_highestOrLowest",
 									Start: ast.Position{
 										Column: 12,
-										Line:   232,
+										Line:   253,
 									},
 								},
 							},
@@ -13518,13 +14776,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   230,
+								Line:   251,
 							},
 							File:   "universe.flux",
 							Source: "n",
 							Start: ast.Position{
 								Column: 18,
-								Line:   230,
+								Line:   251,
 							},
 						},
 					},
@@ -13534,13 +14792,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   230,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "n",
 								Start: ast.Position{
 									Column: 18,
-									Line:   230,
+									Line:   251,
 								},
 							},
 						},
@@ -13553,13 +14811,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 39,
-								Line:   230,
+								Line:   251,
 							},
 							File:   "universe.flux",
 							Source: "columns=[\"_value\"]",
 							Start: ast.Position{
 								Column: 21,
-								Line:   230,
+								Line:   251,
 							},
 						},
 					},
@@ -13569,13 +14827,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 28,
-									Line:   230,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "columns",
 								Start: ast.Position{
 									Column: 21,
-									Line:   230,
+									Line:   251,
 								},
 							},
 						},
@@ -13587,13 +14845,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 39,
-									Line:   230,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "[\"_value\"]",
 								Start: ast.Position{
 									Column: 29,
-									Line:   230,
+									Line:   251,
 								},
 							},
 						},
@@ -13603,13 +14861,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   230,
+										Line:   251,
 									},
 									File:   "universe.flux",
 									Source: "\"_value\"",
 									Start: ast.Position{
 										Column: 30,
-										Line:   230,
+										Line:   251,
 									},
 								},
 							},
@@ -13622,13 +14880,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 56,
-								Line:   230,
+								Line:   251,
 							},
 							File:   "universe.flux",
 							Source: "groupColumns=[]",
 							Start: ast.Position{
 								Column: 41,
-								Line:   230,
+								Line:   251,
 							},
 						},
 					},
@@ -13638,13 +14896,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 53,
-									Line:   230,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "groupColumns",
 								Start: ast.Position{
 									Column: 41,
-									Line:   230,
+									Line:   251,
 								},
 							},
 						},
@@ -13656,13 +14914,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 56,
-									Line:   230,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "[]",
 								Start: ast.Position{
 									Column: 54,
-									Line:   230,
+									Line:   251,
 								},
 							},
 						},
@@ -13674,13 +14932,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   230,
+								Line:   251,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 58,
-								Line:   230,
+								Line:   251,
 							},
 						},
 					},
@@ -13690,13 +14948,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   230,
+									Line:   251,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 58,
-									Line:   230,
+									Line:   251,
 								},
 							},
 						},
@@ -13707,13 +14965,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 67,
-								Line:   230,
+								Line:   251,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 65,
-								Line:   230,
+								Line:   251,
 							},
 						},
 					}},
@@ -13725,13 +14983,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 70,
-						Line:   240,
+						Line:   261,
 					},
 					File:   "universe.flux",
 					Source: "toString = (tables=<-) => tables |> map(fn:(r) => string(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   240,
+						Line:   261,
 					},
 				},
 			},
@@ -13741,13 +14999,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 9,
-							Line:   240,
+							Line:   261,
 						},
 						File:   "universe.flux",
 						Source: "toString",
 						Start: ast.Position{
 							Column: 1,
-							Line:   240,
+							Line:   261,
 						},
 					},
 				},
@@ -13759,13 +15017,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 70,
-							Line:   240,
+							Line:   261,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => string(v:r._value))",
 						Start: ast.Position{
 							Column: 12,
-							Line:   240,
+							Line:   261,
 						},
 					},
 				},
@@ -13776,13 +15034,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 33,
-									Line:   240,
+									Line:   261,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 27,
-									Line:   240,
+									Line:   261,
 								},
 							},
 						},
@@ -13793,13 +15051,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 70,
-								Line:   240,
+								Line:   261,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => string(v:r._value))",
 							Start: ast.Position{
 								Column: 27,
-								Line:   240,
+								Line:   261,
 							},
 						},
 					},
@@ -13810,13 +15068,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 69,
-										Line:   240,
+										Line:   261,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => string(v:r._value)",
 									Start: ast.Position{
 										Column: 41,
-										Line:   240,
+										Line:   261,
 									},
 								},
 							},
@@ -13826,13 +15084,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 69,
-											Line:   240,
+											Line:   261,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => string(v:r._value)",
 										Start: ast.Position{
 											Column: 41,
-											Line:   240,
+											Line:   261,
 										},
 									},
 								},
@@ -13842,13 +15100,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 43,
-												Line:   240,
+												Line:   261,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 41,
-												Line:   240,
+												Line:   261,
 											},
 										},
 									},
@@ -13860,13 +15118,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 69,
-												Line:   240,
+												Line:   261,
 											},
 											File:   "universe.flux",
 											Source: "(r) => string(v:r._value)",
 											Start: ast.Position{
 												Column: 44,
-												Line:   240,
+												Line:   261,
 											},
 										},
 									},
@@ -13877,13 +15135,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 68,
-														Line:   240,
+														Line:   261,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 58,
-														Line:   240,
+														Line:   261,
 													},
 												},
 											},
@@ -13893,13 +15151,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 68,
-															Line:   240,
+															Line:   261,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 58,
-															Line:   240,
+															Line:   261,
 														},
 													},
 												},
@@ -13909,13 +15167,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 59,
-																Line:   240,
+																Line:   261,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 58,
-																Line:   240,
+																Line:   261,
 															},
 														},
 													},
@@ -13927,13 +15185,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 68,
-																Line:   240,
+																Line:   261,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 60,
-																Line:   240,
+																Line:   261,
 															},
 														},
 													},
@@ -13943,13 +15201,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 61,
-																	Line:   240,
+																	Line:   261,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 60,
-																	Line:   240,
+																	Line:   261,
 																},
 															},
 														},
@@ -13961,13 +15219,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 68,
-																	Line:   240,
+																	Line:   261,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 62,
-																	Line:   240,
+																	Line:   261,
 																},
 															},
 														},
@@ -13981,13 +15239,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 69,
-													Line:   240,
+													Line:   261,
 												},
 												File:   "universe.flux",
 												Source: "string(v:r._value)",
 												Start: ast.Position{
 													Column: 51,
-													Line:   240,
+													Line:   261,
 												},
 											},
 										},
@@ -13997,13 +15255,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 57,
-														Line:   240,
+														Line:   261,
 													},
 													File:   "universe.flux",
 													Source: "string",
 													Start: ast.Position{
 														Column: 51,
-														Line:   240,
+														Line:   261,
 													},
 												},
 											},
@@ -14016,13 +15274,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 46,
-													Line:   240,
+													Line:   261,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 45,
-													Line:   240,
+													Line:   261,
 												},
 											},
 										},
@@ -14032,13 +15290,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 46,
-														Line:   240,
+														Line:   261,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 45,
-														Line:   240,
+														Line:   261,
 													},
 												},
 											},
@@ -14054,13 +15312,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 70,
-									Line:   240,
+									Line:   261,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => string(v:r._value))",
 								Start: ast.Position{
 									Column: 37,
-									Line:   240,
+									Line:   261,
 								},
 							},
 						},
@@ -14070,13 +15328,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 40,
-										Line:   240,
+										Line:   261,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 37,
-										Line:   240,
+										Line:   261,
 									},
 								},
 							},
@@ -14090,13 +15348,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   240,
+								Line:   261,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 13,
-								Line:   240,
+								Line:   261,
 							},
 						},
 					},
@@ -14106,13 +15364,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 19,
-									Line:   240,
+									Line:   261,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 13,
-									Line:   240,
+									Line:   261,
 								},
 							},
 						},
@@ -14123,13 +15381,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 22,
-								Line:   240,
+								Line:   261,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 20,
-								Line:   240,
+								Line:   261,
 							},
 						},
 					}},
@@ -14141,13 +15399,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 64,
-						Line:   241,
+						Line:   262,
 					},
 					File:   "universe.flux",
 					Source: "toInt = (tables=<-) => tables |> map(fn:(r) => int(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   241,
+						Line:   262,
 					},
 				},
 			},
@@ -14157,13 +15415,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 6,
-							Line:   241,
+							Line:   262,
 						},
 						File:   "universe.flux",
 						Source: "toInt",
 						Start: ast.Position{
 							Column: 1,
-							Line:   241,
+							Line:   262,
 						},
 					},
 				},
@@ -14175,13 +15433,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 64,
-							Line:   241,
+							Line:   262,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => int(v:r._value))",
 						Start: ast.Position{
 							Column: 9,
-							Line:   241,
+							Line:   262,
 						},
 					},
 				},
@@ -14192,13 +15450,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 30,
-									Line:   241,
+									Line:   262,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 24,
-									Line:   241,
+									Line:   262,
 								},
 							},
 						},
@@ -14209,13 +15467,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 64,
-								Line:   241,
+								Line:   262,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => int(v:r._value))",
 							Start: ast.Position{
 								Column: 24,
-								Line:   241,
+								Line:   262,
 							},
 						},
 					},
@@ -14226,13 +15484,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 63,
-										Line:   241,
+										Line:   262,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => int(v:r._value)",
 									Start: ast.Position{
 										Column: 38,
-										Line:   241,
+										Line:   262,
 									},
 								},
 							},
@@ -14242,13 +15500,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 63,
-											Line:   241,
+											Line:   262,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => int(v:r._value)",
 										Start: ast.Position{
 											Column: 38,
-											Line:   241,
+											Line:   262,
 										},
 									},
 								},
@@ -14258,13 +15516,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 40,
-												Line:   241,
+												Line:   262,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 38,
-												Line:   241,
+												Line:   262,
 											},
 										},
 									},
@@ -14276,13 +15534,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 63,
-												Line:   241,
+												Line:   262,
 											},
 											File:   "universe.flux",
 											Source: "(r) => int(v:r._value)",
 											Start: ast.Position{
 												Column: 41,
-												Line:   241,
+												Line:   262,
 											},
 										},
 									},
@@ -14293,13 +15551,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 62,
-														Line:   241,
+														Line:   262,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 52,
-														Line:   241,
+														Line:   262,
 													},
 												},
 											},
@@ -14309,13 +15567,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 62,
-															Line:   241,
+															Line:   262,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 52,
-															Line:   241,
+															Line:   262,
 														},
 													},
 												},
@@ -14325,13 +15583,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 53,
-																Line:   241,
+																Line:   262,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 52,
-																Line:   241,
+																Line:   262,
 															},
 														},
 													},
@@ -14343,13 +15601,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 62,
-																Line:   241,
+																Line:   262,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 54,
-																Line:   241,
+																Line:   262,
 															},
 														},
 													},
@@ -14359,13 +15617,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 55,
-																	Line:   241,
+																	Line:   262,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 54,
-																	Line:   241,
+																	Line:   262,
 																},
 															},
 														},
@@ -14377,13 +15635,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 62,
-																	Line:   241,
+																	Line:   262,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   241,
+																	Line:   262,
 																},
 															},
 														},
@@ -14397,13 +15655,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 63,
-													Line:   241,
+													Line:   262,
 												},
 												File:   "universe.flux",
 												Source: "int(v:r._value)",
 												Start: ast.Position{
 													Column: 48,
-													Line:   241,
+													Line:   262,
 												},
 											},
 										},
@@ -14413,13 +15671,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 51,
-														Line:   241,
+														Line:   262,
 													},
 													File:   "universe.flux",
 													Source: "int",
 													Start: ast.Position{
 														Column: 48,
-														Line:   241,
+														Line:   262,
 													},
 												},
 											},
@@ -14432,13 +15690,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 43,
-													Line:   241,
+													Line:   262,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 42,
-													Line:   241,
+													Line:   262,
 												},
 											},
 										},
@@ -14448,13 +15706,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 43,
-														Line:   241,
+														Line:   262,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 42,
-														Line:   241,
+														Line:   262,
 													},
 												},
 											},
@@ -14470,13 +15728,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 64,
-									Line:   241,
+									Line:   262,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => int(v:r._value))",
 								Start: ast.Position{
 									Column: 34,
-									Line:   241,
+									Line:   262,
 								},
 							},
 						},
@@ -14486,13 +15744,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 37,
-										Line:   241,
+										Line:   262,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 34,
-										Line:   241,
+										Line:   262,
 									},
 								},
 							},
@@ -14506,13 +15764,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   241,
+								Line:   262,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 10,
-								Line:   241,
+								Line:   262,
 							},
 						},
 					},
@@ -14522,13 +15780,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 16,
-									Line:   241,
+									Line:   262,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 10,
-									Line:   241,
+									Line:   262,
 								},
 							},
 						},
@@ -14539,13 +15797,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 19,
-								Line:   241,
+								Line:   262,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 17,
-								Line:   241,
+								Line:   262,
 							},
 						},
 					}},
@@ -14557,13 +15815,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 66,
-						Line:   242,
+						Line:   263,
 					},
 					File:   "universe.flux",
 					Source: "toUInt = (tables=<-) => tables |> map(fn:(r) => uint(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   242,
+						Line:   263,
 					},
 				},
 			},
@@ -14573,13 +15831,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   242,
+							Line:   263,
 						},
 						File:   "universe.flux",
 						Source: "toUInt",
 						Start: ast.Position{
 							Column: 1,
-							Line:   242,
+							Line:   263,
 						},
 					},
 				},
@@ -14591,13 +15849,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 66,
-							Line:   242,
+							Line:   263,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => uint(v:r._value))",
 						Start: ast.Position{
 							Column: 10,
-							Line:   242,
+							Line:   263,
 						},
 					},
 				},
@@ -14608,13 +15866,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   242,
+									Line:   263,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 25,
-									Line:   242,
+									Line:   263,
 								},
 							},
 						},
@@ -14625,13 +15883,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 66,
-								Line:   242,
+								Line:   263,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => uint(v:r._value))",
 							Start: ast.Position{
 								Column: 25,
-								Line:   242,
+								Line:   263,
 							},
 						},
 					},
@@ -14642,13 +15900,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 65,
-										Line:   242,
+										Line:   263,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => uint(v:r._value)",
 									Start: ast.Position{
 										Column: 39,
-										Line:   242,
+										Line:   263,
 									},
 								},
 							},
@@ -14658,13 +15916,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 65,
-											Line:   242,
+											Line:   263,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => uint(v:r._value)",
 										Start: ast.Position{
 											Column: 39,
-											Line:   242,
+											Line:   263,
 										},
 									},
 								},
@@ -14674,13 +15932,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 41,
-												Line:   242,
+												Line:   263,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 39,
-												Line:   242,
+												Line:   263,
 											},
 										},
 									},
@@ -14692,13 +15950,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   242,
+												Line:   263,
 											},
 											File:   "universe.flux",
 											Source: "(r) => uint(v:r._value)",
 											Start: ast.Position{
 												Column: 42,
-												Line:   242,
+												Line:   263,
 											},
 										},
 									},
@@ -14709,13 +15967,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 64,
-														Line:   242,
+														Line:   263,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 54,
-														Line:   242,
+														Line:   263,
 													},
 												},
 											},
@@ -14725,13 +15983,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 64,
-															Line:   242,
+															Line:   263,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 54,
-															Line:   242,
+															Line:   263,
 														},
 													},
 												},
@@ -14741,13 +15999,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 55,
-																Line:   242,
+																Line:   263,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 54,
-																Line:   242,
+																Line:   263,
 															},
 														},
 													},
@@ -14759,13 +16017,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 64,
-																Line:   242,
+																Line:   263,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 56,
-																Line:   242,
+																Line:   263,
 															},
 														},
 													},
@@ -14775,13 +16033,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 57,
-																	Line:   242,
+																	Line:   263,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   242,
+																	Line:   263,
 																},
 															},
 														},
@@ -14793,13 +16051,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 64,
-																	Line:   242,
+																	Line:   263,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   242,
+																	Line:   263,
 																},
 															},
 														},
@@ -14813,13 +16071,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 65,
-													Line:   242,
+													Line:   263,
 												},
 												File:   "universe.flux",
 												Source: "uint(v:r._value)",
 												Start: ast.Position{
 													Column: 49,
-													Line:   242,
+													Line:   263,
 												},
 											},
 										},
@@ -14829,13 +16087,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 53,
-														Line:   242,
+														Line:   263,
 													},
 													File:   "universe.flux",
 													Source: "uint",
 													Start: ast.Position{
 														Column: 49,
-														Line:   242,
+														Line:   263,
 													},
 												},
 											},
@@ -14848,13 +16106,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 44,
-													Line:   242,
+													Line:   263,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 43,
-													Line:   242,
+													Line:   263,
 												},
 											},
 										},
@@ -14864,13 +16122,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 44,
-														Line:   242,
+														Line:   263,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 43,
-														Line:   242,
+														Line:   263,
 													},
 												},
 											},
@@ -14886,13 +16144,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 66,
-									Line:   242,
+									Line:   263,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => uint(v:r._value))",
 								Start: ast.Position{
 									Column: 35,
-									Line:   242,
+									Line:   263,
 								},
 							},
 						},
@@ -14902,13 +16160,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   242,
+										Line:   263,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 35,
-										Line:   242,
+										Line:   263,
 									},
 								},
 							},
@@ -14922,13 +16180,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   242,
+								Line:   263,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 11,
-								Line:   242,
+								Line:   263,
 							},
 						},
 					},
@@ -14938,13 +16196,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   242,
+									Line:   263,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 11,
-									Line:   242,
+									Line:   263,
 								},
 							},
 						},
@@ -14955,13 +16213,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   242,
+								Line:   263,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 18,
-								Line:   242,
+								Line:   263,
 							},
 						},
 					}},
@@ -14973,13 +16231,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 68,
-						Line:   243,
+						Line:   264,
 					},
 					File:   "universe.flux",
 					Source: "toFloat = (tables=<-) => tables |> map(fn:(r) => float(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   243,
+						Line:   264,
 					},
 				},
 			},
@@ -14989,13 +16247,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 8,
-							Line:   243,
+							Line:   264,
 						},
 						File:   "universe.flux",
 						Source: "toFloat",
 						Start: ast.Position{
 							Column: 1,
-							Line:   243,
+							Line:   264,
 						},
 					},
 				},
@@ -15007,13 +16265,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 68,
-							Line:   243,
+							Line:   264,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => float(v:r._value))",
 						Start: ast.Position{
 							Column: 11,
-							Line:   243,
+							Line:   264,
 						},
 					},
 				},
@@ -15024,13 +16282,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 32,
-									Line:   243,
+									Line:   264,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 26,
-									Line:   243,
+									Line:   264,
 								},
 							},
 						},
@@ -15041,13 +16299,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 68,
-								Line:   243,
+								Line:   264,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => float(v:r._value))",
 							Start: ast.Position{
 								Column: 26,
-								Line:   243,
+								Line:   264,
 							},
 						},
 					},
@@ -15058,13 +16316,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 67,
-										Line:   243,
+										Line:   264,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => float(v:r._value)",
 									Start: ast.Position{
 										Column: 40,
-										Line:   243,
+										Line:   264,
 									},
 								},
 							},
@@ -15074,13 +16332,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 67,
-											Line:   243,
+											Line:   264,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => float(v:r._value)",
 										Start: ast.Position{
 											Column: 40,
-											Line:   243,
+											Line:   264,
 										},
 									},
 								},
@@ -15090,13 +16348,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 42,
-												Line:   243,
+												Line:   264,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 40,
-												Line:   243,
+												Line:   264,
 											},
 										},
 									},
@@ -15108,13 +16366,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 67,
-												Line:   243,
+												Line:   264,
 											},
 											File:   "universe.flux",
 											Source: "(r) => float(v:r._value)",
 											Start: ast.Position{
 												Column: 43,
-												Line:   243,
+												Line:   264,
 											},
 										},
 									},
@@ -15125,13 +16383,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 66,
-														Line:   243,
+														Line:   264,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 56,
-														Line:   243,
+														Line:   264,
 													},
 												},
 											},
@@ -15141,13 +16399,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 66,
-															Line:   243,
+															Line:   264,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 56,
-															Line:   243,
+															Line:   264,
 														},
 													},
 												},
@@ -15157,13 +16415,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 57,
-																Line:   243,
+																Line:   264,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 56,
-																Line:   243,
+																Line:   264,
 															},
 														},
 													},
@@ -15175,13 +16433,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 66,
-																Line:   243,
+																Line:   264,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 58,
-																Line:   243,
+																Line:   264,
 															},
 														},
 													},
@@ -15191,13 +16449,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 59,
-																	Line:   243,
+																	Line:   264,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   243,
+																	Line:   264,
 																},
 															},
 														},
@@ -15209,13 +16467,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 66,
-																	Line:   243,
+																	Line:   264,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 60,
-																	Line:   243,
+																	Line:   264,
 																},
 															},
 														},
@@ -15229,13 +16487,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 67,
-													Line:   243,
+													Line:   264,
 												},
 												File:   "universe.flux",
 												Source: "float(v:r._value)",
 												Start: ast.Position{
 													Column: 50,
-													Line:   243,
+													Line:   264,
 												},
 											},
 										},
@@ -15245,13 +16503,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 55,
-														Line:   243,
+														Line:   264,
 													},
 													File:   "universe.flux",
 													Source: "float",
 													Start: ast.Position{
 														Column: 50,
-														Line:   243,
+														Line:   264,
 													},
 												},
 											},
@@ -15264,13 +16522,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 45,
-													Line:   243,
+													Line:   264,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 44,
-													Line:   243,
+													Line:   264,
 												},
 											},
 										},
@@ -15280,13 +16538,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 45,
-														Line:   243,
+														Line:   264,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 44,
-														Line:   243,
+														Line:   264,
 													},
 												},
 											},
@@ -15302,13 +16560,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 68,
-									Line:   243,
+									Line:   264,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => float(v:r._value))",
 								Start: ast.Position{
 									Column: 36,
-									Line:   243,
+									Line:   264,
 								},
 							},
 						},
@@ -15318,13 +16576,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 39,
-										Line:   243,
+										Line:   264,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 36,
-										Line:   243,
+										Line:   264,
 									},
 								},
 							},
@@ -15338,13 +16596,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 21,
-								Line:   243,
+								Line:   264,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 12,
-								Line:   243,
+								Line:   264,
 							},
 						},
 					},
@@ -15354,13 +16612,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 18,
-									Line:   243,
+									Line:   264,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 12,
-									Line:   243,
+									Line:   264,
 								},
 							},
 						},
@@ -15371,13 +16629,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 21,
-								Line:   243,
+								Line:   264,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 19,
-								Line:   243,
+								Line:   264,
 							},
 						},
 					}},
@@ -15389,13 +16647,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 66,
-						Line:   244,
+						Line:   265,
 					},
 					File:   "universe.flux",
 					Source: "toBool = (tables=<-) => tables |> map(fn:(r) => bool(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   244,
+						Line:   265,
 					},
 				},
 			},
@@ -15405,13 +16663,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   244,
+							Line:   265,
 						},
 						File:   "universe.flux",
 						Source: "toBool",
 						Start: ast.Position{
 							Column: 1,
-							Line:   244,
+							Line:   265,
 						},
 					},
 				},
@@ -15423,13 +16681,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 66,
-							Line:   244,
+							Line:   265,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => bool(v:r._value))",
 						Start: ast.Position{
 							Column: 10,
-							Line:   244,
+							Line:   265,
 						},
 					},
 				},
@@ -15440,13 +16698,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   244,
+									Line:   265,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 25,
-									Line:   244,
+									Line:   265,
 								},
 							},
 						},
@@ -15457,13 +16715,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 66,
-								Line:   244,
+								Line:   265,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => bool(v:r._value))",
 							Start: ast.Position{
 								Column: 25,
-								Line:   244,
+								Line:   265,
 							},
 						},
 					},
@@ -15474,13 +16732,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 65,
-										Line:   244,
+										Line:   265,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => bool(v:r._value)",
 									Start: ast.Position{
 										Column: 39,
-										Line:   244,
+										Line:   265,
 									},
 								},
 							},
@@ -15490,13 +16748,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 65,
-											Line:   244,
+											Line:   265,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => bool(v:r._value)",
 										Start: ast.Position{
 											Column: 39,
-											Line:   244,
+											Line:   265,
 										},
 									},
 								},
@@ -15506,13 +16764,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 41,
-												Line:   244,
+												Line:   265,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 39,
-												Line:   244,
+												Line:   265,
 											},
 										},
 									},
@@ -15524,13 +16782,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   244,
+												Line:   265,
 											},
 											File:   "universe.flux",
 											Source: "(r) => bool(v:r._value)",
 											Start: ast.Position{
 												Column: 42,
-												Line:   244,
+												Line:   265,
 											},
 										},
 									},
@@ -15541,13 +16799,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 64,
-														Line:   244,
+														Line:   265,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 54,
-														Line:   244,
+														Line:   265,
 													},
 												},
 											},
@@ -15557,13 +16815,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 64,
-															Line:   244,
+															Line:   265,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 54,
-															Line:   244,
+															Line:   265,
 														},
 													},
 												},
@@ -15573,13 +16831,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 55,
-																Line:   244,
+																Line:   265,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 54,
-																Line:   244,
+																Line:   265,
 															},
 														},
 													},
@@ -15591,13 +16849,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 64,
-																Line:   244,
+																Line:   265,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 56,
-																Line:   244,
+																Line:   265,
 															},
 														},
 													},
@@ -15607,13 +16865,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 57,
-																	Line:   244,
+																	Line:   265,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   244,
+																	Line:   265,
 																},
 															},
 														},
@@ -15625,13 +16883,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 64,
-																	Line:   244,
+																	Line:   265,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   244,
+																	Line:   265,
 																},
 															},
 														},
@@ -15645,13 +16903,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 65,
-													Line:   244,
+													Line:   265,
 												},
 												File:   "universe.flux",
 												Source: "bool(v:r._value)",
 												Start: ast.Position{
 													Column: 49,
-													Line:   244,
+													Line:   265,
 												},
 											},
 										},
@@ -15661,13 +16919,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 53,
-														Line:   244,
+														Line:   265,
 													},
 													File:   "universe.flux",
 													Source: "bool",
 													Start: ast.Position{
 														Column: 49,
-														Line:   244,
+														Line:   265,
 													},
 												},
 											},
@@ -15680,13 +16938,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 44,
-													Line:   244,
+													Line:   265,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 43,
-													Line:   244,
+													Line:   265,
 												},
 											},
 										},
@@ -15696,13 +16954,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 44,
-														Line:   244,
+														Line:   265,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 43,
-														Line:   244,
+														Line:   265,
 													},
 												},
 											},
@@ -15718,13 +16976,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 66,
-									Line:   244,
+									Line:   265,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => bool(v:r._value))",
 								Start: ast.Position{
 									Column: 35,
-									Line:   244,
+									Line:   265,
 								},
 							},
 						},
@@ -15734,13 +16992,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   244,
+										Line:   265,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 35,
-										Line:   244,
+										Line:   265,
 									},
 								},
 							},
@@ -15754,13 +17012,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   244,
+								Line:   265,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 11,
-								Line:   244,
+								Line:   265,
 							},
 						},
 					},
@@ -15770,13 +17028,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   244,
+									Line:   265,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 11,
-									Line:   244,
+									Line:   265,
 								},
 							},
 						},
@@ -15787,13 +17045,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   244,
+								Line:   265,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 18,
-								Line:   244,
+								Line:   265,
 							},
 						},
 					}},
@@ -15805,13 +17063,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 66,
-						Line:   245,
+						Line:   266,
 					},
 					File:   "universe.flux",
 					Source: "toTime = (tables=<-) => tables |> map(fn:(r) => time(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   245,
+						Line:   266,
 					},
 				},
 			},
@@ -15821,13 +17079,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 7,
-							Line:   245,
+							Line:   266,
 						},
 						File:   "universe.flux",
 						Source: "toTime",
 						Start: ast.Position{
 							Column: 1,
-							Line:   245,
+							Line:   266,
 						},
 					},
 				},
@@ -15839,13 +17097,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 66,
-							Line:   245,
+							Line:   266,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => time(v:r._value))",
 						Start: ast.Position{
 							Column: 10,
-							Line:   245,
+							Line:   266,
 						},
 					},
 				},
@@ -15856,13 +17114,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 31,
-									Line:   245,
+									Line:   266,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 25,
-									Line:   245,
+									Line:   266,
 								},
 							},
 						},
@@ -15873,13 +17131,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 66,
-								Line:   245,
+								Line:   266,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => time(v:r._value))",
 							Start: ast.Position{
 								Column: 25,
-								Line:   245,
+								Line:   266,
 							},
 						},
 					},
@@ -15890,13 +17148,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 65,
-										Line:   245,
+										Line:   266,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => time(v:r._value)",
 									Start: ast.Position{
 										Column: 39,
-										Line:   245,
+										Line:   266,
 									},
 								},
 							},
@@ -15906,13 +17164,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 65,
-											Line:   245,
+											Line:   266,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => time(v:r._value)",
 										Start: ast.Position{
 											Column: 39,
-											Line:   245,
+											Line:   266,
 										},
 									},
 								},
@@ -15922,13 +17180,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 41,
-												Line:   245,
+												Line:   266,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 39,
-												Line:   245,
+												Line:   266,
 											},
 										},
 									},
@@ -15940,13 +17198,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 65,
-												Line:   245,
+												Line:   266,
 											},
 											File:   "universe.flux",
 											Source: "(r) => time(v:r._value)",
 											Start: ast.Position{
 												Column: 42,
-												Line:   245,
+												Line:   266,
 											},
 										},
 									},
@@ -15957,13 +17215,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 64,
-														Line:   245,
+														Line:   266,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 54,
-														Line:   245,
+														Line:   266,
 													},
 												},
 											},
@@ -15973,13 +17231,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 64,
-															Line:   245,
+															Line:   266,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 54,
-															Line:   245,
+															Line:   266,
 														},
 													},
 												},
@@ -15989,13 +17247,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 55,
-																Line:   245,
+																Line:   266,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 54,
-																Line:   245,
+																Line:   266,
 															},
 														},
 													},
@@ -16007,13 +17265,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 64,
-																Line:   245,
+																Line:   266,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 56,
-																Line:   245,
+																Line:   266,
 															},
 														},
 													},
@@ -16023,13 +17281,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 57,
-																	Line:   245,
+																	Line:   266,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 56,
-																	Line:   245,
+																	Line:   266,
 																},
 															},
 														},
@@ -16041,13 +17299,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 64,
-																	Line:   245,
+																	Line:   266,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 58,
-																	Line:   245,
+																	Line:   266,
 																},
 															},
 														},
@@ -16061,13 +17319,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 65,
-													Line:   245,
+													Line:   266,
 												},
 												File:   "universe.flux",
 												Source: "time(v:r._value)",
 												Start: ast.Position{
 													Column: 49,
-													Line:   245,
+													Line:   266,
 												},
 											},
 										},
@@ -16077,13 +17335,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 53,
-														Line:   245,
+														Line:   266,
 													},
 													File:   "universe.flux",
 													Source: "time",
 													Start: ast.Position{
 														Column: 49,
-														Line:   245,
+														Line:   266,
 													},
 												},
 											},
@@ -16096,13 +17354,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 44,
-													Line:   245,
+													Line:   266,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 43,
-													Line:   245,
+													Line:   266,
 												},
 											},
 										},
@@ -16112,13 +17370,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 44,
-														Line:   245,
+														Line:   266,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 43,
-														Line:   245,
+														Line:   266,
 													},
 												},
 											},
@@ -16134,13 +17392,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 66,
-									Line:   245,
+									Line:   266,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => time(v:r._value))",
 								Start: ast.Position{
 									Column: 35,
-									Line:   245,
+									Line:   266,
 								},
 							},
 						},
@@ -16150,13 +17408,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 38,
-										Line:   245,
+										Line:   266,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 35,
-										Line:   245,
+										Line:   266,
 									},
 								},
 							},
@@ -16170,13 +17428,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   245,
+								Line:   266,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 11,
-								Line:   245,
+								Line:   266,
 							},
 						},
 					},
@@ -16186,13 +17444,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 17,
-									Line:   245,
+									Line:   266,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 11,
-									Line:   245,
+									Line:   266,
 								},
 							},
 						},
@@ -16203,13 +17461,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 20,
-								Line:   245,
+								Line:   266,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 18,
-								Line:   245,
+								Line:   266,
 							},
 						},
 					}},
@@ -16221,13 +17479,13 @@ var pkgAST = &ast.Package{
 				Loc: &ast.SourceLocation{
 					End: ast.Position{
 						Column: 74,
-						Line:   246,
+						Line:   267,
 					},
 					File:   "universe.flux",
 					Source: "toDuration = (tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
 					Start: ast.Position{
 						Column: 1,
-						Line:   246,
+						Line:   267,
 					},
 				},
 			},
@@ -16237,13 +17495,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 11,
-							Line:   246,
+							Line:   267,
 						},
 						File:   "universe.flux",
 						Source: "toDuration",
 						Start: ast.Position{
 							Column: 1,
-							Line:   246,
+							Line:   267,
 						},
 					},
 				},
@@ -16255,13 +17513,13 @@ var pkgAST = &ast.Package{
 					Loc: &ast.SourceLocation{
 						End: ast.Position{
 							Column: 74,
-							Line:   246,
+							Line:   267,
 						},
 						File:   "universe.flux",
 						Source: "(tables=<-) => tables |> map(fn:(r) => duration(v:r._value))",
 						Start: ast.Position{
 							Column: 14,
-							Line:   246,
+							Line:   267,
 						},
 					},
 				},
@@ -16272,13 +17530,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 35,
-									Line:   246,
+									Line:   267,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 29,
-									Line:   246,
+									Line:   267,
 								},
 							},
 						},
@@ -16289,13 +17547,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 74,
-								Line:   246,
+								Line:   267,
 							},
 							File:   "universe.flux",
 							Source: "tables |> map(fn:(r) => duration(v:r._value))",
 							Start: ast.Position{
 								Column: 29,
-								Line:   246,
+								Line:   267,
 							},
 						},
 					},
@@ -16306,13 +17564,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 73,
-										Line:   246,
+										Line:   267,
 									},
 									File:   "universe.flux",
 									Source: "fn:(r) => duration(v:r._value)",
 									Start: ast.Position{
 										Column: 43,
-										Line:   246,
+										Line:   267,
 									},
 								},
 							},
@@ -16322,13 +17580,13 @@ var pkgAST = &ast.Package{
 									Loc: &ast.SourceLocation{
 										End: ast.Position{
 											Column: 73,
-											Line:   246,
+											Line:   267,
 										},
 										File:   "universe.flux",
 										Source: "fn:(r) => duration(v:r._value)",
 										Start: ast.Position{
 											Column: 43,
-											Line:   246,
+											Line:   267,
 										},
 									},
 								},
@@ -16338,13 +17596,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 45,
-												Line:   246,
+												Line:   267,
 											},
 											File:   "universe.flux",
 											Source: "fn",
 											Start: ast.Position{
 												Column: 43,
-												Line:   246,
+												Line:   267,
 											},
 										},
 									},
@@ -16356,13 +17614,13 @@ var pkgAST = &ast.Package{
 										Loc: &ast.SourceLocation{
 											End: ast.Position{
 												Column: 73,
-												Line:   246,
+												Line:   267,
 											},
 											File:   "universe.flux",
 											Source: "(r) => duration(v:r._value)",
 											Start: ast.Position{
 												Column: 46,
-												Line:   246,
+												Line:   267,
 											},
 										},
 									},
@@ -16373,13 +17631,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 72,
-														Line:   246,
+														Line:   267,
 													},
 													File:   "universe.flux",
 													Source: "v:r._value",
 													Start: ast.Position{
 														Column: 62,
-														Line:   246,
+														Line:   267,
 													},
 												},
 											},
@@ -16389,13 +17647,13 @@ var pkgAST = &ast.Package{
 													Loc: &ast.SourceLocation{
 														End: ast.Position{
 															Column: 72,
-															Line:   246,
+															Line:   267,
 														},
 														File:   "universe.flux",
 														Source: "v:r._value",
 														Start: ast.Position{
 															Column: 62,
-															Line:   246,
+															Line:   267,
 														},
 													},
 												},
@@ -16405,13 +17663,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 63,
-																Line:   246,
+																Line:   267,
 															},
 															File:   "universe.flux",
 															Source: "v",
 															Start: ast.Position{
 																Column: 62,
-																Line:   246,
+																Line:   267,
 															},
 														},
 													},
@@ -16423,13 +17681,13 @@ var pkgAST = &ast.Package{
 														Loc: &ast.SourceLocation{
 															End: ast.Position{
 																Column: 72,
-																Line:   246,
+																Line:   267,
 															},
 															File:   "universe.flux",
 															Source: "r._value",
 															Start: ast.Position{
 																Column: 64,
-																Line:   246,
+																Line:   267,
 															},
 														},
 													},
@@ -16439,13 +17697,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 65,
-																	Line:   246,
+																	Line:   267,
 																},
 																File:   "universe.flux",
 																Source: "r",
 																Start: ast.Position{
 																	Column: 64,
-																	Line:   246,
+																	Line:   267,
 																},
 															},
 														},
@@ -16457,13 +17715,13 @@ var pkgAST = &ast.Package{
 															Loc: &ast.SourceLocation{
 																End: ast.Position{
 																	Column: 72,
-																	Line:   246,
+																	Line:   267,
 																},
 																File:   "universe.flux",
 																Source: "_value",
 																Start: ast.Position{
 																	Column: 66,
-																	Line:   246,
+																	Line:   267,
 																},
 															},
 														},
@@ -16477,13 +17735,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 73,
-													Line:   246,
+													Line:   267,
 												},
 												File:   "universe.flux",
 												Source: "duration(v:r._value)",
 												Start: ast.Position{
 													Column: 53,
-													Line:   246,
+													Line:   267,
 												},
 											},
 										},
@@ -16493,13 +17751,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 61,
-														Line:   246,
+														Line:   267,
 													},
 													File:   "universe.flux",
 													Source: "duration",
 													Start: ast.Position{
 														Column: 53,
-														Line:   246,
+														Line:   267,
 													},
 												},
 											},
@@ -16512,13 +17770,13 @@ var pkgAST = &ast.Package{
 											Loc: &ast.SourceLocation{
 												End: ast.Position{
 													Column: 48,
-													Line:   246,
+													Line:   267,
 												},
 												File:   "universe.flux",
 												Source: "r",
 												Start: ast.Position{
 													Column: 47,
-													Line:   246,
+													Line:   267,
 												},
 											},
 										},
@@ -16528,13 +17786,13 @@ var pkgAST = &ast.Package{
 												Loc: &ast.SourceLocation{
 													End: ast.Position{
 														Column: 48,
-														Line:   246,
+														Line:   267,
 													},
 													File:   "universe.flux",
 													Source: "r",
 													Start: ast.Position{
 														Column: 47,
-														Line:   246,
+														Line:   267,
 													},
 												},
 											},
@@ -16550,13 +17808,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 74,
-									Line:   246,
+									Line:   267,
 								},
 								File:   "universe.flux",
 								Source: "map(fn:(r) => duration(v:r._value))",
 								Start: ast.Position{
 									Column: 39,
-									Line:   246,
+									Line:   267,
 								},
 							},
 						},
@@ -16566,13 +17824,13 @@ var pkgAST = &ast.Package{
 								Loc: &ast.SourceLocation{
 									End: ast.Position{
 										Column: 42,
-										Line:   246,
+										Line:   267,
 									},
 									File:   "universe.flux",
 									Source: "map",
 									Start: ast.Position{
 										Column: 39,
-										Line:   246,
+										Line:   267,
 									},
 								},
 							},
@@ -16586,13 +17844,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 24,
-								Line:   246,
+								Line:   267,
 							},
 							File:   "universe.flux",
 							Source: "tables=<-",
 							Start: ast.Position{
 								Column: 15,
-								Line:   246,
+								Line:   267,
 							},
 						},
 					},
@@ -16602,13 +17860,13 @@ var pkgAST = &ast.Package{
 							Loc: &ast.SourceLocation{
 								End: ast.Position{
 									Column: 21,
-									Line:   246,
+									Line:   267,
 								},
 								File:   "universe.flux",
 								Source: "tables",
 								Start: ast.Position{
 									Column: 15,
-									Line:   246,
+									Line:   267,
 								},
 							},
 						},
@@ -16619,13 +17877,13 @@ var pkgAST = &ast.Package{
 						Loc: &ast.SourceLocation{
 							End: ast.Position{
 								Column: 24,
-								Line:   246,
+								Line:   267,
 							},
 							File:   "universe.flux",
 							Source: "<-",
 							Start: ast.Position{
 								Column: 22,
-								Line:   246,
+								Line:   267,
 							},
 						},
 					}},
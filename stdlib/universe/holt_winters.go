@@ -0,0 +1,546 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/internal/pkg/neldermead"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const HoltWintersKind = "holtWinters"
+
+type HoltWintersOpSpec struct {
+	N           int64         `json:"n"`
+	Seasonality int64         `json:"seasonality"`
+	Interval    flux.Duration `json:"interval"`
+	WithFit     bool          `json:"withFit"`
+	Column      string        `json:"column"`
+	TimeColumn  string        `json:"timeColumn"`
+}
+
+func init() {
+	holtWintersSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"n":           semantic.Int,
+			"seasonality": semantic.Int,
+			"interval":    semantic.Duration,
+			"withFit":     semantic.Bool,
+			"column":      semantic.String,
+			"timeColumn":  semantic.String,
+		},
+		[]string{"n", "interval"},
+	)
+
+	flux.RegisterPackageValue("universe", HoltWintersKind, flux.FunctionValue(HoltWintersKind, createHoltWintersOpSpec, holtWintersSignature))
+	flux.RegisterOpSpec(HoltWintersKind, newHoltWintersOp)
+	plan.RegisterProcedureSpec(HoltWintersKind, newHoltWintersProcedure, HoltWintersKind)
+	execute.RegisterTransformation(HoltWintersKind, createHoltWintersTransformation)
+}
+
+func createHoltWintersOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(HoltWintersOpSpec)
+
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("cannot forecast %d values", n)
+	}
+	spec.N = n
+
+	if s, ok, err := args.GetInt("seasonality"); err != nil {
+		return nil, err
+	} else if ok {
+		if s < 0 {
+			return nil, fmt.Errorf("seasonality must not be negative, got %d", s)
+		}
+		spec.Seasonality = s
+	}
+
+	interval, err := args.GetRequiredDuration("interval")
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+	spec.Interval = interval
+
+	if withFit, ok, err := args.GetBool("withFit"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.WithFit = withFit
+	}
+
+	if col, ok, err := args.GetString("column"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.Column = col
+	} else {
+		spec.Column = execute.DefaultValueColLabel
+	}
+
+	if col, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = col
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	return spec, nil
+}
+
+func newHoltWintersOp() flux.OperationSpec {
+	return new(HoltWintersOpSpec)
+}
+
+func (s *HoltWintersOpSpec) Kind() flux.OperationKind {
+	return HoltWintersKind
+}
+
+type HoltWintersProcedureSpec struct {
+	plan.DefaultCost
+	N           int64
+	Seasonality int64
+	Interval    flux.Duration
+	WithFit     bool
+	Column      string
+	TimeColumn  string
+}
+
+func newHoltWintersProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*HoltWintersOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &HoltWintersProcedureSpec{
+		N:           spec.N,
+		Seasonality: spec.Seasonality,
+		Interval:    spec.Interval,
+		WithFit:     spec.WithFit,
+		Column:      spec.Column,
+		TimeColumn:  spec.TimeColumn,
+	}, nil
+}
+
+func (s *HoltWintersProcedureSpec) Kind() plan.ProcedureKind {
+	return HoltWintersKind
+}
+func (s *HoltWintersProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(HoltWintersProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createHoltWintersTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*HoltWintersProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewHoltWintersTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type holtWintersTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	n           int
+	seasonality int
+	interval    int64
+	withFit     bool
+	column      string
+	timeCol     string
+}
+
+func NewHoltWintersTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *HoltWintersProcedureSpec) *holtWintersTransformation {
+	return &holtWintersTransformation{
+		d:           d,
+		cache:       cache,
+		n:           int(spec.N),
+		seasonality: int(spec.Seasonality),
+		interval:    int64(spec.Interval),
+		withFit:     spec.WithFit,
+		column:      spec.Column,
+		timeCol:     spec.TimeColumn,
+	}
+}
+
+func (t *holtWintersTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *holtWintersTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("holtWinters found duplicate table with key: %v", key)
+	}
+	cols := tbl.Cols()
+	valueIdx := execute.ColIdx(t.column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.column)
+	}
+	switch typ := cols[valueIdx].Type; typ {
+	case flux.TInt, flux.TUInt, flux.TFloat:
+	default:
+		return fmt.Errorf("holtWinters does not support %v", typ)
+	}
+	timeIdx := execute.ColIdx(t.timeCol, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.timeCol)
+	}
+	if typ := cols[timeIdx].Type; typ != flux.TTime {
+		return fmt.Errorf("time column %q is of type %v, expected time", t.timeCol, typ)
+	}
+	if key.HasCol(t.column) || key.HasCol(t.timeCol) {
+		return errors.New("holtWinters cannot be applied to group key columns")
+	}
+
+	if err := execute.AddTableKeyCols(key, builder); err != nil {
+		return err
+	}
+	outTimeIdx, err := builder.AddCol(flux.ColMeta{Label: t.timeCol, Type: flux.TTime})
+	if err != nil {
+		return err
+	}
+	outValueIdx, err := builder.AddCol(flux.ColMeta{Label: t.column, Type: flux.TFloat})
+	if err != nil {
+		return err
+	}
+
+	// Null values and rows with a null time are ignored.
+	var times []int64
+	var vs []float64
+	err = tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			ts := cr.Times(timeIdx)
+			v := execute.ValueForRow(cr, i, valueIdx)
+			if ts.IsNull(i) || v.IsNull() {
+				continue
+			}
+			tm := ts.Value(i)
+			if len(times) > 0 && tm < times[len(times)-1] {
+				return errors.New("holtWinters found out-of-order times in time column")
+			}
+			times = append(times, tm)
+			vs = append(vs, floatValue(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	hw := newHoltWinters(t.n, t.seasonality, t.interval, t.withFit)
+	times, vs = hw.do(times, vs)
+	for i := range times {
+		if err := execute.AppendKeyValues(key, builder); err != nil {
+			return err
+		}
+		if err := builder.AppendTime(outTimeIdx, values.Time(times[i])); err != nil {
+			return err
+		}
+		if err := builder.AppendFloat(outValueIdx, vs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *holtWintersTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *holtWintersTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *holtWintersTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+const (
+	// Arbitrary weight for initializing some initial guesses.
+	// This should be in the range [0,1].
+	hwWeight = 0.5
+	// Epsilon value for the minimization process.
+	hwDefaultEpsilon = 1.0e-4
+	// Define a grid of initial guesses for the parameters: alpha, beta, gamma, and phi.
+	// Keep in mind that this grid is N^4 so we should keep N small.
+	// The starting lower guess.
+	hwGuessLower = 0.3
+	// The upper bound on the grid.
+	hwGuessUpper = 1.0
+	// The step between guesses.
+	hwGuessStep = 0.4
+)
+
+// holtWinters forecasts a series with the damped multiplicative Holt-Winters method,
+// also known as triple exponential smoothing.
+// The smoothing parameters alpha, beta, gamma and phi, and the initial level, trend and seasonal components,
+// are those that minimize the sum of squared errors of the fitted values,
+// as found by the Nelder-Mead method from a grid of starting points.
+type holtWinters struct {
+	// n is the number of values to forecast.
+	n int
+	// m is the number of values in a season, the model is seasonal only if m is at least 2.
+	m        int
+	seasonal bool
+	// interval is the time between values, halfInterval is used to round times to intervals.
+	interval, halfInterval int64
+	withFit                bool
+
+	optim   *neldermead.Optimizer
+	epsilon float64
+
+	// y holds the values at every interval, NaN where the value is missing.
+	y []float64
+}
+
+func newHoltWinters(n, m int, interval int64, withFit bool) *holtWinters {
+	return &holtWinters{
+		n:            n,
+		m:            m,
+		seasonal:     m >= 2,
+		interval:     interval,
+		halfInterval: interval / 2,
+		withFit:      withFit,
+		optim:        neldermead.New(),
+		epsilon:      hwDefaultEpsilon,
+	}
+}
+
+func (r *holtWinters) roundTime(t int64) int64 {
+	// Overflow safe round function
+	remainder := t % r.interval
+	if remainder > r.halfInterval {
+		// Round up
+		return (t/r.interval + 1) * r.interval
+	}
+	// Round down
+	return (t / r.interval) * r.interval
+}
+
+// do returns the forecast of the values at the given times, which must be sorted.
+// Values whose time rounds to the same interval as the previous value are ignored.
+// There is no forecast if the values span less than two intervals,
+// or less than a season if the model is seasonal.
+func (r *holtWinters) do(times []int64, vs []float64) ([]int64, []float64) {
+	if len(times) < 2 {
+		return nil, nil
+	}
+	// First fill in r.y with values and NaNs for missing values.
+	start, stop := r.roundTime(times[0]), r.roundTime(times[len(times)-1])
+	count := (stop - start) / r.interval
+	if count <= 0 {
+		return nil, nil
+	}
+	r.y = make([]float64, 1, count+1)
+	r.y[0] = vs[0]
+	t := start
+	for i, tm := range times[1:] {
+		rounded := r.roundTime(tm)
+		if rounded <= t {
+			// Drop values that occur for the same time bucket.
+			continue
+		}
+		t += r.interval
+		// Add any missing values before the next point.
+		for rounded != t {
+			r.y = append(r.y, math.NaN())
+			t += r.interval
+		}
+		r.y = append(r.y, vs[i+1])
+	}
+	if r.seasonal && len(r.y) < r.m {
+		return nil, nil
+	}
+
+	// Starting guesses.
+	// Since these values are guesses, missing values can simply be skipped.
+	m := r.m
+	l0 := 0.0
+	if r.seasonal {
+		for i := 0; i < m; i++ {
+			if !math.IsNaN(r.y[i]) {
+				l0 += (1 / float64(m)) * r.y[i]
+			}
+		}
+	} else {
+		l0 += hwWeight * r.y[0]
+	}
+
+	b0 := 0.0
+	if r.seasonal {
+		for i := 0; i < m && m+i < len(r.y); i++ {
+			if !math.IsNaN(r.y[i]) && !math.IsNaN(r.y[m+i]) {
+				b0 += 1 / float64(m*m) * (r.y[m+i] - r.y[i])
+			}
+		}
+	} else if !math.IsNaN(r.y[1]) {
+		b0 = hwWeight * (r.y[1] - r.y[0])
+	}
+
+	var s []float64
+	if r.seasonal {
+		s = make([]float64, m)
+		for i := 0; i < m; i++ {
+			if !math.IsNaN(r.y[i]) {
+				s[i] = r.y[i] / l0
+			} else {
+				s[i] = 0
+			}
+		}
+	}
+
+	parameters := make([]float64, 6+len(s))
+	parameters[4] = l0
+	parameters[5] = b0
+	copy(parameters[6:], s)
+
+	// Determine the best fit for the various parameters.
+	minSSE := math.Inf(1)
+	var bestParams []float64
+	for alpha := hwGuessLower; alpha < hwGuessUpper; alpha += hwGuessStep {
+		for beta := hwGuessLower; beta < hwGuessUpper; beta += hwGuessStep {
+			for gamma := hwGuessLower; gamma < hwGuessUpper; gamma += hwGuessStep {
+				for phi := hwGuessLower; phi < hwGuessUpper; phi += hwGuessStep {
+					parameters[0] = alpha
+					parameters[1] = beta
+					parameters[2] = gamma
+					parameters[3] = phi
+					sse, params := r.optim.Optimize(r.sse, parameters, r.epsilon, 1)
+					if sse < minSSE || bestParams == nil {
+						minSSE = sse
+						bestParams = params
+					}
+				}
+			}
+		}
+	}
+
+	forecasted := r.forecast(r.n, bestParams)
+	var outTimes []int64
+	var outValues []float64
+	if r.withFit {
+		for i, v := range forecasted {
+			if !math.IsNaN(v) {
+				outTimes = append(outTimes, times[0]+r.interval*int64(i))
+				outValues = append(outValues, v)
+			}
+		}
+	} else {
+		last := times[len(times)-1]
+		for i, v := range forecasted[len(r.y):] {
+			if !math.IsNaN(v) {
+				outTimes = append(outTimes, last+r.interval*int64(i+1))
+				outValues = append(outValues, v)
+			}
+		}
+	}
+	return outTimes, outValues
+}
+
+// next computes the next values using the recursive relations of the model.
+func (r *holtWinters) next(alpha, beta, gamma, phi, phiH, yT, lTp, bTp, sTm, sTmh float64) (yTh, lT, bT, sT float64) {
+	lT = alpha*(yT/sTm) + (1-alpha)*(lTp+phi*bTp)
+	bT = beta*(lT-lTp) + (1-beta)*phi*bTp
+	sT = gamma*(yT/(lTp+phi*bTp)) + (1-gamma)*sTm
+	yTh = (lT + phiH*bT) * sTmh
+	return
+}
+
+// forecast returns the fitted values followed by h forecast values.
+func (r *holtWinters) forecast(h int, params []float64) []float64 {
+	// The optimizer owns params, so work on a constrained copy.
+	p := make([]float64, len(params))
+	copy(p, params)
+	r.constrain(p)
+
+	yT := r.y[0]
+
+	phi := p[3]
+	phiH := phi
+
+	lT := p[4]
+	bT := p[5]
+
+	// seasonals is a ring buffer of past sT values.
+	var seasonals []float64
+	var m, so int
+	if r.seasonal {
+		seasonals = p[6:]
+		m = len(seasonals)
+		// Season index offset
+		so = m - 1
+	}
+
+	l := len(r.y)
+	forecasted := make([]float64, l+h)
+	forecasted[0] = yT
+	var hm int
+	stm, stmh := 1.0, 1.0
+	for t := 1; t < l+h; t++ {
+		if r.seasonal {
+			hm = t % m
+			stm = seasonals[(t-m+so)%m]
+			stmh = seasonals[(t-m+hm+so)%m]
+		}
+		var sT float64
+		yT, lT, bT, sT = r.next(p[0], p[1], p[2], phi, phiH, yT, lT, bT, stm, stmh)
+		phiH += math.Pow(phi, float64(t))
+
+		if r.seasonal {
+			seasonals[(t+so)%m] = sT
+			so++
+		}
+
+		forecasted[t] = yT
+	}
+	return forecasted
+}
+
+// sse computes the sum of squared errors of the fitted values for the given parameters.
+func (r *holtWinters) sse(params []float64) float64 {
+	sse := 0.0
+	forecasted := r.forecast(0, params)
+	for i := range forecasted {
+		// Skip missing values since we cannot use them to compute an error.
+		if !math.IsNaN(r.y[i]) {
+			if math.IsNaN(forecasted[i]) {
+				// Penalize forecasted NaNs.
+				return math.Inf(1)
+			}
+			diff := forecasted[i] - r.y[i]
+			sse += diff * diff
+		}
+	}
+	return sse
+}
+
+// constrain constrains alpha, beta, gamma and phi to the range [0, 1].
+func (r *holtWinters) constrain(x []float64) {
+	for i := 0; i < 4; i++ {
+		if x[i] > 1 {
+			x[i] = 1
+		}
+		if x[i] < 0 {
+			x[i] = 0
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestHoltWinters_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mybucket") |> holtWinters(n: 10, seasonality: 4, interval: 1h)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "holtWinters1",
+						Spec: &universe.HoltWintersOpSpec{
+							N:           10,
+							Seasonality: 4,
+							Interval:    flux.Duration(time.Hour),
+							Column:      "_value",
+							TimeColumn:  "_time",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "holtWinters1"},
+				},
+			},
+		},
+		{
+			Name:    "n must be positive",
+			Raw:     `from(bucket:"mybucket") |> holtWinters(n: 0, interval: 1h)`,
+			WantErr: true,
+		},
+		{
+			Name:    "interval must be positive",
+			Raw:     `from(bucket:"mybucket") |> holtWinters(n: 3, interval: 0s)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestHoltWintersOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"holtWinters","kind":"holtWinters","spec":{"n":10,"seasonality":4,"interval":"1h","withFit":true,"column":"_value","timeColumn":"_time"}}`)
+	op := &flux.Operation{
+		ID: "holtWinters",
+		Spec: &universe.HoltWintersOpSpec{
+			N:           10,
+			Seasonality: 4,
+			Interval:    flux.Duration(time.Hour),
+			WithFit:     true,
+			Column:      "_value",
+			TimeColumn:  "_time",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestHoltWinters_Process(t *testing.T) {
+	hour := execute.Time(time.Hour)
+	minute := execute.Time(time.Minute)
+	testCases := []struct {
+		name    string
+		spec    *universe.HoltWintersProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "seasonal with fit",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:           2,
+				Seasonality: 4,
+				Interval:    flux.Duration(time.Hour),
+				WithFit:     true,
+				Column:      "_value",
+				TimeColumn:  "_time",
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t", Type: flux.TString},
+					{Label: "other", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{0 * hour, 1.0, "a", "x"},
+					{1 * hour, 2.0, "a", "x"},
+					{2 * hour, 4.0, "a", "x"},
+					{3 * hour, 3.0, "a", "x"},
+					{4 * hour, 2.0, "a", "x"},
+					{5 * hour, 3.0, "a", "x"},
+					{6 * hour, 5.0, "a", "x"},
+					{7 * hour, 4.0, "a", "x"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "t", Type: flux.TString},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{"a", 0 * hour, 1.0},
+					{"a", 1 * hour, 1.9979814830289386},
+					{"a", 2 * hour, 4.005143559466114},
+					{"a", 3 * hour, 2.99766709417927},
+					{"a", 4 * hour, 2.004885913730733},
+					{"a", 5 * hour, 2.994092361250897},
+					{"a", 6 * hour, 4.9980703565335585},
+					{"a", 7 * hour, 4.010195957625217},
+					{"a", 8 * hour, 2.9317435153854285},
+					{"a", 9 * hour, 3.829677958693174},
+				},
+			}},
+		},
+		{
+			name: "missing and null values",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:          3,
+				Interval:   flux.Duration(10 * time.Minute),
+				Column:     "_value",
+				TimeColumn: "_time",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{0 * minute, int64(10)},
+					{10 * minute, int64(12)},
+					{20 * minute, int64(15)},
+					{30 * minute, nil},
+					{40 * minute, int64(19)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{50 * minute, 20.540015179626373},
+					{60 * minute, 21.85040710539125},
+					{70 * minute, 22.97054593709399},
+				},
+			}},
+		},
+		{
+			name: "not enough values",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:           3,
+				Seasonality: 4,
+				Interval:    flux.Duration(time.Hour),
+				Column:      "_value",
+				TimeColumn:  "_time",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{0 * hour, 1.0},
+					{1 * hour, 2.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			}},
+		},
+		{
+			name: "out of order times",
+			spec: &universe.HoltWintersProcedureSpec{
+				N:          3,
+				Interval:   flux.Duration(time.Hour),
+				Column:     "_value",
+				TimeColumn: "_time",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{1 * hour, 1.0},
+					{0 * hour, 2.0},
+				},
+			}},
+			wantErr: errors.New("holtWinters found out-of-order times in time column"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewHoltWintersTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -22,9 +22,11 @@ const MergeJoinKind = "merge-join"
 func init() {
 	joinSignature := semantic.FunctionPolySignature{
 		Parameters: map[string]semantic.PolyType{
-			"tables": semantic.NewObjectPolyType(nil, nil, semantic.AllLabels()),
-			"on":     semantic.NewArrayPolyType(semantic.String),
-			"method": semantic.String,
+			"tables":    semantic.NewObjectPolyType(nil, nil, semantic.AllLabels()),
+			"on":        semantic.NewArrayPolyType(semantic.String),
+			"method":    semantic.String,
+			"tolerance": semantic.Duration,
+			"direction": semantic.String,
 		},
 		Required: semantic.LabelSet{"tables"},
 		Return:   flux.TableObjectType,
@@ -36,16 +38,39 @@ func init() {
 	execute.RegisterTransformation(MergeJoinKind, createMergeJoinTransformation)
 }
 
-// All supported join types in Flux
+// All supported join types in Flux.
+// Outer joins keep the rows of the left, right or both tables that have no match,
+// with null values in the columns of the other table.
+// A cross join joins every row of the left table with every row of the right table.
+// An as-of join joins every row of the left table with the row of the right table closest in time.
 var methods = map[string]bool{
 	"inner": true,
+	"left":  true,
+	"right": true,
+	"full":  true,
+	"cross": true,
+	"asof":  true,
 }
 
+// The directions in which an as-of join looks for the row of the right table closest in time.
+// A backward join takes the last row at or before the time of the left row,
+// a forward join takes the first row at or after it, and a nearest join takes the closest of the two.
+var asOfDirections = map[string]bool{
+	"backward": true,
+	"forward":  true,
+	"nearest":  true,
+}
+
+// asOfTimeColumn is the column compared by an as-of join.
+const asOfTimeColumn = execute.DefaultTimeColLabel
+
 // JoinOpSpec specifies a particular join operation
 type JoinOpSpec struct {
 	TableNames map[flux.OperationID]string `json:"tableNames"`
 	On         []string                    `json:"on"`
 	Method     string                      `json:"method"`
+	Tolerance  flux.Duration               `json:"tolerance,omitempty"`
+	Direction  string                      `json:"direction,omitempty"`
 
 	// Note: this field below is non-exported and is not part of the public Flux.Spec
 	// interface (used by the transpiler).  It should not be assumed to be populated
@@ -112,6 +137,41 @@ func createJoinOpSpec(args flux.Arguments, a *flux.Administration) (flux.Operati
 		return nil, errors.New("cross product and 'on' are mutually exclusive")
 	}
 
+	// The tolerance and direction only apply to an as-of join,
+	// which requires a tolerance and defaults to the backward direction.
+	if spec.Method == "asof" {
+		tolerance, err := args.GetRequiredDuration("tolerance")
+		if err != nil {
+			return nil, err
+		}
+		if tolerance < 0 {
+			return nil, errors.New("tolerance must not be negative")
+		}
+		spec.Tolerance = tolerance
+
+		if direction, ok, err := args.GetString("direction"); err != nil {
+			return nil, err
+		} else if ok && !asOfDirections[direction] {
+			return nil, fmt.Errorf("%s is not a valid as-of join direction", direction)
+		} else if ok {
+			spec.Direction = direction
+		} else {
+			spec.Direction = "backward"
+		}
+
+		for _, label := range spec.On {
+			if label == asOfTimeColumn {
+				return nil, fmt.Errorf("an as-of join compares %q by time, it must not be in 'on'", asOfTimeColumn)
+			}
+		}
+	} else {
+		for _, name := range []string{"tolerance", "direction"} {
+			if _, ok := args.Get(name); ok {
+				return nil, fmt.Errorf("%q is only valid for the asof join method", name)
+			}
+		}
+	}
+
 	tables, err := args.GetRequiredObject("tables")
 	if err != nil {
 		return nil, err
@@ -169,6 +229,10 @@ type MergeJoinProcedureSpec struct {
 	plan.DefaultCost
 	TableNames []string `json:"table_names"`
 	On         []string `json:"keys"`
+	Method     string   `json:"method"`
+
+	Tolerance flux.Duration `json:"tolerance"`
+	Direction string        `json:"direction"`
 }
 
 func newMergeJoinProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
@@ -195,6 +259,9 @@ func newMergeJoinProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.
 	return &MergeJoinProcedureSpec{
 		On:         on,
 		TableNames: tableNames,
+		Method:     spec.Method,
+		Tolerance:  spec.Tolerance,
+		Direction:  spec.Direction,
 	}, nil
 }
 
@@ -204,9 +271,16 @@ func (s *MergeJoinProcedureSpec) Kind() plan.ProcedureKind {
 func (s *MergeJoinProcedureSpec) Copy() plan.ProcedureSpec {
 	ns := new(MergeJoinProcedureSpec)
 
+	ns.TableNames = make([]string, len(s.TableNames))
+	copy(ns.TableNames, s.TableNames)
+
 	ns.On = make([]string, len(s.On))
 	copy(ns.On, s.On)
 
+	ns.Method = s.Method
+	ns.Tolerance = s.Tolerance
+	ns.Direction = s.Direction
+
 	return ns
 }
 
@@ -226,7 +300,14 @@ func createMergeJoinTransformation(id execute.DatasetID, mode execute.Accumulati
 		tableNames[parents[i]] = name
 	}
 
-	cache := NewMergeJoinCache(a.Allocator(), parents, tableNames, s.On)
+	if s.Method == "asof" {
+		cache := execute.NewTableBuilderCache(a.Allocator())
+		d := execute.NewDataset(id, mode, cache)
+		t := NewAsOfJoinTransformation(d, cache, s, parents, tableNames, a.Allocator())
+		return t, d, nil
+	}
+
+	cache := NewMergeJoinCache(a.Allocator(), parents, tableNames, s.On, s.Method)
 	d := execute.NewDataset(id, mode, cache)
 	t := NewMergeJoinTransformation(d, cache, s, parents, tableNames)
 	return t, d, nil
@@ -284,9 +365,9 @@ func (t *mergeJoinTransformation) Process(id execute.DatasetID, tbl flux.Table)
 		return err
 	}
 
-	// Check if enough data sources have been seen to produce an output schema
-	if !t.cache.isBufferEmpty(t.leftID) && !t.cache.isBufferEmpty(t.rightID) && !t.cache.postJoinSchemaBuilt() {
-		t.cache.buildPostJoinSchema()
+	// Check if enough data sources have been seen to determine the join columns
+	if !t.cache.isBufferEmpty(t.leftID) && !t.cache.isBufferEmpty(t.rightID) && !t.cache.joinColumnsResolved() {
+		t.cache.resolveJoinColumns()
 	}
 
 	// Register any new output group keys that can be constructed from the new table
@@ -338,6 +419,8 @@ func (t *mergeJoinTransformation) Finish(id execute.DatasetID, err error) {
 	}
 
 	if finished {
+		// The tables without a match are only known once every parent is finished.
+		t.cache.registerUnmatchedKeys()
 		t.d.Finish(nil)
 	}
 }
@@ -355,7 +438,10 @@ func (t *mergeJoinTransformation) Finish(id execute.DatasetID, err error) {
 // reverseLookup:   Each output group key that is stored is mapped to its
 //                  corresponding pre-join group keys. These pre-join group
 //                  keys are then used to retrieve their corresponding
-//                  tables from the buffers.
+//                  tables from the buffers. For outer joins, the pre-join
+//                  group key of a side is nil for the rows of a table
+//                  that have no match, and these rows may have the same
+//                  output group key as a pair of joined tables.
 //
 // tables:          All output tables are materialized and stored in this
 //                  map before being sent to downstream operators.
@@ -367,15 +453,13 @@ type MergeJoinCache struct {
 	schemas map[execute.DatasetID]schema
 	buffers map[execute.DatasetID]*streamBuffer
 
+	method       string
 	on           map[string]bool
 	intersection map[string]bool
-
-	schema    schema
-	colIndex  map[flux.ColMeta]int
-	schemaMap map[tableCol]flux.ColMeta
+	onResolved   bool
 
 	postJoinKeys  *execute.GroupLookup
-	reverseLookup map[flux.GroupKey]preJoinGroupKeys
+	reverseLookup map[flux.GroupKey][]preJoinGroupKeys
 
 	tables      map[flux.GroupKey]flux.Table
 	alloc       *memory.Allocator
@@ -387,6 +471,7 @@ type streamBuffer struct {
 	consumed map[values.Value]int
 	ready    map[values.Value]bool
 	stale    map[flux.GroupKey]bool
+	partners map[flux.GroupKey][]flux.GroupKey
 	last     values.Value
 	alloc    *memory.Allocator
 }
@@ -397,6 +482,7 @@ func newStreamBuffer(alloc *memory.Allocator) *streamBuffer {
 		consumed: make(map[values.Value]int),
 		ready:    make(map[values.Value]bool),
 		stale:    make(map[flux.GroupKey]bool),
+		partners: make(map[flux.GroupKey][]flux.GroupKey),
 		alloc:    alloc,
 	}
 }
@@ -492,7 +578,7 @@ func (s schema) Swap(i int, j int) {
 }
 
 // NewMergeJoinCache constructs a new instance of a MergeJoinCache
-func NewMergeJoinCache(alloc *memory.Allocator, datasetIDs []execute.DatasetID, tableNames map[execute.DatasetID]string, key []string) *MergeJoinCache {
+func NewMergeJoinCache(alloc *memory.Allocator, datasetIDs []execute.DatasetID, tableNames map[execute.DatasetID]string, key []string, method string) *MergeJoinCache {
 	// Join currently only accepts two data sources(streams) as input
 	if len(datasetIDs) != 2 {
 		panic("Join only accepts two data sources")
@@ -515,7 +601,12 @@ func NewMergeJoinCache(alloc *memory.Allocator, datasetIDs []execute.DatasetID,
 		intersection[k] = true
 	}
 
+	if method == "" {
+		method = "inner"
+	}
+
 	return &MergeJoinCache{
+		method:        method,
 		on:            on,
 		intersection:  intersection,
 		leftID:        datasetIDs[0],
@@ -523,36 +614,23 @@ func NewMergeJoinCache(alloc *memory.Allocator, datasetIDs []execute.DatasetID,
 		names:         names,
 		schemas:       schemas,
 		buffers:       buffers,
-		reverseLookup: make(map[flux.GroupKey]preJoinGroupKeys),
+		reverseLookup: make(map[flux.GroupKey][]preJoinGroupKeys),
 		postJoinKeys:  execute.NewGroupLookup(),
 		tables:        make(map[flux.GroupKey]flux.Table),
 		alloc:         alloc,
 	}
 }
 
-// Table joins the two tables associated with a single output group key and returns the resulting table
+// Table joins the tables associated with a single output group key and returns the resulting table
 func (c *MergeJoinCache) Table(key flux.GroupKey) (flux.Table, error) {
-	preJoinGroupKeys, ok := c.reverseLookup[key]
-
-	if !ok {
+	if _, ok := c.reverseLookup[key]; !ok {
 		return nil, fmt.Errorf("no table exists with group key: %v", key)
 	}
 
 	if _, ok := c.tables[key]; !ok {
-
-		left := c.buffers[c.leftID].table(preJoinGroupKeys.left)
-		if left == nil {
-			return nil, fmt.Errorf("no table in left join buffer with key: %v", key)
-		}
-
-		right := c.buffers[c.rightID].table(preJoinGroupKeys.right)
-		if left == nil {
-			return nil, fmt.Errorf("no table in right join buffer with key: %v", key)
-		}
-
-		table, err := c.join(left, right)
+		table, err := c.joinTables(key)
 		if err != nil {
-			return nil, fmt.Errorf("table with group key (%v) could not be fetched", key)
+			return nil, errors.Wrapf(err, "table with group key (%v) could not be fetched", key)
 		}
 
 		c.tables[key] = table
@@ -566,15 +644,7 @@ func (c *MergeJoinCache) ForEach(f func(flux.GroupKey)) {
 
 		if _, ok := c.tables[key]; !ok {
 
-			preJoinGroupKeys := c.reverseLookup[key]
-
-			leftKey := preJoinGroupKeys.left
-			rightKey := preJoinGroupKeys.right
-
-			leftBuilder := c.buffers[c.leftID].table(leftKey)
-			rightBuilder := c.buffers[c.rightID].table(rightKey)
-
-			table, err := c.join(leftBuilder, rightBuilder)
+			table, err := c.joinTables(key)
 			if err != nil || table.Empty() {
 				c.DiscardTable(key)
 				return
@@ -592,17 +662,9 @@ func (c *MergeJoinCache) ForEachWithContext(f func(flux.GroupKey, execute.Trigge
 
 	c.postJoinKeys.Range(func(key flux.GroupKey, value interface{}) {
 
-		preJoinGroupKeys := c.reverseLookup[key]
-
-		leftKey := preJoinGroupKeys.left
-		rightKey := preJoinGroupKeys.right
-
-		leftBuilder := c.buffers[c.leftID].table(leftKey)
-		rightBuilder := c.buffers[c.rightID].table(rightKey)
-
 		if _, ok := c.tables[key]; !ok {
 
-			table, err := c.join(leftBuilder, rightBuilder)
+			table, err := c.joinTables(key)
 
 			if err != nil || table.Empty() {
 				c.DiscardTable(key)
@@ -612,12 +674,19 @@ func (c *MergeJoinCache) ForEachWithContext(f func(flux.GroupKey, execute.Trigge
 			c.tables[key] = table
 		}
 
-		leftsize := leftBuilder.NRows()
-		rightsize := rightBuilder.NRows()
+		var count int
+		for _, preJoinGroupKeys := range c.reverseLookup[key] {
+			if leftBuilder := c.buffers[c.leftID].table(preJoinGroupKeys.left); leftBuilder != nil {
+				count += leftBuilder.NRows()
+			}
+			if rightBuilder := c.buffers[c.rightID].table(preJoinGroupKeys.right); rightBuilder != nil {
+				count += rightBuilder.NRows()
+			}
+		}
 
 		ctx := execute.TableContext{
 			Key:   key,
-			Count: leftsize + rightsize,
+			Count: count,
 		}
 
 		f(key, trigger, ctx)
@@ -639,13 +708,17 @@ func (c *MergeJoinCache) ExpireTable(key flux.GroupKey) {
 	delete(c.tables, key)
 
 	// Clear any stale data
-	preJoinGroupKeys := c.reverseLookup[key]
-
 	leftBuffer := c.buffers[c.leftID]
 	rightBuffer := c.buffers[c.rightID]
 
-	leftBuffer.expire(preJoinGroupKeys.left)
-	rightBuffer.expire(preJoinGroupKeys.right)
+	for _, preJoinGroupKeys := range c.reverseLookup[key] {
+		if preJoinGroupKeys.left != nil {
+			leftBuffer.expire(preJoinGroupKeys.left)
+		}
+		if preJoinGroupKeys.right != nil {
+			rightBuffer.expire(preJoinGroupKeys.right)
+		}
+	}
 
 	if c.canEvictTables() {
 
@@ -668,13 +741,32 @@ func (c *MergeJoinCache) SetTriggerSpec(spec flux.TriggerSpec) {
 
 // Currently tables are the smallest unit of data that can be evicted from the join's internal
 // buffers. This is the rule that specifies whether a data cache can early evict tables.
+// The tables of an outer join are kept until every table has been joined,
+// since their rows without a match are only known then.
 func (c *MergeJoinCache) canEvictTables() bool {
+	if c.preserves(c.leftID) || c.preserves(c.rightID) {
+		return false
+	}
 	leftKey := c.schemas[c.leftID].key
 	rightKey := c.schemas[c.rightID].key
 	return len(leftKey) > 0 && len(rightKey) > 0 &&
 		leftKey[0].Label == rightKey[0].Label && c.on[leftKey[0].Label]
 }
 
+// preserves reports whether the rows of the stream associated with id are kept when they have no match
+func (c *MergeJoinCache) preserves(id execute.DatasetID) bool {
+	switch c.method {
+	case "full":
+		return true
+	case "left":
+		return id == c.leftID
+	case "right":
+		return id == c.rightID
+	default:
+		return false
+	}
+}
+
 // insertIntoBuffer adds the rows of an incoming table to one of the Join's internal buffers
 func (c *MergeJoinCache) insertIntoBuffer(id execute.DatasetID, tbl flux.Table) error {
 	// Initialize schema if tbl is first from its stream
@@ -703,9 +795,10 @@ func (c *MergeJoinCache) insertIntoBuffer(id execute.DatasetID, tbl flux.Table)
 	// Optimization: if any group key columns overlap join key columns,
 	// and there are any nulls in those columns, we can discard this table,
 	// since null != null for joining purposes.
+	// The rows of an outer join must be kept, since they have no match.
 	k := tbl.Key()
 	for j, col := range k.Cols() {
-		if c.on[col.Label] {
+		if c.on[col.Label] && !c.preserves(id) {
 			if k.IsNull(j) {
 				// Discard the table and return.  Note: we need to iterate over the
 				// table at least once:
@@ -727,55 +820,81 @@ func (c *MergeJoinCache) insertIntoBuffer(id execute.DatasetID, tbl flux.Table)
 // that two group keys will not join (due to having different values on a join column)
 // they are skipped.
 func (c *MergeJoinCache) registerKey(id execute.DatasetID, key flux.GroupKey) {
-	var empty struct{}
+	if c.buffers[id].table(key) == nil {
+		// The table was discarded
+		return
+	}
+
 	switch id {
 
 	case c.leftID:
 
 		c.buffers[c.rightID].iterate(func(groupKey flux.GroupKey) {
-
-			keys := map[execute.DatasetID]flux.GroupKey{
-				c.leftID:  key,
-				c.rightID: groupKey,
-			}
-
 			for k := range c.intersection {
 				if !key.LabelValue(k).Equal(groupKey.LabelValue(k)) {
 					return
 				}
 			}
-
-			outputGroupKey := c.postJoinGroupKey(keys)
-			c.postJoinKeys.Set(outputGroupKey, empty)
-
-			c.reverseLookup[outputGroupKey] = preJoinGroupKeys{
-				left:  key,
-				right: groupKey,
-			}
+			c.registerPair(key, groupKey)
 		})
 
 	case c.rightID:
 
 		c.buffers[c.leftID].iterate(func(groupKey flux.GroupKey) {
-
-			keys := map[execute.DatasetID]flux.GroupKey{
-				c.leftID:  groupKey,
-				c.rightID: key,
-			}
-
 			for k := range c.intersection {
 				if !key.LabelValue(k).Equal(groupKey.LabelValue(k)) {
 					return
 				}
 			}
+			c.registerPair(groupKey, key)
+		})
+	}
+}
 
-			outputGroupKey := c.postJoinGroupKey(keys)
-			c.postJoinKeys.Set(outputGroupKey, empty)
+// registerPair stores the output group key of the tables with a left and a right group key.
+// One of the keys is nil for the rows of a table that have no match.
+func (c *MergeJoinCache) registerPair(left, right flux.GroupKey) {
+	if left != nil && right != nil {
+		leftBuffer := c.buffers[c.leftID]
+		leftBuffer.partners[left] = append(leftBuffer.partners[left], right)
 
-			c.reverseLookup[outputGroupKey] = preJoinGroupKeys{
-				left:  groupKey,
-				right: key,
-			}
+		rightBuffer := c.buffers[c.rightID]
+		rightBuffer.partners[right] = append(rightBuffer.partners[right], left)
+	}
+
+	outputGroupKey := c.postJoinGroupKey(left, right)
+	if key, ok := c.postJoinKeys.Lookup(outputGroupKey); ok {
+		// The rows without a match are added to the table with the same output group key
+		outputGroupKey = key.(flux.GroupKey)
+	} else {
+		c.postJoinKeys.Set(outputGroupKey, outputGroupKey)
+	}
+
+	c.reverseLookup[outputGroupKey] = append(c.reverseLookup[outputGroupKey], preJoinGroupKeys{
+		left:  left,
+		right: right,
+	})
+}
+
+// registerUnmatchedKeys stores the output group keys of the rows without a match of an outer join.
+// It must be called once every table has been registered.
+func (c *MergeJoinCache) registerUnmatchedKeys() {
+	if !c.preserves(c.leftID) && !c.preserves(c.rightID) {
+		return
+	}
+
+	if !c.joinColumnsResolved() {
+		c.resolveJoinColumns()
+	}
+
+	if c.preserves(c.leftID) {
+		c.buffers[c.leftID].iterate(func(key flux.GroupKey) {
+			c.registerPair(key, nil)
+		})
+	}
+	if c.preserves(c.rightID) {
+		c.buffers[c.rightID].iterate(func(key flux.GroupKey) {
+			c.registerPair(nil, key)
 		})
 	}
 }
@@ -784,15 +903,21 @@ func (c *MergeJoinCache) isBufferEmpty(id execute.DatasetID) bool {
 	return len(c.buffers[id].data) == 0
 }
 
-func (c *MergeJoinCache) postJoinSchemaBuilt() bool {
-	return c.schemaMap != nil
+func (c *MergeJoinCache) joinColumnsResolved() bool {
+	return c.onResolved
 }
 
-func (c *MergeJoinCache) buildPostJoinSchema() {
-	left := c.schemas[c.leftID].columns
-	right := c.schemas[c.rightID].columns
+// resolveJoinColumns defaults the join columns to the columns shared by the first table of each stream.
+// A cross join has no join columns.
+func (c *MergeJoinCache) resolveJoinColumns() {
+	c.onResolved = true
+	if len(c.on) == 0 && c.method != "cross" {
+		c.on = sharedColumns(c.schemas[c.leftID].columns, c.schemas[c.rightID].columns)
+	}
+}
 
-	// Find column names shared between the two tables
+// sharedColumns returns the labels of the columns that are in both the left and the right columns.
+func sharedColumns(left, right []flux.ColMeta) map[string]bool {
 	shared := make(map[string]bool, len(left))
 	for _, leftColumn := range left {
 		for _, rightColumn := range right {
@@ -803,31 +928,46 @@ func (c *MergeJoinCache) buildPostJoinSchema() {
 			}
 		}
 	}
+	return shared
+}
 
-	if len(c.on) == 0 {
-		c.on = shared
+// columns returns the columns of the table with the given group key.
+// The table of a nil key has no match, its columns are those of the first table of the stream.
+func (c *MergeJoinCache) columns(id execute.DatasetID, key flux.GroupKey) []flux.ColMeta {
+	if key == nil {
+		return c.schemas[id].columns
+	}
+	if table := c.buffers[id].table(key); table != nil {
+		return table.Cols()
 	}
+	return key.Cols()
+}
 
-	ncols := len(left) + len(right)
+// postJoinSchema builds the schema of the table joining the tables with a left and a right group key,
+// and maps each column of these tables to its column in the joined table.
+func (c *MergeJoinCache) postJoinSchema(left, right flux.GroupKey) (schema, map[tableCol]flux.ColMeta) {
+	leftColumns := c.columns(c.leftID, left)
+	rightColumns := c.columns(c.rightID, right)
 
-	c.schema = schema{
-		columns: make([]flux.ColMeta, 0, ncols-len(c.on)),
-		key:     make([]flux.ColMeta, 0, ncols-len(c.on)),
+	// Find column names shared between the two tables
+	shared := sharedColumns(leftColumns, rightColumns)
+
+	ncols := len(leftColumns) + len(rightColumns)
+
+	s := schema{
+		columns: make([]flux.ColMeta, 0, ncols),
 	}
 
-	c.colIndex = make(map[flux.ColMeta]int, ncols-len(c.on))
-	c.schemaMap = make(map[tableCol]flux.ColMeta, ncols)
-	added := make(map[string]bool, ncols-len(c.on))
+	schemaMap := make(map[tableCol]flux.ColMeta, ncols)
+	added := make(map[string]bool, ncols)
 
 	// Build schema for output table
-	addColumnsToSchema(c.names[c.leftID], left, added, shared, c.on, &c.schema, c.schemaMap)
-	addColumnsToSchema(c.names[c.rightID], right, added, shared, c.on, &c.schema, c.schemaMap)
+	addColumnsToSchema(c.names[c.leftID], leftColumns, added, shared, c.on, &s, schemaMap)
+	addColumnsToSchema(c.names[c.rightID], rightColumns, added, shared, c.on, &s, schemaMap)
 
 	// Give schema an order
-	sort.Sort(c.schema)
-	for j, column := range c.schema.columns {
-		c.colIndex[column] = j
-	}
+	sort.Sort(s)
+	return s, schemaMap
 }
 
 // equalJoinKeys compares two keys for equality.
@@ -843,133 +983,319 @@ func equalJoinkeys(left, right flux.GroupKey) bool {
 	return true
 }
 
-func (c *MergeJoinCache) join(left, right *execute.ColListTableBuilder) (flux.Table, error) {
-	// Determine sort order for the joining tables
-	on := make([]string, len(c.on))
+// sortedJoinColumns determines sort order for the joining tables.
+// The keys of the rows are built in the same order,
+// so that they compare consistently when the tables order their columns differently.
+func (c *MergeJoinCache) sortedJoinColumns() []string {
+	on := make([]string, 0, len(c.on))
 
 	for k := range c.on {
 		on = append(on, k)
 	}
+	sort.Strings(on)
+	return on
+}
 
-	// Sort input tables
-	left.Sort(on, false)
-	right.Sort(on, false)
+// joinTables joins the pairs of tables associated with an output group key.
+// The rows of every pair make up a single table.
+func (c *MergeJoinCache) joinTables(key flux.GroupKey) (flux.Table, error) {
+	pairs := c.reverseLookup[key]
+	tables := make([]flux.Table, 0, len(pairs))
+	for _, preJoinGroupKeys := range pairs {
+		left := c.buffers[c.leftID].table(preJoinGroupKeys.left)
+		if left == nil && preJoinGroupKeys.left != nil {
+			return nil, fmt.Errorf("no table in left join buffer with key: %v", key)
+		}
 
-	var leftSet, rightSet subset
-	var leftKey, rightKey flux.GroupKey
+		right := c.buffers[c.rightID].table(preJoinGroupKeys.right)
+		if right == nil && preJoinGroupKeys.right != nil {
+			return nil, fmt.Errorf("no table in right join buffer with key: %v", key)
+		}
 
-	leftSet, leftKey = c.advance(leftSet.Stop, left)
-	rightSet, rightKey = c.advance(rightSet.Stop, right)
+		table, err := c.join(left, right)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	if len(tables) == 1 {
+		return tables[0], nil
+	}
+	return c.concat(key, tables)
+}
+
+// concat returns a table with the rows of the tables sorted by the join columns.
+// A column that is not in a table is null for its rows.
+func (c *MergeJoinCache) concat(key flux.GroupKey, tables []flux.Table) (flux.Table, error) {
+	s := schema{}
+	for _, table := range tables {
+		for _, column := range table.Cols() {
+			if j := execute.ColIdx(column.Label, s.columns); j < 0 {
+				s.columns = append(s.columns, column)
+			} else if s.columns[j].Type != column.Type {
+				return nil, fmt.Errorf("column %q has conflicting types %v and %v", column.Label, s.columns[j].Type, column.Type)
+			}
+		}
+	}
+	sort.Sort(s)
 
-	keys := map[execute.DatasetID]flux.GroupKey{
-		c.leftID:  left.Key(),
-		c.rightID: right.Key(),
+	builder := execute.NewColListTableBuilder(key, c.alloc)
+	for _, column := range s.columns {
+		if _, err := builder.AddCol(column); err != nil {
+			return nil, err
+		}
+	}
+	for _, table := range tables {
+		if err := table.Do(func(cr flux.ColReader) error {
+			for i := 0; i < cr.Len(); i++ {
+				for j, column := range s.columns {
+					v := values.NewNull(flux.SemanticType(column.Type))
+					if k := execute.ColIdx(column.Label, cr.Cols()); k >= 0 {
+						v = execute.ValueForRow(cr, i, k)
+					}
+					if err := builder.AppendValue(j, v); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	builder.Sort(c.sortedJoinColumns(), false)
+	return builder.Table()
+}
+
+// join joins a left and a right table.
+// If one of the tables is nil, the rows of the other table that have no match are joined with null values.
+func (c *MergeJoinCache) join(left, right *execute.ColListTableBuilder) (flux.Table, error) {
+	var leftKey, rightKey flux.GroupKey
+	if left != nil {
+		leftKey = left.Key()
+	}
+	if right != nil {
+		rightKey = right.Key()
 	}
 
 	// Instantiate a builder for the output table
-	groupKey := c.postJoinGroupKey(keys)
+	s, schemaMap := c.postJoinSchema(leftKey, rightKey)
+	groupKey := c.postJoinGroupKey(leftKey, rightKey)
 	builder := execute.NewColListTableBuilder(groupKey, c.alloc)
 
-	for _, column := range c.schema.columns {
+	colIndex := make(map[string]int, len(s.columns))
+	for j, column := range s.columns {
 		_, err := builder.AddCol(column)
 		if err != nil {
 			return nil, err
 		}
+		colIndex[column.Label] = j
+	}
+
+	// appendRow appends the row joining a left and a right record.
+	// The columns of a nil record are null.
+	appendRow := func(leftRecord, rightRecord values.Object) error {
+		row := make([]values.Value, len(s.columns))
+		set := func(id execute.DatasetID, record values.Object) {
+			record.Range(func(columnName string, columnVal values.Value) {
+				column := tableCol{
+					table: c.names[id],
+					col:   columnName,
+				}
+				newColumnIdx := colIndex[schemaMap[column].Label]
+				row[newColumnIdx] = columnVal
+			})
+		}
+		if leftRecord != nil {
+			set(c.leftID, leftRecord)
+		}
+		// The values of the join columns are the same in both records
+		if rightRecord != nil {
+			set(c.rightID, rightRecord)
+		}
+		for j, v := range row {
+			if v == nil {
+				v = values.NewNull(flux.SemanticType(s.columns[j].Type))
+			}
+			if err := builder.AppendValue(j, v); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
+	on := c.sortedJoinColumns()
+
+	if left == nil {
+		matched := c.matchedRows(c.rightID, right, on)
+		for r := 0; r < right.NRows(); r++ {
+			if matched[r] {
+				continue
+			}
+			if err := appendRow(nil, right.GetRow(r)); err != nil {
+				return nil, err
+			}
+		}
+		return builder.Table()
+	}
+	if right == nil {
+		matched := c.matchedRows(c.leftID, left, on)
+		for l := 0; l < left.NRows(); l++ {
+			if matched[l] {
+				continue
+			}
+			if err := appendRow(left.GetRow(l), nil); err != nil {
+				return nil, err
+			}
+		}
+		return builder.Table()
+	}
+
+	// Sort input tables
+	left.Sort(on, false)
+	right.Sort(on, false)
+
+	var leftSet, rightSet subset
+	var leftRowKey, rightRowKey flux.GroupKey
+
+	leftSet, leftRowKey = c.advance(leftSet.Stop, left, on)
+	rightSet, rightRowKey = c.advance(rightSet.Stop, right, on)
+
 	// Perform sort merge join
 	for !leftSet.Empty() && !rightSet.Empty() {
-		if equalJoinkeys(leftKey, rightKey) {
+		if equalJoinkeys(leftRowKey, rightRowKey) {
 
 			for l := leftSet.Start; l < leftSet.Stop; l++ {
 				for r := rightSet.Start; r < rightSet.Stop; r++ {
-
-					leftRecord := left.GetRow(l)
-					rightRecord := right.GetRow(r)
-
-					leftRecord.Range(func(columnName string, columnVal values.Value) {
-						column := tableCol{
-							table: c.names[c.leftID],
-							col:   columnName,
-						}
-						newColumn := c.schemaMap[column]
-						newColumnIdx := c.colIndex[newColumn]
-						_ = builder.AppendValue(newColumnIdx, columnVal)
-					})
-
-					rightRecord.Range(func(columnName string, columnVal values.Value) {
-						column := tableCol{
-							table: c.names[c.rightID],
-							col:   columnName,
-						}
-						newColumn := c.schemaMap[column]
-						newColumnIdx := c.colIndex[newColumn]
-
-						// No need to append value if column is part of the join key.
-						// Because value already appended when iterating over left record.
-						if !c.on[newColumn.Label] {
-							_ = builder.AppendValue(newColumnIdx, columnVal)
-						}
-					})
+					if err := appendRow(left.GetRow(l), right.GetRow(r)); err != nil {
+						return nil, err
+					}
 				}
 			}
-			leftSet, leftKey = c.advance(leftSet.Stop, left)
-			rightSet, rightKey = c.advance(rightSet.Stop, right)
-		} else if leftKey.Less(rightKey) {
-			leftSet, leftKey = c.advance(leftSet.Stop, left)
+			leftSet, leftRowKey = c.advance(leftSet.Stop, left, on)
+			rightSet, rightRowKey = c.advance(rightSet.Stop, right, on)
+		} else if leftRowKey.Less(rightRowKey) {
+			leftSet, leftRowKey = c.advance(leftSet.Stop, left, on)
 		} else {
-			rightSet, rightKey = c.advance(rightSet.Stop, right)
+			rightSet, rightRowKey = c.advance(rightSet.Stop, right, on)
 		}
 	}
 
 	return builder.Table()
 }
 
-// postJoinGroupKey produces a new group key value from a left and a right group key value
-func (c *MergeJoinCache) postJoinGroupKey(keys map[execute.DatasetID]flux.GroupKey) flux.GroupKey {
+// matchedRows sorts a table of the stream associated with id and reports which of its rows
+// join with a row of any of the tables of the other stream that it was joined with.
+func (c *MergeJoinCache) matchedRows(id execute.DatasetID, table *execute.ColListTableBuilder, on []string) []bool {
+	otherID := c.leftID
+	if id == c.leftID {
+		otherID = c.rightID
+	}
+
+	table.Sort(on, false)
+	matched := make([]bool, table.NRows())
+
+	for _, key := range c.buffers[id].partners[table.Key()] {
+		other := c.buffers[otherID].table(key)
+		if other == nil {
+			continue
+		}
+		other.Sort(on, false)
+
+		set, rowKey := c.advance(0, table, on)
+		otherSet, otherRowKey := c.advance(0, other, on)
+
+		for !set.Empty() && !otherSet.Empty() {
+			if equalJoinkeys(rowKey, otherRowKey) {
+				for i := set.Start; i < set.Stop; i++ {
+					matched[i] = true
+				}
+				set, rowKey = c.advance(set.Stop, table, on)
+				otherSet, otherRowKey = c.advance(otherSet.Stop, other, on)
+			} else if rowKey.Less(otherRowKey) {
+				set, rowKey = c.advance(set.Stop, table, on)
+			} else {
+				otherSet, otherRowKey = c.advance(otherSet.Stop, other, on)
+			}
+		}
+	}
+	return matched
+}
+
+// postJoinGroupKey produces a new group key value from a left and a right group key value.
+// The key of the table without a match of an outer join is nil,
+// its group key columns are those of the first table of the stream with null values.
+func (c *MergeJoinCache) postJoinGroupKey(left, right flux.GroupKey) flux.GroupKey {
+	keys := map[execute.DatasetID]flux.GroupKey{
+		c.leftID:  left,
+		c.rightID: right,
+	}
+
 	key := groupKey{
 		cols: make([]flux.ColMeta, 0, len(keys)*5),
 		vals: make([]values.Value, 0, len(keys)*5),
 	}
 
+	_, schemaMap := c.postJoinSchema(left, right)
 	added := make(map[string]bool, len(keys)*5)
 
-	for id, groupKey := range keys {
-		for j, column := range groupKey.Cols() {
+	add := func(id execute.DatasetID, cols []flux.ColMeta, value func(j int) values.Value) {
+		for j, column := range cols {
 
 			tableAndColumn := tableCol{
 				table: c.names[id],
 				col:   column.Label,
 			}
 
-			colMeta := c.schemaMap[tableAndColumn]
+			colMeta := schemaMap[tableAndColumn]
 
 			if !added[colMeta.Label] {
 				key.cols = append(key.cols, colMeta)
-				key.vals = append(key.vals, groupKey.Value(j))
+				key.vals = append(key.vals, value(j))
 			}
 
 			added[colMeta.Label] = true
 		}
 	}
 
+	// The columns of the present keys are added first,
+	// so that a join column takes its value from a table that has rows.
+	for id, groupKey := range keys {
+		if groupKey != nil {
+			add(id, groupKey.Cols(), groupKey.Value)
+		}
+	}
+	for id, groupKey := range keys {
+		if groupKey == nil {
+			cols := c.schemas[id].key
+			add(id, cols, func(j int) values.Value {
+				return values.NewNull(flux.SemanticType(cols[j].Type))
+			})
+		}
+	}
+
 	// Table columns are always sorted so need
 	// to sort the group key for consistency
 	sort.Sort(key)
 	return execute.NewGroupKey(key.cols, key.vals)
 }
 
-// advance advances the row pointer of a sorted table that is being joined
-func (c *MergeJoinCache) advance(offset int, table *execute.ColListTableBuilder) (subset, flux.GroupKey) {
+// colReader returns the rows of a table that is being joined.
+func colReader(table *execute.ColListTableBuilder) flux.ColReader {
 	// TODO(jlapacik): this is a temporary hack
 	// remove when ColListTableBuilder implements ColReader
 	tbl, _ := table.Table()
-	cr := tbl.(flux.ColReader)
+	return tbl.(flux.ColReader)
+}
+
+// advance advances the row pointer of a sorted table that is being joined
+func (c *MergeJoinCache) advance(offset int, table *execute.ColListTableBuilder, on []string) (subset, flux.GroupKey) {
+	cr := colReader(table)
 	if n := cr.Len(); n == offset {
 		return subset{Start: n, Stop: n}, nil
 	}
 	start := offset
-	key := execute.GroupKeyForRowOn(start, cr, c.on)
+	key := joinKeyForRow(start, cr, on)
 	sequence := subset{Start: start}
 	offset++
 	for offset < cr.Len() && equalRowKeys(start, offset, cr, c.on) {
@@ -979,6 +1305,21 @@ func (c *MergeJoinCache) advance(offset int, table *execute.ColListTableBuilder)
 	return sequence, key
 }
 
+// joinKeyForRow returns the values of the join columns of a row as a key, with the columns in the given order.
+func joinKeyForRow(i int, cr flux.ColReader, on []string) flux.GroupKey {
+	cols := make([]flux.ColMeta, 0, len(on))
+	vs := make([]values.Value, 0, len(on))
+	for _, label := range on {
+		j := execute.ColIdx(label, cr.Cols())
+		if j < 0 {
+			continue
+		}
+		cols = append(cols, cr.Cols()[j])
+		vs = append(vs, execute.ValueForRow(cr, i, j))
+	}
+	return execute.NewGroupKey(cols, vs)
+}
+
 type subset struct {
 	Start int
 	Stop  int
@@ -988,7 +1329,8 @@ func (s subset) Empty() bool {
 	return s.Start == s.Stop
 }
 
-// equalRowKeys determines whether two rows of a table are equal on the set of columns defined by on
+// equalRowKeys determines whether two rows of a table are equal on the set of columns defined by on.
+// Two null values are equal, a null value is not equal to any other value.
 func equalRowKeys(x, y int, cr flux.ColReader, on map[string]bool) bool {
 	for j, c := range cr.Cols() {
 		if !on[c.Label] {
@@ -996,27 +1338,27 @@ func equalRowKeys(x, y int, cr flux.ColReader, on map[string]bool) bool {
 		}
 		switch c.Type {
 		case flux.TBool:
-			if xv, yv := cr.Bools(j).Value(x), cr.Bools(j).Value(y); xv != yv {
+			if vs := cr.Bools(j); vs.IsValid(x) != vs.IsValid(y) || vs.IsValid(x) && vs.Value(x) != vs.Value(y) {
 				return false
 			}
 		case flux.TInt:
-			if xv, yv := cr.Ints(j).Value(x), cr.Ints(j).Value(y); xv != yv {
+			if vs := cr.Ints(j); vs.IsValid(x) != vs.IsValid(y) || vs.IsValid(x) && vs.Value(x) != vs.Value(y) {
 				return false
 			}
 		case flux.TUInt:
-			if xv, yv := cr.UInts(j).Value(x), cr.UInts(j).Value(y); xv != yv {
+			if vs := cr.UInts(j); vs.IsValid(x) != vs.IsValid(y) || vs.IsValid(x) && vs.Value(x) != vs.Value(y) {
 				return false
 			}
 		case flux.TFloat:
-			if xv, yv := cr.Floats(j).Value(x), cr.Floats(j).Value(y); xv != yv {
+			if vs := cr.Floats(j); vs.IsValid(x) != vs.IsValid(y) || vs.IsValid(x) && vs.Value(x) != vs.Value(y) {
 				return false
 			}
 		case flux.TString:
-			if xv, yv := cr.Strings(j).ValueString(x), cr.Strings(j).ValueString(y); xv != yv {
+			if vs := cr.Strings(j); vs.IsValid(x) != vs.IsValid(y) || vs.IsValid(x) && vs.ValueString(x) != vs.ValueString(y) {
 				return false
 			}
 		case flux.TTime:
-			if xv, yv := cr.Times(j).Value(x), cr.Times(j).Value(y); xv != yv {
+			if vs := cr.Times(j); vs.IsValid(x) != vs.IsValid(y) || vs.IsValid(x) && vs.Value(x) != vs.Value(y) {
 				return false
 			}
 		default:
@@ -1,6 +1,7 @@
 package universe_test
 
 import (
+	"errors"
 	"sort"
 	"testing"
 	"time"
@@ -151,6 +152,111 @@ func TestJoin_NewQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "asof join",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				join(tables:{a:a,b:b}, on:["host"], method:"asof", tolerance:10ms, direction:"nearest")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "dbA",
+						},
+					},
+					{
+						ID: "from1",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "dbB",
+						},
+					},
+					{
+						ID: "join2",
+						Spec: &universe.JoinOpSpec{
+							On:         []string{"host"},
+							TableNames: map[flux.OperationID]string{"from0": "a", "from1": "b"},
+							Method:     "asof",
+							Tolerance:  flux.Duration(10 * time.Millisecond),
+							Direction:  "nearest",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "join2"},
+					{Parent: "from1", Child: "join2"},
+				},
+			},
+		},
+		{
+			Name: "asof join with default direction",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				join(tables:{a:a,b:b}, method:"asof", tolerance:1s)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "dbA",
+						},
+					},
+					{
+						ID: "from1",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "dbB",
+						},
+					},
+					{
+						ID: "join2",
+						Spec: &universe.JoinOpSpec{
+							TableNames: map[flux.OperationID]string{"from0": "a", "from1": "b"},
+							Method:     "asof",
+							Tolerance:  flux.Duration(time.Second),
+							Direction:  "backward",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "join2"},
+					{Parent: "from1", Child: "join2"},
+				},
+			},
+		},
+		{
+			Name: "asof join without tolerance",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				join(tables:{a:a,b:b}, method:"asof")`,
+			WantErr: true,
+		},
+		{
+			Name: "asof join with invalid direction",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				join(tables:{a:a,b:b}, method:"asof", tolerance:1s, direction:"sideways")`,
+			WantErr: true,
+		},
+		{
+			Name: "asof join on time",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				join(tables:{a:a,b:b}, on:["_time"], method:"asof", tolerance:1s)`,
+			WantErr: true,
+		},
+		{
+			Name: "tolerance without asof join",
+			Raw: `
+				a = from(bucket:"dbA")
+				b = from(bucket:"dbB")
+				join(tables:{a:a,b:b}, on:["_time"], tolerance:1s)`,
+			WantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -622,6 +728,59 @@ func TestMergeJoin_Process(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "inner with different column order",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time", "t1", "t2"},
+				TableNames: tableNames,
+			},
+			data0: []*executetest.Table{
+				{
+					KeyCols: []string{"t1", "t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "t1", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "a", 1.0, "x"},
+						{execute.Time(2), "a", 2.0, "x"},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					KeyCols: []string{"t1", "t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "t2", Type: flux.TString},
+						{Label: "_time", Type: flux.TTime},
+						{Label: "t1", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"x", execute.Time(1), "a", 10.0},
+						{"x", execute.Time(2), "a", 20.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"t1", "t2"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "t1", Type: flux.TString},
+						{Label: "t2", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, 10.0, "a", "x"},
+						{execute.Time(2), 2.0, 20.0, "a", "x"},
+					},
+				},
+			},
+		},
 		{
 			name: "inner with common tags and nulls",
 			spec: &universe.MergeJoinProcedureSpec{
@@ -1502,47 +1661,771 @@ func TestMergeJoin_Process(t *testing.T) {
 				},
 			},
 		},
-	}
-	for _, tc := range testCases {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			if tc.skip {
-				t.Skip()
-			}
-
-			id0 := executetest.RandomDatasetID()
-			id1 := executetest.RandomDatasetID()
-
-			parents := []execute.DatasetID{
-				execute.DatasetID(id0),
-				execute.DatasetID(id1),
-			}
-
-			tableNames := make(map[execute.DatasetID]string, len(tc.spec.TableNames))
-			for i, name := range tc.spec.TableNames {
-				tableNames[parents[i]] = name
-			}
-
-			d := executetest.NewDataset(executetest.RandomDatasetID())
-			c := universe.NewMergeJoinCache(executetest.UnlimitedAllocator, parents, tableNames, tc.spec.On)
-			c.SetTriggerSpec(execute.DefaultTriggerSpec)
-			jt := universe.NewMergeJoinTransformation(d, c, tc.spec, parents, tableNames)
-
-			l := len(tc.data0)
-			if len(tc.data1) > l {
-				l = len(tc.data1)
-			}
-			for i := 0; i < l; i++ {
-				if i < len(tc.data0) {
-					if err := jt.Process(parents[0], tc.data0[i]); err != nil {
-						t.Fatal(err)
-					}
-				}
-				if i < len(tc.data1) {
-					if err := jt.Process(parents[1], tc.data1[i]); err != nil {
-						t.Fatal(err)
-					}
-				}
+		{
+			name: "left with missing values",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time"},
+				TableNames: tableNames,
+				Method:     "left",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+						{execute.Time(3), 3.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 10.0},
+						{execute.Time(3), 30.0},
+						{execute.Time(4), 40.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, 10.0},
+						{execute.Time(2), 2.0, nil},
+						{execute.Time(3), 3.0, 30.0},
+					},
+				},
+			},
+		},
+		{
+			name: "right with missing values",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time"},
+				TableNames: tableNames,
+				Method:     "right",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+						{execute.Time(3), 3.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 10.0},
+						{execute.Time(3), 30.0},
+						{execute.Time(4), 40.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, 10.0},
+						{execute.Time(3), 3.0, 30.0},
+						{execute.Time(4), nil, 40.0},
+					},
+				},
+			},
+		},
+		{
+			name: "full with different columns",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time"},
+				TableNames: tableNames,
+				Method:     "full",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "count", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), int64(20)},
+						{execute.Time(3), int64(30)},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "count", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, nil},
+						{execute.Time(2), 2.0, int64(20)},
+						{execute.Time(3), nil, int64(30)},
+					},
+				},
+			},
+		},
+		{
+			name: "full with nulls in join columns",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time"},
+				TableNames: tableNames,
+				Method:     "full",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{nil, 100.0},
+						{execute.Time(1), 1.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 10.0},
+						{nil, 300.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{nil, 100.0, nil},
+						{nil, nil, 300.0},
+						{execute.Time(1), 1.0, 10.0},
+					},
+				},
+			},
+		},
+		{
+			name: "left with tables without a match",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time", "tag"},
+				TableNames: tableNames,
+				Method:     "left",
+			},
+			data0: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a"},
+						{execute.Time(2), 2.0, "a"},
+					},
+				},
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 3.0, "b"},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 10.0, "a"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, 10.0, "a"},
+						{execute.Time(2), 2.0, nil, "a"},
+					},
+				},
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 3.0, nil, "b"},
+					},
+				},
+			},
+		},
+		{
+			name: "left with different group keys",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"_time"},
+				TableNames: tableNames,
+				Method:     "left",
+			},
+			data0: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a"},
+						{execute.Time(2), 2.0, "a"},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					KeyCols: []string{"host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 10.0, "A"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"host", "tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "host", Type: flux.TString},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, 10.0, "A", "a"},
+					},
+				},
+				{
+					KeyCols: []string{"host", "tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "host", Type: flux.TString},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(2), 2.0, nil, nil, "a"},
+					},
+				},
+			},
+		},
+		{
+			name: "cross",
+			spec: &universe.MergeJoinProcedureSpec{
+				TableNames: tableNames,
+				Method:     "cross",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0},
+						{execute.Time(2), 2.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 10.0},
+						{execute.Time(3), 30.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time_a", Type: flux.TTime},
+						{Label: "_time_b", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), execute.Time(1), 1.0, 10.0},
+						{execute.Time(1), execute.Time(3), 1.0, 30.0},
+						{execute.Time(2), execute.Time(1), 2.0, 10.0},
+						{execute.Time(2), execute.Time(3), 2.0, 30.0},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip {
+				t.Skip()
+			}
+
+			id0 := executetest.RandomDatasetID()
+			id1 := executetest.RandomDatasetID()
+
+			parents := []execute.DatasetID{
+				execute.DatasetID(id0),
+				execute.DatasetID(id1),
+			}
+
+			tableNames := make(map[execute.DatasetID]string, len(tc.spec.TableNames))
+			for i, name := range tc.spec.TableNames {
+				tableNames[parents[i]] = name
+			}
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := universe.NewMergeJoinCache(executetest.UnlimitedAllocator, parents, tableNames, tc.spec.On, tc.spec.Method)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			jt := universe.NewMergeJoinTransformation(d, c, tc.spec, parents, tableNames)
+
+			l := len(tc.data0)
+			if len(tc.data1) > l {
+				l = len(tc.data1)
+			}
+			for i := 0; i < l; i++ {
+				if i < len(tc.data0) {
+					if err := jt.Process(parents[0], tc.data0[i]); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if i < len(tc.data1) {
+					if err := jt.Process(parents[1], tc.data1[i]); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			jt.Finish(parents[0], nil)
+			jt.Finish(parents[1], nil)
+
+			got, err := executetest.TablesFromCache(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			executetest.NormalizeTables(got)
+			executetest.NormalizeTables(tc.want)
+
+			sort.Sort(executetest.SortedTables(got))
+			sort.Sort(executetest.SortedTables(tc.want))
+
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestAsOfJoin_Process(t *testing.T) {
+	tableNames := []string{"a", "b"}
+
+	testCases := []struct {
+		name    string
+		spec    *universe.MergeJoinProcedureSpec
+		data0   []*executetest.Table // data from parent 0
+		data1   []*executetest.Table // data from parent 1
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "asof backward",
+			spec: &universe.MergeJoinProcedureSpec{
+				TableNames: tableNames,
+				Method:     "asof",
+				Tolerance:  flux.Duration(5),
+				Direction:  "backward",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{nil, 5.0},
+						{execute.Time(10), 1.0},
+						{execute.Time(20), 2.0},
+						{execute.Time(30), 3.0},
+						{execute.Time(40), 4.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{nil, 60.0},
+						{execute.Time(9), 10.0},
+						{execute.Time(21), 20.0},
+						{execute.Time(28), 30.0},
+						{execute.Time(50), 50.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{nil, 5.0, nil},
+						{execute.Time(10), 1.0, 10.0},
+						{execute.Time(20), 2.0, nil},
+						{execute.Time(30), 3.0, 30.0},
+						{execute.Time(40), 4.0, nil},
+					},
+				},
+			},
+		},
+		{
+			name: "asof forward",
+			spec: &universe.MergeJoinProcedureSpec{
+				TableNames: tableNames,
+				Method:     "asof",
+				Tolerance:  flux.Duration(10),
+				Direction:  "forward",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0},
+						{execute.Time(20), 2.0},
+						{execute.Time(30), 3.0},
+						{execute.Time(40), 4.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(9), 10.0},
+						{execute.Time(21), 20.0},
+						{execute.Time(50), 50.0},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, nil},
+						{execute.Time(20), 2.0, 20.0},
+						{execute.Time(30), 3.0, nil},
+						{execute.Time(40), 4.0, 50.0},
+					},
+				},
+			},
+		},
+		{
+			name: "asof nearest on tags",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"tag"},
+				TableNames: tableNames,
+				Method:     "asof",
+				Tolerance:  flux.Duration(5),
+				Direction:  "nearest",
+			},
+			data0: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, "a"},
+						{execute.Time(20), 2.0, "a"},
+					},
+				},
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 3.0, "b"},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(8), 10.0, "a"},
+						{execute.Time(11), 11.0, "a"},
+						{execute.Time(18), 18.0, "a"},
+						{execute.Time(22), 22.0, "a"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, 11.0, "a"},
+						{execute.Time(20), 2.0, 18.0, "a"},
+					},
+				},
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value_a", Type: flux.TFloat},
+						{Label: "_value_b", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 3.0, nil, "b"},
+					},
+				},
+			},
+		},
+		{
+			name: "asof unsorted",
+			spec: &universe.MergeJoinProcedureSpec{
+				TableNames: tableNames,
+				Method:     "asof",
+				Tolerance:  flux.Duration(5),
+				Direction:  "backward",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0},
+					},
+				},
+			},
+			data1: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(21), 20.0},
+						{execute.Time(9), 10.0},
+					},
+				},
+			},
+			wantErr: errors.New(`an as-of join requires tables sorted by "_time"`),
+		},
+		{
+			name: "asof on column not in group key",
+			spec: &universe.MergeJoinProcedureSpec{
+				On:         []string{"tag"},
+				TableNames: tableNames,
+				Method:     "asof",
+				Tolerance:  flux.Duration(5),
+				Direction:  "backward",
+			},
+			data0: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, "a"},
+					},
+				},
+			},
+			wantErr: errors.New(`the join columns of an as-of join must be in the group key, "tag" is not in the group key {}`),
+		},
+		{
+			name: "asof several right tables",
+			spec: &universe.MergeJoinProcedureSpec{
+				TableNames: tableNames,
+				Method:     "asof",
+				Tolerance:  flux.Duration(5),
+				Direction:  "backward",
+			},
+			data1: []*executetest.Table{
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 1.0, "a"},
+					},
+				},
+				{
+					KeyCols: []string{"tag"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "tag", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(10), 2.0, "b"},
+					},
+				},
+			},
+			wantErr: errors.New(`the right stream of an as-of join must have a single table for each value of the join columns, found several tables with {}`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parents := []execute.DatasetID{
+				executetest.RandomDatasetID(),
+				executetest.RandomDatasetID(),
+			}
+
+			tableNames := make(map[execute.DatasetID]string, len(tc.spec.TableNames))
+			for i, name := range tc.spec.TableNames {
+				tableNames[parents[i]] = name
+			}
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			jt := universe.NewAsOfJoinTransformation(d, c, tc.spec, parents, tableNames, executetest.UnlimitedAllocator)
+
+			var err error
+			l := len(tc.data0)
+			if len(tc.data1) > l {
+				l = len(tc.data1)
+			}
+			for i := 0; i < l && err == nil; i++ {
+				if i < len(tc.data0) {
+					err = jt.Process(parents[0], tc.data0[i])
+				}
+				if i < len(tc.data1) && err == nil {
+					err = jt.Process(parents[1], tc.data1[i])
+				}
+			}
+			if err == nil {
+				jt.Finish(parents[0], nil)
+				jt.Finish(parents[1], nil)
+				err = d.FinishedErr
+			}
+			if tc.wantErr != nil {
+				if err == nil || err.Error() != tc.wantErr.Error() {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
 			}
 
 			got, err := executetest.TablesFromCache(c)
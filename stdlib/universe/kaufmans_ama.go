@@ -0,0 +1,140 @@
+package universe
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+const KaufmansAMAKind = "kaufmansAMA"
+
+type KaufmansAMAOpSpec struct {
+	MovingAverageConfig
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", KaufmansAMAKind, flux.FunctionValue(KaufmansAMAKind, createKaufmansAMAOpSpec, movingAverageSignature()))
+	flux.RegisterOpSpec(KaufmansAMAKind, newKaufmansAMAOp)
+	plan.RegisterProcedureSpec(KaufmansAMAKind, newKaufmansAMAProcedure, KaufmansAMAKind)
+	execute.RegisterTransformation(KaufmansAMAKind, createKaufmansAMATransformation)
+}
+
+func createKaufmansAMAOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(KaufmansAMAOpSpec)
+	if err := spec.MovingAverageConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newKaufmansAMAOp() flux.OperationSpec {
+	return new(KaufmansAMAOpSpec)
+}
+
+func (s *KaufmansAMAOpSpec) Kind() flux.OperationKind {
+	return KaufmansAMAKind
+}
+
+type KaufmansAMAProcedureSpec struct {
+	plan.DefaultCost
+	MovingAverageConfig
+}
+
+func newKaufmansAMAProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*KaufmansAMAOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &KaufmansAMAProcedureSpec{
+		MovingAverageConfig: spec.MovingAverageConfig,
+	}, nil
+}
+
+func (s *KaufmansAMAProcedureSpec) Kind() plan.ProcedureKind {
+	return KaufmansAMAKind
+}
+func (s *KaufmansAMAProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(KaufmansAMAProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createKaufmansAMATransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*KaufmansAMAProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewKaufmansAMATransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewKaufmansAMATransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *KaufmansAMAProcedureSpec) execute.Transformation {
+	n := int(spec.N)
+	return newAveragingTransformation(d, cache, KaufmansAMAKind, spec.Column, func() averager {
+		return newKaufmansAverager(n)
+	})
+}
+
+// The smoothing constants of the fastest and slowest exponential moving averages
+// that Kaufman's adaptive moving average moves between, those of 2 and 30 values.
+const (
+	kaufmansFastest = 2.0 / (2 + 1)
+	kaufmansSlowest = 2.0 / (30 + 1)
+)
+
+// kaufmansAverager computes Kaufman's adaptive moving average.
+// Its smoothing constant follows the efficiency ratio of the last n changes,
+// the net change divided by the sum of the absolute changes.
+// It starts from the nth value, so the first average is that of the value after it.
+type kaufmansAverager struct {
+	n int
+	// values holds the last n+1 values.
+	values []float64
+	kama   float64
+}
+
+func newKaufmansAverager(n int) *kaufmansAverager {
+	return &kaufmansAverager{
+		n:      n,
+		values: make([]float64, 0, n+1),
+	}
+}
+
+func (a *kaufmansAverager) add(v float64) (float64, bool) {
+	if len(a.values) == a.n+1 {
+		copy(a.values, a.values[1:])
+		a.values = a.values[:a.n]
+	}
+	a.values = append(a.values, v)
+	if len(a.values) < a.n {
+		return 0, false
+	}
+	if len(a.values) == a.n {
+		a.kama = v
+		return 0, false
+	}
+
+	change := math.Abs(v - a.values[0])
+	volatility := 0.0
+	for i := 1; i < len(a.values); i++ {
+		volatility += math.Abs(a.values[i] - a.values[i-1])
+	}
+	er := 0.0
+	if volatility != 0 {
+		er = change / volatility
+	}
+	sc := er*(kaufmansFastest-kaufmansSlowest) + kaufmansSlowest
+	sc *= sc
+	a.kama += sc * (v - a.kama)
+	return a.kama, true
+}
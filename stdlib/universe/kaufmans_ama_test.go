@@ -0,0 +1,61 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestKaufmansAMAOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"kaufmansAMA","kind":"kaufmansAMA","spec":{"n":2,"column":"_value"}}`)
+	op := &flux.Operation{
+		ID: "kaufmansAMA",
+		Spec: &universe.KaufmansAMAOpSpec{
+			MovingAverageConfig: universe.MovingAverageConfig{N: 2, Column: "_value"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestKaufmansAMA_Process(t *testing.T) {
+	spec := &universe.KaufmansAMAProcedureSpec{
+		MovingAverageConfig: universe.MovingAverageConfig{N: 2, Column: execute.DefaultValueColLabel},
+	}
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 5.0},
+			{execute.Time(2), 5.0},
+			{execute.Time(3), 5.0},
+			{execute.Time(4), nil},
+			{execute.Time(5), 5.0},
+		},
+	}}
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(3), 5.0},
+			{execute.Time(4), nil},
+			{execute.Time(5), 5.0},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return universe.NewKaufmansAMATransformation(d, c, spec)
+		},
+	)
+}
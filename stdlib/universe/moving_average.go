@@ -0,0 +1,280 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const MovingAverageKind = "movingAverage"
+
+type MovingAverageOpSpec struct {
+	MovingAverageConfig
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", MovingAverageKind, flux.FunctionValue(MovingAverageKind, createMovingAverageOpSpec, movingAverageSignature()))
+	flux.RegisterOpSpec(MovingAverageKind, newMovingAverageOp)
+	plan.RegisterProcedureSpec(MovingAverageKind, newMovingAverageProcedure, MovingAverageKind)
+	execute.RegisterTransformation(MovingAverageKind, createMovingAverageTransformation)
+}
+
+func createMovingAverageOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(MovingAverageOpSpec)
+	if err := spec.MovingAverageConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// MovingAverageConfig is the configuration shared by the moving averages.
+type MovingAverageConfig struct {
+	N      int64  `json:"n"`
+	Column string `json:"column"`
+}
+
+// ReadArgs reads the number of points n, which must be positive,
+// and the column to average, which defaults to _value.
+func (c *MovingAverageConfig) ReadArgs(args flux.Arguments) error {
+	n, err := args.GetRequiredInt("n")
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return fmt.Errorf("cannot take moving average of %d points", n)
+	}
+	c.N = n
+
+	c.Column = execute.DefaultValueColLabel
+	if col, ok, err := args.GetString("column"); err != nil {
+		return err
+	} else if ok {
+		c.Column = col
+	}
+	return nil
+}
+
+// movingAverageSignature returns the function signature shared by the moving averages.
+func movingAverageSignature() semantic.FunctionPolySignature {
+	return flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"n":      semantic.Int,
+			"column": semantic.String,
+		},
+		[]string{"n"},
+	)
+}
+
+func newMovingAverageOp() flux.OperationSpec {
+	return new(MovingAverageOpSpec)
+}
+
+func (s *MovingAverageOpSpec) Kind() flux.OperationKind {
+	return MovingAverageKind
+}
+
+type MovingAverageProcedureSpec struct {
+	plan.DefaultCost
+	MovingAverageConfig
+}
+
+func newMovingAverageProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*MovingAverageOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &MovingAverageProcedureSpec{
+		MovingAverageConfig: spec.MovingAverageConfig,
+	}, nil
+}
+
+func (s *MovingAverageProcedureSpec) Kind() plan.ProcedureKind {
+	return MovingAverageKind
+}
+func (s *MovingAverageProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(MovingAverageProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createMovingAverageTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*MovingAverageProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewMovingAverageTransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewMovingAverageTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *MovingAverageProcedureSpec) execute.Transformation {
+	n := int(spec.N)
+	return newAveragingTransformation(d, cache, MovingAverageKind, spec.Column, func() averager {
+		return newSimpleAverager(n)
+	})
+}
+
+// averager computes a moving average over a series of values that are added one at a time.
+type averager interface {
+	// add adds the next value of the series and returns the average up to that value.
+	// It reports false while there are not enough values for the average to be defined.
+	add(v float64) (float64, bool)
+}
+
+// averagingTransformation replaces the values of a column with their moving average,
+// using a new averager for each table.
+//
+// Rows are dropped until the average is defined.
+// After that, a null value produces a null average and does not change the average of the following rows,
+// the same way derivative and difference handle nulls.
+type averagingTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	name        string
+	column      string
+	newAverager func() averager
+}
+
+func newAveragingTransformation(d execute.Dataset, cache execute.TableBuilderCache, name, column string, newAverager func() averager) *averagingTransformation {
+	return &averagingTransformation{
+		d:           d,
+		cache:       cache,
+		name:        name,
+		column:      column,
+		newAverager: newAverager,
+	}
+}
+
+func (t *averagingTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *averagingTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("%s found duplicate table with key: %v", t.name, key)
+	}
+	if key.HasCol(t.column) {
+		return fmt.Errorf("%s cannot average group key column %q", t.name, t.column)
+	}
+	cols := tbl.Cols()
+	valueIdx := execute.ColIdx(t.column, cols)
+	if valueIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.column)
+	}
+	switch typ := cols[valueIdx].Type; typ {
+	case flux.TInt, flux.TUInt, flux.TFloat:
+	default:
+		return fmt.Errorf("%s does not support %v", t.name, typ)
+	}
+	for j, c := range cols {
+		if j == valueIdx {
+			// The average is always a float.
+			c.Type = flux.TFloat
+		}
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+
+	avg := t.newAverager()
+	defined := false
+	return tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			v := execute.ValueForRow(cr, i, valueIdx)
+			if v.IsNull() {
+				if !defined {
+					continue
+				}
+				v = values.NewNull(semantic.Float)
+			} else {
+				a, ok := avg.add(floatValue(v))
+				if !ok {
+					continue
+				}
+				defined = true
+				v = values.NewFloat(a)
+			}
+			for j := range cols {
+				if j == valueIdx {
+					if err := builder.AppendValue(j, v); err != nil {
+						return err
+					}
+				} else if err := builder.AppendValue(j, execute.ValueForRow(cr, i, j)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (t *averagingTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *averagingTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *averagingTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// floatValue converts a non-null int, uint or float value to a float64.
+func floatValue(v values.Value) float64 {
+	switch v.Type() {
+	case semantic.Int:
+		return float64(v.Int())
+	case semantic.UInt:
+		return float64(v.UInt())
+	case semantic.Float:
+		return v.Float()
+	default:
+		panic(errors.New("value is not a number"))
+	}
+}
+
+// simpleAverager computes the mean of the last n values.
+type simpleAverager struct {
+	window []float64
+	// i is the index of the oldest value of the window.
+	i     int
+	count int
+}
+
+func newSimpleAverager(n int) *simpleAverager {
+	return &simpleAverager{
+		window: make([]float64, n),
+	}
+}
+
+func (a *simpleAverager) add(v float64) (float64, bool) {
+	n := len(a.window)
+	a.window[a.i] = v
+	a.i = (a.i + 1) % n
+	if a.count < n {
+		a.count++
+		if a.count < n {
+			return 0, false
+		}
+	}
+	// Summing the window again rather than keeping a running sum avoids accumulating rounding errors.
+	sum := 0.0
+	for _, v := range a.window {
+		sum += v
+	}
+	return sum / float64(n), true
+}
@@ -0,0 +1,296 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestMovingAverage_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "default column",
+			Raw:  `from(bucket:"mybucket") |> movingAverage(n: 3)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "movingAverage1",
+						Spec: &universe.MovingAverageOpSpec{
+							MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: "_value"},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "movingAverage1"},
+				},
+			},
+		},
+		{
+			Name:    "n must be positive",
+			Raw:     `from(bucket:"mybucket") |> movingAverage(n: 0)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestMovingAverageOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"movingAverage","kind":"movingAverage","spec":{"n":5,"column":"x"}}`)
+	op := &flux.Operation{
+		ID: "movingAverage",
+		Spec: &universe.MovingAverageOpSpec{
+			MovingAverageConfig: universe.MovingAverageConfig{N: 5, Column: "x"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestMovingAverage_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *universe.MovingAverageProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "float",
+			spec: &universe.MovingAverageProcedureSpec{
+				MovingAverageConfig: universe.MovingAverageConfig{N: 2, Column: execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 2.0},
+					{execute.Time(2), 4.0},
+					{execute.Time(3), 9.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), 3.0},
+					{execute.Time(3), 6.5},
+				},
+			}},
+		},
+		{
+			name: "int",
+			spec: &universe.MovingAverageProcedureSpec{
+				MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), int64(1)},
+					{execute.Time(2), int64(2)},
+					{execute.Time(3), int64(6)},
+					{execute.Time(4), int64(4)},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(3), 3.0},
+					{execute.Time(4), 4.0},
+				},
+			}},
+		},
+		{
+			name: "nulls",
+			spec: &universe.MovingAverageProcedureSpec{
+				MovingAverageConfig: universe.MovingAverageConfig{N: 2, Column: execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), nil},
+					{execute.Time(2), 2.0},
+					{execute.Time(3), nil},
+					{execute.Time(4), 4.0},
+					{execute.Time(5), nil},
+					{execute.Time(6), 8.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(4), 3.0},
+					{execute.Time(5), nil},
+					{execute.Time(6), 6.0},
+				},
+			}},
+		},
+		{
+			name: "fewer than n values",
+			spec: &universe.MovingAverageProcedureSpec{
+				MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: execute.DefaultValueColLabel},
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"t"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a"},
+					{execute.Time(2), 2.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols:   []string{"t"},
+				KeyValues: []interface{}{"a"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "t", Type: flux.TString},
+				},
+			}},
+		},
+		{
+			name: "string column",
+			spec: &universe.MovingAverageProcedureSpec{
+				MovingAverageConfig: universe.MovingAverageConfig{N: 1, Column: "t"},
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "t", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+				},
+			}},
+			wantErr: errors.New("movingAverage does not support string"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewMovingAverageTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
+
+// TestMovingAverages_NullsAndShortInput checks that every moving average drops rows until its average is defined
+// and that a null value produces a null average without changing the following averages.
+// The values are constant, so every average is that constant.
+func TestMovingAverages_NullsAndShortInput(t *testing.T) {
+	config := universe.MovingAverageConfig{N: 2, Column: execute.DefaultValueColLabel}
+	testCases := []struct {
+		name string
+		// need is the number of non-null values before the first average.
+		need   int
+		create func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation
+	}{
+		{
+			name: "movingAverage",
+			need: 2,
+			create: func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				return universe.NewMovingAverageTransformation(d, c, &universe.MovingAverageProcedureSpec{MovingAverageConfig: config})
+			},
+		},
+		{
+			name: "exponentialMovingAverage",
+			need: 2,
+			create: func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				return universe.NewExponentialMovingAverageTransformation(d, c, &universe.ExponentialMovingAverageProcedureSpec{MovingAverageConfig: config})
+			},
+		},
+		{
+			name: "doubleEMA",
+			need: 3,
+			create: func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				return universe.NewDoubleEMATransformation(d, c, &universe.DoubleEMAProcedureSpec{MovingAverageConfig: config})
+			},
+		},
+		{
+			name: "tripleEMA",
+			need: 4,
+			create: func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				return universe.NewTripleEMATransformation(d, c, &universe.TripleEMAProcedureSpec{MovingAverageConfig: config})
+			},
+		},
+		{
+			name: "kaufmansAMA",
+			need: 3,
+			create: func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+				return universe.NewKaufmansAMATransformation(d, c, &universe.KaufmansAMAProcedureSpec{MovingAverageConfig: config})
+			},
+		},
+	}
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+	}
+	// table returns a table with a null value before each of the n values and after the last one.
+	table := func(n int) *executetest.Table {
+		tbl := &executetest.Table{ColMeta: cols}
+		for i := 0; i < n; i++ {
+			tbl.Data = append(tbl.Data,
+				[]interface{}{execute.Time(2*i + 1), nil},
+				[]interface{}{execute.Time(2*i + 2), 2.0},
+			)
+		}
+		tbl.Data = append(tbl.Data, []interface{}{execute.Time(2*n + 1), nil})
+		return tbl
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name+" nulls", func(t *testing.T) {
+			want := &executetest.Table{ColMeta: cols}
+			// The rows before the value of the first average are dropped.
+			want.Data = table(tc.need + 2).Data[2*tc.need-1:]
+			executetest.ProcessTestHelper(t, []flux.Table{table(tc.need + 2)}, []*executetest.Table{want}, nil, tc.create)
+		})
+		t.Run(tc.name+" fewer values than needed", func(t *testing.T) {
+			want := &executetest.Table{ColMeta: cols}
+			executetest.ProcessTestHelper(t, []flux.Table{table(tc.need - 1)}, []*executetest.Table{want}, nil, tc.create)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package universe
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/pkg/errors"
+)
+
+const ReduceKind = "reduce"
+
+// ReduceOpSpec holds the reduce function and the identity record the accumulator starts from.
+// The columns are the properties of the identity sorted by label,
+// and the identity values are of the Go types bool, int64, uint64, float64, string and values.Time,
+// according to the type of their column.
+type ReduceOpSpec struct {
+	Fn       *semantic.FunctionExpression `json:"fn"`
+	Columns  []flux.ColMeta               `json:"columns"`
+	Identity []interface{}                `json:"identity"`
+}
+
+func init() {
+	reduceSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"fn": semantic.NewFunctionPolyType(semantic.FunctionPolySignature{
+				Parameters: map[string]semantic.PolyType{
+					execute.ReduceRecordParam:      semantic.Tvar(1),
+					execute.ReduceAccumulatorParam: semantic.Tvar(2),
+				},
+				Required: semantic.LabelSet{execute.ReduceRecordParam, execute.ReduceAccumulatorParam},
+				Return:   semantic.Tvar(2),
+			}),
+			"identity": semantic.Tvar(2),
+		},
+		[]string{"fn", "identity"},
+	)
+
+	flux.RegisterPackageValue("universe", ReduceKind, flux.FunctionValue(ReduceKind, createReduceOpSpec, reduceSignature))
+	flux.RegisterOpSpec(ReduceKind, newReduceOp)
+	plan.RegisterProcedureSpec(ReduceKind, newReduceProcedure, ReduceKind)
+	execute.RegisterTransformation(ReduceKind, createReduceTransformation)
+}
+
+func createReduceOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(ReduceOpSpec)
+
+	if f, err := args.GetRequiredFunction("fn"); err != nil {
+		return nil, err
+	} else {
+		fn, err := interpreter.ResolveFunction(f)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fn = fn
+	}
+
+	identity, err := args.GetRequiredObject("identity")
+	if err != nil {
+		return nil, err
+	}
+	if identity.Len() == 0 {
+		return nil, errors.New("identity must have at least one property")
+	}
+	identity.Range(func(label string, v values.Value) {
+		if err != nil {
+			return
+		}
+		typ := flux.ColumnType(v.Type())
+		if typ == flux.TInvalid {
+			err = fmt.Errorf("unsupported type %v of identity property %q", v.Type(), label)
+			return
+		}
+		spec.Columns = append(spec.Columns, flux.ColMeta{Label: label, Type: typ})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(spec.Columns, func(i, j int) bool {
+		return spec.Columns[i].Label < spec.Columns[j].Label
+	})
+	spec.Identity = make([]interface{}, len(spec.Columns))
+	for j, c := range spec.Columns {
+		v, _ := identity.Get(c.Label)
+		spec.Identity[j] = execute.GoValue(v)
+	}
+	return spec, nil
+}
+
+// UnmarshalJSON decodes the identity values into the Go types of their columns.
+func (s *ReduceOpSpec) UnmarshalJSON(data []byte) error {
+	var spec struct {
+		Fn       *semantic.FunctionExpression `json:"fn"`
+		Columns  []flux.ColMeta               `json:"columns"`
+		Identity []json.RawMessage            `json:"identity"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+	if len(spec.Identity) != len(spec.Columns) {
+		return fmt.Errorf("identity has %d values, expected %d", len(spec.Identity), len(spec.Columns))
+	}
+	s.Fn = spec.Fn
+	s.Columns = spec.Columns
+	s.Identity = make([]interface{}, len(spec.Identity))
+	for j, c := range s.Columns {
+		v, err := execute.UnmarshalGoValue(spec.Identity[j], c.Type)
+		if err != nil {
+			return errors.Wrapf(err, "identity property %q", c.Label)
+		}
+		s.Identity[j] = v
+	}
+	return nil
+}
+
+func newReduceOp() flux.OperationSpec {
+	return new(ReduceOpSpec)
+}
+
+func (s *ReduceOpSpec) Kind() flux.OperationKind {
+	return ReduceKind
+}
+
+type ReduceProcedureSpec struct {
+	plan.DefaultCost
+	Fn       *semantic.FunctionExpression
+	Columns  []flux.ColMeta
+	Identity []interface{}
+}
+
+func newReduceProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*ReduceOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &ReduceProcedureSpec{
+		Fn:       spec.Fn,
+		Columns:  spec.Columns,
+		Identity: spec.Identity,
+	}, nil
+}
+
+func (s *ReduceProcedureSpec) Kind() plan.ProcedureKind {
+	return ReduceKind
+}
+func (s *ReduceProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReduceProcedureSpec)
+	*ns = *s
+	ns.Fn = s.Fn.Copy().(*semantic.FunctionExpression)
+	ns.Columns = make([]flux.ColMeta, len(s.Columns))
+	copy(ns.Columns, s.Columns)
+	ns.Identity = make([]interface{}, len(s.Identity))
+	copy(ns.Identity, s.Identity)
+	return ns
+}
+
+func createReduceTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*ReduceProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t, err := NewReduceTransformation(d, cache, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return t, d, nil
+}
+
+type reduceTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	fn       *execute.RowReduceFn
+	columns  []flux.ColMeta
+	identity values.Object
+}
+
+func NewReduceTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *ReduceProcedureSpec) (*reduceTransformation, error) {
+	identity := values.NewObject()
+	for j, c := range spec.Columns {
+		identity.Set(c.Label, values.New(spec.Identity[j]))
+	}
+	fn, err := execute.NewRowReduceFn(spec.Fn, identity)
+	if err != nil {
+		return nil, err
+	}
+	return &reduceTransformation{
+		d:        d,
+		cache:    cache,
+		fn:       fn,
+		columns:  spec.Columns,
+		identity: identity,
+	}, nil
+}
+
+func (t *reduceTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *reduceTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	for _, c := range t.columns {
+		if key.HasCol(c.Label) {
+			return fmt.Errorf("reduce accumulator property %q conflicts with a group key column", c.Label)
+		}
+	}
+
+	if err := t.fn.Prepare(tbl.Cols()); err != nil {
+		return err
+	}
+
+	accumulator := t.identity
+	err := tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			m, err := t.fn.Eval(i, cr, accumulator)
+			if err != nil {
+				return errors.Wrap(err, "failed to evaluate reduce function")
+			}
+			accumulator = m
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("reduce found duplicate table with key: %v", key)
+	}
+	if err := execute.AddTableKeyCols(key, builder); err != nil {
+		return err
+	}
+	for _, c := range t.columns {
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+	if err := execute.AppendKeyValues(key, builder); err != nil {
+		return err
+	}
+	for _, c := range t.columns {
+		v, _ := accumulator.Get(c.Label)
+		if err := builder.AppendValue(execute.ColIdx(c.Label, builder.Cols()), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *reduceTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *reduceTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *reduceTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
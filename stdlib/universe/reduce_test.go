@@ -0,0 +1,358 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+// sumCountFn is the function (r, accumulator) => ({sum: accumulator.sum + r._value, n: accumulator.n + 1}).
+var sumCountFn = &semantic.FunctionExpression{
+	Block: &semantic.FunctionBlock{
+		Parameters: &semantic.FunctionParameters{
+			List: []*semantic.FunctionParameter{
+				{Key: &semantic.Identifier{Name: "r"}},
+				{Key: &semantic.Identifier{Name: "accumulator"}},
+			},
+		},
+		Body: &semantic.ObjectExpression{
+			Properties: []*semantic.Property{
+				{
+					Key: &semantic.Identifier{Name: "sum"},
+					Value: &semantic.BinaryExpression{
+						Operator: ast.AdditionOperator,
+						Left: &semantic.MemberExpression{
+							Object:   &semantic.IdentifierExpression{Name: "accumulator"},
+							Property: "sum",
+						},
+						Right: &semantic.MemberExpression{
+							Object:   &semantic.IdentifierExpression{Name: "r"},
+							Property: "_value",
+						},
+					},
+				},
+				{
+					Key: &semantic.Identifier{Name: "n"},
+					Value: &semantic.BinaryExpression{
+						Operator: ast.AdditionOperator,
+						Left: &semantic.MemberExpression{
+							Object:   &semantic.IdentifierExpression{Name: "accumulator"},
+							Property: "n",
+						},
+						Right: &semantic.IntegerLiteral{Value: 1},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestReduce_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "sum and count",
+			Raw:  `from(bucket:"mybucket") |> reduce(fn: (r, accumulator) => ({sum: accumulator.sum + r._value, n: accumulator.n + 1}), identity: {sum: 0.0, n: 0})`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "reduce1",
+						Spec: &universe.ReduceOpSpec{
+							Fn: sumCountFn,
+							Columns: []flux.ColMeta{
+								{Label: "n", Type: flux.TInt},
+								{Label: "sum", Type: flux.TFloat},
+							},
+							Identity: []interface{}{int64(0), 0.0},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "reduce1"},
+				},
+			},
+		},
+		{
+			Name:    "missing identity",
+			Raw:     `from(bucket:"mybucket") |> reduce(fn: (r, accumulator) => ({sum: accumulator.sum + r._value}))`,
+			WantErr: true,
+		},
+		{
+			Name: "string identity",
+			Raw:  `from(bucket:"mybucket") |> reduce(fn: (r, accumulator) => ({sum: accumulator.sum + r._value}), identity: {sum: "a"})`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "reduce1",
+						Spec: &universe.ReduceOpSpec{
+							Fn: &semantic.FunctionExpression{
+								Block: &semantic.FunctionBlock{
+									Parameters: sumCountFn.Block.Parameters,
+									Body: &semantic.ObjectExpression{
+										Properties: sumCountFn.Block.Body.(*semantic.ObjectExpression).Properties[:1],
+									},
+								},
+							},
+							Columns:  []flux.ColMeta{{Label: "sum", Type: flux.TString}},
+							Identity: []interface{}{"a"},
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "reduce1"},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestReduceOperation_Marshaling(t *testing.T) {
+	data := []byte(`{
+		"id":"reduce",
+		"kind":"reduce",
+		"spec":{
+			"fn":{
+				"type": "FunctionExpression",
+				"block":{
+					"type":"FunctionBlock",
+					"parameters": {
+						"type":"FunctionParameters",
+						"list": [
+							{"type":"FunctionParam","key":{"type":"Identifier","name":"r"}},
+							{"type":"FunctionParam","key":{"type":"Identifier","name":"accumulator"}}
+						]
+					},
+					"body":{
+						"type":"ObjectExpression",
+						"properties":[
+							{
+								"type":"Property",
+								"key":{"type":"Identifier","name":"sum"},
+								"value":{
+									"type":"BinaryExpression",
+									"operator": "+",
+									"left":{
+										"type":"MemberExpression",
+										"object": {"type": "IdentifierExpression", "name":"accumulator"},
+										"property": "sum"
+									},
+									"right":{
+										"type":"MemberExpression",
+										"object": {"type": "IdentifierExpression", "name":"r"},
+										"property": "_value"
+									}
+								}
+							},
+							{
+								"type":"Property",
+								"key":{"type":"Identifier","name":"n"},
+								"value":{
+									"type":"BinaryExpression",
+									"operator": "+",
+									"left":{
+										"type":"MemberExpression",
+										"object": {"type": "IdentifierExpression", "name":"accumulator"},
+										"property": "n"
+									},
+									"right":{
+										"type":"IntegerLiteral",
+										"value": "1"
+									}
+								}
+							}
+						]
+					}
+				}
+			},
+			"columns":[{"Label":"n","Type":2},{"Label":"sum","Type":4}],
+			"identity":[0,0.5]
+		}
+	}`)
+	op := &flux.Operation{
+		ID: "reduce",
+		Spec: &universe.ReduceOpSpec{
+			Fn: sumCountFn,
+			Columns: []flux.ColMeta{
+				{Label: "n", Type: flux.TInt},
+				{Label: "sum", Type: flux.TFloat},
+			},
+			Identity: []interface{}{int64(0), 0.5},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestReduce_Process(t *testing.T) {
+	sumCountSpec := &universe.ReduceProcedureSpec{
+		Fn: sumCountFn,
+		Columns: []flux.ColMeta{
+			{Label: "n", Type: flux.TInt},
+			{Label: "sum", Type: flux.TFloat},
+		},
+		Identity: []interface{}{int64(0), 0.0},
+	}
+	testCases := []struct {
+		name    string
+		spec    *universe.ReduceProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "sum and count",
+			spec: sumCountSpec,
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(2), 6.0},
+					{execute.Time(3), 2.5},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "n", Type: flux.TInt},
+					{Label: "sum", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{int64(3), 9.5},
+				},
+			}},
+		},
+		{
+			name: "group key preserved",
+			spec: sumCountSpec,
+			data: []flux.Table{
+				&executetest.Table{
+					KeyCols: []string{"t"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a"},
+						{execute.Time(2), 2.0, "a"},
+					},
+				},
+				&executetest.Table{
+					KeyCols: []string{"t"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "t", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 4.0, "b"},
+					},
+				},
+			},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"t"},
+					ColMeta: []flux.ColMeta{
+						{Label: "t", Type: flux.TString},
+						{Label: "n", Type: flux.TInt},
+						{Label: "sum", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"a", int64(2), 3.0},
+					},
+				},
+				{
+					KeyCols: []string{"t"},
+					ColMeta: []flux.ColMeta{
+						{Label: "t", Type: flux.TString},
+						{Label: "n", Type: flux.TInt},
+						{Label: "sum", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{"b", int64(1), 4.0},
+					},
+				},
+			},
+		},
+		{
+			name: "empty table",
+			spec: sumCountSpec,
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+			}},
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "n", Type: flux.TInt},
+					{Label: "sum", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{int64(0), 0.0},
+				},
+			}},
+		},
+		{
+			name: "accumulator conflicts with group key",
+			spec: sumCountSpec,
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"n"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "n", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, int64(1)},
+				},
+			}},
+			wantErr: errors.New(`reduce accumulator property "n" conflicts with a group key column`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					f, err := universe.NewReduceTransformation(d, c, tc.spec)
+					if err != nil {
+						t.Fatal(err)
+					}
+					return f
+				},
+			)
+		})
+	}
+}
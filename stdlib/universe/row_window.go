@@ -0,0 +1,334 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const RowWindowKind = "rowWindow"
+
+type RowWindowOpSpec struct {
+	Count        int64            `json:"count"`
+	Step         int64            `json:"step"`
+	Triggering   flux.TriggerSpec `json:"triggering"`
+	TimeColumn   string           `json:"timeColumn"`
+	StartColumn  string           `json:"startColumn"`
+	StopColumn   string           `json:"stopColumn"`
+	WindowColumn string           `json:"windowColumn"`
+}
+
+func init() {
+	rowWindowSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"count":        semantic.Int,
+			"step":         semantic.Int,
+			"timeColumn":   semantic.String,
+			"startColumn":  semantic.String,
+			"stopColumn":   semantic.String,
+			"windowColumn": semantic.String,
+		},
+		[]string{"count"},
+	)
+
+	flux.RegisterPackageValue("universe", RowWindowKind, flux.FunctionValue(RowWindowKind, createRowWindowOpSpec, rowWindowSignature))
+	flux.RegisterOpSpec(RowWindowKind, newRowWindowOp)
+	plan.RegisterProcedureSpec(RowWindowKind, newRowWindowProcedure, RowWindowKind)
+	execute.RegisterTransformation(RowWindowKind, createRowWindowTransformation)
+}
+
+func createRowWindowOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(RowWindowOpSpec)
+
+	count, err := args.GetRequiredInt("count")
+	if err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, errors.New("count must be positive")
+	}
+	spec.Count = count
+
+	if step, ok, err := args.GetInt("step"); err != nil {
+		return nil, err
+	} else if ok {
+		if step <= 0 {
+			return nil, errors.New("step must be positive")
+		}
+		spec.Step = step
+	} else {
+		spec.Step = count
+	}
+
+	if label, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = label
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+	if label, ok, err := args.GetString("startColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.StartColumn = label
+	} else {
+		spec.StartColumn = execute.DefaultStartColLabel
+	}
+	if label, ok, err := args.GetString("stopColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.StopColumn = label
+	} else {
+		spec.StopColumn = execute.DefaultStopColLabel
+	}
+	// The window column is optional, windows only get an index if it is set.
+	if spec.WindowColumn, _, err = args.GetString("windowColumn"); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newRowWindowOp() flux.OperationSpec {
+	return new(RowWindowOpSpec)
+}
+
+func (s *RowWindowOpSpec) Kind() flux.OperationKind {
+	return RowWindowKind
+}
+
+type RowWindowProcedureSpec struct {
+	plan.DefaultCost
+	Count      int64
+	Step       int64
+	Triggering flux.TriggerSpec
+	TimeColumn,
+	StartColumn,
+	StopColumn,
+	WindowColumn string
+}
+
+func newRowWindowProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	s, ok := qs.(*RowWindowOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	p := &RowWindowProcedureSpec{
+		Count:        s.Count,
+		Step:         s.Step,
+		Triggering:   s.Triggering,
+		TimeColumn:   s.TimeColumn,
+		StartColumn:  s.StartColumn,
+		StopColumn:   s.StopColumn,
+		WindowColumn: s.WindowColumn,
+	}
+	if p.Triggering == nil {
+		p.Triggering = flux.DefaultTrigger
+	}
+	return p, nil
+}
+
+func (s *RowWindowProcedureSpec) Kind() plan.ProcedureKind {
+	return RowWindowKind
+}
+func (s *RowWindowProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(RowWindowProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func (s *RowWindowProcedureSpec) TriggerSpec() flux.TriggerSpec {
+	return s.Triggering
+}
+
+func createRowWindowTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*RowWindowProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewRowWindowTransformation(d, cache, s)
+	return t, d, nil
+}
+
+// rowWindowTransformation groups the rows of each table into windows of count rows,
+// starting a new window every step rows.
+// Windows are tumbling when step equals count and sliding when step is less than count.
+// Only complete windows are produced, so the rows after the last complete window are dropped.
+//
+// Each window gets its own table, with the start and stop columns set to the earliest and latest
+// times of its rows and added to the group key, the same way window does.
+// Windows can have the same bounds when rows share a time, so if the window column is set,
+// the index of the window within its input table is added to the group key as well.
+type rowWindowTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	count, step int
+	timeCol,
+	startCol,
+	stopCol,
+	windowCol string
+}
+
+func NewRowWindowTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *RowWindowProcedureSpec) execute.Transformation {
+	return &rowWindowTransformation{
+		d:         d,
+		cache:     cache,
+		count:     int(spec.Count),
+		step:      int(spec.Step),
+		timeCol:   spec.TimeColumn,
+		startCol:  spec.StartColumn,
+		stopCol:   spec.StopColumn,
+		windowCol: spec.WindowColumn,
+	}
+}
+
+func (t *rowWindowTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *rowWindowTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.timeCol, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("missing time column %q", t.timeCol)
+	}
+	if typ := cols[timeIdx].Type; typ != flux.TTime {
+		return fmt.Errorf("time column %q is of type %v, expected time", t.timeCol, typ)
+	}
+	if t.timeCol == t.startCol || t.timeCol == t.stopCol {
+		return fmt.Errorf("rowWindow cannot use the %q column as both time and bounds column", t.timeCol)
+	}
+	if t.windowCol != "" {
+		if execute.ColIdx(t.windowCol, cols) >= 0 {
+			return fmt.Errorf("rowWindow window column %q already exists", t.windowCol)
+		}
+		if t.windowCol == t.startCol || t.windowCol == t.stopCol {
+			return fmt.Errorf("rowWindow cannot use the %q column as both window and bounds column", t.windowCol)
+		}
+	}
+
+	newCols := make([]flux.ColMeta, 0, len(cols)+3)
+	for _, c := range cols {
+		if c.Label == t.startCol || c.Label == t.stopCol {
+			if c.Type != flux.TTime {
+				return fmt.Errorf("bounds column %q is of type %v, expected time", c.Label, c.Type)
+			}
+		}
+		newCols = append(newCols, c)
+	}
+	if execute.ColIdx(t.startCol, newCols) < 0 {
+		newCols = append(newCols, flux.ColMeta{Label: t.startCol, Type: flux.TTime})
+	}
+	if execute.ColIdx(t.stopCol, newCols) < 0 {
+		newCols = append(newCols, flux.ColMeta{Label: t.stopCol, Type: flux.TTime})
+	}
+	if t.windowCol != "" {
+		newCols = append(newCols, flux.ColMeta{Label: t.windowCol, Type: flux.TInt})
+	}
+
+	key := tbl.Key()
+	// rows holds the last count rows, which are the rows of the window ending at the current row.
+	rows := make([][]values.Value, 0, t.count)
+	n, window := 0, int64(0)
+	return tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			if cr.Times(timeIdx).IsNull(i) {
+				return errors.New("rowWindow found null time in time column")
+			}
+			row := make([]values.Value, len(cols))
+			for j := range cols {
+				row[j] = execute.ValueForRow(cr, i, j)
+			}
+			if len(rows) == t.count {
+				copy(rows, rows[1:])
+				rows = rows[:t.count-1]
+			}
+			rows = append(rows, row)
+			n++
+
+			if n >= t.count && (n-t.count)%t.step == 0 {
+				if err := t.appendWindow(key, newCols, timeIdx, window, rows); err != nil {
+					return err
+				}
+				window++
+			}
+		}
+		return nil
+	})
+}
+
+// appendWindow appends the rows of the window with the given index to a new table.
+func (t *rowWindowTransformation) appendWindow(key flux.GroupKey, cols []flux.ColMeta, timeIdx int, window int64, rows [][]values.Value) error {
+	var bnds execute.Bounds
+	for i, row := range rows {
+		tm := row[timeIdx].Time()
+		if i == 0 || tm < bnds.Start {
+			bnds.Start = tm
+		}
+		if i == 0 || tm > bnds.Stop {
+			bnds.Stop = tm
+		}
+	}
+
+	kb := execute.NewGroupKeyBuilder(key).
+		SetKeyValue(t.startCol, values.NewTime(bnds.Start)).
+		SetKeyValue(t.stopCol, values.NewTime(bnds.Stop))
+	if t.windowCol != "" {
+		kb.SetKeyValue(t.windowCol, values.NewInt(window))
+	}
+	windowKey, err := kb.Build()
+	if err != nil {
+		return err
+	}
+	builder, created := t.cache.TableBuilder(windowKey)
+	if !created {
+		return fmt.Errorf("rowWindow found duplicate table with key: %v, set windowColumn to tell apart windows with the same bounds", windowKey)
+	}
+	for _, c := range cols {
+		if _, err := builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		for j, c := range cols {
+			var v values.Value
+			switch c.Label {
+			case t.startCol:
+				v = values.NewTime(bnds.Start)
+			case t.stopCol:
+				v = values.NewTime(bnds.Stop)
+			case t.windowCol:
+				v = values.NewInt(window)
+			default:
+				v = row[j]
+			}
+			if err := builder.AppendValue(j, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *rowWindowTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *rowWindowTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *rowWindowTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
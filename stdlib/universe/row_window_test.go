@@ -0,0 +1,344 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestRowWindow_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "tumbling",
+			Raw:  `from(bucket:"mybucket") |> rowWindow(count: 100)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "rowWindow1",
+						Spec: &universe.RowWindowOpSpec{
+							Count:       100,
+							Step:        100,
+							TimeColumn:  "_time",
+							StartColumn: "_start",
+							StopColumn:  "_stop",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "rowWindow1"},
+				},
+			},
+		},
+		{
+			Name: "sliding",
+			Raw:  `from(bucket:"mybucket") |> rowWindow(count: 10, step: 1)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "rowWindow1",
+						Spec: &universe.RowWindowOpSpec{
+							Count:       10,
+							Step:        1,
+							TimeColumn:  "_time",
+							StartColumn: "_start",
+							StopColumn:  "_stop",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "rowWindow1"},
+				},
+			},
+		},
+		{
+			Name: "with window column",
+			Raw:  `from(bucket:"mybucket") |> rowWindow(count: 10, windowColumn: "_window")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "rowWindow1",
+						Spec: &universe.RowWindowOpSpec{
+							Count:        10,
+							Step:         10,
+							TimeColumn:   "_time",
+							StartColumn:  "_start",
+							StopColumn:   "_stop",
+							WindowColumn: "_window",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "rowWindow1"},
+				},
+			},
+		},
+		{
+			Name:    "zero count",
+			Raw:     `from(bucket:"mybucket") |> rowWindow(count: 0)`,
+			WantErr: true,
+		},
+		{
+			Name:    "negative step",
+			Raw:     `from(bucket:"mybucket") |> rowWindow(count: 10, step: -1)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestRowWindowOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"rowWindow","kind":"rowWindow","spec":{"count":10,"step":1,"timeColumn":"_time","startColumn":"_start","stopColumn":"_stop","windowColumn":"_window"}}`)
+	op := &flux.Operation{
+		ID: "rowWindow",
+		Spec: &universe.RowWindowOpSpec{
+			Count:        10,
+			Step:         1,
+			TimeColumn:   "_time",
+			StartColumn:  "_start",
+			StopColumn:   "_stop",
+			WindowColumn: "_window",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestRowWindow_Process(t *testing.T) {
+	data := &executetest.Table{
+		KeyCols: []string{"t"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+			{Label: "t", Type: flux.TString},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 1.0, "a"},
+			{execute.Time(2), 2.0, "a"},
+			{execute.Time(4), 3.0, "a"},
+			{execute.Time(8), 4.0, "a"},
+			{execute.Time(16), 5.0, "a"},
+		},
+	}
+	windowCols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "t", Type: flux.TString},
+		{Label: "_start", Type: flux.TTime},
+		{Label: "_stop", Type: flux.TTime},
+	}
+	testCases := []struct {
+		name    string
+		spec    *universe.RowWindowProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "tumbling",
+			spec: &universe.RowWindowProcedureSpec{
+				Count:       2,
+				Step:        2,
+				TimeColumn:  "_time",
+				StartColumn: "_start",
+				StopColumn:  "_stop",
+			},
+			data: []flux.Table{data},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"t", "_start", "_stop"},
+					ColMeta: windowCols,
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a", execute.Time(1), execute.Time(2)},
+						{execute.Time(2), 2.0, "a", execute.Time(1), execute.Time(2)},
+					},
+				},
+				{
+					KeyCols: []string{"t", "_start", "_stop"},
+					ColMeta: windowCols,
+					Data: [][]interface{}{
+						{execute.Time(4), 3.0, "a", execute.Time(4), execute.Time(8)},
+						{execute.Time(8), 4.0, "a", execute.Time(4), execute.Time(8)},
+					},
+				},
+			},
+		},
+		{
+			name: "sliding",
+			spec: &universe.RowWindowProcedureSpec{
+				Count:       3,
+				Step:        1,
+				TimeColumn:  "_time",
+				StartColumn: "_start",
+				StopColumn:  "_stop",
+			},
+			data: []flux.Table{data},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"t", "_start", "_stop"},
+					ColMeta: windowCols,
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a", execute.Time(1), execute.Time(4)},
+						{execute.Time(2), 2.0, "a", execute.Time(1), execute.Time(4)},
+						{execute.Time(4), 3.0, "a", execute.Time(1), execute.Time(4)},
+					},
+				},
+				{
+					KeyCols: []string{"t", "_start", "_stop"},
+					ColMeta: windowCols,
+					Data: [][]interface{}{
+						{execute.Time(2), 2.0, "a", execute.Time(2), execute.Time(8)},
+						{execute.Time(4), 3.0, "a", execute.Time(2), execute.Time(8)},
+						{execute.Time(8), 4.0, "a", execute.Time(2), execute.Time(8)},
+					},
+				},
+				{
+					KeyCols: []string{"t", "_start", "_stop"},
+					ColMeta: windowCols,
+					Data: [][]interface{}{
+						{execute.Time(4), 3.0, "a", execute.Time(4), execute.Time(16)},
+						{execute.Time(8), 4.0, "a", execute.Time(4), execute.Time(16)},
+						{execute.Time(16), 5.0, "a", execute.Time(4), execute.Time(16)},
+					},
+				},
+			},
+		},
+		{
+			name: "fewer rows than count",
+			spec: &universe.RowWindowProcedureSpec{
+				Count:       10,
+				Step:        10,
+				TimeColumn:  "_time",
+				StartColumn: "_start",
+				StopColumn:  "_stop",
+			},
+			data: []flux.Table{data},
+			want: []*executetest.Table(nil),
+		},
+		{
+			name: "duplicate times",
+			spec: &universe.RowWindowProcedureSpec{
+				Count:        1,
+				Step:         1,
+				TimeColumn:   "_time",
+				StartColumn:  "_start",
+				StopColumn:   "_stop",
+				WindowColumn: "_window",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(1), 2.0},
+				},
+			}},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"_start", "_stop", "_window"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_window", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, execute.Time(1), execute.Time(1), int64(0)},
+					},
+				},
+				{
+					KeyCols: []string{"_start", "_stop", "_window"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+						{Label: "_window", Type: flux.TInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 2.0, execute.Time(1), execute.Time(1), int64(1)},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate times without window column",
+			spec: &universe.RowWindowProcedureSpec{
+				Count:       1,
+				Step:        1,
+				TimeColumn:  "_time",
+				StartColumn: "_start",
+				StopColumn:  "_stop",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0},
+					{execute.Time(1), 2.0},
+				},
+			}},
+			wantErr: errors.New(`rowWindow found duplicate table with key: {_start=1970-01-01T00:00:00.000000001Z,_stop=1970-01-01T00:00:00.000000001Z}, set windowColumn to tell apart windows with the same bounds`),
+		},
+		{
+			name: "existing window column",
+			spec: &universe.RowWindowProcedureSpec{
+				Count:        2,
+				Step:         2,
+				TimeColumn:   "_time",
+				StartColumn:  "_start",
+				StopColumn:   "_stop",
+				WindowColumn: "t",
+			},
+			data:    []flux.Table{data},
+			wantErr: errors.New(`rowWindow window column "t" already exists`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewRowWindowTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package universe
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const SessionWindowKind = "sessionWindow"
+
+type SessionWindowOpSpec struct {
+	Gap         flux.Duration    `json:"gap"`
+	Triggering  flux.TriggerSpec `json:"triggering"`
+	TimeColumn  string           `json:"timeColumn"`
+	StartColumn string           `json:"startColumn"`
+	StopColumn  string           `json:"stopColumn"`
+}
+
+func init() {
+	sessionWindowSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"gap":         semantic.Duration,
+			"timeColumn":  semantic.String,
+			"startColumn": semantic.String,
+			"stopColumn":  semantic.String,
+		},
+		[]string{"gap"},
+	)
+
+	flux.RegisterPackageValue("universe", SessionWindowKind, flux.FunctionValue(SessionWindowKind, createSessionWindowOpSpec, sessionWindowSignature))
+	flux.RegisterOpSpec(SessionWindowKind, newSessionWindowOp)
+	plan.RegisterProcedureSpec(SessionWindowKind, newSessionWindowProcedure, SessionWindowKind)
+	execute.RegisterTransformation(SessionWindowKind, createSessionWindowTransformation)
+}
+
+func createSessionWindowOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(SessionWindowOpSpec)
+
+	gap, err := args.GetRequiredDuration("gap")
+	if err != nil {
+		return nil, err
+	}
+	if gap <= 0 {
+		return nil, errors.New("gap must be positive")
+	}
+	spec.Gap = gap
+
+	if label, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = label
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+	if label, ok, err := args.GetString("startColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.StartColumn = label
+	} else {
+		spec.StartColumn = execute.DefaultStartColLabel
+	}
+	if label, ok, err := args.GetString("stopColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.StopColumn = label
+	} else {
+		spec.StopColumn = execute.DefaultStopColLabel
+	}
+	return spec, nil
+}
+
+func newSessionWindowOp() flux.OperationSpec {
+	return new(SessionWindowOpSpec)
+}
+
+func (s *SessionWindowOpSpec) Kind() flux.OperationKind {
+	return SessionWindowKind
+}
+
+type SessionWindowProcedureSpec struct {
+	plan.DefaultCost
+	Gap        flux.Duration
+	Triggering flux.TriggerSpec
+	TimeColumn,
+	StartColumn,
+	StopColumn string
+}
+
+func newSessionWindowProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	s, ok := qs.(*SessionWindowOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+	p := &SessionWindowProcedureSpec{
+		Gap:         s.Gap,
+		Triggering:  s.Triggering,
+		TimeColumn:  s.TimeColumn,
+		StartColumn: s.StartColumn,
+		StopColumn:  s.StopColumn,
+	}
+	if p.Triggering == nil {
+		p.Triggering = flux.DefaultTrigger
+	}
+	return p, nil
+}
+
+func (s *SessionWindowProcedureSpec) Kind() plan.ProcedureKind {
+	return SessionWindowKind
+}
+func (s *SessionWindowProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(SessionWindowProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func (s *SessionWindowProcedureSpec) TriggerSpec() flux.TriggerSpec {
+	return s.Triggering
+}
+
+func createSessionWindowTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*SessionWindowProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+
+	// Sessions are clipped to the bounds of the query, if there are any.
+	bounds := execute.AllTime
+	if b := a.StreamContext().Bounds(); b != nil {
+		bounds = *b
+	}
+	t := NewSessionWindowTransformation(d, cache, bounds, s)
+	return t, d, nil
+}
+
+// sessionWindowTransformation groups the rows of each table into sessions,
+// which are runs of rows where each row is less than gap after the previous one.
+// A session starts at the time of its first row and stops gap after the time of its last row,
+// so the following session starts at or after the stop of the previous one.
+// Each session gets its own table, with the start and stop columns set to its bounds
+// and added to the group key, the same way window does.
+type sessionWindowTransformation struct {
+	d      execute.Dataset
+	cache  execute.TableBuilderCache
+	bounds execute.Bounds
+
+	gap execute.Duration
+	timeCol,
+	startCol,
+	stopCol string
+}
+
+func NewSessionWindowTransformation(d execute.Dataset, cache execute.TableBuilderCache, bounds execute.Bounds, spec *SessionWindowProcedureSpec) execute.Transformation {
+	return &sessionWindowTransformation{
+		d:        d,
+		cache:    cache,
+		bounds:   bounds,
+		gap:      execute.Duration(spec.Gap),
+		timeCol:  spec.TimeColumn,
+		startCol: spec.StartColumn,
+		stopCol:  spec.StopColumn,
+	}
+}
+
+func (t *sessionWindowTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *sessionWindowTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	cols := tbl.Cols()
+	timeIdx := execute.ColIdx(t.timeCol, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("missing time column %q", t.timeCol)
+	}
+	if typ := cols[timeIdx].Type; typ != flux.TTime {
+		return fmt.Errorf("time column %q is of type %v, expected time", t.timeCol, typ)
+	}
+	if t.timeCol == t.startCol || t.timeCol == t.stopCol {
+		return fmt.Errorf("sessionWindow cannot use the %q column as both time and bounds column", t.timeCol)
+	}
+
+	newCols := make([]flux.ColMeta, 0, len(cols)+2)
+	for _, c := range cols {
+		if c.Label == t.startCol || c.Label == t.stopCol {
+			if c.Type != flux.TTime {
+				return fmt.Errorf("bounds column %q is of type %v, expected time", c.Label, c.Type)
+			}
+		}
+		newCols = append(newCols, c)
+	}
+	if execute.ColIdx(t.startCol, newCols) < 0 {
+		newCols = append(newCols, flux.ColMeta{Label: t.startCol, Type: flux.TTime})
+	}
+	if execute.ColIdx(t.stopCol, newCols) < 0 {
+		newCols = append(newCols, flux.ColMeta{Label: t.stopCol, Type: flux.TTime})
+	}
+
+	s := &session{
+		t:    t,
+		key:  tbl.Key(),
+		cols: newCols,
+	}
+	err := tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			ts := cr.Times(timeIdx)
+			if ts.IsNull(i) {
+				return errors.New("sessionWindow found null time in time column")
+			}
+			tm := values.Time(ts.Value(i))
+			if len(s.rows) > 0 {
+				if tm < s.last {
+					return errors.New("sessionWindow found out-of-order times in time column")
+				}
+				if tm >= s.last.Add(values.Duration(t.gap)) {
+					if err := s.flush(); err != nil {
+						return err
+					}
+				}
+			}
+			if len(s.rows) == 0 {
+				s.start = tm
+			}
+			s.last = tm
+
+			row := make([]values.Value, len(cols))
+			for j := range cols {
+				row[j] = execute.ValueForRow(cr, i, j)
+			}
+			s.rows = append(s.rows, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// session holds the rows of the current session of a table until it is known to be over.
+type session struct {
+	t    *sessionWindowTransformation
+	key  flux.GroupKey
+	cols []flux.ColMeta
+
+	start, last values.Time
+	rows        [][]values.Value
+}
+
+// flush appends the rows of the session to the table of its bounds and starts a new session.
+func (s *session) flush() error {
+	if len(s.rows) == 0 {
+		return nil
+	}
+	defer func() {
+		s.rows = s.rows[:0]
+	}()
+
+	bnds := execute.Bounds{
+		Start: s.start,
+		Stop:  s.last.Add(values.Duration(s.t.gap)),
+	}
+	if bnds.Stop > s.t.bounds.Stop || bnds.Stop < s.last {
+		// Clip the session to the query bounds, or to the max time on overflow.
+		bnds.Stop = s.t.bounds.Stop
+	}
+	key, err := execute.NewGroupKeyBuilder(s.key).
+		SetKeyValue(s.t.startCol, values.NewTime(bnds.Start)).
+		SetKeyValue(s.t.stopCol, values.NewTime(bnds.Stop)).
+		Build()
+	if err != nil {
+		return err
+	}
+	builder, created := s.t.cache.TableBuilder(key)
+	if created {
+		for _, c := range s.cols {
+			if _, err := builder.AddCol(c); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, row := range s.rows {
+		for j, c := range builder.Cols() {
+			var v values.Value
+			switch c.Label {
+			case s.t.startCol:
+				v = values.NewTime(bnds.Start)
+			case s.t.stopCol:
+				v = values.NewTime(bnds.Stop)
+			default:
+				v = row[j]
+			}
+			if err := builder.AppendValue(j, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *sessionWindowTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *sessionWindowTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *sessionWindowTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
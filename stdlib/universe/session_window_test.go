@@ -0,0 +1,240 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestSessionWindow_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mybucket") |> sessionWindow(gap: 5m)`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "sessionWindow1",
+						Spec: &universe.SessionWindowOpSpec{
+							Gap:         flux.Duration(5 * time.Minute),
+							TimeColumn:  "_time",
+							StartColumn: "_start",
+							StopColumn:  "_stop",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "sessionWindow1"},
+				},
+			},
+		},
+		{
+			Name: "time column",
+			Raw:  `from(bucket:"mybucket") |> sessionWindow(gap: 1h, timeColumn: "t")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "sessionWindow1",
+						Spec: &universe.SessionWindowOpSpec{
+							Gap:         flux.Duration(time.Hour),
+							TimeColumn:  "t",
+							StartColumn: "_start",
+							StopColumn:  "_stop",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "sessionWindow1"},
+				},
+			},
+		},
+		{
+			Name:    "missing gap",
+			Raw:     `from(bucket:"mybucket") |> sessionWindow()`,
+			WantErr: true,
+		},
+		{
+			Name:    "negative gap",
+			Raw:     `from(bucket:"mybucket") |> sessionWindow(gap: -1m)`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestSessionWindowOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"sessionWindow","kind":"sessionWindow","spec":{"gap":"5m","timeColumn":"_time","startColumn":"_start","stopColumn":"_stop"}}`)
+	op := &flux.Operation{
+		ID: "sessionWindow",
+		Spec: &universe.SessionWindowOpSpec{
+			Gap:         flux.Duration(5 * time.Minute),
+			TimeColumn:  "_time",
+			StartColumn: "_start",
+			StopColumn:  "_stop",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestSessionWindow_Process(t *testing.T) {
+	spec := &universe.SessionWindowProcedureSpec{
+		Gap:         flux.Duration(10),
+		TimeColumn:  "_time",
+		StartColumn: "_start",
+		StopColumn:  "_stop",
+	}
+	testCases := []struct {
+		name    string
+		bounds  execute.Bounds
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name:   "sessions",
+			bounds: execute.AllTime,
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"u"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "u", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, "a"},
+					{execute.Time(5), 2.0, "a"},
+					{execute.Time(14), 3.0, "a"},
+					{execute.Time(24), 4.0, "a"},
+					{execute.Time(40), 5.0, "a"},
+				},
+			}},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"u", "_start", "_stop"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "u", Type: flux.TString},
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.0, "a", execute.Time(1), execute.Time(24)},
+						{execute.Time(5), 2.0, "a", execute.Time(1), execute.Time(24)},
+						{execute.Time(14), 3.0, "a", execute.Time(1), execute.Time(24)},
+					},
+				},
+				{
+					KeyCols: []string{"u", "_start", "_stop"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "u", Type: flux.TString},
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+					},
+					Data: [][]interface{}{
+						{execute.Time(24), 4.0, "a", execute.Time(24), execute.Time(34)},
+					},
+				},
+				{
+					KeyCols: []string{"u", "_start", "_stop"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "u", Type: flux.TString},
+						{Label: "_start", Type: flux.TTime},
+						{Label: "_stop", Type: flux.TTime},
+					},
+					Data: [][]interface{}{
+						{execute.Time(40), 5.0, "a", execute.Time(40), execute.Time(50)},
+					},
+				},
+			},
+		},
+		{
+			name:   "replaces bounds and clips stop",
+			bounds: execute.Bounds{Start: 0, Stop: 20},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(20), execute.Time(2), 1.0},
+					{execute.Time(0), execute.Time(20), execute.Time(11), 2.0},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(2), execute.Time(20), execute.Time(2), 1.0},
+					{execute.Time(2), execute.Time(20), execute.Time(11), 2.0},
+				},
+			}},
+		},
+		{
+			name:   "out of order",
+			bounds: execute.AllTime,
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(5), 1.0},
+					{execute.Time(1), 2.0},
+				},
+			}},
+			wantErr: errors.New("sessionWindow found out-of-order times in time column"),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewSessionWindowTransformation(d, c, tc.bounds, spec)
+				},
+			)
+		})
+	}
+}
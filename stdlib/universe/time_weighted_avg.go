@@ -0,0 +1,369 @@
+package universe
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const TimeWeightedAvgKind = "timeWeightedAvg"
+
+// The ways to interpolate the values between two rows for the time-weighted average.
+const (
+	// LinearInterpolation interpolates linearly between the values of the rows.
+	LinearInterpolation = "linear"
+	// StepInterpolation holds the value of a row until the next row.
+	StepInterpolation = "step"
+)
+
+type TimeWeightedAvgOpSpec struct {
+	Unit       flux.Duration `json:"unit"`
+	Method     string        `json:"method"`
+	TimeColumn string        `json:"timeColumn"`
+	execute.AggregateConfig
+}
+
+func init() {
+	timeWeightedAvgSignature := execute.AggregateSignature(
+		map[string]semantic.PolyType{
+			"unit":       semantic.Duration,
+			"method":     semantic.String,
+			"timeColumn": semantic.String,
+		},
+		nil,
+	)
+
+	flux.RegisterPackageValue("universe", TimeWeightedAvgKind, flux.FunctionValue(TimeWeightedAvgKind, createTimeWeightedAvgOpSpec, timeWeightedAvgSignature))
+	flux.RegisterOpSpec(TimeWeightedAvgKind, newTimeWeightedAvgOp)
+	plan.RegisterProcedureSpec(TimeWeightedAvgKind, newTimeWeightedAvgProcedure, TimeWeightedAvgKind)
+	execute.RegisterTransformation(TimeWeightedAvgKind, createTimeWeightedAvgTransformation)
+}
+
+func createTimeWeightedAvgOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(TimeWeightedAvgOpSpec)
+
+	if unit, ok, err := args.GetDuration("unit"); err != nil {
+		return nil, err
+	} else if ok {
+		if unit <= 0 {
+			return nil, fmt.Errorf("unit must be positive")
+		}
+		spec.Unit = unit
+	} else {
+		//Default is 1s
+		spec.Unit = flux.Duration(time.Second)
+	}
+
+	if method, ok, err := args.GetString("method"); err != nil {
+		return nil, err
+	} else if ok {
+		switch method {
+		case LinearInterpolation, StepInterpolation:
+		default:
+			return nil, fmt.Errorf("method must be one of %q or %q, got %q", LinearInterpolation, StepInterpolation, method)
+		}
+		spec.Method = method
+	} else {
+		spec.Method = LinearInterpolation
+	}
+
+	if timeValue, ok, err := args.GetString("timeColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.TimeColumn = timeValue
+	} else {
+		spec.TimeColumn = execute.DefaultTimeColLabel
+	}
+
+	if err := spec.AggregateConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newTimeWeightedAvgOp() flux.OperationSpec {
+	return new(TimeWeightedAvgOpSpec)
+}
+
+func (s *TimeWeightedAvgOpSpec) Kind() flux.OperationKind {
+	return TimeWeightedAvgKind
+}
+
+type TimeWeightedAvgProcedureSpec struct {
+	Unit       flux.Duration `json:"unit"`
+	Method     string        `json:"method"`
+	TimeColumn string        `json:"timeColumn"`
+	execute.AggregateConfig
+}
+
+func newTimeWeightedAvgProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TimeWeightedAvgOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &TimeWeightedAvgProcedureSpec{
+		Unit:            spec.Unit,
+		Method:          spec.Method,
+		TimeColumn:      spec.TimeColumn,
+		AggregateConfig: spec.AggregateConfig,
+	}, nil
+}
+
+func (s *TimeWeightedAvgProcedureSpec) Kind() plan.ProcedureKind {
+	return TimeWeightedAvgKind
+}
+func (s *TimeWeightedAvgProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(TimeWeightedAvgProcedureSpec)
+	*ns = *s
+
+	ns.AggregateConfig = s.AggregateConfig.Copy()
+
+	return ns
+}
+
+func createTimeWeightedAvgTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TimeWeightedAvgProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewTimeWeightedAvgTransformation(d, cache, s)
+	return t, d, nil
+}
+
+type timeWeightedAvgTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	spec TimeWeightedAvgProcedureSpec
+}
+
+func NewTimeWeightedAvgTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *TimeWeightedAvgProcedureSpec) *timeWeightedAvgTransformation {
+	return &timeWeightedAvgTransformation{
+		d:     d,
+		cache: cache,
+		spec:  *spec,
+	}
+}
+
+func (t *timeWeightedAvgTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *timeWeightedAvgTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	builder, created := t.cache.TableBuilder(key)
+	if !created {
+		return fmt.Errorf("timeWeightedAvg found duplicate table with key: %v", key)
+	}
+
+	if err := execute.AddTableKeyCols(key, builder); err != nil {
+		return err
+	}
+
+	// The average is taken over the bounds of the table, when it has them.
+	bounds, hasBounds := tableBounds(key)
+
+	cols := tbl.Cols()
+	averages := make([]*timeWeightedAverage, len(cols))
+	colMap := make([]int, len(cols))
+
+	for _, c := range t.spec.Columns {
+		idx := execute.ColIdx(c, cols)
+		if idx < 0 {
+			return fmt.Errorf("column %q does not exist", c)
+		}
+
+		if key.HasCol(c) {
+			return fmt.Errorf("cannot aggregate columns that are part of the group key")
+		}
+
+		if typ := cols[idx].Type; typ != flux.TFloat &&
+			typ != flux.TInt &&
+			typ != flux.TUInt {
+			return fmt.Errorf("cannot perform timeWeightedAvg over %v", typ)
+		}
+
+		averages[idx] = newTimeWeightedAverage(time.Duration(t.spec.Unit), t.spec.Method == StepInterpolation)
+		if hasBounds {
+			averages[idx].setBounds(bounds)
+		}
+		newIdx, err := builder.AddCol(flux.ColMeta{
+			Label: c,
+			Type:  flux.TFloat,
+		})
+		if err != nil {
+			return err
+		}
+		colMap[idx] = newIdx
+	}
+
+	timeIdx := execute.ColIdx(t.spec.TimeColumn, cols)
+	if timeIdx < 0 {
+		return fmt.Errorf("no column %q exists", t.spec.TimeColumn)
+	}
+	if err := tbl.Do(func(cr flux.ColReader) error {
+		if cr.Times(timeIdx).NullN() > 0 {
+			return fmt.Errorf("timeWeightedAvg found null time in time column")
+		}
+
+		for j, avg := range averages {
+			if avg == nil {
+				continue
+			}
+
+			l := cr.Len()
+			for i := 0; i < l; i++ {
+				tm := execute.Time(cr.Times(timeIdx).Value(i))
+				v := execute.ValueForRow(cr, i, j)
+				if v.IsNull() {
+					continue
+				}
+				if err := avg.update(tm, floatValue(v)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := execute.AppendKeyValues(key, builder); err != nil {
+		return err
+	}
+	for j, avg := range averages {
+		if avg == nil {
+			continue
+		}
+		if err := builder.AppendValue(colMap[j], avg.value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tableBounds returns the bounds of a table from the start and stop columns of its group key.
+func tableBounds(key flux.GroupKey) (execute.Bounds, bool) {
+	start, stop := key.LabelValue(execute.DefaultStartColLabel), key.LabelValue(execute.DefaultStopColLabel)
+	if start == nil || stop == nil || start.Type() != semantic.Time || stop.Type() != semantic.Time {
+		return execute.Bounds{}, false
+	}
+	return execute.Bounds{
+		Start: start.Time(),
+		Stop:  stop.Time(),
+	}, true
+}
+
+func (t *timeWeightedAvgTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *timeWeightedAvgTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *timeWeightedAvgTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
+
+// timePoint is a value at a time.
+type timePoint struct {
+	t execute.Time
+	v float64
+}
+
+// timeWeightedAverage computes the average of a series of values weighted by the time each of them holds,
+// between the bounds of the series or, without bounds, between its first and last times.
+//
+// The values are interpolated between two points either linearly or as steps, where a value holds until the next point.
+// With either method, the first value holds from the start to the first point and the last value holds
+// from the last point until the stop, since extending the first and last lines could reach arbitrarily large values.
+type timeWeightedAverage struct {
+	unit      float64
+	step      bool
+	bounds    execute.Bounds
+	hasBounds bool
+
+	n           int
+	first, last timePoint
+
+	sum float64
+}
+
+func newTimeWeightedAverage(unit time.Duration, step bool) *timeWeightedAverage {
+	return &timeWeightedAverage{
+		unit: float64(unit),
+		step: step,
+	}
+}
+
+func (a *timeWeightedAverage) setBounds(bounds execute.Bounds) {
+	a.bounds = bounds
+	a.hasBounds = true
+}
+
+func (a *timeWeightedAverage) update(t execute.Time, v float64) error {
+	p := timePoint{t: t, v: v}
+	if a.n == 0 {
+		a.first = p
+		a.last = p
+		a.n++
+		return nil
+	}
+	if t < a.last.t {
+		return fmt.Errorf("timeWeightedAvg found out-of-order times in time column")
+	} else if t == a.last.t {
+		// skip repeated times the same way integral does
+		return nil
+	}
+	a.sum += a.area(a.last, p)
+	a.last = p
+	a.n++
+	return nil
+}
+
+// area returns the area under the interpolation between two points.
+func (a *timeWeightedAverage) area(p0, p1 timePoint) float64 {
+	elapsed := float64(p1.t-p0.t) / a.unit
+	if a.step {
+		return p0.v * elapsed
+	}
+	return 0.5 * (p0.v + p1.v) * elapsed
+}
+
+func (a *timeWeightedAverage) value() values.Value {
+	if a.n == 0 {
+		return values.NewNull(semantic.Float)
+	}
+
+	start, stop := a.first.t, a.last.t
+	if a.hasBounds {
+		// Points outside of the bounds extend them.
+		if a.bounds.Start < start {
+			start = a.bounds.Start
+		}
+		if a.bounds.Stop > stop {
+			stop = a.bounds.Stop
+		}
+	}
+	if start == stop {
+		// A single point without bounds to hold it over.
+		return values.NewFloat(a.first.v)
+	}
+
+	sum := a.sum
+	sum += a.area(timePoint{t: start, v: a.first.v}, a.first)
+	sum += a.area(a.last, timePoint{t: stop, v: a.last.v})
+	return values.NewFloat(sum / (float64(stop-start) / a.unit))
+}
@@ -0,0 +1,283 @@
+package universe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestTimeWeightedAvg_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mybucket") |> timeWeightedAvg()`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "timeWeightedAvg1",
+						Spec: &universe.TimeWeightedAvgOpSpec{
+							Unit:            flux.Duration(time.Second),
+							Method:          "linear",
+							TimeColumn:      "_time",
+							AggregateConfig: execute.DefaultAggregateConfig,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "timeWeightedAvg1"},
+				},
+			},
+		},
+		{
+			Name: "step",
+			Raw:  `from(bucket:"mybucket") |> timeWeightedAvg(unit: 1m, method: "step")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "timeWeightedAvg1",
+						Spec: &universe.TimeWeightedAvgOpSpec{
+							Unit:            flux.Duration(time.Minute),
+							Method:          "step",
+							TimeColumn:      "_time",
+							AggregateConfig: execute.DefaultAggregateConfig,
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "timeWeightedAvg1"},
+				},
+			},
+		},
+		{
+			Name:    "unknown method",
+			Raw:     `from(bucket:"mybucket") |> timeWeightedAvg(method: "spline")`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestTimeWeightedAvgOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"timeWeightedAvg","kind":"timeWeightedAvg","spec":{"unit":"1m","method":"step","timeColumn":"_time","columns":["_value"]}}`)
+	op := &flux.Operation{
+		ID: "timeWeightedAvg",
+		Spec: &universe.TimeWeightedAvgOpSpec{
+			Unit:            flux.Duration(time.Minute),
+			Method:          "step",
+			TimeColumn:      "_time",
+			AggregateConfig: execute.DefaultAggregateConfig,
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestTimeWeightedAvg_Process(t *testing.T) {
+	// The values 1, 3 and 2 at times 10, 20 and 40 within the bounds [0, 60).
+	bounded := &executetest.Table{
+		KeyCols: []string{"_start", "_stop"},
+		ColMeta: []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(0), execute.Time(60), execute.Time(10), 1.0},
+			{execute.Time(0), execute.Time(60), execute.Time(15), nil},
+			{execute.Time(0), execute.Time(60), execute.Time(20), 3.0},
+			{execute.Time(0), execute.Time(60), execute.Time(40), 2.0},
+		},
+	}
+	boundedResult := func(v interface{}) []*executetest.Table {
+		return []*executetest.Table{{
+			KeyCols: []string{"_start", "_stop"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_start", Type: flux.TTime},
+				{Label: "_stop", Type: flux.TTime},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(0), execute.Time(60), v},
+			},
+		}}
+	}
+	testCases := []struct {
+		name    string
+		spec    *universe.TimeWeightedAvgProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "step",
+			spec: &universe.TimeWeightedAvgProcedureSpec{
+				Unit:            1,
+				Method:          "step",
+				TimeColumn:      "_time",
+				AggregateConfig: execute.DefaultAggregateConfig,
+			},
+			data: []flux.Table{bounded},
+			// (1*10 + 1*10 + 3*20 + 2*20) / 60
+			want: boundedResult(2.0),
+		},
+		{
+			name: "linear",
+			spec: &universe.TimeWeightedAvgProcedureSpec{
+				Unit:            1,
+				Method:          "linear",
+				TimeColumn:      "_time",
+				AggregateConfig: execute.DefaultAggregateConfig,
+			},
+			data: []flux.Table{bounded},
+			// The first and last values hold until the bounds:
+			// (1*10 + 2*10 + 2.5*20 + 2*20) / 60
+			want: boundedResult(2.0),
+		},
+		{
+			name: "linear steep edges",
+			spec: &universe.TimeWeightedAvgProcedureSpec{
+				Unit:            flux.Duration(time.Second),
+				Method:          "linear",
+				TimeColumn:      "_time",
+				AggregateConfig: execute.DefaultAggregateConfig,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(60 * time.Second), execute.Time(9 * time.Second), 0.0},
+					{execute.Time(0), execute.Time(60 * time.Second), execute.Time(10 * time.Second), 100.0},
+				},
+			}},
+			// Extending the segment would reach 5100 at 60s, but the values hold flat instead:
+			// (0*9 + 50*1 + 100*50) / 60
+			want: []*executetest.Table{{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(60 * time.Second), 5050.0 / 60.0},
+				},
+			}},
+		},
+		{
+			name: "single point",
+			spec: &universe.TimeWeightedAvgProcedureSpec{
+				Unit:            1,
+				Method:          "linear",
+				TimeColumn:      "_time",
+				AggregateConfig: execute.DefaultAggregateConfig,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(60), execute.Time(10), int64(5)},
+				},
+			}},
+			want: boundedResult(5.0),
+		},
+		{
+			name: "no values",
+			spec: &universe.TimeWeightedAvgProcedureSpec{
+				Unit:            1,
+				Method:          "linear",
+				TimeColumn:      "_time",
+				AggregateConfig: execute.DefaultAggregateConfig,
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"_start", "_stop"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_start", Type: flux.TTime},
+					{Label: "_stop", Type: flux.TTime},
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(60), execute.Time(10), nil},
+				},
+			}},
+			want: boundedResult(nil),
+		},
+		{
+			name: "without bounds",
+			spec: &universe.TimeWeightedAvgProcedureSpec{
+				Unit:            1,
+				Method:          "step",
+				TimeColumn:      "_time",
+				AggregateConfig: execute.DefaultAggregateConfig,
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(10), 1.0},
+					{execute.Time(20), 3.0},
+					{execute.Time(40), 2.0},
+				},
+			}},
+			// (1*10 + 3*20) / 30
+			want: []*executetest.Table{{
+				ColMeta: []flux.ColMeta{
+					{Label: "_value", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{70.0 / 30.0},
+				},
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewTimeWeightedAvgTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+)
+
+const TripleEMAKind = "tripleEMA"
+
+type TripleEMAOpSpec struct {
+	MovingAverageConfig
+}
+
+func init() {
+	flux.RegisterPackageValue("universe", TripleEMAKind, flux.FunctionValue(TripleEMAKind, createTripleEMAOpSpec, movingAverageSignature()))
+	flux.RegisterOpSpec(TripleEMAKind, newTripleEMAOp)
+	plan.RegisterProcedureSpec(TripleEMAKind, newTripleEMAProcedure, TripleEMAKind)
+	execute.RegisterTransformation(TripleEMAKind, createTripleEMATransformation)
+}
+
+func createTripleEMAOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(TripleEMAOpSpec)
+	if err := spec.MovingAverageConfig.ReadArgs(args); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func newTripleEMAOp() flux.OperationSpec {
+	return new(TripleEMAOpSpec)
+}
+
+func (s *TripleEMAOpSpec) Kind() flux.OperationKind {
+	return TripleEMAKind
+}
+
+type TripleEMAProcedureSpec struct {
+	plan.DefaultCost
+	MovingAverageConfig
+}
+
+func newTripleEMAProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*TripleEMAOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &TripleEMAProcedureSpec{
+		MovingAverageConfig: spec.MovingAverageConfig,
+	}, nil
+}
+
+func (s *TripleEMAProcedureSpec) Kind() plan.ProcedureKind {
+	return TripleEMAKind
+}
+func (s *TripleEMAProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(TripleEMAProcedureSpec)
+	*ns = *s
+	return ns
+}
+
+func createTripleEMATransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*TripleEMAProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewTripleEMATransformation(d, cache, s)
+	return t, d, nil
+}
+
+func NewTripleEMATransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *TripleEMAProcedureSpec) execute.Transformation {
+	n := int(spec.N)
+	return newAveragingTransformation(d, cache, TripleEMAKind, spec.Column, func() averager {
+		return newTripleExponentialAverager(n)
+	})
+}
+
+// tripleExponentialAverager computes 3*EMA - 3*EMA(EMA) + EMA(EMA(EMA)),
+// where the exponential moving averages are those of exponentialAverager.
+type tripleExponentialAverager struct {
+	ema, emaOfEMA, emaOfEMAOfEMA *exponentialAverager
+}
+
+func newTripleExponentialAverager(n int) *tripleExponentialAverager {
+	return &tripleExponentialAverager{
+		ema:           newExponentialAverager(n),
+		emaOfEMA:      newExponentialAverager(n),
+		emaOfEMAOfEMA: newExponentialAverager(n),
+	}
+}
+
+func (a *tripleExponentialAverager) add(v float64) (float64, bool) {
+	ema, ok := a.ema.add(v)
+	if !ok {
+		return 0, false
+	}
+	emaOfEMA, ok := a.emaOfEMA.add(ema)
+	if !ok {
+		return 0, false
+	}
+	emaOfEMAOfEMA, ok := a.emaOfEMAOfEMA.add(emaOfEMA)
+	if !ok {
+		return 0, false
+	}
+	return 3*ema - 3*emaOfEMA + emaOfEMAOfEMA, true
+}
@@ -0,0 +1,63 @@
+package universe_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestTripleEMAOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"tripleEMA","kind":"tripleEMA","spec":{"n":3,"column":"_value"}}`)
+	op := &flux.Operation{
+		ID: "tripleEMA",
+		Spec: &universe.TripleEMAOpSpec{
+			MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: "_value"},
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestTripleEMA_Process(t *testing.T) {
+	spec := &universe.TripleEMAProcedureSpec{
+		MovingAverageConfig: universe.MovingAverageConfig{N: 3, Column: execute.DefaultValueColLabel},
+	}
+	data := []flux.Table{&executetest.Table{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(1), 0.0},
+			{execute.Time(2), 0.0},
+			{execute.Time(3), 0.0},
+			{execute.Time(4), 6.0},
+			{execute.Time(5), 9.0},
+			{execute.Time(6), 3.0},
+			{execute.Time(7), 10.5},
+			{execute.Time(8), 1.5},
+		},
+	}}
+	want := []*executetest.Table{{
+		ColMeta: []flux.ColMeta{
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: flux.TFloat},
+		},
+		Data: [][]interface{}{
+			{execute.Time(7), 9.75},
+			{execute.Time(8), 2.90625},
+		},
+	}}
+	executetest.ProcessTestHelper(
+		t,
+		data,
+		want,
+		nil,
+		func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+			return universe.NewTripleEMATransformation(d, c, spec)
+		},
+	)
+}
@@ -0,0 +1,272 @@
+package universe
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/interpreter"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+const (
+	UnpivotKind = "unpivot"
+
+	defaultUnpivotKeyColumn = "_field"
+)
+
+type UnpivotOpSpec struct {
+	Columns     []string `json:"columns"`
+	KeyColumn   string   `json:"keyColumn"`
+	ValueColumn string   `json:"valueColumn"`
+}
+
+func init() {
+	unpivotSignature := flux.FunctionSignature(
+		map[string]semantic.PolyType{
+			"columns":     semantic.NewArrayPolyType(semantic.String),
+			"keyColumn":   semantic.String,
+			"valueColumn": semantic.String,
+		},
+		nil,
+	)
+
+	flux.RegisterPackageValue("universe", UnpivotKind, flux.FunctionValue(UnpivotKind, createUnpivotOpSpec, unpivotSignature))
+	flux.RegisterOpSpec(UnpivotKind, newUnpivotOp)
+	plan.RegisterProcedureSpec(UnpivotKind, newUnpivotProcedure, UnpivotKind)
+	execute.RegisterTransformation(UnpivotKind, createUnpivotTransformation)
+}
+
+func createUnpivotOpSpec(args flux.Arguments, a *flux.Administration) (flux.OperationSpec, error) {
+	if err := a.AddParentFromArgs(args); err != nil {
+		return nil, err
+	}
+
+	spec := new(UnpivotOpSpec)
+
+	if cols, ok, err := args.GetArray("columns", semantic.String); err != nil {
+		return nil, err
+	} else if ok {
+		columns, err := interpreter.ToStringArray(cols)
+		if err != nil {
+			return nil, err
+		}
+		spec.Columns = columns
+	}
+
+	if col, ok, err := args.GetString("keyColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.KeyColumn = col
+	} else {
+		spec.KeyColumn = defaultUnpivotKeyColumn
+	}
+
+	if col, ok, err := args.GetString("valueColumn"); err != nil {
+		return nil, err
+	} else if ok {
+		spec.ValueColumn = col
+	} else {
+		spec.ValueColumn = execute.DefaultValueColLabel
+	}
+
+	if spec.KeyColumn == spec.ValueColumn {
+		return nil, fmt.Errorf("keyColumn and valueColumn must differ, both are %q", spec.KeyColumn)
+	}
+	for _, c := range spec.Columns {
+		if c == spec.KeyColumn || c == spec.ValueColumn {
+			return nil, fmt.Errorf("cannot unpivot column %q into itself", c)
+		}
+	}
+	return spec, nil
+}
+
+func newUnpivotOp() flux.OperationSpec {
+	return new(UnpivotOpSpec)
+}
+
+func (s *UnpivotOpSpec) Kind() flux.OperationKind {
+	return UnpivotKind
+}
+
+type UnpivotProcedureSpec struct {
+	plan.DefaultCost
+	// Columns are the columns to unpivot.
+	// When empty, all columns that are neither in the group key nor the _time column are unpivoted.
+	Columns     []string
+	KeyColumn   string
+	ValueColumn string
+}
+
+func newUnpivotProcedure(qs flux.OperationSpec, pa plan.Administration) (plan.ProcedureSpec, error) {
+	spec, ok := qs.(*UnpivotOpSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", qs)
+	}
+
+	return &UnpivotProcedureSpec{
+		Columns:     spec.Columns,
+		KeyColumn:   spec.KeyColumn,
+		ValueColumn: spec.ValueColumn,
+	}, nil
+}
+
+func (s *UnpivotProcedureSpec) Kind() plan.ProcedureKind {
+	return UnpivotKind
+}
+func (s *UnpivotProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := new(UnpivotProcedureSpec)
+	*ns = *s
+	if s.Columns != nil {
+		ns.Columns = make([]string, len(s.Columns))
+		copy(ns.Columns, s.Columns)
+	}
+	return ns
+}
+
+func createUnpivotTransformation(id execute.DatasetID, mode execute.AccumulationMode, spec plan.ProcedureSpec, a execute.Administration) (execute.Transformation, execute.Dataset, error) {
+	s, ok := spec.(*UnpivotProcedureSpec)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid spec type %T", spec)
+	}
+	cache := execute.NewTableBuilderCache(a.Allocator())
+	d := execute.NewDataset(id, mode, cache)
+	t := NewUnpivotTransformation(d, cache, s)
+	return t, d, nil
+}
+
+// unpivotTransformation turns each unpivoted column of a row into its own row,
+// with the name of the column in the key column and its value in the value column.
+// The key column is added to the group key, so every output table holds the values
+// of a single column, and thus of a single type, the same way pivot expects its input.
+// Null values are dropped, as pivot produces them for missing values.
+type unpivotTransformation struct {
+	d     execute.Dataset
+	cache execute.TableBuilderCache
+
+	columns     []string
+	keyColumn   string
+	valueColumn string
+}
+
+func NewUnpivotTransformation(d execute.Dataset, cache execute.TableBuilderCache, spec *UnpivotProcedureSpec) *unpivotTransformation {
+	return &unpivotTransformation{
+		d:           d,
+		cache:       cache,
+		columns:     spec.Columns,
+		keyColumn:   spec.KeyColumn,
+		valueColumn: spec.ValueColumn,
+	}
+}
+
+func (t *unpivotTransformation) RetractTable(id execute.DatasetID, key flux.GroupKey) error {
+	return t.d.RetractTable(key)
+}
+
+func (t *unpivotTransformation) Process(id execute.DatasetID, tbl flux.Table) error {
+	key := tbl.Key()
+	cols := tbl.Cols()
+
+	unpivoted := make([]bool, len(cols))
+	if len(t.columns) > 0 {
+		for _, c := range t.columns {
+			j := execute.ColIdx(c, cols)
+			if j < 0 {
+				return fmt.Errorf("no column %q exists", c)
+			}
+			if key.HasCol(c) {
+				return fmt.Errorf("unpivot cannot unpivot group key column %q", c)
+			}
+			unpivoted[j] = true
+		}
+	} else {
+		for j, c := range cols {
+			unpivoted[j] = !key.HasCol(c.Label) && c.Label != execute.DefaultTimeColLabel
+		}
+	}
+
+	// The columns that are not unpivoted are kept as they are.
+	var kept []int
+	for j, c := range cols {
+		if unpivoted[j] {
+			continue
+		}
+		if c.Label == t.keyColumn || c.Label == t.valueColumn {
+			return fmt.Errorf("unpivot output column %q conflicts with an existing column", c.Label)
+		}
+		kept = append(kept, j)
+	}
+
+	builders := make([]execute.TableBuilder, len(cols))
+	builder := func(j int) (execute.TableBuilder, error) {
+		if b := builders[j]; b != nil {
+			return b, nil
+		}
+		outKey, err := execute.NewGroupKeyBuilder(key).
+			AddKeyValue(t.keyColumn, values.NewString(cols[j].Label)).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		b, created := t.cache.TableBuilder(outKey)
+		if !created {
+			return nil, fmt.Errorf("unpivot found duplicate table with key: %v", outKey)
+		}
+		for _, k := range kept {
+			if _, err := b.AddCol(cols[k]); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := b.AddCol(flux.ColMeta{Label: t.keyColumn, Type: flux.TString}); err != nil {
+			return nil, err
+		}
+		if _, err := b.AddCol(flux.ColMeta{Label: t.valueColumn, Type: cols[j].Type}); err != nil {
+			return nil, err
+		}
+		builders[j] = b
+		return b, nil
+	}
+
+	return tbl.Do(func(cr flux.ColReader) error {
+		l := cr.Len()
+		for i := 0; i < l; i++ {
+			for j, c := range cols {
+				if !unpivoted[j] {
+					continue
+				}
+				v := execute.ValueForRow(cr, i, j)
+				if v.IsNull() {
+					continue
+				}
+				b, err := builder(j)
+				if err != nil {
+					return err
+				}
+				for n, k := range kept {
+					if err := b.AppendValue(n, execute.ValueForRow(cr, i, k)); err != nil {
+						return err
+					}
+				}
+				if err := b.AppendString(len(kept), c.Label); err != nil {
+					return err
+				}
+				if err := b.AppendValue(len(kept)+1, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (t *unpivotTransformation) UpdateWatermark(id execute.DatasetID, mark execute.Time) error {
+	return t.d.UpdateWatermark(mark)
+}
+func (t *unpivotTransformation) UpdateProcessingTime(id execute.DatasetID, pt execute.Time) error {
+	return t.d.UpdateProcessingTime(pt)
+}
+func (t *unpivotTransformation) Finish(id execute.DatasetID, err error) {
+	t.d.Finish(err)
+}
@@ -0,0 +1,241 @@
+package universe_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/flux/querytest"
+	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/flux/stdlib/universe"
+)
+
+func TestUnpivot_NewQuery(t *testing.T) {
+	tests := []querytest.NewQueryTestCase{
+		{
+			Name: "defaults",
+			Raw:  `from(bucket:"mybucket") |> unpivot()`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "unpivot1",
+						Spec: &universe.UnpivotOpSpec{
+							KeyColumn:   "_field",
+							ValueColumn: "_value",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "unpivot1"},
+				},
+			},
+		},
+		{
+			Name: "columns",
+			Raw:  `from(bucket:"mybucket") |> unpivot(columns: ["a", "b"], keyColumn: "k", valueColumn: "v")`,
+			Want: &flux.Spec{
+				Operations: []*flux.Operation{
+					{
+						ID: "from0",
+						Spec: &influxdb.FromOpSpec{
+							Bucket: "mybucket",
+						},
+					},
+					{
+						ID: "unpivot1",
+						Spec: &universe.UnpivotOpSpec{
+							Columns:     []string{"a", "b"},
+							KeyColumn:   "k",
+							ValueColumn: "v",
+						},
+					},
+				},
+				Edges: []flux.Edge{
+					{Parent: "from0", Child: "unpivot1"},
+				},
+			},
+		},
+		{
+			Name:    "same key and value column",
+			Raw:     `from(bucket:"mybucket") |> unpivot(keyColumn: "k", valueColumn: "k")`,
+			WantErr: true,
+		},
+		{
+			Name:    "unpivot value column",
+			Raw:     `from(bucket:"mybucket") |> unpivot(columns: ["_value"])`,
+			WantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			querytest.NewQueryTestHelper(t, tc)
+		})
+	}
+}
+
+func TestUnpivotOperation_Marshaling(t *testing.T) {
+	data := []byte(`{"id":"unpivot","kind":"unpivot","spec":{"columns":["a","b"],"keyColumn":"_field","valueColumn":"_value"}}`)
+	op := &flux.Operation{
+		ID: "unpivot",
+		Spec: &universe.UnpivotOpSpec{
+			Columns:     []string{"a", "b"},
+			KeyColumn:   "_field",
+			ValueColumn: "_value",
+		},
+	}
+	querytest.OperationMarshalingTestHelper(t, data, op)
+}
+
+func TestUnpivot_Process(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    *universe.UnpivotProcedureSpec
+		data    []flux.Table
+		want    []*executetest.Table
+		wantErr error
+	}{
+		{
+			name: "mixed types",
+			spec: &universe.UnpivotProcedureSpec{
+				KeyColumn:   "_field",
+				ValueColumn: "_value",
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+					{Label: "cpu", Type: flux.TFloat},
+					{Label: "up", Type: flux.TBool},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a", 1.5, true},
+					{execute.Time(2), "a", nil, false},
+					{execute.Time(3), "a", 2.5, nil},
+				},
+			}},
+			want: []*executetest.Table{
+				{
+					KeyCols: []string{"host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "a", "cpu", 1.5},
+						{execute.Time(3), "a", "cpu", 2.5},
+					},
+				},
+				{
+					KeyCols: []string{"host", "_field"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "host", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_value", Type: flux.TBool},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "a", "up", true},
+						{execute.Time(2), "a", "up", false},
+					},
+				},
+			},
+		},
+		{
+			name: "columns",
+			spec: &universe.UnpivotProcedureSpec{
+				Columns:     []string{"b"},
+				KeyColumn:   "k",
+				ValueColumn: "v",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "id", Type: flux.TInt},
+					{Label: "a", Type: flux.TString},
+					{Label: "b", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{int64(1), "x", int64(10)},
+					{int64(2), "y", int64(20)},
+				},
+			}},
+			want: []*executetest.Table{{
+				KeyCols: []string{"k"},
+				ColMeta: []flux.ColMeta{
+					{Label: "id", Type: flux.TInt},
+					{Label: "a", Type: flux.TString},
+					{Label: "k", Type: flux.TString},
+					{Label: "v", Type: flux.TInt},
+				},
+				Data: [][]interface{}{
+					{int64(1), "x", "b", int64(10)},
+					{int64(2), "y", "b", int64(20)},
+				},
+			}},
+		},
+		{
+			name: "group key column",
+			spec: &universe.UnpivotProcedureSpec{
+				Columns:     []string{"host"},
+				KeyColumn:   "_field",
+				ValueColumn: "_value",
+			},
+			data: []flux.Table{&executetest.Table{
+				KeyCols: []string{"host"},
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "host", Type: flux.TString},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), "a"},
+				},
+			}},
+			wantErr: errors.New(`unpivot cannot unpivot group key column "host"`),
+		},
+		{
+			name: "existing value column",
+			spec: &universe.UnpivotProcedureSpec{
+				Columns:     []string{"cpu"},
+				KeyColumn:   "_field",
+				ValueColumn: "_value",
+			},
+			data: []flux.Table{&executetest.Table{
+				ColMeta: []flux.ColMeta{
+					{Label: "_time", Type: flux.TTime},
+					{Label: "_value", Type: flux.TFloat},
+					{Label: "cpu", Type: flux.TFloat},
+				},
+				Data: [][]interface{}{
+					{execute.Time(1), 1.0, 2.0},
+				},
+			}},
+			wantErr: errors.New(`unpivot output column "_value" conflicts with an existing column`),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			executetest.ProcessTestHelper(
+				t,
+				tc.data,
+				tc.want,
+				tc.wantErr,
+				func(d execute.Dataset, c execute.TableBuilderCache) execute.Transformation {
+					return universe.NewUnpivotTransformation(d, c, tc.spec)
+				},
+			)
+		})
+	}
+}